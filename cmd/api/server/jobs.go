@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"github.com/snykk/golib_backend/domains/rankings"
+	"github.com/snykk/golib_backend/domains/recommendations"
+	"github.com/snykk/golib_backend/domains/trash"
+)
+
+// runPeriodically calls fn once at start and then every interval until ctx is done, logging what fails
+func runPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			log.Printf("[JOB] %s failed: %s", name, err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runTrashRetention hard deletes whatever sat in the trash longer than the retention
+func runTrashRetention(ctx context.Context, trashUsecase trash.Usecase, retention time.Duration) {
+	runPeriodically(ctx, "trash retention", constants.TrashPurgeInterval, func(ctx context.Context) error {
+		purged, err := trashUsecase.PurgeExpired(ctx, time.Now().Add(-retention))
+		if err == nil && len(purged) > 0 {
+			log.Printf("[JOB] trash retention purged %d items", len(purged))
+		}
+		return err
+	})
+}
+
+// runRecommendationRefresh recomputes the stored recommendations from the latest review ratings
+func runRecommendationRefresh(ctx context.Context, recommendationUsecase recommendations.Usecase) {
+	runPeriodically(ctx, "recommendation refresh", constants.RecommendationRefreshInterval, func(ctx context.Context) error {
+		_, err := recommendationUsecase.Refresh(ctx)
+		return err
+	})
+}
+
+// runRankingRefresh recomputes the top rated and trending books
+func runRankingRefresh(ctx context.Context, rankingUsecase rankings.Usecase) {
+	runPeriodically(ctx, "ranking refresh", constants.RankingRefreshInterval, rankingUsecase.Refresh)
+}
+
+// runSimilarityIndex builds the index behind the similar books at startup and rebuilds it to pick up the changes
+// made outside the book usecase
+func runSimilarityIndex(ctx context.Context, bookUsecase books.Usecase) {
+	runPeriodically(ctx, "similarity index", constants.SimilarIndexRefreshInterval, bookUsecase.RebuildSimilarityIndex)
+}
+
+// runHoldExpiry releases the copies of ready holds that weren't picked up in time
+func runHoldExpiry(ctx context.Context, circulationUsecase circulations.Usecase) {
+	runPeriodically(ctx, "hold expiry", constants.HoldExpiryInterval, func(ctx context.Context) error {
+		expired, err := circulationUsecase.ExpireHolds(ctx)
+		if expired > 0 {
+			log.Printf("[JOB] hold expiry expired %d holds", expired)
+		}
+		return err
+	})
+}
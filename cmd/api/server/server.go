@@ -13,7 +13,19 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/snykk/golib_backend/config"
 	"github.com/snykk/golib_backend/datasources/cache"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	circulationRepository "github.com/snykk/golib_backend/datasources/databases/circulations"
 	"github.com/snykk/golib_backend/datasources/databases/drivers"
+	rankingRepository "github.com/snykk/golib_backend/datasources/databases/rankings"
+	recommendationRepository "github.com/snykk/golib_backend/datasources/databases/recommendations"
+	trashRepository "github.com/snykk/golib_backend/datasources/databases/trash"
+	userRepository "github.com/snykk/golib_backend/datasources/databases/users"
+	"github.com/snykk/golib_backend/datasources/storage"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"github.com/snykk/golib_backend/domains/rankings"
+	"github.com/snykk/golib_backend/domains/recommendations"
+	"github.com/snykk/golib_backend/domains/trash"
 	"github.com/snykk/golib_backend/http/logger"
 	"github.com/snykk/golib_backend/http/middlewares"
 	"github.com/snykk/golib_backend/http/routes"
@@ -23,6 +35,7 @@ import (
 
 type App struct {
 	HttpServer *http.Server
+	jobs       []func(ctx context.Context)
 }
 
 func NewApp() (*App, error) {
@@ -45,6 +58,19 @@ func NewApp() (*App, error) {
 		panic(err)
 	}
 
+	// blob storage
+	blobStorage, err := storage.NewLocalStorage(config.AppConfig.StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// ranking options
+	rankingOptions := rankings.Options{
+		PriorWeight:    config.AppConfig.RatingPriorWeight,
+		PriorMean:      config.AppConfig.RatingPriorMean,
+		TrendingWindow: time.Duration(config.AppConfig.TrendingWindowDays) * 24 * time.Hour,
+	}
+
 	// user middleware
 	authMiddleware := middlewares.NewAuthMiddleware(jwtService, false)
 	// admin middleware
@@ -53,8 +79,47 @@ func NewApp() (*App, error) {
 	// Routes
 	router.GET("/", routes.RootHandler)
 	routes.NewUsersRoute(conn, jwtService, redisCache, ristrettoCache, router, authMiddleware).UsersRoute()
-	routes.NewBooksRoute(conn, jwtService, ristrettoCache, router, authMiddleware, authAdminMiddleware).BooksRoute()
+	// the book usecase is shared with the job building its similarity index
+	bookUsecase := books.NewBookUsecase(bookRepository.NewPostgreBookRepository(conn), blobStorage)
+	routes.NewBooksRoute(conn, jwtService, ristrettoCache, bookUsecase, router, authMiddleware, authAdminMiddleware).BooksRoute()
 	routes.NewReviewsRoute(conn, jwtService, ristrettoCache, router, authMiddleware).ReviewsRoute()
+	routes.NewListsRoute(conn, router, authMiddleware).ListsRoute()
+	routes.NewCirculationsRoute(conn, router, authMiddleware, authAdminMiddleware).CirculationsRoute()
+	routes.NewAuthorsRoute(conn, ristrettoCache, router, authMiddleware, authAdminMiddleware).AuthorsRoute()
+	routes.NewPublishersRoute(conn, ristrettoCache, router, authMiddleware, authAdminMiddleware).PublishersRoute()
+	routes.NewCategoriesRoute(conn, ristrettoCache, router, authMiddleware, authAdminMiddleware).CategoriesRoute()
+	routes.NewTrashRoute(conn, ristrettoCache, blobStorage, router, authAdminMiddleware).TrashRoute()
+	routes.NewCollectionsRoute(conn, ristrettoCache, router, authAdminMiddleware).CollectionsRoute()
+	routes.NewFeedsRoute(conn, router).FeedsRoute()
+	routes.NewRecommendationsRoute(conn, router, authMiddleware).RecommendationsRoute()
+	routes.NewRankingsRoute(conn, ristrettoCache, rankingOptions, router, authMiddleware).RankingsRoute()
+	routes.NewShelvesRoute(conn, ristrettoCache, router, authMiddleware).ShelvesRoute()
+
+	// background jobs
+	recommendationUsecase := recommendations.NewRecommendationUsecase(recommendationRepository.NewPostgreRecommendationRepository(conn))
+	rankingUsecase := rankings.NewRankingUsecase(rankingRepository.NewPostgreRankingRepository(conn), rankingOptions)
+	circulationUsecase := circulations.NewCirculationUsecase(circulationRepository.NewPostgreCirculationRepository(conn), bookRepository.NewPostgreBookRepository(conn), userRepository.NewPostgreUserRepository(conn))
+	jobs := []func(ctx context.Context){
+		func(ctx context.Context) {
+			runRecommendationRefresh(ctx, recommendationUsecase)
+		},
+		func(ctx context.Context) {
+			runRankingRefresh(ctx, rankingUsecase)
+		},
+		func(ctx context.Context) {
+			runHoldExpiry(ctx, circulationUsecase)
+		},
+		func(ctx context.Context) {
+			runSimilarityIndex(ctx, bookUsecase)
+		},
+	}
+	if config.AppConfig.TrashRetentionDays > 0 {
+		trashUsecase := trash.NewTrashUsecase(trashRepository.NewPostgreTrashRepository(conn), bookRepository.NewPostgreBookRepository(conn), blobStorage)
+		retention := time.Duration(config.AppConfig.TrashRetentionDays) * 24 * time.Hour
+		jobs = append(jobs, func(ctx context.Context) {
+			runTrashRetention(ctx, trashUsecase, retention)
+		})
+	}
 
 	// setup http server
 	server := &http.Server{
@@ -67,10 +132,17 @@ func NewApp() (*App, error) {
 
 	return &App{
 		HttpServer: server,
+		jobs:       jobs,
 	}, nil
 }
 
 func (a *App) Run() error {
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	for _, job := range a.jobs {
+		go job(jobsCtx)
+	}
+
 	// Gracefull Shutdown
 	go func() {
 		if err := a.HttpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -84,6 +156,7 @@ func (a *App) Run() error {
 	// make blocking channel and waiting for a signal
 	<-quit
 	log.Println("[CLOSE] shutdown server ...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
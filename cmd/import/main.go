@@ -0,0 +1,148 @@
+// Command import ingests an Open Library dump or an ONIX 3.0 file, matching its records with the books by isbn.
+// Unknown books are created, known ones only get the description, publisher, page count and publication date they lack.
+//
+//	go run ./cmd/import -file ol_dump_editions.txt.gz -dry-run
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/datasources/databases/drivers"
+	"github.com/snykk/golib_backend/datasources/storage"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/helpers"
+	"github.com/snykk/golib_backend/http/controllers/books/imports"
+	"github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+var (
+	filePath = flag.String("file", "", "path of the Open Library dump or ONIX 3.0 file, may be gzipped")
+	format   = flag.String("format", "", "openlibrary or onix, guessed from the file extension when left out")
+	dryRun   = flag.Bool("dry-run", false, "only report what would be created or enriched")
+)
+
+func init() {
+	if err := config.InitializeAppConfig(); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func main() {
+	flag.Parse()
+	if *filePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dumpFormat, err := imports.DetectFormat(*format, *filePath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer file.Close()
+
+	reader, err := imports.NewReader(file, dumpFormat)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// only connect, initializing the database would drop every table in development
+	configDB := drivers.ConfigPostgreSQL{
+		DB_Username: config.AppConfig.DBUsername,
+		DB_Password: config.AppConfig.DBPassword,
+		DB_Host:     config.AppConfig.DBHost,
+		DB_Port:     config.AppConfig.DBPort,
+		DB_Database: config.AppConfig.DBDatabase,
+		DB_DSN:      config.AppConfig.DBDsn,
+	}
+	conn, err := configDB.ConnectPostgreSQL()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	blobStorage, err := storage.NewLocalStorage(config.AppConfig.StoragePath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	bookUsecase := books.NewBookUsecase(bookRepository.NewPostgreBookRepository(conn), blobStorage)
+
+	report := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(report, "LINE\tSTATUS\tISBN\tBOOK\tDETAIL")
+
+	summary := responses.MetadataImportSummary{DryRun: *dryRun}
+	// the dump goes in batches, duplicates across batches are caught here since a dry run never stores the first one
+	firstLineOfISBN := make(map[string]int)
+	batch := make([]books.MetadataRecord, 0, constants.MetadataImportBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		results, _, err := bookUsecase.ImportMetadata(context.Background(), batch, *dryRun)
+		if err != nil {
+			report.Flush()
+			log.Fatalln(err)
+		}
+		summary.Add(results)
+
+		for _, result := range results {
+			if result.Status == constants.BookImportUnchanged {
+				continue
+			}
+			detail := result.Error
+			if len(result.Fields) > 0 {
+				detail = strings.Join(result.Fields, ", ")
+			}
+			fmt.Fprintf(report, "%d\t%s\t%s\t%d\t%s\n", result.Line, result.Status, result.ISBN, result.BookID, detail)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			report.Flush()
+			log.Fatalln(err)
+		}
+
+		if isbn, err := helpers.NormalizeISBN(record.Book.ISBN); err == nil && record.Error == "" {
+			if line, ok := firstLineOfISBN[isbn]; ok {
+				record.Error = fmt.Sprintf("isbn %s is duplicated on line %d", isbn, line)
+			} else {
+				firstLineOfISBN[isbn] = record.Line
+			}
+		}
+
+		batch = append(batch, record)
+		if len(batch) == constants.MetadataImportBatchSize {
+			flush()
+		}
+	}
+	flush()
+	report.Flush()
+
+	if *dryRun {
+		fmt.Printf("dry run, %d books would be created and %d enriched, %d unchanged and %d failed out of %d\n", summary.Created, summary.Enriched, summary.Unchanged, summary.Failed, summary.Total)
+		return
+	}
+	fmt.Printf("%d books created and %d enriched, %d unchanged and %d failed out of %d\n", summary.Created, summary.Enriched, summary.Unchanged, summary.Failed, summary.Total)
+}
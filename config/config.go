@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 
+	"github.com/snykk/golib_backend/constants"
 	"github.com/spf13/viper"
 )
 
@@ -31,6 +32,14 @@ type Config struct {
 	REDISHost     string
 	REDISPassword string
 	REDISExpired  int
+
+	StoragePath string
+
+	TrashRetentionDays int
+
+	RatingPriorWeight  float64
+	RatingPriorMean    float64
+	TrendingWindowDays int
 }
 
 func InitializeAppConfig() error {
@@ -66,6 +75,28 @@ func InitializeAppConfig() error {
 	AppConfig.REDISPassword = viper.GetString("REDIS_PASS")
 	AppConfig.REDISExpired = viper.GetInt("REDIS_EXPIRED")
 
+	AppConfig.StoragePath = viper.GetString("STORAGE_PATH")
+	if AppConfig.StoragePath == "" {
+		AppConfig.StoragePath = "storage"
+	}
+
+	// a negative retention keeps soft deleted records forever
+	AppConfig.TrashRetentionDays = viper.GetInt("TRASH_RETENTION_DAYS")
+	if AppConfig.TrashRetentionDays == 0 {
+		AppConfig.TrashRetentionDays = constants.DefaultTrashRetentionDays
+	}
+
+	// without a prior mean the average rating of every review is used
+	AppConfig.RatingPriorWeight = viper.GetFloat64("RATING_PRIOR_WEIGHT")
+	if AppConfig.RatingPriorWeight <= 0 {
+		AppConfig.RatingPriorWeight = constants.DefaultRatingPriorWeight
+	}
+	AppConfig.RatingPriorMean = viper.GetFloat64("RATING_PRIOR_MEAN")
+	AppConfig.TrendingWindowDays = viper.GetInt("TRENDING_WINDOW_DAYS")
+	if AppConfig.TrendingWindowDays <= 0 {
+		AppConfig.TrendingWindowDays = constants.DefaultTrendingWindowDays
+	}
+
 	// check
 	if AppConfig.Port == 0 || AppConfig.Environment == "" || AppConfig.JWTSecret == "" || AppConfig.JWTExpired == 0 || AppConfig.JWTIssuer == "" || AppConfig.OTPEmail == "" || AppConfig.OTPPassword == "" || AppConfig.REDISHost == "" || AppConfig.REDISPassword == "" || AppConfig.REDISExpired == 0 {
 		return errors.New("required variabel environment is empty")
@@ -0,0 +1,11 @@
+package constants
+
+const (
+	AuthorRoleAuthor     = "author"
+	AuthorRoleEditor     = "editor"
+	AuthorRoleTranslator = "translator"
+)
+
+var (
+	ListAuthorRole = []string{AuthorRoleAuthor, AuthorRoleEditor, AuthorRoleTranslator}
+)
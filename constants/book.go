@@ -0,0 +1,94 @@
+package constants
+
+import "time"
+
+const (
+	DefaultBookPage  = 1
+	DefaultBookLimit = 10
+	MaxBookLimit     = 100
+	DefaultBookSort  = "created_at"
+	DefaultBookOrder = "desc"
+
+	MinSuggestPrefixLength = 2
+	DefaultSuggestLimit    = 5
+	MaxSuggestLimit        = 20
+	SuggestTimeout         = 150 * time.Millisecond
+	SuggestCacheTTL        = 5 * time.Minute
+	BookListCacheTTL       = 5 * time.Minute
+
+	BookImportCSV       = "csv"
+	BookImportNDJSON    = "ndjson"
+	MaxBookImportSize   = 5 << 20
+	MaxBookImportRows   = 5000
+	BookImportBatchSize = 100
+	BookImportCreated   = "created"
+	BookImportEnriched  = "enriched"
+	BookImportUnchanged = "unchanged"
+	BookImportFailed    = "failed"
+
+	MetadataImportOpenLibrary = "openlibrary"
+	MetadataImportONIX        = "onix"
+	MaxMetadataImportSize     = 50 << 20
+	MaxMetadataImportRecords  = 5000
+	MetadataImportBatchSize   = 1000
+
+	BookExportFlushEvery  = 500
+	BookExportWriteWindow = time.Minute
+
+	MaxCoverSize          = 5 << 20
+	MaxCoverDimension     = 6000
+	CoverThumbnailQuality = 85
+	CoverURLPrefix        = "/covers/"
+	CoverSmall            = "small"
+	CoverMedium           = "medium"
+
+	MaxTagLength = 30
+	MaxBookTags  = 20
+
+	BookVersionCreated  = "created"
+	BookVersionUpdated  = "updated"
+	BookVersionDeleted  = "deleted"
+	BookVersionReverted = "reverted"
+	BookVersionRestored = "restored"
+
+	MaxBookTitleLength     = 100
+	MaxBookAuthorLength    = 255
+	MaxBookPublisherLength = 100
+	MaxBookSubtitleLength  = 255
+	MaxBookEditionLength   = 50
+	MaxBookSeriesLength    = 100
+	MaxBookPageCount       = 100000
+
+	BookFormatHardcover = "hardcover"
+	BookFormatPaperback = "paperback"
+	BookFormatEbook     = "ebook"
+	BookFormatAudio     = "audio"
+
+	DefaultSimilarLimit         = 5
+	MaxSimilarLimit             = 20
+	SimilarIndexRefreshInterval = 30 * time.Minute
+
+	MinRating = 1
+	MaxRating = 10
+
+	PublicationDateLayout = "2006-01-02"
+)
+
+var (
+	ListCoverContentType = []string{"image/jpeg", "image/png", "image/webp"}
+
+	MapperCoverContentTypeToExtension = map[string]string{
+		"image/jpeg": "jpg",
+		"image/png":  "png",
+		"image/webp": "webp",
+	}
+
+	ListCoverThumbnailSize = []string{CoverSmall, CoverMedium}
+
+	ListBookFormat = []string{BookFormatHardcover, BookFormatPaperback, BookFormatEbook, BookFormatAudio}
+
+	MapperCoverThumbnailSizeToWidth = map[string]int{
+		CoverSmall:  150,
+		CoverMedium: 400,
+	}
+)
@@ -0,0 +1,27 @@
+package constants
+
+import "time"
+
+const (
+	CopyAvailable = "available"
+	CopyOnLoan    = "on_loan"
+	CopyOnHold    = "on_hold"
+
+	HoldWaiting   = "waiting"
+	HoldReady     = "ready"
+	HoldFulfilled = "fulfilled"
+	HoldCancelled = "cancelled"
+	HoldExpired   = "expired"
+
+	LoanPeriod         = 14 * 24 * time.Hour
+	HoldPickupPeriod   = 3 * 24 * time.Hour
+	HoldExpiryInterval = 15 * time.Minute
+	MaxRenewals        = 2
+	MaxActiveLoans     = 5
+	FinePerDay         = 1000
+	MaxFine            = 50000
+)
+
+var (
+	ListCopyCondition = []string{"new", "good", "fair", "poor", "damaged"}
+)
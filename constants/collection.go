@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+const (
+	MaxCollectionTitleLength       = 100
+	MaxCollectionDescriptionLength = 1000
+	MaxCollectionBooks             = 50
+
+	HomeNewestLimit   = 10
+	HomeTopRatedLimit = 10
+	HomeCacheTTL      = 5 * time.Minute
+)
@@ -0,0 +1,16 @@
+package constants
+
+const (
+	FeedPageSize = 20
+	FeedAuthor   = "Golib"
+
+	FeedSortNewest = "newest"
+	FeedSortTitle  = "title"
+
+	OPDSNavigationType  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	OPDSAcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	AtomContentType     = "application/atom+xml; charset=utf-8"
+	RSSContentType      = "application/rss+xml; charset=utf-8"
+)
+
+var ListFeedSort = []string{FeedSortNewest, FeedSortTitle}
@@ -0,0 +1,5 @@
+package constants
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+)
@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+const (
+	RankingTop      = "top"
+	RankingTrending = "trending"
+
+	DefaultRankingLimit    = 10
+	MaxRankingLimit        = 100
+	RankingRefreshInterval = 15 * time.Minute
+	RankingCacheTTL        = 5 * time.Minute
+
+	// a book needs about this many reviews before its own average outweighs the prior
+	DefaultRatingPriorWeight  = 10
+	DefaultTrendingWindowDays = 7
+)
+
+var (
+	ListRankingKind = []string{RankingTop, RankingTrending}
+)
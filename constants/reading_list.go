@@ -0,0 +1,24 @@
+package constants
+
+const (
+	ReadingListPublic   = "public"
+	ReadingListUnlisted = "unlisted"
+	ReadingListPrivate  = "private"
+
+	ReadingListSortVotes  = "votes"
+	ReadingListSortRecent = "recent"
+
+	MaxReadingListTitleLength       = 100
+	MaxReadingListDescriptionLength = 1000
+	MaxReadingListNoteLength        = 500
+	MaxReadingListBooks             = 500
+	MaxReadingListEditors           = 10
+
+	DefaultReadingListPage  = 1
+	DefaultReadingListLimit = 20
+	MaxReadingListLimit     = 100
+)
+
+var (
+	ListReadingListVisibility = []string{ReadingListPublic, ReadingListUnlisted, ReadingListPrivate}
+)
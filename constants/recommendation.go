@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const (
+	DefaultRecommendationLimit    = 10
+	MaxRecommendationLimit        = 50
+	MaxStoredRecommendations      = 50
+	MinCoRaters                   = 2
+	RecommendationRefreshInterval = time.Hour
+
+	RecommendationPersonalized = "personalized"
+	RecommendationTopRated     = "top_rated"
+)
@@ -0,0 +1,28 @@
+package constants
+
+import "time"
+
+const (
+	ReviewSortNewest        = "newest"
+	ReviewSortOldest        = "oldest"
+	ReviewSortHighestRating = "highest_rating"
+	ReviewSortLowestRating  = "lowest_rating"
+	ReviewSortMostHelpful   = "most_helpful"
+
+	ReviewVoteUp   = "up"
+	ReviewVoteDown = "down"
+
+	DefaultReviewSort  = ReviewSortNewest
+	DefaultReviewLimit = 20
+	MaxReviewLimit     = 100
+	ReviewListCacheTTL = 5 * time.Minute
+)
+
+var (
+	ListReviewSort = []string{ReviewSortNewest, ReviewSortOldest, ReviewSortHighestRating, ReviewSortLowestRating, ReviewSortMostHelpful}
+
+	MapperReviewVoteToValue = map[string]int{
+		ReviewVoteUp:   1,
+		ReviewVoteDown: -1,
+	}
+)
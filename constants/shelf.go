@@ -0,0 +1,17 @@
+package constants
+
+const (
+	ShelfWantToRead = "want_to_read"
+	ShelfReading    = "reading"
+	ShelfRead       = "read"
+
+	MaxShelfNameLength = 30
+
+	DefaultShelfPage  = 1
+	DefaultShelfLimit = 20
+	MaxShelfLimit     = 100
+)
+
+var (
+	ListShelfStatus = []string{ShelfWantToRead, ShelfReading, ShelfRead}
+)
@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+const (
+	// trash types are named after the tables they live in
+	TrashBooks   = "books"
+	TrashUsers   = "users"
+	TrashReviews = "reviews"
+
+	DefaultTrashPage  = 1
+	DefaultTrashLimit = 20
+	MaxTrashLimit     = 100
+
+	DefaultTrashRetentionDays = 30
+	TrashPurgeInterval        = time.Hour
+)
+
+var (
+	ListTrashType = []string{TrashBooks, TrashUsers, TrashReviews}
+)
@@ -6,6 +6,9 @@ const (
 	User                    = "user"
 	Male                    = "male"
 	Female                  = "female"
+
+	MaxFullNameLength = 30
+	MaxUsernameLength = 30
 )
 
 var (
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // RistrettoCache is an autogenerated mock type for the RistrettoCache type
 type RistrettoCache struct {
@@ -41,6 +45,11 @@ func (_m *RistrettoCache) Set(key string, value interface{}) {
 	_m.Called(key, value)
 }
 
+// SetWithTTL provides a mock function with given fields: key, value, ttl
+func (_m *RistrettoCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	_m.Called(key, value, ttl)
+}
+
 type mockConstructorTestingTNewRistrettoCache interface {
 	mock.TestingT
 	Cleanup(func())
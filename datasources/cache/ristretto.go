@@ -1,11 +1,14 @@
 package cache
 
 import (
+	"time"
+
 	ristr "github.com/dgraph-io/ristretto"
 )
 
 type RistrettoCache interface {
 	Set(key string, value interface{})
+	SetWithTTL(key string, value interface{}, ttl time.Duration)
 	Get(key string) interface{}
 	Del(key ...string)
 }
@@ -31,6 +34,10 @@ func (cache *ristrettoCache) Set(key string, value interface{}) {
 	cache.cache.Set(key, value, 1)
 }
 
+func (cache *ristrettoCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	cache.cache.SetWithTTL(key, value, 1, ttl)
+}
+
 func (cache *ristrettoCache) Get(key string) interface{} {
 	val, _ := cache.cache.Get(key)
 
@@ -0,0 +1,130 @@
+package authors
+
+import (
+	"context"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/authors"
+	"gorm.io/gorm"
+)
+
+type postgreAuthorRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreAuthorRepository(conn *gorm.DB) authors.Repository {
+	return &postgreAuthorRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreAuthorRepository) Store(ctx context.Context, domain *authors.Domain) (authors.Domain, error) {
+	author := FromDomain(domain)
+	if err := r.conn.Create(&author).Error; err != nil {
+		return authors.Domain{}, err
+	}
+
+	return author.ToDomain(), nil
+}
+
+func (r *postgreAuthorRepository) GetAll(ctx context.Context) ([]authors.Domain, error) {
+	var records []Author
+	if err := r.conn.Order("name").Find(&records).Error; err != nil {
+		return []authors.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreAuthorRepository) GetById(ctx context.Context, id int) (authors.Domain, error) {
+	var author Author
+	if err := r.conn.First(&author, id).Error; err != nil {
+		return authors.Domain{}, err
+	}
+
+	return author.ToDomain(), nil
+}
+
+func (r *postgreAuthorRepository) GetByName(ctx context.Context, name string) (authors.Domain, error) {
+	var author Author
+	if err := r.conn.Where("lower(name) = lower(?)", name).First(&author).Error; err != nil {
+		return authors.Domain{}, err
+	}
+
+	return author.ToDomain(), nil
+}
+
+func (r *postgreAuthorRepository) GetByIds(ctx context.Context, ids []int) ([]authors.Domain, error) {
+	var records []Author
+	if err := r.conn.Where("id IN ?", ids).Find(&records).Error; err != nil {
+		return []authors.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreAuthorRepository) Update(ctx context.Context, domain *authors.Domain) ([]int, error) {
+	author := FromDomain(domain)
+
+	var bookIds []int
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Author{}).Where("id = ?", author.Id).Updates(map[string]interface{}{"name": author.Name, "bio": author.Bio}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&BookAuthor{}).Where("author_id = ?", author.Id).Distinct().Pluck("book_id", &bookIds).Error; err != nil {
+			return err
+		}
+
+		// a renamed author changes the author text of every book crediting them
+		return books.SyncBookAuthorText(tx, bookIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookIds, nil
+}
+
+func (r *postgreAuthorRepository) Delete(ctx context.Context, id int) error {
+	return r.conn.Delete(&Author{}, id).Error
+}
+
+func (r *postgreAuthorRepository) GetCreditsByAuthorId(ctx context.Context, authorId int) ([]authors.Credit, error) {
+	var records []BookAuthor
+	if err := r.conn.Joins("Book").Preload("Author").Where(BookAuthor{AuthorId: authorId}).Order(`"Book".title, role`).Find(&records).Error; err != nil {
+		return []authors.Credit{}, err
+	}
+
+	return ToArrayOfCreditDomain(&records), nil
+}
+
+func (r *postgreAuthorRepository) GetCreditsByBookId(ctx context.Context, bookId int) ([]authors.Credit, error) {
+	var records []BookAuthor
+	if err := r.conn.Joins("Author").Preload("Book").Where(BookAuthor{BookId: bookId}).Order("position").Find(&records).Error; err != nil {
+		return []authors.Credit{}, err
+	}
+
+	return ToArrayOfCreditDomain(&records), nil
+}
+
+func (r *postgreAuthorRepository) ReplaceBookCredits(ctx context.Context, bookId int, credits []authors.Credit) error {
+	records := make([]BookAuthor, len(credits))
+	for i := range credits {
+		records[i] = FromCreditDomain(&credits[i])
+	}
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", bookId).Delete(&BookAuthor{}).Error; err != nil {
+			return err
+		}
+
+		if len(records) > 0 {
+			if err := tx.Create(&records).Error; err != nil {
+				return err
+			}
+		}
+
+		return books.SyncBookAuthorText(tx, []int{bookId})
+	})
+}
@@ -0,0 +1,87 @@
+package authors
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/authors"
+	"gorm.io/gorm"
+)
+
+type Author struct {
+	Id        int    `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"type:varchar(100); not null"`
+	Bio       string `gorm:"type:text; not null; default:''"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type BookAuthor struct {
+	BookId   int `gorm:"primaryKey"`
+	Book     books.Book
+	AuthorId int `gorm:"primaryKey;index"`
+	Author   Author
+	Role     string `gorm:"primaryKey; type:varchar(15)"`
+	Position int    `gorm:"type:integer; not null"`
+}
+
+func (a *Author) ToDomain() authors.Domain {
+	return authors.Domain{
+		ID:        a.Id,
+		Name:      a.Name,
+		Bio:       a.Bio,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func FromDomain(domain *authors.Domain) Author {
+	return Author{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		Bio:       domain.Bio,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func (ba *BookAuthor) ToDomain() authors.Credit {
+	return authors.Credit{
+		BookId:   ba.BookId,
+		Book:     ba.Book.ToDomain(),
+		AuthorId: ba.AuthorId,
+		Author:   ba.Author.ToDomain(),
+		Role:     ba.Role,
+		Position: ba.Position,
+	}
+}
+
+func FromCreditDomain(domain *authors.Credit) BookAuthor {
+	return BookAuthor{
+		BookId:   domain.BookId,
+		AuthorId: domain.AuthorId,
+		Role:     domain.Role,
+		Position: domain.Position,
+	}
+}
+
+func ToArrayOfDomain(records *[]Author) []authors.Domain {
+	var result []authors.Domain
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
+
+func ToArrayOfCreditDomain(records *[]BookAuthor) []authors.Credit {
+	var result []authors.Credit
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
@@ -0,0 +1,121 @@
+package books
+
+import (
+	"github.com/snykk/golib_backend/constants"
+	"gorm.io/gorm"
+)
+
+// LinkAuthors replaces the "author" credits of the given books with the authors named in their author text,
+// creating the authors that don't exist yet. Multiple authors are split on "," "&" and ";", whitespace is collapsed
+// and names differing only in case are merged under their most common spelling. Books whose credits already spell
+// out their text are left alone, so are editors and translators.
+func LinkAuthors(tx *gorm.DB, bookIds []int) error {
+	if len(bookIds) == 0 {
+		return nil
+	}
+
+	err := tx.Exec(`CREATE TEMPORARY TABLE book_author_names ON COMMIT DROP AS
+		SELECT b.id AS book_id, names.position, regexp_replace(trim(names.name), '\s+', ' ', 'g') AS name
+		FROM "books" b
+		CROSS JOIN LATERAL regexp_split_to_table(b.author, '\s*[,&;]\s*') WITH ORDINALITY AS names(name, position)
+		WHERE b.id IN ? AND trim(names.name) <> ''
+		AND b.author IS DISTINCT FROM (
+			SELECT string_agg(a.name, ', ' ORDER BY ba.position)
+			FROM "book_authors" ba JOIN "authors" a ON a.id = ba.author_id AND a."deleted_at" IS NULL
+			WHERE ba.book_id = b.id AND ba.role = ?
+		)`, bookIds, constants.AuthorRoleAuthor).Error
+	if err != nil {
+		return err
+	}
+
+	var staleIds []int
+	if err = tx.Raw(`SELECT DISTINCT book_id FROM book_author_names`).Scan(&staleIds).Error; err != nil {
+		return err
+	}
+
+	if len(staleIds) > 0 {
+		err = tx.Exec(`INSERT INTO "authors" (name, bio, created_at, updated_at)
+			SELECT DISTINCT ON (lower(name)) name, '', now(), now()
+			FROM (SELECT name, count(*) AS total FROM book_author_names GROUP BY name) AS spellings
+			WHERE NOT EXISTS (SELECT 1 FROM "authors" a WHERE lower(a.name) = lower(spellings.name) AND a."deleted_at" IS NULL)
+			ORDER BY lower(name), total DESC, name`).Error
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Exec(`DELETE FROM "book_authors" WHERE book_id IN ? AND role = ?`, staleIds, constants.AuthorRoleAuthor).Error; err != nil {
+			return err
+		}
+
+		err = tx.Exec(`INSERT INTO "book_authors" (book_id, author_id, role, position)
+			SELECT n.book_id, a.id, ?, min(n.position)
+			FROM book_author_names n JOIN "authors" a ON lower(a.name) = lower(n.name) AND a."deleted_at" IS NULL
+			GROUP BY n.book_id, a.id`, constants.AuthorRoleAuthor).Error
+		if err != nil {
+			return err
+		}
+
+		if err = SyncBookAuthorText(tx, staleIds); err != nil {
+			return err
+		}
+	}
+
+	// a transaction can link more than once, so the names can't wait for the commit to go
+	return tx.Exec(`DROP TABLE book_author_names`).Error
+}
+
+// LinkPublishers points the given books at the publisher named in their publisher text, creating the publisher
+// when it doesn't exist yet. Books without a publisher lose their link.
+func LinkPublishers(tx *gorm.DB, bookIds []int) error {
+	if len(bookIds) == 0 {
+		return nil
+	}
+
+	err := tx.Exec(`INSERT INTO "publishers" (name, created_at, updated_at)
+		SELECT DISTINCT ON (lower(name)) name, now(), now()
+		FROM (
+			SELECT regexp_replace(trim(publisher), '\s+', ' ', 'g') AS name, count(*) AS total
+			FROM "books" WHERE id IN ? AND trim(publisher) <> ''
+			GROUP BY 1
+		) AS spellings
+		WHERE NOT EXISTS (SELECT 1 FROM "publishers" p WHERE lower(p.name) = lower(spellings.name) AND p."deleted_at" IS NULL)
+		ORDER BY lower(name), total DESC, name`, bookIds).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Exec(`UPDATE "books" b SET publisher_id = p.id, publisher = p.name
+		FROM "publishers" p
+		WHERE b.id IN ? AND p."deleted_at" IS NULL
+		AND lower(p.name) = lower(regexp_replace(trim(b.publisher), '\s+', ' ', 'g'))`, bookIds).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(`UPDATE "books" SET publisher_id = NULL WHERE id IN ? AND trim(publisher) = ''`, bookIds).Error
+}
+
+// SyncBookAuthorText rebuilds the denormalized author text of the given books from their "author" credits
+func SyncBookAuthorText(tx *gorm.DB, bookIds []int) error {
+	if len(bookIds) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE "books" SET author = left(credits.names, 255)
+		FROM (
+			SELECT ba.book_id, string_agg(a.name, ', ' ORDER BY ba.position) AS names
+			FROM "book_authors" ba JOIN "authors" a ON a.id = ba.author_id AND a."deleted_at" IS NULL
+			WHERE ba.role = ? AND ba.book_id IN ?
+			GROUP BY ba.book_id
+		) AS credits
+		WHERE "books".id = credits.book_id`, constants.AuthorRoleAuthor, bookIds).Error
+}
+
+// linkCredits links written books to their authors and publisher, it runs in the transaction of the write
+func linkCredits(tx *gorm.DB, bookIds ...int) error {
+	if err := LinkAuthors(tx, bookIds); err != nil {
+		return err
+	}
+
+	return LinkPublishers(tx, bookIds)
+}
@@ -15,6 +15,20 @@ type Repository struct {
 	mock.Mock
 }
 
+// AddTags provides a mock function with given fields: ctx, id, tags
+func (_m *Repository) AddTags(ctx context.Context, id int, tags []string) error {
+	ret := _m.Called(ctx, id, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, id, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *Repository) Delete(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -29,22 +43,73 @@ func (_m *Repository) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *Repository) GetAll(ctx context.Context) ([]books.Domain, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: ctx, query
+func (_m *Repository) GetAll(ctx context.Context, query *books.Query) ([]books.Domain, int, error) {
+	ret := _m.Called(ctx, query)
 
 	var r0 []books.Domain
-	if rf, ok := ret.Get(0).(func(context.Context) []books.Domain); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *books.Query) []books.Domain); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]books.Domain)
 		}
 	}
 
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, *books.Query) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *books.Query) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByISBN provides a mock function with given fields: ctx, isbn
+func (_m *Repository) GetByISBN(ctx context.Context, isbn string) (books.Domain, error) {
+	ret := _m.Called(ctx, isbn)
+
+	var r0 books.Domain
+	if rf, ok := ret.Get(0).(func(context.Context, string) books.Domain); ok {
+		r0 = rf(ctx, isbn)
+	} else {
+		r0 = ret.Get(0).(books.Domain)
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, isbn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByISBNs provides a mock function with given fields: ctx, isbns
+func (_m *Repository) GetByISBNs(ctx context.Context, isbns []string) ([]books.Domain, error) {
+	ret := _m.Called(ctx, isbns)
+
+	var r0 []books.Domain
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []books.Domain); ok {
+		r0 = rf(ctx, isbns)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.Domain)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, isbns)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,6 +138,200 @@ func (_m *Repository) GetById(ctx context.Context, id int) (books.Domain, error)
 	return r0, r1
 }
 
+// GetByIds provides a mock function with given fields: ctx, ids
+func (_m *Repository) GetByIds(ctx context.Context, ids []int) ([]books.Domain, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []books.Domain
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []books.Domain); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.Domain)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRatingStats provides a mock function with given fields: ctx, id
+func (_m *Repository) GetRatingStats(ctx context.Context, id int) ([]books.RatingCount, []books.RatingMonth, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []books.RatingCount
+	if rf, ok := ret.Get(0).(func(context.Context, int) []books.RatingCount); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.RatingCount)
+		}
+	}
+
+	var r1 []books.RatingMonth
+	if rf, ok := ret.Get(1).(func(context.Context, int) []books.RatingMonth); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]books.RatingMonth)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTags provides a mock function with given fields: ctx
+func (_m *Repository) GetTags(ctx context.Context) ([]books.Tag, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []books.Tag
+	if rf, ok := ret.Get(0).(func(context.Context) []books.Tag); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersion provides a mock function with given fields: ctx, id, version
+func (_m *Repository) GetVersion(ctx context.Context, id int, version int) (books.Version, error) {
+	ret := _m.Called(ctx, id, version)
+
+	var r0 books.Version
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) books.Version); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(books.Version)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersions provides a mock function with given fields: ctx, id
+func (_m *Repository) GetVersions(ctx context.Context, id int) ([]books.Version, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []books.Version
+	if rf, ok := ret.Get(0).(func(context.Context, int) []books.Version); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.Version)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: ctx, book, fields
+func (_m *Repository) Patch(ctx context.Context, book *books.Domain, fields []string) error {
+	ret := _m.Called(ctx, book, fields)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *books.Domain, []string) error); ok {
+		r0 = rf(ctx, book, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTag provides a mock function with given fields: ctx, id, tag
+func (_m *Repository) RemoveTag(ctx context.Context, id int, tag string) error {
+	ret := _m.Called(ctx, id, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Revert provides a mock function with given fields: ctx, book, version
+func (_m *Repository) Revert(ctx context.Context, book *books.Domain, version int) error {
+	ret := _m.Called(ctx, book, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *books.Domain, int) error); ok {
+		r0 = rf(ctx, book, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, query
+func (_m *Repository) Search(ctx context.Context, query *books.SearchQuery) ([]books.SearchResult, int, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []books.SearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, *books.SearchQuery) []books.SearchResult); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.SearchResult)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, *books.SearchQuery) int); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *books.SearchQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Store provides a mock function with given fields: ctx, book
 func (_m *Repository) Store(ctx context.Context, book *books.Domain) (books.Domain, error) {
 	ret := _m.Called(ctx, book)
@@ -94,6 +353,75 @@ func (_m *Repository) Store(ctx context.Context, book *books.Domain) (books.Doma
 	return r0, r1
 }
 
+// StoreBatch provides a mock function with given fields: ctx, _a1
+func (_m *Repository) StoreBatch(ctx context.Context, _a1 []books.Domain) ([]books.Domain, []error, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 []books.Domain
+	if rf, ok := ret.Get(0).(func(context.Context, []books.Domain) []books.Domain); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.Domain)
+		}
+	}
+
+	var r1 []error
+	if rf, ok := ret.Get(1).(func(context.Context, []books.Domain) []error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []books.Domain) error); ok {
+		r2 = rf(ctx, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Stream provides a mock function with given fields: ctx, fn
+func (_m *Repository) Stream(ctx context.Context, fn func(books.Domain) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(books.Domain) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Suggest provides a mock function with given fields: ctx, prefix, limit
+func (_m *Repository) Suggest(ctx context.Context, prefix string, limit int) ([]books.Suggestion, error) {
+	ret := _m.Called(ctx, prefix, limit)
+
+	var r0 []books.Suggestion
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []books.Suggestion); ok {
+		r0 = rf(ctx, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]books.Suggestion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, book
 func (_m *Repository) Update(ctx context.Context, book *books.Domain) error {
 	ret := _m.Called(ctx, book)
@@ -108,6 +436,20 @@ func (_m *Repository) Update(ctx context.Context, book *books.Domain) error {
 	return r0
 }
 
+// UpdateCover provides a mock function with given fields: ctx, id, cover
+func (_m *Repository) UpdateCover(ctx context.Context, id int, cover string) error {
+	ret := _m.Called(ctx, id, cover)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, cover)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
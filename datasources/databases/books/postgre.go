@@ -2,9 +2,12 @@ package books
 
 import (
 	"context"
+	"strings"
 
+	"github.com/snykk/golib_backend/constants"
 	"github.com/snykk/golib_backend/domains/books"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type postgreBookRepository struct {
@@ -19,19 +22,196 @@ func NewPostgreBookRepository(conn *gorm.DB) books.Repository {
 
 func (r *postgreBookRepository) Store(ctx context.Context, b *books.Domain) (books.Domain, error) {
 	var result = FromDomain(b)
-	if err := r.conn.Save(&result).Error; err != nil {
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&result).Error; err != nil {
+			return err
+		}
+
+		// the links settle the author and publisher text, so the history starts from what they left
+		if err := linkCredits(tx, result.Id); err != nil {
+			return err
+		}
+		if err := tx.First(&result, result.Id).Error; err != nil {
+			return err
+		}
+
+		return recordVersion(ctx, tx, constants.BookVersionCreated, nil, &result, nil)
+	})
+	if err != nil {
 		return books.Domain{}, err
 	}
 
 	return result.ToDomain(), nil
 }
 
-func (r *postgreBookRepository) GetAll(ctx context.Context) ([]books.Domain, error) {
+func (r *postgreBookRepository) StoreBatch(ctx context.Context, bookDomains []books.Domain) ([]books.Domain, []error, error) {
+	records := make([]Book, len(bookDomains))
+	for i := range bookDomains {
+		records[i] = FromDomain(&bookDomains[i])
+	}
+	failures := make([]error, len(records))
+	result := make([]books.Domain, len(records))
+
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(records); start += constants.BookImportBatchSize {
+			end := start + constants.BookImportBatchSize
+			if end > len(records) {
+				end = len(records)
+			}
+			if err := storeRows(tx, records[start:end], failures[start:end]); err != nil {
+				return err
+			}
+		}
+
+		var bookIds []int
+		for i := range records {
+			if failures[i] == nil {
+				bookIds = append(bookIds, records[i].Id)
+			}
+		}
+		if len(bookIds) == 0 {
+			return nil
+		}
+
+		if err := linkCredits(tx, bookIds...); err != nil {
+			return err
+		}
+		var linked []Book
+		if err := tx.Where("id IN ?", bookIds).Find(&linked).Error; err != nil {
+			return err
+		}
+		linkedById := make(map[int]Book, len(linked))
+		for _, record := range linked {
+			linkedById[record.Id] = record
+		}
+
+		// every imported book is new, so each history starts at the first version
+		var versions []BookVersion
+		for i := range records {
+			if failures[i] != nil {
+				continue
+			}
+			records[i] = linkedById[records[i].Id]
+			version, _, err := newVersion(ctx, constants.BookVersionCreated, nil, &records[i], nil)
+			if err != nil {
+				return err
+			}
+			version.Version = 1
+			versions = append(versions, version)
+			result[i] = records[i].ToDomain()
+		}
+
+		return tx.CreateInBatches(&versions, constants.BookImportBatchSize).Error
+	})
+	if err != nil {
+		return []books.Domain{}, []error{}, err
+	}
+
+	return result, failures, nil
+}
+
+// storeRows inserts the rows in one go, when that fails they are retried one by one behind savepoints so a bad row
+// only fails itself and the rest of the import still goes through
+func storeRows(tx *gorm.DB, records []Book, failures []error) error {
+	if err := tx.SavePoint("batch").Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&records).Error; err == nil {
+		return nil
+	}
+	if err := tx.RollbackTo("batch").Error; err != nil {
+		return err
+	}
+
+	for i := range records {
+		records[i].Id = 0
+		if err := tx.SavePoint("row").Error; err != nil {
+			return err
+		}
+		if failures[i] = tx.Create(&records[i]).Error; failures[i] != nil {
+			if err := tx.RollbackTo("row").Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *postgreBookRepository) GetAll(ctx context.Context, query *books.Query) ([]books.Domain, int, error) {
+	db := r.conn.Model(&Book{})
+	if query.Author != "" {
+		db = db.Where("author ILIKE ?", "%"+escapeLike(query.Author)+"%")
+	}
+	if query.Publisher != "" {
+		db = db.Where("publisher ILIKE ?", "%"+escapeLike(query.Publisher)+"%")
+	}
+	if query.ISBN != "" {
+		db = db.Where("isbn = ?", query.ISBN)
+	}
+	if query.MinRating != nil {
+		db = db.Where("rating >= ?", *query.MinRating)
+	}
+	if query.MaxRating != nil {
+		db = db.Where("rating <= ?", *query.MaxRating)
+	}
+	if query.Category != 0 {
+		// a category also lists the books of every category below it
+		db = db.Where(`id IN (
+			SELECT bc.book_id FROM "book_categories" bc WHERE bc.category_id IN (
+				WITH RECURSIVE tree AS (
+					SELECT id FROM "categories" WHERE id = ? AND "deleted_at" IS NULL
+					UNION ALL
+					SELECT c.id FROM "categories" c JOIN tree ON c.parent_id = tree.id WHERE c."deleted_at" IS NULL
+				)
+				SELECT id FROM tree
+			)
+		)`, query.Category)
+	}
+	for _, tag := range query.Tags {
+		db = db.Where(`id IN (SELECT bt.book_id FROM "book_tags" bt JOIN "tags" t ON t.id = bt.tag_id WHERE t.name = ?)`, tag)
+	}
+	if query.Language != "" {
+		db = db.Where("language = ?", query.Language)
+	}
+	if query.Format != "" {
+		db = db.Where("format = ?", query.Format)
+	}
+	if query.Series != "" {
+		db = db.Where("lower(series) = lower(?)", query.Series)
+	}
+	if query.MinPages != nil {
+		db = db.Where("page_count >= ?", *query.MinPages)
+	}
+	if query.MaxPages != nil {
+		db = db.Where("page_count <= ?", *query.MaxPages)
+	}
+	if query.PublishedFrom != nil {
+		db = db.Where("publication_date >= ?", *query.PublishedFrom)
+	}
+	if query.PublishedTo != nil {
+		db = db.Where("publication_date <= ?", *query.PublishedTo)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return []books.Domain{}, 0, err
+	}
+
+	// books without a publication date go last whichever way the dates are ordered
+	if query.Sort == "publication_date" {
+		db = db.Order("publication_date IS NULL")
+	}
+
 	var booksFromDB []Book
-	err := r.conn.Find(&booksFromDB).Error
+	err := db.Scopes(withLabels).Order(clause.OrderByColumn{Column: clause.Column{Name: query.Sort}, Desc: query.Order == "desc"}).
+		Order("id").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&booksFromDB).Error
 
 	if err != nil {
-		return []books.Domain{}, err
+		return []books.Domain{}, 0, err
 	}
 
 	var convertedBook []books.Domain
@@ -40,27 +220,267 @@ func (r *postgreBookRepository) GetAll(ctx context.Context) ([]books.Domain, err
 		convertedBook = append(convertedBook, val.ToDomain())
 	}
 
-	return convertedBook, nil
+	return convertedBook, int(total), nil
+}
+
+func (r *postgreBookRepository) Search(ctx context.Context, query *books.SearchQuery) ([]books.SearchResult, int, error) {
+	var total int64
+	if err := r.conn.Raw(`SELECT COUNT(*) FROM "books" WHERE search_vector @@ websearch_to_tsquery('simple', ?) AND "deleted_at" IS NULL`, query.Keyword).Scan(&total).Error; err != nil {
+		return []books.SearchResult{}, 0, err
+	}
+
+	var rows []SearchRow
+	err := r.conn.Raw(`SELECT "books".*,
+			ts_rank_cd("books".search_vector, q) AS rank,
+			ts_headline('simple', "books".title, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS title_highlight,
+			ts_headline('simple', "books".description, q, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=25, MinWords=10') AS snippet
+		FROM "books", websearch_to_tsquery('simple', ?) AS q
+		WHERE "books".search_vector @@ q AND "books"."deleted_at" IS NULL
+		ORDER BY rank DESC, "books".id
+		LIMIT ? OFFSET ?`, query.Keyword, query.Limit, (query.Page-1)*query.Limit).Scan(&rows).Error
+	if err != nil {
+		return []books.SearchResult{}, 0, err
+	}
+
+	// the raw query can't preload, categories and tags of the page are fetched in one go afterwards
+	if len(rows) > 0 {
+		ids := make([]int, len(rows))
+		for i, row := range rows {
+			ids[i] = row.Id
+		}
+
+		var labelled []Book
+		if err := r.conn.Scopes(withLabels).Find(&labelled, ids).Error; err != nil {
+			return []books.SearchResult{}, 0, err
+		}
+
+		labels := make(map[int]Book, len(labelled))
+		for _, book := range labelled {
+			labels[book.Id] = book
+		}
+		for i := range rows {
+			rows[i].Categories = labels[rows[i].Id].Categories
+			rows[i].Tags = labels[rows[i].Id].Tags
+		}
+	}
+
+	var results []books.SearchResult
+	for _, row := range rows {
+		results = append(results, row.ToDomain())
+	}
+
+	return results, int(total), nil
+}
+
+func (r *postgreBookRepository) Suggest(ctx context.Context, prefix string, limit int) ([]books.Suggestion, error) {
+	// matches anywhere in the value are served by the trigram indexes, values starting with the prefix are ranked first
+	var suggestions []books.Suggestion
+	err := r.conn.WithContext(ctx).Raw(`SELECT value, field FROM (
+			(SELECT title AS value, 'title' AS field, title ILIKE @starts AS is_prefix, similarity(title, @prefix) AS score
+				FROM "books" WHERE title ILIKE @contains AND "deleted_at" IS NULL
+				GROUP BY title ORDER BY is_prefix DESC, score DESC LIMIT @limit)
+			UNION ALL
+			(SELECT author AS value, 'author' AS field, author ILIKE @starts AS is_prefix, similarity(author, @prefix) AS score
+				FROM "books" WHERE author ILIKE @contains AND "deleted_at" IS NULL
+				GROUP BY author ORDER BY is_prefix DESC, score DESC LIMIT @limit)
+		) AS suggestions
+		ORDER BY is_prefix DESC, score DESC, value
+		LIMIT @limit`, map[string]interface{}{
+		"prefix":   prefix,
+		"starts":   escapeLike(prefix) + "%",
+		"contains": "%" + escapeLike(prefix) + "%",
+		"limit":    limit,
+	}).Scan(&suggestions).Error
+	if err != nil {
+		return []books.Suggestion{}, err
+	}
+
+	return suggestions, nil
 }
 
 func (r *postgreBookRepository) GetById(ctx context.Context, id int) (books.Domain, error) {
 	var book Book
 
-	if err := r.conn.First(&book, id).Error; err != nil {
+	if err := r.conn.Scopes(withLabels).First(&book, id).Error; err != nil {
+		return books.Domain{}, err
+	}
+
+	return book.ToDomain(), nil
+}
+
+func (r *postgreBookRepository) GetByISBN(ctx context.Context, isbn string) (books.Domain, error) {
+	var book Book
+
+	if err := r.conn.Scopes(withLabels).Where("isbn = ?", isbn).First(&book).Error; err != nil {
 		return books.Domain{}, err
 	}
 
 	return book.ToDomain(), nil
 }
 
+func (r *postgreBookRepository) GetByISBNs(ctx context.Context, isbns []string) ([]books.Domain, error) {
+	var booksFromDB []Book
+
+	if err := r.conn.Where("isbn IN ?", isbns).Find(&booksFromDB).Error; err != nil {
+		return []books.Domain{}, err
+	}
+
+	var result []books.Domain
+	for _, val := range booksFromDB {
+		result = append(result, val.ToDomain())
+	}
+
+	return result, nil
+}
+
+func (r *postgreBookRepository) GetByIds(ctx context.Context, ids []int) ([]books.Domain, error) {
+	var booksFromDB []Book
+
+	if err := r.conn.Where("id IN ?", ids).Find(&booksFromDB).Error; err != nil {
+		return []books.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&booksFromDB), nil
+}
+
+func (r *postgreBookRepository) Stream(ctx context.Context, fn func(book books.Domain) error) error {
+	// rows are read one by one from the cursor so the export never loads the whole table
+	rows, err := r.conn.WithContext(ctx).Model(&Book{}).Order("id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book Book
+		if err := r.conn.ScanRows(rows, &book); err != nil {
+			return err
+		}
+		if err := fn(book.ToDomain()); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (r *postgreBookRepository) Update(ctx context.Context, b *books.Domain) (err error) {
 	bookFromDB := FromDomain(b)
-	err = r.conn.Model(&Book{}).Model(&bookFromDB).Updates(&bookFromDB).Error
-	return
+	return r.versioned(ctx, bookFromDB.Id, constants.BookVersionUpdated, nil, func(tx *gorm.DB) error {
+		if err := tx.Model(&bookFromDB).Updates(&bookFromDB).Error; err != nil {
+			return err
+		}
 
+		return linkCredits(tx, bookFromDB.Id)
+	})
+}
+
+func (r *postgreBookRepository) Patch(ctx context.Context, b *books.Domain, fields []string) error {
+	book := FromDomain(b)
+
+	// selecting the columns makes gorm write them even when the patch emptied them out
+	return r.versioned(ctx, book.Id, constants.BookVersionUpdated, nil, func(tx *gorm.DB) error {
+		if err := tx.Model(&book).Select(fields).Updates(&book).Error; err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			if field == "author" || field == "publisher" {
+				return linkCredits(tx, book.Id)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgreBookRepository) UpdateCover(ctx context.Context, id int, cover string) error {
+	return r.versioned(ctx, id, constants.BookVersionUpdated, nil, func(tx *gorm.DB) error {
+		return tx.Model(&Book{}).Where("id = ?", id).Update("cover", cover).Error
+	})
 }
 
 func (r *postgreBookRepository) Delete(ctx context.Context, id int) (err error) {
-	err = r.conn.Delete(&Book{}, id).Error
-	return
+	return r.versioned(ctx, id, constants.BookVersionDeleted, nil, func(tx *gorm.DB) error {
+		return tx.Delete(&Book{}, id).Error
+	})
+}
+
+func (r *postgreBookRepository) GetVersions(ctx context.Context, id int) ([]books.Version, error) {
+	var records []BookVersion
+	if err := r.conn.Where("book_id = ?", id).Order("version DESC").Find(&records).Error; err != nil {
+		return []books.Version{}, err
+	}
+
+	return ToArrayOfVersionDomain(&records), nil
+}
+
+func (r *postgreBookRepository) GetVersion(ctx context.Context, id int, version int) (books.Version, error) {
+	var record BookVersion
+	if err := r.conn.Where("book_id = ? AND version = ?", id, version).First(&record).Error; err != nil {
+		return books.Version{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreBookRepository) Revert(ctx context.Context, b *books.Domain, version int) error {
+	book := FromDomain(b)
+
+	return r.versioned(ctx, book.Id, constants.BookVersionReverted, &version, func(tx *gorm.DB) error {
+		if err := tx.Model(&book).Select("title", "subtitle", "description", "author", "publisher", "publisher_id", "isbn", "edition", "language", "format", "series", "series_position", "page_count", "publication_date").Updates(&book).Error; err != nil {
+			return err
+		}
+
+		return linkCredits(tx, book.Id)
+	})
+}
+
+func (r *postgreBookRepository) GetTags(ctx context.Context) ([]books.Tag, error) {
+	var tags []books.Tag
+	err := r.conn.Raw(`SELECT t.name, COUNT(b.id) AS books
+		FROM "tags" t
+		JOIN "book_tags" bt ON bt.tag_id = t.id
+		JOIN "books" b ON b.id = bt.book_id AND b."deleted_at" IS NULL
+		GROUP BY t.name
+		ORDER BY books DESC, t.name`).Scan(&tags).Error
+	if err != nil {
+		return []books.Tag{}, err
+	}
+
+	return tags, nil
+}
+
+func (r *postgreBookRepository) AddTags(ctx context.Context, id int, tags []string) error {
+	records := make([]Tag, len(tags))
+	for i, name := range tags {
+		records[i] = Tag{Name: name}
+	}
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		// tags are shared between books, only the ones nobody used before are created
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&records).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`INSERT INTO "book_tags" (book_id, tag_id)
+			SELECT ?, id FROM "tags" WHERE name IN ?
+			ON CONFLICT DO NOTHING`, id, tags).Error
+	})
+}
+
+func (r *postgreBookRepository) RemoveTag(ctx context.Context, id int, tag string) error {
+	return r.conn.Exec(`DELETE FROM "book_tags" WHERE book_id = ? AND tag_id = (SELECT id FROM "tags" WHERE name = ?)`, id, tag).Error
+}
+
+// withLabels loads the categories and tags shown along with a book
+func withLabels(db *gorm.DB) *gorm.DB {
+	return db.Preload("Categories", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	})
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
 }
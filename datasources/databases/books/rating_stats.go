@@ -0,0 +1,90 @@
+package books
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+	"gorm.io/gorm"
+)
+
+// BookRatingCount is one bar of a book's rating histogram
+type BookRatingCount struct {
+	BookId  int `gorm:"primaryKey"`
+	Rating  int `gorm:"primaryKey; type:smallint"`
+	Reviews int `gorm:"type:integer; not null"`
+}
+
+// BookRatingMonth sums up the reviews a book got in the month they were written
+type BookRatingMonth struct {
+	BookId    int       `gorm:"primaryKey"`
+	Month     time.Time `gorm:"primaryKey; type:date"`
+	Reviews   int       `gorm:"type:integer; not null"`
+	RatingSum int       `gorm:"type:integer; not null"`
+}
+
+// AddRating counts a review in or out (delta -1) of the rating statistics of its book, it runs inside the
+// transaction writing the review so the statistics never need a full scan of the reviews
+func AddRating(tx *gorm.DB, bookId int, rating int, createdAt time.Time, delta int) error {
+	if err := tx.Exec(`INSERT INTO "book_rating_counts" (book_id, rating, reviews) VALUES (?, ?, ?)
+		ON CONFLICT (book_id, rating) DO UPDATE SET reviews = "book_rating_counts".reviews + EXCLUDED.reviews`,
+		bookId, rating, delta).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`INSERT INTO "book_rating_months" (book_id, month, reviews, rating_sum) VALUES (?, date_trunc('month', ?::timestamptz)::date, ?, ?)
+		ON CONFLICT (book_id, month) DO UPDATE SET reviews = "book_rating_months".reviews + EXCLUDED.reviews, rating_sum = "book_rating_months".rating_sum + EXCLUDED.rating_sum`,
+		bookId, createdAt, delta, delta*rating).Error; err != nil {
+		return err
+	}
+
+	// a counted out review may leave empty buckets behind
+	if delta < 0 {
+		if err := tx.Exec(`DELETE FROM "book_rating_counts" WHERE book_id = ? AND reviews <= 0`, bookId).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM "book_rating_months" WHERE book_id = ? AND reviews <= 0`, bookId).Error
+	}
+
+	return nil
+}
+
+// SyncRatingStats rebuilds the rating statistics of the given books from their live reviews, for the few
+// places where reviews come and go in bulk (restoring or purging a user or a book)
+func SyncRatingStats(tx *gorm.DB, bookIds []int) error {
+	if len(bookIds) == 0 {
+		return nil
+	}
+
+	for _, table := range []string{"book_rating_counts", "book_rating_months"} {
+		if err := tx.Exec(`DELETE FROM "`+table+`" WHERE book_id IN ?`, bookIds).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec(`INSERT INTO "book_rating_counts" (book_id, rating, reviews)
+		SELECT book_id, rating, count(*) FROM "reviews"
+		WHERE book_id IN ? AND "deleted_at" IS NULL
+		GROUP BY book_id, rating`, bookIds).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`INSERT INTO "book_rating_months" (book_id, month, reviews, rating_sum)
+		SELECT book_id, date_trunc('month', created_at)::date, count(*), sum(rating) FROM "reviews"
+		WHERE book_id IN ? AND "deleted_at" IS NULL
+		GROUP BY book_id, date_trunc('month', created_at)::date`, bookIds).Error
+}
+
+func (r *postgreBookRepository) GetRatingStats(ctx context.Context, id int) ([]books.RatingCount, []books.RatingMonth, error) {
+	var counts []books.RatingCount
+	if err := r.conn.Model(&BookRatingCount{}).Select("rating, reviews").Where("book_id = ?", id).Order("rating").Scan(&counts).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var months []books.RatingMonth
+	if err := r.conn.Model(&BookRatingMonth{}).Select("month, reviews, rating_sum").Where("book_id = ?", id).Order("month").Scan(&months).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return counts, months, nil
+}
@@ -3,47 +3,124 @@ package books
 import (
 	"time"
 
+	categoryRecord "github.com/snykk/golib_backend/datasources/databases/categories"
 	books "github.com/snykk/golib_backend/domains/books"
 	"gorm.io/gorm"
 )
 
 type Book struct {
-	Id          int      `gorm:"primaryKey;autoIncrement"`
-	Title       string   `gorm:"type:varchar(100); not null"`
-	Description string   `gorm:"type:text; not null"`
-	Author      string   `gorm:"type:varchar(30); not null"`
-	Publisher   string   `gorm:"type:varchar(30); not null"`
-	ISBN        string   `gorm:"type:char(13); not null"`
-	Rating      *float64 `gorm:"type:NUMERIC(2,1); not null"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Id              int                       `gorm:"primaryKey;autoIncrement"`
+	Title           string                    `gorm:"type:varchar(100); not null"`
+	Subtitle        string                    `gorm:"type:varchar(255); not null; default:''"`
+	Description     string                    `gorm:"type:text; not null"`
+	Author          string                    `gorm:"type:varchar(255); not null"`
+	Publisher       string                    `gorm:"type:varchar(100); not null"`
+	PublisherId     *int                      `gorm:"index"`
+	ISBN            string                    `gorm:"type:char(13); not null"`
+	Edition         string                    `gorm:"type:varchar(50); not null; default:''"`
+	Language        string                    `gorm:"type:varchar(3); not null; default:''; index"`
+	Format          string                    `gorm:"type:varchar(10); not null; default:''; index"`
+	Series          string                    `gorm:"type:varchar(100); not null; default:''; index"`
+	SeriesPosition  int                       `gorm:"not null; default:0"`
+	PageCount       int                       `gorm:"not null; default:0"`
+	PublicationDate *time.Time                `gorm:"type:date"`
+	Rating          *float64                  `gorm:"type:NUMERIC(2,1); not null"`
+	Cover           string                    `gorm:"type:varchar(100)"`
+	Categories      []categoryRecord.Category `gorm:"many2many:book_categories"`
+	Tags            []Tag                     `gorm:"many2many:book_tags"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+type Tag struct {
+	Id        int    `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"type:varchar(30); not null; uniqueIndex"`
+	CreatedAt time.Time
+}
+
+type SearchRow struct {
+	Book           `gorm:"embedded"`
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+}
+
+func (row *SearchRow) ToDomain() books.SearchResult {
+	return books.SearchResult{
+		Book:           row.Book.ToDomain(),
+		Rank:           row.Rank,
+		TitleHighlight: row.TitleHighlight,
+		Snippet:        row.Snippet,
+	}
 }
 
 func (book *Book) ToDomain() books.Domain {
+	var categories []books.Category
+	for _, val := range book.Categories {
+		categories = append(categories, books.Category{ID: val.Id, Name: val.Name, ParentId: val.ParentId})
+	}
+
+	var tags []string
+	for _, val := range book.Tags {
+		tags = append(tags, val.Name)
+	}
+
 	return books.Domain{
-		ID:          book.Id,
-		Title:       book.Title,
-		Description: book.Description,
-		Author:      book.Author,
-		Publisher:   book.Publisher,
-		ISBN:        book.ISBN,
-		Rating:      book.Rating,
-		CreatedAt:   book.CreatedAt,
-		UpdatedAt:   book.UpdatedAt,
+		ID:              book.Id,
+		Title:           book.Title,
+		Subtitle:        book.Subtitle,
+		Description:     book.Description,
+		Author:          book.Author,
+		Publisher:       book.Publisher,
+		PublisherId:     book.PublisherId,
+		ISBN:            book.ISBN,
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Format:          book.Format,
+		Series:          book.Series,
+		SeriesPosition:  book.SeriesPosition,
+		PageCount:       book.PageCount,
+		PublicationDate: book.PublicationDate,
+		Rating:          book.Rating,
+		Cover:           book.Cover,
+		Categories:      categories,
+		Tags:            tags,
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
 	}
 }
 
 func FromDomain(book *books.Domain) Book {
 	return Book{
-		Id:          book.ID,
-		Title:       book.Title,
-		Description: book.Description,
-		Author:      book.Author,
-		Publisher:   book.Publisher,
-		ISBN:        book.ISBN,
-		Rating:      book.Rating,
-		CreatedAt:   book.CreatedAt,
-		UpdatedAt:   book.UpdatedAt,
+		Id:              book.ID,
+		Title:           book.Title,
+		Subtitle:        book.Subtitle,
+		Description:     book.Description,
+		Author:          book.Author,
+		Publisher:       book.Publisher,
+		PublisherId:     book.PublisherId,
+		ISBN:            book.ISBN,
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Format:          book.Format,
+		Series:          book.Series,
+		SeriesPosition:  book.SeriesPosition,
+		PageCount:       book.PageCount,
+		PublicationDate: book.PublicationDate,
+		Rating:          book.Rating,
+		Cover:           book.Cover,
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
+	}
+}
+
+func ToArrayOfDomain(records *[]Book) []books.Domain {
+	var result []books.Domain
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
 	}
+
+	return result
 }
@@ -0,0 +1,277 @@
+package books
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookVersion struct {
+	Id         int    `gorm:"primaryKey;autoIncrement"`
+	BookId     int    `gorm:"not null; uniqueIndex:idx_book_versions_version"`
+	Version    int    `gorm:"not null; uniqueIndex:idx_book_versions_version"`
+	Action     string `gorm:"type:varchar(10); not null"`
+	UserId     *int
+	Changes    string `gorm:"type:jsonb; not null"`
+	Snapshot   string `gorm:"type:jsonb; not null"`
+	RevertedTo *int
+	CreatedAt  time.Time
+}
+
+// snapshot is the part of a book that is kept in its history
+type snapshot struct {
+	Title           string     `json:"title"`
+	Subtitle        string     `json:"subtitle"`
+	Description     string     `json:"description"`
+	Author          string     `json:"author"`
+	Publisher       string     `json:"publisher"`
+	PublisherId     *int       `json:"publisher_id"`
+	ISBN            string     `json:"isbn"`
+	Edition         string     `json:"edition"`
+	Language        string     `json:"language"`
+	Format          string     `json:"format"`
+	Series          string     `json:"series"`
+	SeriesPosition  int        `json:"series_position"`
+	PageCount       int        `json:"page_count"`
+	PublicationDate *time.Time `json:"publication_date"`
+	Cover           string     `json:"cover"`
+}
+
+type fieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+func snapshotOf(b *Book) snapshot {
+	return snapshot{
+		Title:           b.Title,
+		Subtitle:        b.Subtitle,
+		Description:     b.Description,
+		Author:          b.Author,
+		Publisher:       b.Publisher,
+		PublisherId:     b.PublisherId,
+		ISBN:            b.ISBN,
+		Edition:         b.Edition,
+		Language:        b.Language,
+		Format:          b.Format,
+		Series:          b.Series,
+		SeriesPosition:  b.SeriesPosition,
+		PageCount:       b.PageCount,
+		PublicationDate: b.PublicationDate,
+		Cover:           b.Cover,
+	}
+}
+
+// diff lists the fields that differ between two snapshots, a missing before stands for a book that didn't exist yet
+func diff(before *snapshot, after *snapshot) []fieldChange {
+	changes := []fieldChange{}
+	add := func(field string, from interface{}, to interface{}, changed bool) {
+		if changed {
+			changes = append(changes, fieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	if before == nil {
+		add("title", nil, after.Title, after.Title != "")
+		add("subtitle", nil, after.Subtitle, after.Subtitle != "")
+		add("description", nil, after.Description, after.Description != "")
+		add("author", nil, after.Author, after.Author != "")
+		add("publisher", nil, after.Publisher, after.Publisher != "")
+		add("publisher_id", nil, after.PublisherId, after.PublisherId != nil)
+		add("isbn", nil, after.ISBN, after.ISBN != "")
+		add("edition", nil, after.Edition, after.Edition != "")
+		add("language", nil, after.Language, after.Language != "")
+		add("format", nil, after.Format, after.Format != "")
+		add("series", nil, after.Series, after.Series != "")
+		add("series_position", nil, after.SeriesPosition, after.SeriesPosition != 0)
+		add("page_count", nil, after.PageCount, after.PageCount != 0)
+		add("publication_date", nil, after.PublicationDate, after.PublicationDate != nil)
+		add("cover", nil, after.Cover, after.Cover != "")
+		return changes
+	}
+
+	add("title", before.Title, after.Title, before.Title != after.Title)
+	add("subtitle", before.Subtitle, after.Subtitle, before.Subtitle != after.Subtitle)
+	add("description", before.Description, after.Description, before.Description != after.Description)
+	add("author", before.Author, after.Author, before.Author != after.Author)
+	add("publisher", before.Publisher, after.Publisher, before.Publisher != after.Publisher)
+	add("publisher_id", before.PublisherId, after.PublisherId, !equalIntPtr(before.PublisherId, after.PublisherId))
+	add("isbn", before.ISBN, after.ISBN, before.ISBN != after.ISBN)
+	add("edition", before.Edition, after.Edition, before.Edition != after.Edition)
+	add("language", before.Language, after.Language, before.Language != after.Language)
+	add("format", before.Format, after.Format, before.Format != after.Format)
+	add("series", before.Series, after.Series, before.Series != after.Series)
+	add("series_position", before.SeriesPosition, after.SeriesPosition, before.SeriesPosition != after.SeriesPosition)
+	add("page_count", before.PageCount, after.PageCount, before.PageCount != after.PageCount)
+	add("publication_date", before.PublicationDate, after.PublicationDate, !equalTimePtr(before.PublicationDate, after.PublicationDate))
+	add("cover", before.Cover, after.Cover, before.Cover != after.Cover)
+	return changes
+}
+
+func equalIntPtr(a *int, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalTimePtr(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// newVersion builds the history entry of a write, it reports false for updates that didn't touch any tracked field
+func newVersion(ctx context.Context, action string, before *Book, after *Book, revertedTo *int) (BookVersion, bool, error) {
+	var beforeSnapshot *snapshot
+	if before != nil {
+		s := snapshotOf(before)
+		beforeSnapshot = &s
+	}
+	afterSnapshot := snapshotOf(after)
+
+	// deleting and restoring leave every field as it was, the entry only marks when the book left or came back
+	var changes []fieldChange
+	if action != constants.BookVersionDeleted && action != constants.BookVersionRestored {
+		changes = diff(beforeSnapshot, &afterSnapshot)
+		if len(changes) == 0 && action != constants.BookVersionCreated {
+			return BookVersion{}, false, nil
+		}
+	} else {
+		changes = []fieldChange{}
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return BookVersion{}, false, err
+	}
+	snapshotJSON, err := json.Marshal(afterSnapshot)
+	if err != nil {
+		return BookVersion{}, false, err
+	}
+
+	return BookVersion{
+		BookId:     after.Id,
+		Action:     action,
+		UserId:     helpers.ActorFrom(ctx),
+		Changes:    string(changesJSON),
+		Snapshot:   string(snapshotJSON),
+		RevertedTo: revertedTo,
+	}, true, nil
+}
+
+// recordVersion appends an entry to the history of a book, the book row has to be locked by the caller
+func recordVersion(ctx context.Context, tx *gorm.DB, action string, before *Book, after *Book, revertedTo *int) error {
+	version, ok, err := newVersion(ctx, action, before, after, revertedTo)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := tx.Raw(`SELECT COALESCE(MAX(version), 0) + 1 FROM "book_versions" WHERE book_id = ?`, version.BookId).Scan(&version.Version).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&version).Error
+}
+
+// RecordRestore appends the entry of a book that came back from the trash, it runs in the transaction of the restore
+func RecordRestore(ctx context.Context, tx *gorm.DB, id int) error {
+	var book Book
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&book, id).Error; err != nil {
+		return err
+	}
+
+	return recordVersion(ctx, tx, constants.BookVersionRestored, &book, &book, nil)
+}
+
+// versioned runs a write on an existing book inside a transaction and records what it changed. The row lock
+// keeps concurrent writers from claiming the same version number. A missing book is left to the caller to
+// notice when it reads the book back.
+func (r *postgreBookRepository) versioned(ctx context.Context, id int, action string, revertedTo *int, write func(tx *gorm.DB) error) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		var before Book
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := write(tx); err != nil {
+			return err
+		}
+
+		if action == constants.BookVersionDeleted {
+			return recordVersion(ctx, tx, action, &before, &before, revertedTo)
+		}
+
+		var after Book
+		if err := tx.First(&after, id).Error; err != nil {
+			return err
+		}
+
+		return recordVersion(ctx, tx, action, &before, &after, revertedTo)
+	})
+}
+
+func (v *BookVersion) ToDomain() books.Version {
+	var s snapshot
+	_ = json.Unmarshal([]byte(v.Snapshot), &s)
+
+	var changes []fieldChange
+	_ = json.Unmarshal([]byte(v.Changes), &changes)
+	domainChanges := make([]books.FieldChange, 0, len(changes))
+	for _, change := range changes {
+		domainChanges = append(domainChanges, books.FieldChange{Field: change.Field, From: change.From, To: change.To})
+	}
+
+	return books.Version{
+		ID:      v.Id,
+		BookId:  v.BookId,
+		Version: v.Version,
+		Action:  v.Action,
+		UserId:  v.UserId,
+		Changes: domainChanges,
+		Snapshot: books.Domain{
+			ID:              v.BookId,
+			Title:           s.Title,
+			Subtitle:        s.Subtitle,
+			Description:     s.Description,
+			Author:          s.Author,
+			Publisher:       s.Publisher,
+			PublisherId:     s.PublisherId,
+			ISBN:            s.ISBN,
+			Edition:         s.Edition,
+			Language:        s.Language,
+			Format:          s.Format,
+			Series:          s.Series,
+			SeriesPosition:  s.SeriesPosition,
+			PageCount:       s.PageCount,
+			PublicationDate: s.PublicationDate,
+			Cover:           s.Cover,
+		},
+		RevertedTo: v.RevertedTo,
+		CreatedAt:  v.CreatedAt,
+	}
+}
+
+func ToArrayOfVersionDomain(records *[]BookVersion) []books.Version {
+	var result []books.Version
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
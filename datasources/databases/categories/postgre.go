@@ -0,0 +1,116 @@
+package categories
+
+import (
+	"context"
+
+	"github.com/snykk/golib_backend/domains/categories"
+	"gorm.io/gorm"
+)
+
+type postgreCategoryRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreCategoryRepository(conn *gorm.DB) categories.Repository {
+	return &postgreCategoryRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreCategoryRepository) Store(ctx context.Context, domain *categories.Domain) (categories.Domain, error) {
+	category := FromDomain(domain)
+	if err := r.conn.Create(&category).Error; err != nil {
+		return categories.Domain{}, err
+	}
+
+	return category.ToDomain(), nil
+}
+
+func (r *postgreCategoryRepository) GetAll(ctx context.Context) ([]categories.Domain, error) {
+	var records []Category
+	if err := r.conn.Order("name").Find(&records).Error; err != nil {
+		return []categories.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreCategoryRepository) GetById(ctx context.Context, id int) (categories.Domain, error) {
+	var category Category
+	if err := r.conn.First(&category, id).Error; err != nil {
+		return categories.Domain{}, err
+	}
+
+	return category.ToDomain(), nil
+}
+
+func (r *postgreCategoryRepository) GetByName(ctx context.Context, name string, parentId *int) (categories.Domain, error) {
+	db := r.conn.Where("lower(name) = lower(?)", name)
+	if parentId == nil {
+		db = db.Where("parent_id IS NULL")
+	} else {
+		db = db.Where("parent_id = ?", *parentId)
+	}
+
+	var category Category
+	if err := db.First(&category).Error; err != nil {
+		return categories.Domain{}, err
+	}
+
+	return category.ToDomain(), nil
+}
+
+func (r *postgreCategoryRepository) GetByIds(ctx context.Context, ids []int) ([]categories.Domain, error) {
+	var records []Category
+	if err := r.conn.Where("id IN ?", ids).Find(&records).Error; err != nil {
+		return []categories.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreCategoryRepository) Update(ctx context.Context, domain *categories.Domain) ([]int, error) {
+	category := FromDomain(domain)
+
+	var bookIds []int
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		// parent_id is set explicitly so a category can be moved back to the root
+		if err := tx.Model(&Category{}).Where("id = ?", category.Id).Updates(map[string]interface{}{"name": category.Name, "parent_id": category.ParentId}).Error; err != nil {
+			return err
+		}
+
+		return tx.Table("book_categories").Where("category_id = ?", category.Id).Pluck("book_id", &bookIds).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookIds, nil
+}
+
+func (r *postgreCategoryRepository) Delete(ctx context.Context, id int) error {
+	return r.conn.Delete(&Category{}, id).Error
+}
+
+func (r *postgreCategoryRepository) CountBooks(ctx context.Context, id int) (int, error) {
+	var total int64
+	err := r.conn.Table("book_categories").
+		Joins(`JOIN "books" ON "books".id = "book_categories".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"book_categories".category_id = ?`, id).
+		Count(&total).Error
+
+	return int(total), err
+}
+
+func (r *postgreCategoryRepository) ReplaceBookCategories(ctx context.Context, bookId int, categoryIds []int) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM "book_categories" WHERE book_id = ?`, bookId).Error; err != nil {
+			return err
+		}
+		if len(categoryIds) == 0 {
+			return nil
+		}
+
+		return tx.Exec(`INSERT INTO "book_categories" (book_id, category_id) SELECT ?, id FROM "categories" WHERE id IN ?`, bookId, categoryIds).Error
+	})
+}
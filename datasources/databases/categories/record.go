@@ -0,0 +1,47 @@
+package categories
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/categories"
+	"gorm.io/gorm"
+)
+
+type Category struct {
+	Id        int    `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"type:varchar(50); not null"`
+	ParentId  *int   `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (c *Category) ToDomain() categories.Domain {
+	return categories.Domain{
+		ID:        c.Id,
+		Name:      c.Name,
+		ParentId:  c.ParentId,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func FromDomain(domain *categories.Domain) Category {
+	return Category{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		ParentId:  domain.ParentId,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToArrayOfDomain(records *[]Category) []categories.Domain {
+	var result []categories.Domain
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
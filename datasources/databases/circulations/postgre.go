@@ -0,0 +1,361 @@
+package circulations
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"gorm.io/gorm"
+)
+
+type postgreCirculationRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreCirculationRepository(conn *gorm.DB) circulations.Repository {
+	return &postgreCirculationRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreCirculationRepository) StoreCopy(ctx context.Context, domain *circulations.CopyDomain, nextHold *circulations.HoldDomain) (circulations.CopyDomain, error) {
+	bookCopy := FromCopyDomain(domain)
+
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		// a new copy goes straight to the first reader in the queue
+		if nextHold != nil {
+			bookCopy.Status = constants.CopyOnHold
+		}
+		if err := tx.Create(&bookCopy).Error; err != nil {
+			return err
+		}
+
+		if nextHold != nil {
+			nextHold.CopyId = bookCopy.Id
+			if err := updateHold(tx, nextHold); err != nil {
+				return err
+			}
+		}
+
+		return tx.Preload("Book").First(&bookCopy, bookCopy.Id).Error
+	})
+	if err != nil {
+		return circulations.CopyDomain{}, err
+	}
+
+	return bookCopy.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetCopyById(ctx context.Context, id int) (circulations.CopyDomain, error) {
+	var bookCopy Copy
+	if err := r.conn.Preload("Book").First(&bookCopy, id).Error; err != nil {
+		return circulations.CopyDomain{}, err
+	}
+
+	return bookCopy.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetCopyByBarcode(ctx context.Context, barcode string) (circulations.CopyDomain, error) {
+	var bookCopy Copy
+	if err := r.conn.Preload("Book").First(&bookCopy, "barcode = ?", barcode).Error; err != nil {
+		return circulations.CopyDomain{}, err
+	}
+
+	return bookCopy.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetCopiesByBookId(ctx context.Context, bookId int) ([]circulations.CopyDomain, error) {
+	var copies []Copy
+	if err := r.conn.Preload("Book").Where(Copy{BookId: bookId}).Order("id").Find(&copies).Error; err != nil {
+		return []circulations.CopyDomain{}, err
+	}
+
+	return ToArrayOfCopyDomain(&copies), nil
+}
+
+func (r *postgreCirculationRepository) UpdateCopy(ctx context.Context, domain *circulations.CopyDomain) (err error) {
+	bookCopy := FromCopyDomain(domain)
+	err = r.conn.Model(&Copy{}).Where("id = ?", bookCopy.Id).Updates(&bookCopy).Error
+	return
+}
+
+func (r *postgreCirculationRepository) DeleteCopy(ctx context.Context, id int) (err error) {
+	err = r.conn.Delete(&Copy{}, id).Error
+	return
+}
+
+func (r *postgreCirculationRepository) Checkout(ctx context.Context, domain *circulations.LoanDomain, hold *circulations.HoldDomain, nextHold *circulations.HoldDomain) (circulations.LoanDomain, error) {
+	loan := FromLoanDomain(domain)
+
+	// a copy set aside for a ready hold is on hold, any other copy has to be on the shelf
+	status := constants.CopyAvailable
+	if hold != nil && hold.Status == constants.HoldReady && hold.CopyId == loan.CopyId {
+		status = constants.CopyOnHold
+	}
+
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		// claiming the copy with its expected status keeps two concurrent checkouts from both lending it
+		claim := tx.Model(&Copy{}).Where("id = ? AND status = ?", loan.CopyId, status).Update("status", constants.CopyOnLoan)
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return circulations.ErrCopyUnavailable
+		}
+
+		if err := tx.Create(&loan).Error; err != nil {
+			return err
+		}
+
+		if hold != nil {
+			// the hold must still be as it was read, the expiry job may have passed its copy on meanwhile
+			fulfilled := tx.Model(&Hold{}).Where("id = ? AND status = ?", hold.ID, hold.Status).Updates(map[string]interface{}{"status": constants.HoldFulfilled, "copy_id": loan.CopyId})
+			if fulfilled.Error != nil {
+				return fulfilled.Error
+			}
+			if fulfilled.RowsAffected == 0 {
+				return circulations.ErrHoldClosed
+			}
+
+			// a ready hold fulfilled by another copy lets go of the one it kept aside
+			if hold.Status == constants.HoldReady && hold.CopyId != loan.CopyId {
+				copyStatus := constants.CopyAvailable
+				if nextHold != nil {
+					copyStatus = constants.CopyOnHold
+					if err := updateHold(tx, nextHold); err != nil {
+						return err
+					}
+				}
+				if err := tx.Model(&Copy{}).Where("id = ?", hold.CopyId).Update("status", copyStatus).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return preloadLoan(tx).First(&loan, loan.Id).Error
+	})
+
+	if err != nil {
+		return circulations.LoanDomain{}, err
+	}
+
+	return loan.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) Return(ctx context.Context, domain *circulations.LoanDomain, nextHold *circulations.HoldDomain) error {
+	loan := FromLoanDomain(domain)
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Loan{}).Where("id = ?", loan.Id).Updates(map[string]interface{}{"returned_at": loan.ReturnedAt, "fine": loan.Fine}).Error; err != nil {
+			return err
+		}
+
+		// hand the copy over to the next reader in the queue, otherwise put it back on the shelf
+		status := constants.CopyAvailable
+		if nextHold != nil {
+			status = constants.CopyOnHold
+			if err := updateHold(tx, nextHold); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&Copy{}).Where("id = ?", loan.CopyId).Update("status", status).Error
+	})
+}
+
+func (r *postgreCirculationRepository) GetLoanById(ctx context.Context, id int) (circulations.LoanDomain, error) {
+	var loan Loan
+	if err := preloadLoan(r.conn).First(&loan, id).Error; err != nil {
+		return circulations.LoanDomain{}, err
+	}
+
+	return loan.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetActiveLoanByCopyId(ctx context.Context, copyId int) (circulations.LoanDomain, error) {
+	var loan Loan
+	if err := preloadLoan(r.conn).Where("copy_id = ? AND returned_at IS NULL", copyId).First(&loan).Error; err != nil {
+		return circulations.LoanDomain{}, err
+	}
+
+	return loan.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetActiveLoans(ctx context.Context) ([]circulations.LoanDomain, error) {
+	var loans []Loan
+	if err := preloadLoan(r.conn).Where("returned_at IS NULL").Order("due_at").Find(&loans).Error; err != nil {
+		return []circulations.LoanDomain{}, err
+	}
+
+	return ToArrayOfLoanDomain(&loans), nil
+}
+
+func (r *postgreCirculationRepository) GetLoansByUserId(ctx context.Context, userId int) ([]circulations.LoanDomain, error) {
+	var loans []Loan
+	if err := preloadLoan(r.conn).Where(Loan{UserId: userId}).Order("checked_out_at DESC").Find(&loans).Error; err != nil {
+		return []circulations.LoanDomain{}, err
+	}
+
+	return ToArrayOfLoanDomain(&loans), nil
+}
+
+func (r *postgreCirculationRepository) CountActiveLoansByUserId(ctx context.Context, userId int) (int, error) {
+	var count int64
+	if err := r.conn.Model(&Loan{}).Where("user_id = ? AND returned_at IS NULL", userId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *postgreCirculationRepository) GetUnpaidFineByUserId(ctx context.Context, userId int) (int, error) {
+	var fine int
+	if err := r.conn.Raw(`SELECT COALESCE(SUM("loans".fine), 0) FROM "loans" WHERE user_id = ? AND fine_paid = false AND "deleted_at" IS NULL`, userId).Scan(&fine).Error; err != nil {
+		return 0, err
+	}
+
+	return fine, nil
+}
+
+func (r *postgreCirculationRepository) UpdateLoan(ctx context.Context, domain *circulations.LoanDomain) (err error) {
+	loan := FromLoanDomain(domain)
+	err = r.conn.Model(&Loan{}).Where("id = ?", loan.Id).Updates(map[string]interface{}{
+		"due_at":    loan.DueAt,
+		"renewals":  loan.Renewals,
+		"fine":      loan.Fine,
+		"fine_paid": loan.FinePaid,
+	}).Error
+	return
+}
+
+func (r *postgreCirculationRepository) StoreHold(ctx context.Context, domain *circulations.HoldDomain) (circulations.HoldDomain, error) {
+	hold := FromHoldDomain(domain)
+
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		// the hold was satisfied right away, keep the copy aside for the reader. Like a checkout it's claimed with
+		// its expected status, so a copy lent out meanwhile isn't put on hold as well
+		if hold.Status == constants.HoldReady {
+			claim := tx.Model(&Copy{}).Where("id = ? AND status = ?", hold.CopyId, constants.CopyAvailable).Update("status", constants.CopyOnHold)
+			if claim.Error != nil {
+				return claim.Error
+			}
+			if claim.RowsAffected == 0 {
+				return circulations.ErrCopyUnavailable
+			}
+		}
+
+		if err := tx.Create(&hold).Error; err != nil {
+			return err
+		}
+
+		return preloadHold(tx).First(&hold, hold.Id).Error
+	})
+
+	if err != nil {
+		return circulations.HoldDomain{}, err
+	}
+
+	return hold.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetHoldById(ctx context.Context, id int) (circulations.HoldDomain, error) {
+	var hold Hold
+	if err := preloadHold(r.conn).First(&hold, id).Error; err != nil {
+		return circulations.HoldDomain{}, err
+	}
+
+	return hold.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetReadyHoldByCopyId(ctx context.Context, copyId int) (circulations.HoldDomain, error) {
+	var hold Hold
+	if err := preloadHold(r.conn).Where(Hold{CopyId: copyId, Status: constants.HoldReady}).First(&hold).Error; err != nil {
+		return circulations.HoldDomain{}, err
+	}
+
+	return hold.ToDomain(), nil
+}
+
+func (r *postgreCirculationRepository) GetActiveHoldsByBookId(ctx context.Context, bookId int) ([]circulations.HoldDomain, error) {
+	var holds []Hold
+	if err := preloadHold(r.conn).Where("book_id = ? AND status IN ?", bookId, []string{constants.HoldWaiting, constants.HoldReady}).Order("created_at, id").Find(&holds).Error; err != nil {
+		return []circulations.HoldDomain{}, err
+	}
+
+	return ToArrayOfHoldDomain(&holds), nil
+}
+
+func (r *postgreCirculationRepository) GetHoldsByUserId(ctx context.Context, userId int) ([]circulations.HoldDomain, error) {
+	var holds []Hold
+	if err := preloadHold(r.conn).Where(Hold{UserId: userId}).Order("created_at DESC").Find(&holds).Error; err != nil {
+		return []circulations.HoldDomain{}, err
+	}
+
+	return ToArrayOfHoldDomain(&holds), nil
+}
+
+func (r *postgreCirculationRepository) GetExpiredHolds(ctx context.Context, at time.Time) ([]circulations.HoldDomain, error) {
+	var holds []Hold
+	if err := r.conn.Where("status = ? AND expires_at <= ?", constants.HoldReady, at).Order("expires_at").Find(&holds).Error; err != nil {
+		return []circulations.HoldDomain{}, err
+	}
+
+	return ToArrayOfHoldDomain(&holds), nil
+}
+
+func (r *postgreCirculationRepository) CancelHold(ctx context.Context, domain *circulations.HoldDomain, nextHold *circulations.HoldDomain) error {
+	return r.closeHold(domain, constants.HoldCancelled, nextHold)
+}
+
+func (r *postgreCirculationRepository) ExpireHold(ctx context.Context, domain *circulations.HoldDomain, nextHold *circulations.HoldDomain) error {
+	return r.closeHold(domain, constants.HoldExpired, nextHold)
+}
+
+// closeHold ends an active hold and passes the copy it kept aside to the next reader, or back to the shelf
+func (r *postgreCirculationRepository) closeHold(domain *circulations.HoldDomain, status string, nextHold *circulations.HoldDomain) error {
+	hold := FromHoldDomain(domain)
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		// the hold must still be as it was read, a checkout may have fulfilled it meanwhile
+		closed := tx.Model(&Hold{}).Where("id = ? AND status = ?", hold.Id, hold.Status).Update("status", status)
+		if closed.Error != nil {
+			return closed.Error
+		}
+		if closed.RowsAffected == 0 {
+			return circulations.ErrHoldClosed
+		}
+
+		// only a ready hold keeps a copy aside
+		if hold.Status != constants.HoldReady {
+			return nil
+		}
+
+		copyStatus := constants.CopyAvailable
+		if nextHold != nil {
+			copyStatus = constants.CopyOnHold
+			if err := updateHold(tx, nextHold); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&Copy{}).Where("id = ?", hold.CopyId).Update("status", copyStatus).Error
+	})
+}
+
+func updateHold(tx *gorm.DB, domain *circulations.HoldDomain) error {
+	hold := FromHoldDomain(domain)
+	return tx.Model(&Hold{}).Where("id = ?", hold.Id).Updates(map[string]interface{}{
+		"status":     hold.Status,
+		"copy_id":    hold.CopyId,
+		"expires_at": hold.ExpiresAt,
+	}).Error
+}
+
+func preloadLoan(db *gorm.DB) *gorm.DB {
+	return db.Preload("Copy.Book").Preload("User.Role").Preload("User.Gender")
+}
+
+func preloadHold(db *gorm.DB) *gorm.DB {
+	return db.Preload("Book").Preload("User.Role").Preload("User.Gender")
+}
@@ -0,0 +1,173 @@
+package circulations
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/datasources/databases/users"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"gorm.io/gorm"
+)
+
+type Copy struct {
+	Id        int `gorm:"primaryKey;autoIncrement"`
+	BookId    int `gorm:"not null"`
+	Book      books.Book
+	Barcode   string `gorm:"type:varchar(30); not null"`
+	Condition string `gorm:"type:varchar(15); not null"`
+	Location  string `gorm:"type:varchar(50); not null"`
+	Status    string `gorm:"type:varchar(15); not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type Loan struct {
+	Id           int `gorm:"primaryKey;autoIncrement"`
+	CopyId       int `gorm:"not null"`
+	Copy         Copy
+	UserId       int `gorm:"not null"`
+	User         users.User
+	CheckedOutAt time.Time `gorm:"not null"`
+	DueAt        time.Time `gorm:"not null"`
+	ReturnedAt   *time.Time
+	Renewals     int  `gorm:"type:integer; not null"`
+	Fine         int  `gorm:"type:integer; not null"`
+	FinePaid     bool `gorm:"not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+type Hold struct {
+	Id        int `gorm:"primaryKey;autoIncrement"`
+	BookId    int `gorm:"not null"`
+	Book      books.Book
+	UserId    int `gorm:"not null"`
+	User      users.User
+	CopyId    int    `gorm:"type:integer; not null"`
+	Status    string `gorm:"type:varchar(15); not null"`
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (c *Copy) ToDomain() circulations.CopyDomain {
+	return circulations.CopyDomain{
+		ID:        c.Id,
+		BookId:    c.BookId,
+		Book:      c.Book.ToDomain(),
+		Barcode:   c.Barcode,
+		Condition: c.Condition,
+		Location:  c.Location,
+		Status:    c.Status,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func FromCopyDomain(domain *circulations.CopyDomain) Copy {
+	return Copy{
+		Id:        domain.ID,
+		BookId:    domain.BookId,
+		Barcode:   domain.Barcode,
+		Condition: domain.Condition,
+		Location:  domain.Location,
+		Status:    domain.Status,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func (l *Loan) ToDomain() circulations.LoanDomain {
+	return circulations.LoanDomain{
+		ID:           l.Id,
+		CopyId:       l.CopyId,
+		Copy:         l.Copy.ToDomain(),
+		UserId:       l.UserId,
+		User:         l.User.ToDomain(),
+		CheckedOutAt: l.CheckedOutAt,
+		DueAt:        l.DueAt,
+		ReturnedAt:   l.ReturnedAt,
+		Renewals:     l.Renewals,
+		Fine:         l.Fine,
+		FinePaid:     l.FinePaid,
+		CreatedAt:    l.CreatedAt,
+		UpdatedAt:    l.UpdatedAt,
+	}
+}
+
+func FromLoanDomain(domain *circulations.LoanDomain) Loan {
+	return Loan{
+		Id:           domain.ID,
+		CopyId:       domain.CopyId,
+		UserId:       domain.UserId,
+		CheckedOutAt: domain.CheckedOutAt,
+		DueAt:        domain.DueAt,
+		ReturnedAt:   domain.ReturnedAt,
+		Renewals:     domain.Renewals,
+		Fine:         domain.Fine,
+		FinePaid:     domain.FinePaid,
+		CreatedAt:    domain.CreatedAt,
+		UpdatedAt:    domain.UpdatedAt,
+	}
+}
+
+func (h *Hold) ToDomain() circulations.HoldDomain {
+	return circulations.HoldDomain{
+		ID:        h.Id,
+		BookId:    h.BookId,
+		Book:      h.Book.ToDomain(),
+		UserId:    h.UserId,
+		User:      h.User.ToDomain(),
+		CopyId:    h.CopyId,
+		Status:    h.Status,
+		ExpiresAt: h.ExpiresAt,
+		CreatedAt: h.CreatedAt,
+		UpdatedAt: h.UpdatedAt,
+	}
+}
+
+func FromHoldDomain(domain *circulations.HoldDomain) Hold {
+	return Hold{
+		Id:        domain.ID,
+		BookId:    domain.BookId,
+		UserId:    domain.UserId,
+		CopyId:    domain.CopyId,
+		Status:    domain.Status,
+		ExpiresAt: domain.ExpiresAt,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToArrayOfCopyDomain(copies *[]Copy) []circulations.CopyDomain {
+	var result []circulations.CopyDomain
+
+	for _, val := range *copies {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
+
+func ToArrayOfLoanDomain(loans *[]Loan) []circulations.LoanDomain {
+	var result []circulations.LoanDomain
+
+	for _, val := range *loans {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
+
+func ToArrayOfHoldDomain(holds *[]Hold) []circulations.HoldDomain {
+	var result []circulations.HoldDomain
+
+	for _, val := range *holds {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
@@ -0,0 +1,116 @@
+package collections
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/collections"
+	"gorm.io/gorm"
+)
+
+type postgreCollectionRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreCollectionRepository(conn *gorm.DB) collections.Repository {
+	return &postgreCollectionRepository{
+		conn: conn,
+	}
+}
+
+// withBooks loads the live books of the collections in the order they were curated
+func withBooks(db *gorm.DB) *gorm.DB {
+	return db.Preload("Books", func(db *gorm.DB) *gorm.DB {
+		return db.Joins(`JOIN "books" ON "books".id = "featured_collection_books".book_id AND "books"."deleted_at" IS NULL`).
+			Order(`"featured_collection_books".position`)
+	}).Preload("Books.Book")
+}
+
+func (r *postgreCollectionRepository) GetAll(ctx context.Context) ([]collections.Domain, error) {
+	var records []FeaturedCollection
+	if err := withBooks(r.conn).Order("position").Order("id").Find(&records).Error; err != nil {
+		return []collections.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreCollectionRepository) GetActive(ctx context.Context, now time.Time) ([]collections.Domain, error) {
+	var records []FeaturedCollection
+	err := withBooks(r.conn).
+		Where("(starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at > ?)", now, now).
+		Order("position").
+		Order("id").
+		Find(&records).Error
+	if err != nil {
+		return []collections.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreCollectionRepository) GetById(ctx context.Context, id int) (collections.Domain, error) {
+	var record FeaturedCollection
+	if err := withBooks(r.conn).First(&record, id).Error; err != nil {
+		return collections.Domain{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreCollectionRepository) Store(ctx context.Context, domain *collections.Domain) (collections.Domain, error) {
+	record := FromDomain(domain)
+
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Books").Create(&record).Error; err != nil {
+			return err
+		}
+
+		return replaceBooks(tx, record.Id, record.Books)
+	})
+	if err != nil {
+		return collections.Domain{}, err
+	}
+
+	return r.GetById(ctx, record.Id)
+}
+
+func (r *postgreCollectionRepository) Update(ctx context.Context, domain *collections.Domain) error {
+	record := FromDomain(domain)
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&FeaturedCollection{Id: record.Id}).
+			Select("title", "description", "position", "starts_at", "ends_at").
+			Updates(&record).Error
+		if err != nil {
+			return err
+		}
+
+		return replaceBooks(tx, record.Id, record.Books)
+	})
+}
+
+func (r *postgreCollectionRepository) Delete(ctx context.Context, id int) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&FeaturedCollectionBook{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&FeaturedCollection{}, id).Error
+	})
+}
+
+func replaceBooks(tx *gorm.DB, id int, books []FeaturedCollectionBook) error {
+	if err := tx.Where("collection_id = ?", id).Delete(&FeaturedCollectionBook{}).Error; err != nil {
+		return err
+	}
+	if len(books) == 0 {
+		return nil
+	}
+
+	for i := range books {
+		books[i].CollectionId = id
+	}
+
+	return tx.Omit("Book").Create(&books).Error
+}
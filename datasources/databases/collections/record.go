@@ -0,0 +1,73 @@
+package collections
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/collections"
+)
+
+type FeaturedCollection struct {
+	Id          int    `gorm:"primaryKey"`
+	Title       string `gorm:"type:varchar(100); not null"`
+	Description string `gorm:"type:text; not null; default:''"`
+	Position    int    `gorm:"type:integer; not null; default:0"`
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	Books       []FeaturedCollectionBook `gorm:"foreignKey:CollectionId"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type FeaturedCollectionBook struct {
+	CollectionId int `gorm:"primaryKey"`
+	BookId       int `gorm:"primaryKey;index"`
+	Book         books.Book
+	Position     int `gorm:"type:integer; not null"`
+}
+
+func (r *FeaturedCollection) ToDomain() collections.Domain {
+	domain := collections.Domain{
+		ID:          r.Id,
+		Title:       r.Title,
+		Description: r.Description,
+		Position:    r.Position,
+		StartsAt:    r.StartsAt,
+		EndsAt:      r.EndsAt,
+		BookIds:     make([]int, 0, len(r.Books)),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	for _, book := range r.Books {
+		domain.BookIds = append(domain.BookIds, book.BookId)
+		domain.Books = append(domain.Books, book.Book.ToDomain())
+	}
+
+	return domain
+}
+
+func FromDomain(domain *collections.Domain) FeaturedCollection {
+	record := FeaturedCollection{
+		Id:          domain.ID,
+		Title:       domain.Title,
+		Description: domain.Description,
+		Position:    domain.Position,
+		StartsAt:    domain.StartsAt,
+		EndsAt:      domain.EndsAt,
+	}
+	for i, bookId := range domain.BookIds {
+		record.Books = append(record.Books, FeaturedCollectionBook{CollectionId: domain.ID, BookId: bookId, Position: i + 1})
+	}
+
+	return record
+}
+
+func ToArrayOfDomain(records *[]FeaturedCollection) []collections.Domain {
+	var result []collections.Domain
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
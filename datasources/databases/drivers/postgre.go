@@ -4,12 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	configEnv "github.com/snykk/golib_backend/config"
 	"github.com/snykk/golib_backend/constants"
+	authorRepository "github.com/snykk/golib_backend/datasources/databases/authors"
 	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	categoryRepository "github.com/snykk/golib_backend/datasources/databases/categories"
+	circulationRepository "github.com/snykk/golib_backend/datasources/databases/circulations"
+	collectionRepository "github.com/snykk/golib_backend/datasources/databases/collections"
+	listRepository "github.com/snykk/golib_backend/datasources/databases/lists"
+	publisherRepository "github.com/snykk/golib_backend/datasources/databases/publishers"
+	rankingRepository "github.com/snykk/golib_backend/datasources/databases/rankings"
+	recommendationRepository "github.com/snykk/golib_backend/datasources/databases/recommendations"
 	reviewRepository "github.com/snykk/golib_backend/datasources/databases/reviews"
+	shelfRepository "github.com/snykk/golib_backend/datasources/databases/shelves"
 	userRepository "github.com/snykk/golib_backend/datasources/databases/users"
 	"github.com/snykk/golib_backend/helpers"
 	"gorm.io/driver/postgres"
@@ -26,19 +37,264 @@ type ConfigPostgreSQL struct {
 }
 
 func dbMigrate(db *gorm.DB) (err error) {
+	err = db.AutoMigrate(&publisherRepository.Publisher{}, &authorRepository.Author{})
+	if err != nil {
+		return err
+	}
+	// names are unique regardless of case so the same author can't be spelled twice
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_authors_name ON "authors" (lower(name)) WHERE "deleted_at" IS NULL`).Error
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_publishers_name ON "publishers" (lower(name)) WHERE "deleted_at" IS NULL`).Error
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&categoryRepository.Category{}, &bookRepository.Tag{})
+	if err != nil {
+		return err
+	}
+	// siblings can't share a name, the same name may still appear in different branches of the tree
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON "categories" (coalesce(parent_id, 0), lower(name)) WHERE "deleted_at" IS NULL`).Error
+	if err != nil {
+		return err
+	}
 	err = db.AutoMigrate(&bookRepository.Book{})
 	if err != nil {
 		return err
 	}
+	// weighted full-text vector used by book search, kept up to date by postgres itself
+	err = db.Exec(`ALTER TABLE "books" ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(author, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce(publisher, '')), 'C') ||
+		setweight(to_tsvector('simple', coalesce(description, '')), 'D')
+	) STORED`).Error
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_books_search_vector ON "books" USING GIN (search_vector)`).Error
+	if err != nil {
+		return err
+	}
+	// isbn is normalized to ISBN-13 before it is stored, soft deleted books don't hold on to their isbn
+	err = normalizeBookISBNs(db)
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn ON "books" (isbn) WHERE "deleted_at" IS NULL`).Error
+	if err != nil {
+		return err
+	}
+	// trigram indexes back the typeahead suggestions on title and author
+	err = db.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm`).Error
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_books_title_trgm ON "books" USING GIN (title gin_trgm_ops)`).Error
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_books_author_trgm ON "books" USING GIN (author gin_trgm_ops)`).Error
+	if err != nil {
+		return err
+	}
 	err = db.AutoMigrate(&userRepository.User{})
 	if err != nil {
 		return err
 	}
-	err = db.AutoMigrate(&reviewRepository.Review{})
+	err = db.AutoMigrate(&reviewRepository.Review{}, &reviewRepository.ReviewVote{})
+	if err != nil {
+		return err
+	}
+	// the review listings page by keyset, each index follows one of the sort orders from left to right
+	for _, index := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_reviews_created ON "reviews" (created_at, id) WHERE "deleted_at" IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_book_created ON "reviews" (book_id, created_at, id) WHERE "deleted_at" IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_book_rating ON "reviews" (book_id, rating, created_at, id) WHERE "deleted_at" IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_book_helpful ON "reviews" (book_id, helpful_count, created_at, id) WHERE "deleted_at" IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_user_created ON "reviews" (user_id, created_at, id) WHERE "deleted_at" IS NULL`,
+	} {
+		if err = db.Exec(index).Error; err != nil {
+			return err
+		}
+	}
+	err = db.AutoMigrate(&circulationRepository.Copy{}, &circulationRepository.Loan{}, &circulationRepository.Hold{})
+	if err != nil {
+		return err
+	}
+	// deleted copies give their barcode back, so it's only unique among the copies still in the collection
+	err = db.Exec(`DROP INDEX IF EXISTS idx_barcode`).Error
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_copies_barcode ON "copies" (barcode) WHERE "deleted_at" IS NULL`).Error
+	if err != nil {
+		return err
+	}
+	// a copy can only be out on one loan at a time
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_loans_active_copy ON "loans" (copy_id) WHERE returned_at IS NULL AND "deleted_at" IS NULL`).Error
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&authorRepository.BookAuthor{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&bookRepository.BookVersion{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&recommendationRepository.Recommendation{}, &rankingRepository.BookRanking{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&bookRepository.BookRatingCount{}, &bookRepository.BookRatingMonth{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&shelfRepository.ShelfEntry{}, &shelfRepository.Shelf{}, &shelfRepository.ShelfBook{})
+	if err != nil {
+		return err
+	}
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_shelves_user_name ON "shelves" (user_id, lower(name))`).Error
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&listRepository.ReadingList{}, &listRepository.ReadingListEntry{}, &listRepository.ReadingListEditor{}, &listRepository.ReadingListVote{})
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&collectionRepository.FeaturedCollection{}, &collectionRepository.FeaturedCollectionBook{})
+	if err != nil {
+		return err
+	}
+	err = linkAuthorsAndPublishers(db)
+	if err != nil {
+		return err
+	}
+	err = startBookHistories(db)
+	if err != nil {
+		return err
+	}
+	err = startRatingStats(db)
 	return
 }
 
-func (config *ConfigPostgreSQL) InitializeDatabasePostgreSQL() (*gorm.DB, error) {
+// normalizeBookISBNs brings the isbn of books stored before normalization to ISBN-13, so the unique index compares
+// like with like. Books that turn out to share an isbn are reported instead of guessing which of them to keep.
+// Once the index exists every stored isbn is normalized already and the books are left alone.
+func normalizeBookISBNs(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&bookRepository.Book{}, "idx_books_isbn") {
+		return nil
+	}
+
+	var records []bookRepository.Book
+	if err := db.Select("id", "isbn").Order("id").Find(&records).Error; err != nil {
+		return err
+	}
+
+	normalized := make(map[int]string)
+	bookIdsOfISBN := make(map[string][]int)
+	for _, record := range records {
+		isbn, err := helpers.NormalizeISBN(record.ISBN)
+		if err != nil {
+			// left as it is, an invalid isbn can't collide with a normalized one
+			log.Printf("[INIT] book %d keeps its isbn %q: %s", record.Id, record.ISBN, err.Error())
+			isbn = record.ISBN
+		}
+		if isbn != record.ISBN {
+			normalized[record.Id] = isbn
+		}
+		bookIdsOfISBN[isbn] = append(bookIdsOfISBN[isbn], record.Id)
+	}
+
+	var duplicates []string
+	for isbn, bookIds := range bookIdsOfISBN {
+		if len(bookIds) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("%s (books %v)", isbn, bookIds))
+		}
+	}
+	if len(duplicates) > 0 {
+		sort.Strings(duplicates)
+		return fmt.Errorf("books share an isbn, merge or correct them before the unique isbn index can be built: %s", strings.Join(duplicates, ", "))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for id, isbn := range normalized {
+			if err := tx.Model(&bookRepository.Book{}).Where("id = ?", id).UpdateColumn("isbn", isbn).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// startRatingStats fills the rating statistics of every book the first time they are migrated, after that
+// they are kept up to date along with each review
+func startRatingStats(db *gorm.DB) error {
+	var counted int64
+	if err := db.Model(&bookRepository.BookRatingCount{}).Count(&counted).Error; err != nil {
+		return err
+	}
+	if counted > 0 {
+		return nil
+	}
+
+	var bookIds []int
+	if err := db.Model(&reviewRepository.Review{}).Distinct().Pluck("book_id", &bookIds).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return bookRepository.SyncRatingStats(tx, bookIds)
+	})
+}
+
+// startBookHistories gives every book without a history a first version holding its current state,
+// so even books from before versioning can be reverted to how they were. The snapshot carries every field a
+// revert restores, the publication date is spelled out as a timestamp since that's how the versions decode it.
+func startBookHistories(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO "book_versions" (book_id, version, action, changes, snapshot, created_at)
+		SELECT b.id, 1, ?, '[]', jsonb_build_object(
+			'title', b.title, 'subtitle', b.subtitle, 'description', b.description, 'author', b.author,
+			'publisher', b.publisher, 'publisher_id', b.publisher_id, 'isbn', b.isbn, 'edition', b.edition,
+			'language', b.language, 'format', b.format, 'series', b.series, 'series_position', b.series_position,
+			'page_count', b.page_count, 'publication_date', to_char(b.publication_date, 'YYYY-MM-DD') || 'T00:00:00Z',
+			'cover', coalesce(b.cover, '')
+		), b.created_at
+		FROM "books" b
+		WHERE NOT EXISTS (SELECT 1 FROM "book_versions" v WHERE v.book_id = b.id)`, constants.BookVersionCreated).Error
+}
+
+// linkAuthorsAndPublishers turns the free-text author and publisher columns of books that aren't linked yet into
+// author and publisher records, books written since are linked along with the write. Books already linked are
+// left untouched.
+func linkAuthorsAndPublishers(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var bookIds []int
+		err := tx.Model(&bookRepository.Book{}).
+			Where(`NOT EXISTS (SELECT 1 FROM "book_authors" ba WHERE ba.book_id = "books".id)`).
+			Pluck("id", &bookIds).Error
+		if err != nil {
+			return err
+		}
+		if err = bookRepository.LinkAuthors(tx, bookIds); err != nil {
+			return err
+		}
+
+		bookIds = nil
+		err = tx.Model(&bookRepository.Book{}).Where("publisher_id IS NULL AND trim(publisher) <> ''").Pluck("id", &bookIds).Error
+		if err != nil {
+			return err
+		}
+
+		return bookRepository.LinkPublishers(tx, bookIds)
+	})
+}
+
+// ConnectPostgreSQL only opens the connection, unlike InitializeDatabasePostgreSQL it leaves the schema and data alone
+func (config *ConfigPostgreSQL) ConnectPostgreSQL() (*gorm.DB, error) {
 	var dsn string
 
 	if configEnv.AppConfig.Environment == constants.EnvironmentDevelopment {
@@ -56,8 +312,17 @@ func (config *ConfigPostgreSQL) InitializeDatabasePostgreSQL() (*gorm.DB, error)
 	}
 	log.Println("[INIT] connected to PostgreSQL")
 
+	return db, nil
+}
+
+func (config *ConfigPostgreSQL) InitializeDatabasePostgreSQL() (*gorm.DB, error) {
+	db, err := config.ConnectPostgreSQL()
+	if err != nil {
+		return nil, err
+	}
+
 	if configEnv.AppConfig.Environment == constants.EnvironmentDevelopment {
-		if err = db.Migrator().DropTable("users", "roles", "genders", "books", "reviews"); err != nil {
+		if err = db.Migrator().DropTable("users", "roles", "genders", "books", "reviews", "review_votes", "copies", "loans", "holds", "authors", "book_authors", "publishers", "categories", "book_categories", "tags", "book_tags", "book_versions", "recommendations", "book_rankings", "book_rating_counts", "book_rating_months", "shelf_entries", "shelves", "shelf_books", "reading_lists", "reading_list_entries", "reading_list_editors", "reading_list_votes", "featured_collections", "featured_collection_books"); err != nil {
 			return nil, errors.New("[INIT ]failed droping tables:" + err.Error())
 		}
 		log.Println("[INIT] droping tables success")
@@ -167,7 +432,7 @@ func lazySeeder(db *gorm.DB) (err error) {
 		Description: "Lorem ipsum dolor sit amet consectetur, adipisicing elit. Voluptas cum quas veritatis voluptatem quia id voluptates, eum voluptatum officiis sed, maxime reprehenderit aut, magnam illo architecto earum consectetur ipsam a.",
 		Author:      "James Clear",
 		Publisher:   "Gramedia",
-		ISBN:        "1234567891234",
+		ISBN:        "9780735211292",
 		Rating:      &rating1,
 		CreatedAt:   time.Now(),
 	}
@@ -184,7 +449,7 @@ func lazySeeder(db *gorm.DB) (err error) {
 		Description: "Lorem ipsum dolor sit amet consectetur, adipisicing elit. Voluptas cum quas veritatis voluptatem quia id voluptates, eum voluptatum officiis sed, maxime reprehenderit aut, magnam illo architecto earum consectetur ipsam a.",
 		Author:      "Carrol Dweck",
 		Publisher:   "Gramedia",
-		ISBN:        "1234567891234",
+		ISBN:        "9780345472328",
 		Rating:      &rating2,
 		CreatedAt:   time.Now(),
 	}
@@ -208,6 +473,59 @@ func lazySeeder(db *gorm.DB) (err error) {
 	if err != nil {
 		return
 	}
+	err = bookRepository.AddRating(db, review1.BookId, review1.Rating, review1.CreatedAt, 1)
+	if err != nil {
+		return
+	}
+
+	// Copy
+	copies := []circulationRepository.Copy{
+		{Id: 1, BookId: 1, Barcode: "GLB-000001", Condition: "good", Location: "Shelf A1", Status: constants.CopyAvailable, CreatedAt: time.Now()},
+		{Id: 2, BookId: 1, Barcode: "GLB-000002", Condition: "new", Location: "Shelf A1", Status: constants.CopyAvailable, CreatedAt: time.Now()},
+		{Id: 3, BookId: 2, Barcode: "GLB-000003", Condition: "fair", Location: "Shelf B2", Status: constants.CopyAvailable, CreatedAt: time.Now()},
+	}
+	err = db.Model(&circulationRepository.Copy{}).Create(&copies).Error
+	if err != nil {
+		return
+	}
+
+	// Category
+	parentCategory := 1
+	categories := []categoryRepository.Category{
+		{Id: 1, Name: "Self Improvement", CreatedAt: time.Now()},
+		{Id: 2, Name: "Habits", ParentId: &parentCategory, CreatedAt: time.Now()},
+		{Id: 3, Name: "Psychology", CreatedAt: time.Now()},
+	}
+	err = db.Model(&categoryRepository.Category{}).Create(&categories).Error
+	if err != nil {
+		return
+	}
+	err = db.Exec(`INSERT INTO "book_categories" (book_id, category_id) VALUES (1, 2), (2, 1), (2, 3)`).Error
+	if err != nil {
+		return
+	}
+
+	// Tag
+	tags := []bookRepository.Tag{
+		{Id: 1, Name: "productivity", CreatedAt: time.Now()},
+		{Id: 2, Name: "bestseller", CreatedAt: time.Now()},
+	}
+	err = db.Model(&bookRepository.Tag{}).Create(&tags).Error
+	if err != nil {
+		return
+	}
+	err = db.Exec(`INSERT INTO "book_tags" (book_id, tag_id) VALUES (1, 1), (1, 2), (2, 2)`).Error
+	if err != nil {
+		return
+	}
+
+	// Author & Publisher
+	err = linkAuthorsAndPublishers(db)
+	if err != nil {
+		return
+	}
 
+	// Book history
+	err = startBookHistories(db)
 	return
 }
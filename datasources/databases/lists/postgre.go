@@ -0,0 +1,285 @@
+package lists
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/lists"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgreListRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreListRepository(conn *gorm.DB) lists.Repository {
+	return &postgreListRepository{
+		conn: conn,
+	}
+}
+
+// withCounts selects the lists of live owners together with the name of the owner, the live books on them and their upvotes
+func withCounts(db *gorm.DB) *gorm.DB {
+	return db.Table(`"reading_lists" l`).
+		Select(`l.*, u.username AS owner,
+			(SELECT COUNT(*) FROM "reading_list_entries" e JOIN "books" b ON b.id = e.book_id AND b."deleted_at" IS NULL WHERE e.list_id = l.id) AS books,
+			(SELECT COUNT(*) FROM "reading_list_votes" v WHERE v.list_id = l.id) AS votes`).
+		Joins(`JOIN "users" u ON u.id = l.owner_id AND u."deleted_at" IS NULL`)
+}
+
+// touch bumps the list so that the recently changed lists come first
+func touch(tx *gorm.DB, id int) error {
+	return tx.Model(&ReadingList{}).Where("id = ?", id).Update("updated_at", time.Now()).Error
+}
+
+// Renumber closes the gaps left in the positions of the lists after entries were removed from outside the list
+func Renumber(tx *gorm.DB, listIds []int) error {
+	if len(listIds) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE "reading_list_entries" e SET position = r.position
+		FROM (
+			SELECT list_id, book_id, ROW_NUMBER() OVER (PARTITION BY list_id ORDER BY position, book_id) AS position
+			FROM "reading_list_entries" WHERE list_id IN ?
+		) r
+		WHERE e.list_id = r.list_id AND e.book_id = r.book_id AND e.position <> r.position`, listIds).Error
+}
+
+func (r *postgreListRepository) GetAll(ctx context.Context, query *lists.Query) ([]lists.Domain, int, error) {
+	var total int64
+	if err := r.conn.Model(&ReadingList{}).
+		Joins(`JOIN "users" u ON u.id = "reading_lists".owner_id AND u."deleted_at" IS NULL`).
+		Where(`"reading_lists".visibility = ?`, constants.ReadingListPublic).
+		Count(&total).Error; err != nil {
+		return []lists.Domain{}, 0, err
+	}
+
+	db := withCounts(r.conn).Where("l.visibility = ?", constants.ReadingListPublic)
+	if query.Sort == constants.ReadingListSortRecent {
+		db = db.Order("l.updated_at DESC")
+	} else {
+		db = db.Order("votes DESC").Order("l.updated_at DESC")
+	}
+
+	var records []ReadingList
+	err := db.Order("l.id").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Scan(&records).Error
+	if err != nil {
+		return []lists.Domain{}, 0, err
+	}
+
+	return ToArrayOfDomain(&records), int(total), nil
+}
+
+func (r *postgreListRepository) GetByUserId(ctx context.Context, userId int) ([]lists.Domain, error) {
+	var records []ReadingList
+	err := withCounts(r.conn).
+		Where(`l.owner_id = ? OR EXISTS (SELECT 1 FROM "reading_list_editors" ed WHERE ed.list_id = l.id AND ed.user_id = ?)`, userId, userId).
+		Order("l.updated_at DESC").
+		Order("l.id").
+		Scan(&records).Error
+	if err != nil {
+		return []lists.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreListRepository) GetById(ctx context.Context, id int) (lists.Domain, error) {
+	var record ReadingList
+	if err := withCounts(r.conn).Where("l.id = ?", id).Take(&record).Error; err != nil {
+		return lists.Domain{}, err
+	}
+
+	var editors []ReadingListEditor
+	err := r.conn.Table(`"reading_list_editors" ed`).
+		Select("ed.*, u.username").
+		Joins(`JOIN "users" u ON u.id = ed.user_id AND u."deleted_at" IS NULL`).
+		Where("ed.list_id = ?", id).
+		Order("ed.created_at").
+		Scan(&editors).Error
+	if err != nil {
+		return lists.Domain{}, err
+	}
+
+	list := record.ToDomain()
+	list.Editors = make([]lists.Editor, 0, len(editors))
+	for _, editor := range editors {
+		list.Editors = append(list.Editors, editor.ToDomain())
+	}
+
+	return list, nil
+}
+
+func (r *postgreListRepository) GetEntries(ctx context.Context, id int) ([]lists.Entry, error) {
+	var records []ReadingListEntry
+	err := r.conn.Preload("Book").
+		Joins(`JOIN "books" ON "books".id = "reading_list_entries".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"reading_list_entries".list_id = ?`, id).
+		Order(`"reading_list_entries".position`).
+		Find(&records).Error
+	if err != nil {
+		return []lists.Entry{}, err
+	}
+
+	return ToArrayOfEntryDomain(&records), nil
+}
+
+func (r *postgreListRepository) GetEntry(ctx context.Context, id int, bookId int) (lists.Entry, error) {
+	var record ReadingListEntry
+	if err := r.conn.Preload("Book").Where(ReadingListEntry{ListId: id, BookId: bookId}).First(&record).Error; err != nil {
+		return lists.Entry{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreListRepository) CountEntries(ctx context.Context, id int) (int, error) {
+	var total int64
+	err := r.conn.Model(&ReadingListEntry{}).Where("list_id = ?", id).Count(&total).Error
+
+	return int(total), err
+}
+
+func (r *postgreListRepository) Store(ctx context.Context, domain *lists.Domain) (lists.Domain, error) {
+	record := FromDomain(domain)
+	if err := r.conn.Create(&record).Error; err != nil {
+		return lists.Domain{}, err
+	}
+
+	return r.GetById(ctx, record.Id)
+}
+
+func (r *postgreListRepository) Update(ctx context.Context, domain *lists.Domain) error {
+	record := FromDomain(domain)
+
+	return r.conn.Model(&ReadingList{Id: record.Id}).Select("title", "description", "visibility").Updates(&record).Error
+}
+
+func (r *postgreListRepository) Delete(ctx context.Context, id int) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"reading_list_entries", "reading_list_editors", "reading_list_votes"} {
+			if err := tx.Exec(`DELETE FROM "`+table+`" WHERE list_id = ?`, id).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&ReadingList{}, id).Error
+	})
+}
+
+// InsertEntry makes room at the position of the entry by moving everything from there one place down
+func (r *postgreListRepository) InsertEntry(ctx context.Context, entry *lists.Entry) error {
+	record := FromEntryDomain(entry)
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE "reading_list_entries" SET position = position + 1 WHERE list_id = ? AND position >= ?`, record.ListId, record.Position).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Book").Create(&record).Error; err != nil {
+			return err
+		}
+
+		return touch(tx, record.ListId)
+	})
+}
+
+func (r *postgreListRepository) UpdateNote(ctx context.Context, id int, bookId int, note string) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ReadingListEntry{}).Where("list_id = ? AND book_id = ?", id, bookId).Update("note", note).Error; err != nil {
+			return err
+		}
+
+		return touch(tx, id)
+	})
+}
+
+// MoveEntry shifts the entries between the old and the new position one place towards the old one
+func (r *postgreListRepository) MoveEntry(ctx context.Context, id int, bookId int, position int) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		var current ReadingListEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("list_id = ? AND book_id = ?", id, bookId).First(&current).Error; err != nil {
+			return err
+		}
+
+		var err error
+		if position < current.Position {
+			err = tx.Exec(`UPDATE "reading_list_entries" SET position = position + 1 WHERE list_id = ? AND position >= ? AND position < ?`, id, position, current.Position).Error
+		} else {
+			err = tx.Exec(`UPDATE "reading_list_entries" SET position = position - 1 WHERE list_id = ? AND position > ? AND position <= ?`, id, current.Position, position).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&ReadingListEntry{}).Where("list_id = ? AND book_id = ?", id, bookId).Update("position", position).Error; err != nil {
+			return err
+		}
+
+		return touch(tx, id)
+	})
+}
+
+func (r *postgreListRepository) DeleteEntry(ctx context.Context, id int, bookId int) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		var current ReadingListEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("list_id = ? AND book_id = ?", id, bookId).First(&current).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("list_id = ? AND book_id = ?", id, bookId).Delete(&ReadingListEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE "reading_list_entries" SET position = position - 1 WHERE list_id = ? AND position > ?`, id, current.Position).Error; err != nil {
+			return err
+		}
+
+		return touch(tx, id)
+	})
+}
+
+func (r *postgreListRepository) AddEditor(ctx context.Context, id int, userId int) error {
+	record := ReadingListEditor{ListId: id, UserId: userId}
+
+	return r.conn.Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error
+}
+
+func (r *postgreListRepository) RemoveEditor(ctx context.Context, id int, userId int) (bool, error) {
+	result := r.conn.Where("list_id = ? AND user_id = ?", id, userId).Delete(&ReadingListEditor{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *postgreListRepository) AddVote(ctx context.Context, id int, userId int) (bool, error) {
+	record := ReadingListVote{ListId: id, UserId: userId}
+	result := r.conn.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *postgreListRepository) RemoveVote(ctx context.Context, id int, userId int) (bool, error) {
+	result := r.conn.Where("list_id = ? AND user_id = ?", id, userId).Delete(&ReadingListVote{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *postgreListRepository) CountVotes(ctx context.Context, id int) (int, error) {
+	var total int64
+	err := r.conn.Model(&ReadingListVote{}).Where("list_id = ?", id).Count(&total).Error
+
+	return int(total), err
+}
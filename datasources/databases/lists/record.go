@@ -0,0 +1,121 @@
+package lists
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/lists"
+)
+
+type ReadingList struct {
+	Id          int    `gorm:"primaryKey"`
+	OwnerId     int    `gorm:"not null; index"`
+	Owner       string `gorm:"->;-:migration"`
+	Title       string `gorm:"type:varchar(100); not null"`
+	Description string `gorm:"type:text; not null; default:''"`
+	Visibility  string `gorm:"type:varchar(10); not null; index"`
+	Books       int    `gorm:"->;-:migration"`
+	Votes       int    `gorm:"->;-:migration"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ReadingListEntry struct {
+	ListId    int `gorm:"primaryKey"`
+	BookId    int `gorm:"primaryKey;index"`
+	Book      books.Book
+	Position  int    `gorm:"type:integer; not null"`
+	Note      string `gorm:"type:text; not null; default:''"`
+	AddedBy   *int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ReadingListEditor struct {
+	ListId    int    `gorm:"primaryKey"`
+	UserId    int    `gorm:"primaryKey;index"`
+	Username  string `gorm:"->;-:migration"`
+	CreatedAt time.Time
+}
+
+type ReadingListVote struct {
+	ListId    int `gorm:"primaryKey"`
+	UserId    int `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+func (r *ReadingList) ToDomain() lists.Domain {
+	return lists.Domain{
+		ID:          r.Id,
+		OwnerId:     r.OwnerId,
+		Owner:       r.Owner,
+		Title:       r.Title,
+		Description: r.Description,
+		Visibility:  r.Visibility,
+		Books:       r.Books,
+		Votes:       r.Votes,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func FromDomain(domain *lists.Domain) ReadingList {
+	return ReadingList{
+		Id:          domain.ID,
+		OwnerId:     domain.OwnerId,
+		Title:       domain.Title,
+		Description: domain.Description,
+		Visibility:  domain.Visibility,
+	}
+}
+
+func ToArrayOfDomain(records *[]ReadingList) []lists.Domain {
+	var result []lists.Domain
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
+
+func (r *ReadingListEntry) ToDomain() lists.Entry {
+	return lists.Entry{
+		ListId:    r.ListId,
+		BookId:    r.BookId,
+		Book:      r.Book.ToDomain(),
+		Position:  r.Position,
+		Note:      r.Note,
+		AddedBy:   r.AddedBy,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func FromEntryDomain(domain *lists.Entry) ReadingListEntry {
+	return ReadingListEntry{
+		ListId:   domain.ListId,
+		BookId:   domain.BookId,
+		Position: domain.Position,
+		Note:     domain.Note,
+		AddedBy:  domain.AddedBy,
+	}
+}
+
+func ToArrayOfEntryDomain(records *[]ReadingListEntry) []lists.Entry {
+	var result []lists.Entry
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
+
+func (r *ReadingListEditor) ToDomain() lists.Editor {
+	return lists.Editor{
+		UserId:    r.UserId,
+		Username:  r.Username,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+package publishers
+
+import (
+	"context"
+
+	bookRecord "github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/publishers"
+	"gorm.io/gorm"
+)
+
+type postgrePublisherRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgrePublisherRepository(conn *gorm.DB) publishers.Repository {
+	return &postgrePublisherRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgrePublisherRepository) Store(ctx context.Context, domain *publishers.Domain) (publishers.Domain, error) {
+	publisher := FromDomain(domain)
+	if err := r.conn.Create(&publisher).Error; err != nil {
+		return publishers.Domain{}, err
+	}
+
+	return publisher.ToDomain(), nil
+}
+
+func (r *postgrePublisherRepository) GetAll(ctx context.Context) ([]publishers.Domain, error) {
+	var records []Publisher
+	if err := r.conn.Order("name").Find(&records).Error; err != nil {
+		return []publishers.Domain{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgrePublisherRepository) GetById(ctx context.Context, id int) (publishers.Domain, error) {
+	var publisher Publisher
+	if err := r.conn.First(&publisher, id).Error; err != nil {
+		return publishers.Domain{}, err
+	}
+
+	return publisher.ToDomain(), nil
+}
+
+func (r *postgrePublisherRepository) GetByName(ctx context.Context, name string) (publishers.Domain, error) {
+	var publisher Publisher
+	if err := r.conn.Where("lower(name) = lower(?)", name).First(&publisher).Error; err != nil {
+		return publishers.Domain{}, err
+	}
+
+	return publisher.ToDomain(), nil
+}
+
+func (r *postgrePublisherRepository) Update(ctx context.Context, domain *publishers.Domain) ([]int, error) {
+	publisher := FromDomain(domain)
+
+	var bookIds []int
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Publisher{}).Where("id = ?", publisher.Id).Update("name", publisher.Name).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&bookRecord.Book{}).Where("publisher_id = ?", publisher.Id).Pluck("id", &bookIds).Error; err != nil {
+			return err
+		}
+
+		// keep the denormalized publisher text of the books in step with the new name
+		return tx.Model(&bookRecord.Book{}).Where("publisher_id = ?", publisher.Id).Update("publisher", publisher.Name).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookIds, nil
+}
+
+func (r *postgrePublisherRepository) Delete(ctx context.Context, id int) error {
+	return r.conn.Delete(&Publisher{}, id).Error
+}
+
+func (r *postgrePublisherRepository) GetBooks(ctx context.Context, publisherId int) ([]books.Domain, error) {
+	var records []bookRecord.Book
+	if err := r.conn.Where("publisher_id = ?", publisherId).Order("title").Find(&records).Error; err != nil {
+		return []books.Domain{}, err
+	}
+
+	return bookRecord.ToArrayOfDomain(&records), nil
+}
+
+func (r *postgrePublisherRepository) AssignBook(ctx context.Context, bookId int, publisher publishers.Domain) error {
+	return r.conn.Model(&bookRecord.Book{}).Where("id = ?", bookId).Updates(map[string]interface{}{
+		"publisher_id": publisher.ID,
+		"publisher":    publisher.Name,
+	}).Error
+}
@@ -0,0 +1,44 @@
+package publishers
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/publishers"
+	"gorm.io/gorm"
+)
+
+type Publisher struct {
+	Id        int    `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"type:varchar(100); not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (p *Publisher) ToDomain() publishers.Domain {
+	return publishers.Domain{
+		ID:        p.Id,
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func FromDomain(domain *publishers.Domain) Publisher {
+	return Publisher{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToArrayOfDomain(records *[]Publisher) []publishers.Domain {
+	var result []publishers.Domain
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
@@ -0,0 +1,70 @@
+package rankings
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/rankings"
+	"gorm.io/gorm"
+)
+
+type postgreRankingRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreRankingRepository(conn *gorm.DB) rankings.Repository {
+	return &postgreRankingRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreRankingRepository) GetStats(ctx context.Context, since time.Time) ([]rankings.Stat, error) {
+	db := r.conn.Table("reviews").
+		Select(`"reviews".book_id, COUNT(*) AS reviews, AVG("reviews".rating) AS average`).
+		Joins(`JOIN "books" ON "books".id = "reviews".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"reviews"."deleted_at" IS NULL`).
+		Group(`"reviews".book_id`)
+	if !since.IsZero() {
+		db = db.Where(`"reviews".created_at >= ?`, since)
+	}
+
+	var stats []rankings.Stat
+	if err := db.Scan(&stats).Error; err != nil {
+		return []rankings.Stat{}, err
+	}
+
+	return stats, nil
+}
+
+func (r *postgreRankingRepository) Replace(ctx context.Context, domains []rankings.Ranking) error {
+	records := make([]BookRanking, 0, len(domains))
+	for i := range domains {
+		records = append(records, FromDomain(&domains[i]))
+	}
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM "book_rankings"`).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Book").CreateInBatches(&records, 500).Error
+	})
+}
+
+func (r *postgreRankingRepository) GetByKind(ctx context.Context, kind string, limit int) ([]rankings.Ranking, error) {
+	var records []BookRanking
+	err := r.conn.Preload("Book").
+		Joins(`JOIN "books" ON "books".id = "book_rankings".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"book_rankings".kind = ?`, kind).
+		Order(`"book_rankings".rank`).
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return []rankings.Ranking{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
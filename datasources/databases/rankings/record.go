@@ -0,0 +1,50 @@
+package rankings
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/rankings"
+)
+
+type BookRanking struct {
+	Kind      string `gorm:"primaryKey; type:varchar(10)"`
+	BookId    int    `gorm:"primaryKey;index"`
+	Book      books.Book
+	Score     float64 `gorm:"type:NUMERIC(8,3); not null"`
+	Reviews   int     `gorm:"type:integer; not null"`
+	Rank      int     `gorm:"type:integer; not null"`
+	CreatedAt time.Time
+}
+
+func (r *BookRanking) ToDomain() rankings.Ranking {
+	return rankings.Ranking{
+		Kind:      r.Kind,
+		BookId:    r.BookId,
+		Book:      r.Book.ToDomain(),
+		Score:     r.Score,
+		Reviews:   r.Reviews,
+		Rank:      r.Rank,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func FromDomain(domain *rankings.Ranking) BookRanking {
+	return BookRanking{
+		Kind:    domain.Kind,
+		BookId:  domain.BookId,
+		Score:   domain.Score,
+		Reviews: domain.Reviews,
+		Rank:    domain.Rank,
+	}
+}
+
+func ToArrayOfDomain(records *[]BookRanking) []rankings.Ranking {
+	var result []rankings.Ranking
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package recommendations
+
+import (
+	"context"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	bookDomain "github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/recommendations"
+	"gorm.io/gorm"
+)
+
+type postgreRecommendationRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreRecommendationRepository(conn *gorm.DB) recommendations.Repository {
+	return &postgreRecommendationRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreRecommendationRepository) GetRatings(ctx context.Context) ([]recommendations.Rating, error) {
+	var ratings []recommendations.Rating
+	err := r.conn.Raw(`
+		SELECT rv.user_id, rv.book_id, rv.rating
+		FROM "reviews" rv
+		JOIN "books" b ON b.id = rv.book_id AND b."deleted_at" IS NULL
+		JOIN "users" u ON u.id = rv.user_id AND u."deleted_at" IS NULL
+		WHERE rv."deleted_at" IS NULL
+	`).Scan(&ratings).Error
+
+	return ratings, err
+}
+
+func (r *postgreRecommendationRepository) Replace(ctx context.Context, domains []recommendations.Recommendation) error {
+	records := make([]Recommendation, 0, len(domains))
+	for i := range domains {
+		records = append(records, FromDomain(&domains[i]))
+	}
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM "recommendations"`).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Book").CreateInBatches(&records, 500).Error
+	})
+}
+
+func (r *postgreRecommendationRepository) GetByUserId(ctx context.Context, userId int, limit int) ([]recommendations.Recommendation, error) {
+	// books deleted or reviewed since the last refresh are left out until the next one replaces them
+	var records []Recommendation
+	err := r.conn.Preload("Book").
+		Joins(`JOIN "books" ON "books".id = "recommendations".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"recommendations".user_id = ?`, userId).
+		Where(`NOT EXISTS (SELECT 1 FROM "reviews" rv WHERE rv.book_id = "recommendations".book_id AND rv.user_id = ? AND rv."deleted_at" IS NULL)`, userId).
+		Order(`"recommendations".rank`).
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return []recommendations.Recommendation{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreRecommendationRepository) GetTopRated(ctx context.Context, userId int, limit int) ([]bookDomain.Domain, error) {
+	var records []books.Book
+	err := r.conn.
+		Where(`rating > 0`).
+		Where(`NOT EXISTS (SELECT 1 FROM "reviews" rv WHERE rv.book_id = "books".id AND rv.user_id = ? AND rv."deleted_at" IS NULL)`, userId).
+		Order("rating DESC, id").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return []bookDomain.Domain{}, err
+	}
+
+	return books.ToArrayOfDomain(&records), nil
+}
@@ -0,0 +1,47 @@
+package recommendations
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/recommendations"
+)
+
+type Recommendation struct {
+	UserId    int `gorm:"primaryKey"`
+	BookId    int `gorm:"primaryKey;index"`
+	Book      books.Book
+	Score     float64 `gorm:"type:NUMERIC(4,2); not null"`
+	Rank      int     `gorm:"type:integer; not null"`
+	CreatedAt time.Time
+}
+
+func (r *Recommendation) ToDomain() recommendations.Recommendation {
+	return recommendations.Recommendation{
+		UserId:    r.UserId,
+		BookId:    r.BookId,
+		Book:      r.Book.ToDomain(),
+		Score:     r.Score,
+		Rank:      r.Rank,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func FromDomain(domain *recommendations.Recommendation) Recommendation {
+	return Recommendation{
+		UserId: domain.UserId,
+		BookId: domain.BookId,
+		Score:  domain.Score,
+		Rank:   domain.Rank,
+	}
+}
+
+func ToArrayOfDomain(records *[]Recommendation) []recommendations.Recommendation {
+	var result []recommendations.Recommendation
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
@@ -35,13 +35,13 @@ func (_m *Repository) Delete(ctx context.Context, domain *reviews.Domain) (int,
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: ctx
-func (_m *Repository) GetAll(ctx context.Context) ([]reviews.Domain, error) {
-	ret := _m.Called(ctx)
+// GetAll provides a mock function with given fields: ctx, query
+func (_m *Repository) GetAll(ctx context.Context, query *reviews.Query) ([]reviews.Domain, error) {
+	ret := _m.Called(ctx, query)
 
 	var r0 []reviews.Domain
-	if rf, ok := ret.Get(0).(func(context.Context) []reviews.Domain); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, *reviews.Query) []reviews.Domain); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]reviews.Domain)
@@ -49,8 +49,8 @@ func (_m *Repository) GetAll(ctx context.Context) ([]reviews.Domain, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, *reviews.Query) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,13 +58,13 @@ func (_m *Repository) GetAll(ctx context.Context) ([]reviews.Domain, error) {
 	return r0, r1
 }
 
-// GetByBookId provides a mock function with given fields: ctx, bookId
-func (_m *Repository) GetByBookId(ctx context.Context, bookId int) ([]reviews.Domain, error) {
-	ret := _m.Called(ctx, bookId)
+// GetByBookId provides a mock function with given fields: ctx, bookId, query
+func (_m *Repository) GetByBookId(ctx context.Context, bookId int, query *reviews.Query) ([]reviews.Domain, error) {
+	ret := _m.Called(ctx, bookId, query)
 
 	var r0 []reviews.Domain
-	if rf, ok := ret.Get(0).(func(context.Context, int) []reviews.Domain); ok {
-		r0 = rf(ctx, bookId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *reviews.Query) []reviews.Domain); ok {
+		r0 = rf(ctx, bookId, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]reviews.Domain)
@@ -72,8 +72,8 @@ func (_m *Repository) GetByBookId(ctx context.Context, bookId int) ([]reviews.Do
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, bookId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, *reviews.Query) error); ok {
+		r1 = rf(ctx, bookId, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,13 +102,13 @@ func (_m *Repository) GetById(ctx context.Context, id int) (reviews.Domain, erro
 	return r0, r1
 }
 
-// GetByUserId provides a mock function with given fields: ctx, userId
-func (_m *Repository) GetByUserId(ctx context.Context, userId int) ([]reviews.Domain, error) {
-	ret := _m.Called(ctx, userId)
+// GetByUserId provides a mock function with given fields: ctx, userId, query
+func (_m *Repository) GetByUserId(ctx context.Context, userId int, query *reviews.Query) ([]reviews.Domain, error) {
+	ret := _m.Called(ctx, userId, query)
 
 	var r0 []reviews.Domain
-	if rf, ok := ret.Get(0).(func(context.Context, int) []reviews.Domain); ok {
-		r0 = rf(ctx, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *reviews.Query) []reviews.Domain); ok {
+		r0 = rf(ctx, userId, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]reviews.Domain)
@@ -116,8 +116,8 @@ func (_m *Repository) GetByUserId(ctx context.Context, userId int) ([]reviews.Do
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, *reviews.Query) error); ok {
+		r1 = rf(ctx, userId, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -125,6 +125,36 @@ func (_m *Repository) GetByUserId(ctx context.Context, userId int) ([]reviews.Do
 	return r0, r1
 }
 
+// GetRecentByBookId provides a mock function with given fields: ctx, bookId, page, limit
+func (_m *Repository) GetRecentByBookId(ctx context.Context, bookId int, page int, limit int) ([]reviews.Domain, int, error) {
+	ret := _m.Called(ctx, bookId, page, limit)
+
+	var r0 []reviews.Domain
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []reviews.Domain); ok {
+		r0 = rf(ctx, bookId, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reviews.Domain)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) int); ok {
+		r1 = rf(ctx, bookId, page, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
+		r2 = rf(ctx, bookId, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetUserReview provides a mock function with given fields: ctx, bookId, userId
 func (_m *Repository) GetUserReview(ctx context.Context, bookId int, userId int) (reviews.Domain, error) {
 	ret := _m.Called(ctx, bookId, userId)
@@ -167,6 +197,34 @@ func (_m *Repository) Store(ctx context.Context, domain *reviews.Domain) (review
 	return r0, r1
 }
 
+// Unvote provides a mock function with given fields: ctx, reviewId, userId
+func (_m *Repository) Unvote(ctx context.Context, reviewId int, userId int) (int, bool, error) {
+	ret := _m.Called(ctx, reviewId, userId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, reviewId, userId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) bool); ok {
+		r1 = rf(ctx, reviewId, userId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
+		r2 = rf(ctx, reviewId, userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: ctx, domain
 func (_m *Repository) Update(ctx context.Context, domain *reviews.Domain) error {
 	ret := _m.Called(ctx, domain)
@@ -181,6 +239,27 @@ func (_m *Repository) Update(ctx context.Context, domain *reviews.Domain) error
 	return r0
 }
 
+// Vote provides a mock function with given fields: ctx, reviewId, userId, value
+func (_m *Repository) Vote(ctx context.Context, reviewId int, userId int, value int) (int, error) {
+	ret := _m.Called(ctx, reviewId, userId, value)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) int); ok {
+		r0 = rf(ctx, reviewId, userId, value)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, reviewId, userId, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,11 +3,14 @@ package reviews
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/snykk/golib_backend/constants"
 	bookRepo "github.com/snykk/golib_backend/datasources/databases/books"
 	userRepo "github.com/snykk/golib_backend/datasources/databases/users"
 	"github.com/snykk/golib_backend/domains/reviews"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type postgreReviewRepository struct {
@@ -28,6 +31,10 @@ func (r *postgreReviewRepository) Store(ctx context.Context, domain *reviews.Dom
 			return err
 		}
 
+		if err := bookRepo.AddRating(tx, review.BookId, review.Rating, review.CreatedAt, 1); err != nil {
+			return err
+		}
+
 		// get rating of certain book
 		var rating float64
 		if err := tx.Raw(`SELECT AVG("reviews".rating) FROM "reviews" WHERE book_id = ? AND "deleted_at" IS NULL`, review.BookId).Scan(&rating).Error; err != nil {
@@ -64,15 +71,8 @@ func (r *postgreReviewRepository) Store(ctx context.Context, domain *reviews.Dom
 	return review.ToDomain(), nil
 }
 
-func (r *postgreReviewRepository) GetAll(ctx context.Context) ([]reviews.Domain, error) {
-	var reviewRecords []Review
-	if err := r.conn.Preload("User.Role").Preload("User.Gender").Preload("Book").Find(&reviewRecords).Error; err != nil {
-		return []reviews.Domain{}, err
-	}
-
-	reviewDomains := ToArrayOfDomain(&reviewRecords)
-
-	return reviewDomains, nil
+func (r *postgreReviewRepository) GetAll(ctx context.Context, query *reviews.Query) ([]reviews.Domain, error) {
+	return r.page(r.conn, query)
 }
 
 func (r *postgreReviewRepository) GetById(ctx context.Context, id int) (reviews.Domain, error) {
@@ -84,18 +84,74 @@ func (r *postgreReviewRepository) GetById(ctx context.Context, id int) (reviews.
 	return review.ToDomain(), nil
 }
 
-func (r *postgreReviewRepository) GetByBookId(ctx context.Context, bookId int) ([]reviews.Domain, error) {
+func (r *postgreReviewRepository) GetByBookId(ctx context.Context, bookId int, query *reviews.Query) ([]reviews.Domain, error) {
+	return r.page(r.conn.Where(Review{BookId: bookId}), query)
+}
+
+func (r *postgreReviewRepository) GetRecentByBookId(ctx context.Context, bookId, page, limit int) ([]reviews.Domain, int, error) {
+	db := r.conn.Model(&Review{}).Where(Review{BookId: bookId})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return []reviews.Domain{}, 0, err
+	}
+
 	var review []Review
-	if err := r.conn.Preload("User.Role").Preload("User.Gender").Preload("Book").Where(Review{BookId: bookId}).Find(&review).Error; err != nil {
-		return []reviews.Domain{}, err
+	err := db.Preload("User.Role").Preload("User.Gender").Preload("Book").Order("created_at DESC").Order("id DESC").
+		Offset((page - 1) * limit).Limit(limit).Find(&review).Error
+	if err != nil {
+		return []reviews.Domain{}, 0, err
 	}
 
-	return ToArrayOfDomain(&review), nil
+	return ToArrayOfDomain(&review), int(total), nil
+}
+
+func (r *postgreReviewRepository) GetByUserId(ctx context.Context, userId int, query *reviews.Query) ([]reviews.Domain, error) {
+	return r.page(r.conn.Where(Review{UserId: userId}), query)
 }
 
-func (r *postgreReviewRepository) GetByUserId(ctx context.Context, userId int) ([]reviews.Domain, error) {
+// sortKeys lists the columns every sort order keys on, the id comes last so that no two reviews share a position
+var sortKeys = map[string][]string{
+	constants.ReviewSortNewest:        {"created_at", "id"},
+	constants.ReviewSortOldest:        {"created_at", "id"},
+	constants.ReviewSortHighestRating: {"rating", "created_at", "id"},
+	constants.ReviewSortLowestRating:  {"rating", "created_at", "id"},
+	constants.ReviewSortMostHelpful:   {"helpful_count", "created_at", "id"},
+}
+
+// page reads the reviews after the cursor with a keyset condition instead of an offset,
+// so reviews written while a client pages through never shift the pages it hasn't read yet
+func (r *postgreReviewRepository) page(db *gorm.DB, query *reviews.Query) ([]reviews.Domain, error) {
+	keys := sortKeys[query.Sort]
+	desc := query.Sort != constants.ReviewSortOldest && query.Sort != constants.ReviewSortLowestRating
+
+	if after := query.After; after != nil {
+		values := map[string]interface{}{
+			"created_at":    after.CreatedAt,
+			"id":            after.ID,
+			"rating":        after.Rating,
+			"helpful_count": after.HelpfulCount,
+		}
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+
+		placeholders := make([]string, len(keys))
+		args := make([]interface{}, len(keys))
+		for i, key := range keys {
+			placeholders[i] = "?"
+			args[i] = values[key]
+		}
+		db = db.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(keys, ", "), operator, strings.Join(placeholders, ", ")), args...)
+	}
+
+	for _, key := range keys {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: key}, Desc: desc})
+	}
+
 	var review []Review
-	if err := r.conn.Preload("User.Role").Preload("User.Gender").Preload("Book").Where(Review{UserId: userId}).Find(&review).Error; err != nil {
+	if err := db.Preload("User.Role").Preload("User.Gender").Preload("Book").Limit(query.Limit + 1).Find(&review).Error; err != nil {
 		return []reviews.Domain{}, err
 	}
 
@@ -106,10 +162,25 @@ func (r *postgreReviewRepository) Update(ctx context.Context, b *reviews.Domain)
 	review := FromDomain(b)
 
 	err = r.conn.Transaction(func(tx *gorm.DB) error {
+		var before Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, review.Id).Error; err != nil {
+			return err
+		}
+
 		if err := tx.Model(&Review{}).Where("id = ?", review.Id).Updates(&review).Error; err != nil {
 			return err
 		}
 
+		// a changed rating moves the review to another bar of the histogram, the month it was written stays
+		if review.Rating != 0 && review.Rating != before.Rating {
+			if err := bookRepo.AddRating(tx, before.BookId, before.Rating, before.CreatedAt, -1); err != nil {
+				return err
+			}
+			if err := bookRepo.AddRating(tx, before.BookId, review.Rating, before.CreatedAt, 1); err != nil {
+				return err
+			}
+		}
+
 		// get rating of certain book
 		var rating float64
 		if err := tx.Raw(`SELECT AVG("reviews".rating) FROM "reviews" WHERE book_id = ? AND "deleted_at" IS NULL`, review.BookId).Scan(&rating).Error; err != nil {
@@ -134,10 +205,19 @@ func (r *postgreReviewRepository) Delete(ctx context.Context, domain *reviews.Do
 
 	err = r.conn.Transaction(func(tx *gorm.DB) error {
 		fmt.Println("ini id review pake do", review.Id)
+		var before Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, review.Id).Error; err != nil {
+			return err
+		}
+
 		if err = tx.Delete(&Review{}, review.Id).Error; err != nil {
 			return err
 		}
 
+		if err := bookRepo.AddRating(tx, before.BookId, before.Rating, before.CreatedAt, -1); err != nil {
+			return err
+		}
+
 		// get rating of certain book
 		var rating float64
 		if err := tx.Raw(`SELECT COALESCE(AVG("reviews".rating), 0) FROM "reviews" WHERE book_id = ? AND "deleted_at" IS NULL`, review.BookId).Scan(&rating).Error; err != nil {
@@ -174,3 +254,57 @@ func (r *postgreReviewRepository) GetUserReview(ctx context.Context, bookId, use
 
 	return review.ToDomain(), nil
 }
+
+func (r *postgreReviewRepository) Vote(ctx context.Context, reviewId, userId, value int) (helpfulCount int, err error) {
+	err = r.conn.Transaction(func(tx *gorm.DB) error {
+		// votes on the same review queue up behind this lock, so the count always matches the votes
+		var review Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "helpful_count").First(&review, reviewId).Error; err != nil {
+			return err
+		}
+
+		var before ReviewVote
+		result := tx.Where("review_id = ? AND user_id = ?", reviewId, userId).Limit(1).Find(&before)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		vote := ReviewVote{ReviewId: reviewId, UserId: userId, Value: value}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(&vote).Error; err != nil {
+			return err
+		}
+
+		helpfulCount = review.HelpfulCount + value - before.Value
+		return tx.Model(&Review{}).Where("id = ?", reviewId).UpdateColumn("helpful_count", helpfulCount).Error
+	})
+
+	return
+}
+
+func (r *postgreReviewRepository) Unvote(ctx context.Context, reviewId, userId int) (helpfulCount int, removed bool, err error) {
+	err = r.conn.Transaction(func(tx *gorm.DB) error {
+		var review Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "helpful_count").First(&review, reviewId).Error; err != nil {
+			return err
+		}
+		helpfulCount = review.HelpfulCount
+
+		var before ReviewVote
+		result := tx.Where("review_id = ? AND user_id = ?", reviewId, userId).Limit(1).Find(&before)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Where("review_id = ? AND user_id = ?", reviewId, userId).Delete(&ReviewVote{}).Error; err != nil {
+			return err
+		}
+		removed = true
+
+		helpfulCount -= before.Value
+		return tx.Model(&Review{}).Where("id = ?", reviewId).UpdateColumn("helpful_count", helpfulCount).Error
+	})
+
+	return
+}
@@ -10,29 +10,40 @@ import (
 )
 
 type Review struct {
-	Id        int    `gorm:"primaryKey;autoIncrement"`
-	Text      string `gorm:"type:text; not null"`
-	Rating    int    `gorm:"type:integer; not null"`
-	BookId    int    `gorm:"not null"`
-	Book      books.Book
-	UserId    int `gorm:"not null"`
-	User      users.User
+	Id           int    `gorm:"primaryKey;autoIncrement"`
+	Text         string `gorm:"type:text; not null"`
+	Rating       int    `gorm:"type:integer; not null"`
+	HelpfulCount int    `gorm:"not null; default:0"`
+	BookId       int    `gorm:"not null"`
+	Book         books.Book
+	UserId       int `gorm:"not null"`
+	User         users.User
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// ReviewVote is the helpfulness vote of a user on a review, Value is 1 for helpful and -1 for not helpful
+type ReviewVote struct {
+	ReviewId  int `gorm:"primaryKey"`
+	UserId    int `gorm:"primaryKey;index"`
+	Value     int `gorm:"type:smallint; not null"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 func (u *Review) ToDomain() reviews.Domain {
 	return reviews.Domain{
-		ID:        u.Id,
-		Text:      u.Text,
-		Rating:    u.Rating,
-		BookId:    u.BookId,
-		Book:      u.Book.ToDomain(),
-		UserId:    u.UserId,
-		User:      u.User.ToDomain(),
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:           u.Id,
+		Text:         u.Text,
+		Rating:       u.Rating,
+		HelpfulCount: u.HelpfulCount,
+		BookId:       u.BookId,
+		Book:         u.Book.ToDomain(),
+		UserId:       u.UserId,
+		User:         u.User.ToDomain(),
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
 	}
 }
 
@@ -0,0 +1,229 @@
+package shelves
+
+import (
+	"context"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/shelves"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgreShelfRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreShelfRepository(conn *gorm.DB) shelves.Repository {
+	return &postgreShelfRepository{
+		conn: conn,
+	}
+}
+
+// CountStatuses counts the live books on each built-in shelf of a user, every status is present even when empty
+func CountStatuses(conn *gorm.DB, userId int) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Total  int
+	}
+	err := conn.Raw(`
+		SELECT se.status, COUNT(*) AS total
+		FROM "shelf_entries" se
+		JOIN "books" b ON b.id = se.book_id AND b."deleted_at" IS NULL
+		WHERE se.user_id = ?
+		GROUP BY se.status
+	`, userId).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(constants.ListShelfStatus))
+	for _, status := range constants.ListShelfStatus {
+		counts[status] = 0
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+
+	return counts, nil
+}
+
+func (r *postgreShelfRepository) CountStatuses(ctx context.Context, userId int) (map[string]int, error) {
+	return CountStatuses(r.conn, userId)
+}
+
+func (r *postgreShelfRepository) GetEntries(ctx context.Context, userId int, status string, query *shelves.Query) ([]shelves.Entry, int, error) {
+	db := r.conn.Model(&ShelfEntry{}).
+		Joins(`JOIN "books" ON "books".id = "shelf_entries".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"shelf_entries".user_id = ? AND "shelf_entries".status = ?`, userId, status)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return []shelves.Entry{}, 0, err
+	}
+
+	var records []ShelfEntry
+	err := db.Preload("Book").
+		Order(`"shelf_entries".updated_at DESC`).
+		Order(`"shelf_entries".book_id`).
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&records).Error
+	if err != nil {
+		return []shelves.Entry{}, 0, err
+	}
+
+	return ToArrayOfEntryDomain(&records), int(total), nil
+}
+
+func (r *postgreShelfRepository) GetEntry(ctx context.Context, userId int, bookId int) (shelves.Entry, error) {
+	var record ShelfEntry
+	if err := r.conn.Preload("Book").Where(ShelfEntry{UserId: userId, BookId: bookId}).First(&record).Error; err != nil {
+		return shelves.Entry{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreShelfRepository) SaveEntry(ctx context.Context, entry *shelves.Entry) (shelves.Entry, error) {
+	record := FromEntryDomain(entry)
+
+	err := r.conn.Omit("Book").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "book_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "started_at", "finished_at", "current_page", "total_pages", "updated_at"}),
+	}).Create(&record).Error
+	if err != nil {
+		return shelves.Entry{}, err
+	}
+
+	return r.GetEntry(ctx, entry.UserId, entry.BookId)
+}
+
+func (r *postgreShelfRepository) DeleteEntry(ctx context.Context, userId int, bookId int) error {
+	return r.conn.Where("user_id = ? AND book_id = ?", userId, bookId).Delete(&ShelfEntry{}).Error
+}
+
+func (r *postgreShelfRepository) GetShelves(ctx context.Context, userId int) ([]shelves.Shelf, error) {
+	var records []Shelf
+	err := r.conn.Raw(`
+		SELECT s.*, (
+			SELECT COUNT(*) FROM "shelf_books" sb
+			JOIN "books" b ON b.id = sb.book_id AND b."deleted_at" IS NULL
+			WHERE sb.shelf_id = s.id
+		) AS books
+		FROM "shelves" s
+		WHERE s.user_id = ?
+		ORDER BY lower(s.name), s.id
+	`, userId).Scan(&records).Error
+	if err != nil {
+		return []shelves.Shelf{}, err
+	}
+
+	return ToArrayOfShelfDomain(&records), nil
+}
+
+func (r *postgreShelfRepository) GetShelf(ctx context.Context, userId int, id int) (shelves.Shelf, error) {
+	var record Shelf
+	if err := r.conn.Where(Shelf{Id: id, UserId: userId}).First(&record).Error; err != nil {
+		return shelves.Shelf{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreShelfRepository) GetShelfByName(ctx context.Context, userId int, name string) (shelves.Shelf, error) {
+	var record Shelf
+	if err := r.conn.Where("user_id = ? AND lower(name) = lower(?)", userId, name).First(&record).Error; err != nil {
+		return shelves.Shelf{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreShelfRepository) StoreShelf(ctx context.Context, shelf *shelves.Shelf) (shelves.Shelf, error) {
+	record := FromShelfDomain(shelf)
+	if err := r.conn.Create(&record).Error; err != nil {
+		return shelves.Shelf{}, err
+	}
+
+	return record.ToDomain(), nil
+}
+
+func (r *postgreShelfRepository) UpdateShelf(ctx context.Context, shelf *shelves.Shelf) error {
+	return r.conn.Model(&Shelf{}).Where("id = ?", shelf.ID).Update("name", shelf.Name).Error
+}
+
+func (r *postgreShelfRepository) DeleteShelf(ctx context.Context, id int) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shelf_id = ?", id).Delete(&ShelfBook{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&Shelf{}, id).Error
+	})
+}
+
+func (r *postgreShelfRepository) GetShelfBooks(ctx context.Context, shelfId int, query *shelves.Query) ([]shelves.Entry, int, error) {
+	db := r.conn.Model(&ShelfBook{}).
+		Joins(`JOIN "books" ON "books".id = "shelf_books".book_id AND "books"."deleted_at" IS NULL`).
+		Where(`"shelf_books".shelf_id = ?`, shelfId)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return []shelves.Entry{}, 0, err
+	}
+
+	var records []ShelfBook
+	err := db.Preload("Book").
+		Order(`"shelf_books".created_at DESC`).
+		Order(`"shelf_books".book_id`).
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&records).Error
+	if err != nil {
+		return []shelves.Entry{}, 0, err
+	}
+	if len(records) == 0 {
+		return []shelves.Entry{}, int(total), nil
+	}
+
+	// a book on a custom shelf shows the reading status its owner tracks for it, if any
+	bookIds := make([]int, 0, len(records))
+	for _, record := range records {
+		bookIds = append(bookIds, record.BookId)
+	}
+	var tracked []ShelfEntry
+	err = r.conn.Where(`user_id = (SELECT user_id FROM "shelves" WHERE id = ?) AND book_id IN ?`, shelfId, bookIds).Find(&tracked).Error
+	if err != nil {
+		return []shelves.Entry{}, 0, err
+	}
+	byBook := make(map[int]ShelfEntry, len(tracked))
+	for _, entry := range tracked {
+		byBook[entry.BookId] = entry
+	}
+
+	result := make([]shelves.Entry, 0, len(records))
+	for _, record := range records {
+		entry := byBook[record.BookId]
+		entry.BookId = record.BookId
+		entry.Book = record.Book
+		entry.CreatedAt = record.CreatedAt
+		result = append(result, entry.ToDomain())
+	}
+
+	return result, int(total), nil
+}
+
+func (r *postgreShelfRepository) AddShelfBook(ctx context.Context, shelfId int, bookId int) error {
+	record := ShelfBook{ShelfId: shelfId, BookId: bookId}
+
+	return r.conn.Omit("Book").Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error
+}
+
+func (r *postgreShelfRepository) RemoveShelfBook(ctx context.Context, shelfId int, bookId int) (bool, error) {
+	result := r.conn.Where("shelf_id = ? AND book_id = ?", shelfId, bookId).Delete(&ShelfBook{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
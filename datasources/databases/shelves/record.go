@@ -0,0 +1,107 @@
+package shelves
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/datasources/databases/books"
+	"github.com/snykk/golib_backend/domains/shelves"
+)
+
+type ShelfEntry struct {
+	UserId      int `gorm:"primaryKey"`
+	BookId      int `gorm:"primaryKey;index"`
+	Book        books.Book
+	Status      string `gorm:"type:varchar(15); not null; index"`
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	CurrentPage int  `gorm:"type:integer; not null; default:0"`
+	TotalPages  *int `gorm:"type:integer"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Shelf struct {
+	Id        int    `gorm:"primaryKey"`
+	UserId    int    `gorm:"not null; index"`
+	Name      string `gorm:"type:varchar(30); not null"`
+	Books     int    `gorm:"->;-:migration"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ShelfBook struct {
+	ShelfId   int `gorm:"primaryKey"`
+	BookId    int `gorm:"primaryKey;index"`
+	Book      books.Book
+	CreatedAt time.Time
+}
+
+func (r *ShelfEntry) ToDomain() shelves.Entry {
+	return shelves.Entry{
+		UserId:      r.UserId,
+		BookId:      r.BookId,
+		Book:        r.Book.ToDomain(),
+		Status:      r.Status,
+		StartedAt:   r.StartedAt,
+		FinishedAt:  r.FinishedAt,
+		CurrentPage: &r.CurrentPage,
+		TotalPages:  r.TotalPages,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func FromEntryDomain(domain *shelves.Entry) ShelfEntry {
+	entry := ShelfEntry{
+		UserId:     domain.UserId,
+		BookId:     domain.BookId,
+		Status:     domain.Status,
+		StartedAt:  domain.StartedAt,
+		FinishedAt: domain.FinishedAt,
+		TotalPages: domain.TotalPages,
+	}
+	if domain.CurrentPage != nil {
+		entry.CurrentPage = *domain.CurrentPage
+	}
+
+	return entry
+}
+
+func ToArrayOfEntryDomain(records *[]ShelfEntry) []shelves.Entry {
+	var result []shelves.Entry
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
+
+func (r *Shelf) ToDomain() shelves.Shelf {
+	return shelves.Shelf{
+		ID:        r.Id,
+		UserId:    r.UserId,
+		Name:      r.Name,
+		Books:     r.Books,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func FromShelfDomain(domain *shelves.Shelf) Shelf {
+	return Shelf{
+		Id:     domain.ID,
+		UserId: domain.UserId,
+		Name:   domain.Name,
+	}
+}
+
+func ToArrayOfShelfDomain(records *[]Shelf) []shelves.Shelf {
+	var result []shelves.Shelf
+
+	for _, record := range *records {
+		result = append(result, record.ToDomain())
+	}
+
+	return result
+}
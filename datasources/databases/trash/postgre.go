@@ -0,0 +1,219 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	listRepository "github.com/snykk/golib_backend/datasources/databases/lists"
+	"github.com/snykk/golib_backend/domains/trash"
+	"gorm.io/gorm"
+)
+
+// trashItems gathers every soft deleted book, user and review into one listing
+const trashItems = `
+	SELECT 'books' AS type, id, title AS label, isbn, coalesce(cover, '') AS cover, 0 AS book_id, 0 AS user_id, deleted_at
+	FROM "books" WHERE "deleted_at" IS NOT NULL
+	UNION ALL
+	SELECT 'users', id, username, '', '', 0, 0, deleted_at
+	FROM "users" WHERE "deleted_at" IS NOT NULL
+	UNION ALL
+	SELECT 'reviews', id, left(text, 80), '', '', book_id, user_id, deleted_at
+	FROM "reviews" WHERE "deleted_at" IS NOT NULL`
+
+type postgreTrashRepository struct {
+	conn *gorm.DB
+}
+
+func NewPostgreTrashRepository(conn *gorm.DB) trash.Repository {
+	return &postgreTrashRepository{
+		conn: conn,
+	}
+}
+
+func (r *postgreTrashRepository) GetAll(ctx context.Context, query *trash.Query) ([]trash.Item, int, error) {
+	var total int64
+	if err := r.conn.Raw(`SELECT count(*) FROM (`+trashItems+`) AS trash WHERE ? = '' OR type = ?`, query.Type, query.Type).Scan(&total).Error; err != nil {
+		return []trash.Item{}, 0, err
+	}
+
+	var records []Item
+	if err := r.conn.Raw(`SELECT * FROM (`+trashItems+`) AS trash WHERE ? = '' OR type = ? ORDER BY deleted_at DESC, type, id LIMIT ? OFFSET ?`,
+		query.Type, query.Type, query.Limit, (query.Page-1)*query.Limit).Scan(&records).Error; err != nil {
+		return []trash.Item{}, 0, err
+	}
+
+	return ToArrayOfDomain(&records), int(total), nil
+}
+
+func (r *postgreTrashRepository) GetById(ctx context.Context, itemType string, id int) (trash.Item, error) {
+	var item Item
+	result := r.conn.Raw(`SELECT * FROM (`+trashItems+`) AS trash WHERE type = ? AND id = ?`, itemType, id).Scan(&item)
+	if result.Error != nil {
+		return trash.Item{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return trash.Item{}, gorm.ErrRecordNotFound
+	}
+
+	return item.ToDomain(), nil
+}
+
+func (r *postgreTrashRepository) GetDeletedBefore(ctx context.Context, before time.Time) ([]trash.Item, error) {
+	var records []Item
+	if err := r.conn.Raw(`SELECT * FROM (`+trashItems+`) AS trash WHERE deleted_at < ? ORDER BY deleted_at`, before).Scan(&records).Error; err != nil {
+		return []trash.Item{}, err
+	}
+
+	return ToArrayOfDomain(&records), nil
+}
+
+func (r *postgreTrashRepository) CountCirculations(ctx context.Context, item *trash.Item) (int, error) {
+	var count int
+	var err error
+	switch item.Type {
+	case constants.TrashBooks:
+		err = r.conn.Raw(`SELECT (SELECT count(*) FROM "copies" WHERE book_id = ?) + (SELECT count(*) FROM "holds" WHERE book_id = ?)`, item.ID, item.ID).Scan(&count).Error
+	case constants.TrashUsers:
+		err = r.conn.Raw(`SELECT (SELECT count(*) FROM "loans" WHERE user_id = ?) + (SELECT count(*) FROM "holds" WHERE user_id = ?)`, item.ID, item.ID).Scan(&count).Error
+	}
+
+	return count, err
+}
+
+func (r *postgreTrashRepository) Restore(ctx context.Context, item *trash.Item) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(item.Type).Where("id = ? AND deleted_at IS NOT NULL", item.ID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		// reviews kept changing while the item was away, so the counters are rebuilt instead of adjusted
+		switch item.Type {
+		case constants.TrashBooks:
+			if err := bookRepository.RecordRestore(ctx, tx, item.ID); err != nil {
+				return err
+			}
+			return syncBookRatings(tx, []int{item.ID})
+		case constants.TrashUsers:
+			return syncUserReviews(tx, []int{item.ID})
+		default:
+			if err := syncBookRatings(tx, []int{item.BookId}); err != nil {
+				return err
+			}
+			return syncUserReviews(tx, []int{item.UserId})
+		}
+	})
+}
+
+func (r *postgreTrashRepository) Purge(ctx context.Context, item *trash.Item) error {
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		switch item.Type {
+		case constants.TrashBooks:
+			var userIds []int
+			if err := tx.Table("reviews").Where("book_id = ? AND deleted_at IS NULL", item.ID).Distinct().Pluck("user_id", &userIds).Error; err != nil {
+				return err
+			}
+			var listIds []int
+			if err := tx.Table("reading_list_entries").Where("book_id = ?", item.ID).Pluck("list_id", &listIds).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM "review_votes" WHERE review_id IN (SELECT id FROM "reviews" WHERE book_id = ?)`, item.ID).Error; err != nil {
+				return err
+			}
+			for _, table := range []string{"reviews", "book_authors", "book_categories", "book_tags", "book_versions", "recommendations", "book_rankings", "book_rating_counts", "book_rating_months", "shelf_entries", "shelf_books", "reading_list_entries", "featured_collection_books"} {
+				if err := tx.Exec(`DELETE FROM "`+table+`" WHERE book_id = ?`, item.ID).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Exec(`DELETE FROM "books" WHERE id = ? AND "deleted_at" IS NOT NULL`, item.ID).Error; err != nil {
+				return err
+			}
+			if err := listRepository.Renumber(tx, listIds); err != nil {
+				return err
+			}
+			return syncUserReviews(tx, userIds)
+		case constants.TrashUsers:
+			var bookIds []int
+			if err := tx.Table("reviews").Where("user_id = ? AND deleted_at IS NULL", item.ID).Distinct().Pluck("book_id", &bookIds).Error; err != nil {
+				return err
+			}
+			// the user's votes go with them, so the reviews they voted on need their helpful count again
+			var votedReviewIds []int
+			if err := tx.Table("review_votes").Where("user_id = ?", item.ID).Pluck("review_id", &votedReviewIds).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM "review_votes" WHERE user_id = ? OR review_id IN (SELECT id FROM "reviews" WHERE user_id = ?)`, item.ID, item.ID).Error; err != nil {
+				return err
+			}
+			if err := syncHelpfulCounts(tx, votedReviewIds); err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM "shelf_books" WHERE shelf_id IN (SELECT id FROM "shelves" WHERE user_id = ?)`, item.ID).Error; err != nil {
+				return err
+			}
+			for _, table := range []string{"reading_list_entries", "reading_list_editors", "reading_list_votes"} {
+				if err := tx.Exec(`DELETE FROM "`+table+`" WHERE list_id IN (SELECT id FROM "reading_lists" WHERE owner_id = ?)`, item.ID).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Exec(`DELETE FROM "reading_lists" WHERE owner_id = ?`, item.ID).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`UPDATE "reading_list_entries" SET added_by = NULL WHERE added_by = ?`, item.ID).Error; err != nil {
+				return err
+			}
+			for _, table := range []string{"reviews", "recommendations", "shelf_entries", "shelves", "reading_list_editors", "reading_list_votes"} {
+				if err := tx.Exec(`DELETE FROM "`+table+`" WHERE user_id = ?`, item.ID).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Exec(`DELETE FROM "users" WHERE id = ? AND "deleted_at" IS NOT NULL`, item.ID).Error; err != nil {
+				return err
+			}
+			return syncBookRatings(tx, bookIds)
+		default:
+			if err := tx.Exec(`DELETE FROM "review_votes" WHERE review_id IN (SELECT id FROM "reviews" WHERE id = ? AND "deleted_at" IS NOT NULL)`, item.ID).Error; err != nil {
+				return err
+			}
+			return tx.Exec(`DELETE FROM "reviews" WHERE id = ? AND "deleted_at" IS NOT NULL`, item.ID).Error
+		}
+	})
+}
+
+func syncBookRatings(tx *gorm.DB, bookIds []int) error {
+	if len(bookIds) == 0 {
+		return nil
+	}
+
+	if err := tx.Exec(`UPDATE "books" SET rating = COALESCE((
+			SELECT AVG("reviews".rating) FROM "reviews" WHERE "reviews".book_id = "books".id AND "reviews"."deleted_at" IS NULL
+		), 0)
+		WHERE id IN ?`, bookIds).Error; err != nil {
+		return err
+	}
+
+	return bookRepository.SyncRatingStats(tx, bookIds)
+}
+
+func syncUserReviews(tx *gorm.DB, userIds []int) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE "users" SET reviews = (
+			SELECT count(*) FROM "reviews" WHERE "reviews".user_id = "users".id AND "reviews"."deleted_at" IS NULL
+		)
+		WHERE id IN ?`, userIds).Error
+}
+
+func syncHelpfulCounts(tx *gorm.DB, reviewIds []int) error {
+	if len(reviewIds) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE "reviews" SET helpful_count = COALESCE((
+			SELECT SUM("review_votes".value) FROM "review_votes" WHERE "review_votes".review_id = "reviews".id
+		), 0)
+		WHERE id IN ?`, reviewIds).Error
+}
@@ -0,0 +1,41 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/trash"
+)
+
+type Item struct {
+	Type      string
+	Id        int
+	Label     string
+	ISBN      string `gorm:"column:isbn"`
+	Cover     string
+	BookId    int
+	UserId    int
+	DeletedAt time.Time
+}
+
+func (i *Item) ToDomain() trash.Item {
+	return trash.Item{
+		Type:      i.Type,
+		ID:        i.Id,
+		Label:     i.Label,
+		ISBN:      i.ISBN,
+		Cover:     i.Cover,
+		BookId:    i.BookId,
+		UserId:    i.UserId,
+		DeletedAt: i.DeletedAt,
+	}
+}
+
+func ToArrayOfDomain(records *[]Item) []trash.Item {
+	var result []trash.Item
+
+	for _, val := range *records {
+		result = append(result, val.ToDomain())
+	}
+
+	return result
+}
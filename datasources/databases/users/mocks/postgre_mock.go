@@ -14,6 +14,29 @@ type Repository struct {
 	mock.Mock
 }
 
+// CountShelves provides a mock function with given fields: ctx, userId
+func (_m *Repository) CountShelves(ctx context.Context, userId int) (map[string]int, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 map[string]int
+	if rf, ok := ret.Get(0).(func(context.Context, int) map[string]int); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *Repository) Delete(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -93,6 +116,41 @@ func (_m *Repository) GetById(ctx context.Context, id int) (users.Domain, error)
 	return r0, r1
 }
 
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *Repository) GetByUsername(ctx context.Context, username string) (users.Domain, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 users.Domain
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.Domain); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(users.Domain)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: ctx, domain, fields
+func (_m *Repository) Patch(ctx context.Context, domain *users.Domain, fields []string) error {
+	ret := _m.Called(ctx, domain, fields)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *users.Domain, []string) error); ok {
+		r0 = rf(ctx, domain, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Store provides a mock function with given fields: ctx, domain
 func (_m *Repository) Store(ctx context.Context, domain *users.Domain) (users.Domain, error) {
 	ret := _m.Called(ctx, domain)
@@ -156,5 +214,3 @@ func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
 
 	return mock
 }
-
-
@@ -3,6 +3,7 @@ package users
 import (
 	"context"
 
+	"github.com/snykk/golib_backend/datasources/databases/shelves"
 	"github.com/snykk/golib_backend/domains/users"
 
 	"gorm.io/gorm"
@@ -65,6 +66,18 @@ func (r *postgreUserRepository) Update(ctx context.Context, domain *users.Domain
 	return
 }
 
+func (r *postgreUserRepository) Patch(ctx context.Context, domain *users.Domain, fields []string) error {
+	user := FromDomain(domain)
+
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, patchColumns[field])
+	}
+
+	// selecting the columns makes gorm write them even when they hold a zero value
+	return r.conn.Model(&user).Select(columns).Updates(&user).Error
+}
+
 func (r *postgreUserRepository) UpdateEmail(ctx context.Context, domain *users.Domain) (err error) {
 	user := FromDomain(domain)
 	err = r.conn.Model(&User{}).Model(&user).Updates(map[string]interface{}{"email": domain.Email, "is_activated": false}).Error
@@ -84,3 +97,16 @@ func (r *postgreUserRepository) GetByEmail(ctx context.Context, domain *users.Do
 
 	return result.ToDomain(), nil
 }
+
+func (r *postgreUserRepository) GetByUsername(ctx context.Context, username string) (users.Domain, error) {
+	var result User
+	if err := r.conn.Preload("Role").Preload("Gender").First(&result, "username = ?", username).Error; err != nil {
+		return users.Domain{}, err
+	}
+
+	return result.ToDomain(), nil
+}
+
+func (r *postgreUserRepository) CountShelves(ctx context.Context, userId int) (map[string]int, error) {
+	return shelves.CountStatuses(r.conn, userId)
+}
@@ -35,6 +35,13 @@ type User struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
+// patchColumns maps the patchable fields of a user onto their columns
+var patchColumns = map[string]string{
+	"fullname": "full_name",
+	"username": "username",
+	"gender":   "gender_id",
+}
+
 func (u *User) ToDomain() users.Domain {
 	return users.Domain{
 		ID:          u.Id,
@@ -0,0 +1,50 @@
+package authors
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type Domain struct {
+	ID        int
+	Name      string
+	Bio       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Credit is the part an author played in a book
+type Credit struct {
+	BookId   int
+	Book     books.Domain
+	AuthorId int
+	Author   Domain
+	Role     string
+	Position int
+}
+
+type Usecase interface {
+	Store(ctx context.Context, author *Domain) (domain Domain, statusCode int, err error)
+	GetAll(ctx context.Context) (domains []Domain, statusCode int, err error)
+	GetById(ctx context.Context, id int) (domain Domain, statusCode int, err error)
+	Update(ctx context.Context, author *Domain, id int) (domain Domain, bookIds []int, statusCode int, err error)
+	Delete(ctx context.Context, id int) (statusCode int, err error)
+	GetBooks(ctx context.Context, authorId int) (credits []Credit, statusCode int, err error)
+	GetBookAuthors(ctx context.Context, bookId int) (credits []Credit, statusCode int, err error)
+	SetBookAuthors(ctx context.Context, bookId int, credits []Credit) (result []Credit, statusCode int, err error)
+}
+
+type Repository interface {
+	Store(ctx context.Context, author *Domain) (Domain, error)
+	GetAll(ctx context.Context) ([]Domain, error)
+	GetById(ctx context.Context, id int) (Domain, error)
+	GetByName(ctx context.Context, name string) (Domain, error)
+	GetByIds(ctx context.Context, ids []int) ([]Domain, error)
+	Update(ctx context.Context, author *Domain) (bookIds []int, err error)
+	Delete(ctx context.Context, id int) error
+	GetCreditsByAuthorId(ctx context.Context, authorId int) ([]Credit, error)
+	GetCreditsByBookId(ctx context.Context, bookId int) ([]Credit, error)
+	ReplaceBookCredits(ctx context.Context, bookId int, credits []Credit) error
+}
@@ -0,0 +1,180 @@
+package authors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type authorUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+}
+
+func NewAuthorUsecase(repo Repository, bookRepo books.Repository) Usecase {
+	return &authorUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+	}
+}
+
+func (uc *authorUsecase) Store(ctx context.Context, author *Domain) (Domain, int, error) {
+	author.Name = strings.Join(strings.Fields(author.Name), " ")
+	if _, err := uc.repo.GetByName(ctx, author.Name); err == nil {
+		return Domain{}, http.StatusConflict, fmt.Errorf("author %s already exists", author.Name)
+	}
+
+	result, err := uc.repo.Store(ctx, author)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *authorUsecase) GetAll(ctx context.Context) ([]Domain, int, error) {
+	result, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return []Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *authorUsecase) GetById(ctx context.Context, id int) (Domain, int, error) {
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("author not found")
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *authorUsecase) Update(ctx context.Context, author *Domain, id int) (Domain, []int, int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("author not found")
+	}
+
+	author.ID = id
+	author.Name = strings.Join(strings.Fields(author.Name), " ")
+	if existing, err := uc.repo.GetByName(ctx, author.Name); err == nil && existing.ID != id {
+		return Domain{}, nil, http.StatusConflict, fmt.Errorf("author %s already exists", author.Name)
+	}
+
+	bookIds, err := uc.repo.Update(ctx, author)
+	if err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("author not found")
+	}
+
+	return result, bookIds, http.StatusOK, nil
+}
+
+func (uc *authorUsecase) Delete(ctx context.Context, id int) (int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return http.StatusNotFound, errors.New("author not found")
+	}
+
+	credits, err := uc.repo.GetCreditsByAuthorId(ctx, id)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(credits) > 0 {
+		return http.StatusConflict, fmt.Errorf("author is still credited on %d books", len(credits))
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *authorUsecase) GetBooks(ctx context.Context, authorId int) ([]Credit, int, error) {
+	if _, err := uc.repo.GetById(ctx, authorId); err != nil {
+		return []Credit{}, http.StatusNotFound, errors.New("author not found")
+	}
+
+	credits, err := uc.repo.GetCreditsByAuthorId(ctx, authorId)
+	if err != nil {
+		return []Credit{}, http.StatusInternalServerError, err
+	}
+
+	return credits, http.StatusOK, nil
+}
+
+func (uc *authorUsecase) GetBookAuthors(ctx context.Context, bookId int) ([]Credit, int, error) {
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return []Credit{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	credits, err := uc.repo.GetCreditsByBookId(ctx, bookId)
+	if err != nil {
+		return []Credit{}, http.StatusInternalServerError, err
+	}
+
+	return credits, http.StatusOK, nil
+}
+
+func (uc *authorUsecase) SetBookAuthors(ctx context.Context, bookId int, credits []Credit) ([]Credit, int, error) {
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return []Credit{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	// the book keeps a plain author text for listing and search, it is built from the "author" credits
+	hasAuthor := false
+	seen := make(map[string]bool, len(credits))
+	var authorIds []int
+	for i := range credits {
+		key := fmt.Sprintf("%d/%s", credits[i].AuthorId, credits[i].Role)
+		if seen[key] {
+			return []Credit{}, http.StatusBadRequest, fmt.Errorf("author with id %d is credited as %s more than once", credits[i].AuthorId, credits[i].Role)
+		}
+		seen[key] = true
+
+		if credits[i].Role == constants.AuthorRoleAuthor {
+			hasAuthor = true
+		}
+
+		credits[i].BookId = bookId
+		credits[i].Position = i + 1
+		authorIds = append(authorIds, credits[i].AuthorId)
+	}
+	if !hasAuthor {
+		return []Credit{}, http.StatusBadRequest, fmt.Errorf("book needs at least one credit with role %s", constants.AuthorRoleAuthor)
+	}
+
+	found, err := uc.repo.GetByIds(ctx, authorIds)
+	if err != nil {
+		return []Credit{}, http.StatusInternalServerError, err
+	}
+	exists := make(map[int]bool, len(found))
+	for _, author := range found {
+		exists[author.ID] = true
+	}
+	for _, id := range authorIds {
+		if !exists[id] {
+			return []Credit{}, http.StatusNotFound, fmt.Errorf("author with id %d not found", id)
+		}
+	}
+
+	if err := uc.repo.ReplaceBookCredits(ctx, bookId, credits); err != nil {
+		return []Credit{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetCreditsByBookId(ctx, bookId)
+	if err != nil {
+		return []Credit{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
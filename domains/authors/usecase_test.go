@@ -0,0 +1,231 @@
+package authors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	authorMocks "github.com/snykk/golib_backend/datasources/databases/authors/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	"github.com/snykk/golib_backend/domains/authors"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	authorRepository *authorMocks.Repository
+	bookRepository   *bookMocks.Repository
+	authorUsecase    authors.Usecase
+	authorFromDB     authors.Domain
+	bookFromDB       books.Domain
+	creditFromDB     authors.Credit
+)
+
+func setup(t *testing.T) {
+	authorRepository = authorMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	authorUsecase = authors.NewAuthorUsecase(authorRepository, bookRepository)
+
+	authorFromDB = authors.Domain{
+		ID:        1,
+		Name:      "James Clear",
+		Bio:       "writer and speaker",
+		CreatedAt: time.Now(),
+	}
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+	creditFromDB = authors.Credit{
+		BookId:   bookFromDB.ID,
+		Book:     bookFromDB,
+		AuthorId: authorFromDB.ID,
+		Author:   authorFromDB,
+		Role:     constants.AuthorRoleAuthor,
+		Position: 1,
+	}
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	t.Run("When Success Store Author", func(t *testing.T) {
+		req := authors.Domain{Name: "  James   Clear ", Bio: authorFromDB.Bio}
+		authorRepository.Mock.On("GetByName", mock.Anything, "James Clear").Return(authors.Domain{}, errors.New("record not found")).Once()
+		authorRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*authors.Domain")).Return(authorFromDB, nil).Once()
+
+		result, statusCode, err := authorUsecase.Store(context.Background(), &req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, authorFromDB, result)
+		assert.Equal(t, "James Clear", req.Name)
+	})
+	t.Run("When Failure Author Already Exists", func(t *testing.T) {
+		req := authors.Domain{Name: "james clear"}
+		authorRepository.Mock.On("GetByName", mock.Anything, req.Name).Return(authorFromDB, nil).Once()
+
+		_, statusCode, err := authorUsecase.Store(context.Background(), &req)
+
+		assert.Equal(t, errors.New("author james clear already exists"), err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Author By Id", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+
+		result, statusCode, err := authorUsecase.GetById(context.Background(), authorFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, authorFromDB, result)
+	})
+	t.Run("When Failure Author Not Found", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, 2).Return(authors.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := authorUsecase.GetById(context.Background(), 2)
+
+		assert.Equal(t, errors.New("author not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	setup(t)
+	t.Run("When Success Update Author", func(t *testing.T) {
+		req := authors.Domain{Name: "James Clear Jr"}
+		updated := authorFromDB
+		updated.Name = req.Name
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+		authorRepository.Mock.On("GetByName", mock.Anything, req.Name).Return(authors.Domain{}, errors.New("record not found")).Once()
+		authorRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*authors.Domain")).Return([]int{1, 2}, nil).Once()
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(updated, nil).Once()
+
+		result, bookIds, statusCode, err := authorUsecase.Update(context.Background(), &req, authorFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, updated, result)
+		assert.Equal(t, []int{1, 2}, bookIds)
+	})
+	t.Run("When Failure Name Taken By Another Author", func(t *testing.T) {
+		req := authors.Domain{Name: "Carol Dweck"}
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+		authorRepository.Mock.On("GetByName", mock.Anything, req.Name).Return(authors.Domain{ID: 2, Name: req.Name}, nil).Once()
+
+		_, _, statusCode, err := authorUsecase.Update(context.Background(), &req, authorFromDB.ID)
+
+		assert.Equal(t, errors.New("author Carol Dweck already exists"), err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	setup(t)
+	t.Run("When Success Delete Author", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+		authorRepository.Mock.On("GetCreditsByAuthorId", mock.Anything, authorFromDB.ID).Return([]authors.Credit{}, nil).Once()
+		authorRepository.Mock.On("Delete", mock.Anything, authorFromDB.ID).Return(nil).Once()
+
+		statusCode, err := authorUsecase.Delete(context.Background(), authorFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure Author Still Credited", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+		authorRepository.Mock.On("GetCreditsByAuthorId", mock.Anything, authorFromDB.ID).Return([]authors.Credit{creditFromDB}, nil).Once()
+
+		statusCode, err := authorUsecase.Delete(context.Background(), authorFromDB.ID)
+
+		assert.Equal(t, errors.New("author is still credited on 1 books"), err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Author Books", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+		authorRepository.Mock.On("GetCreditsByAuthorId", mock.Anything, authorFromDB.ID).Return([]authors.Credit{creditFromDB}, nil).Once()
+
+		result, statusCode, err := authorUsecase.GetBooks(context.Background(), authorFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []authors.Credit{creditFromDB}, result)
+	})
+}
+
+func TestSetBookAuthors(t *testing.T) {
+	setup(t)
+	t.Run("When Success Set Book Authors", func(t *testing.T) {
+		credits := []authors.Credit{
+			{AuthorId: 1, Role: constants.AuthorRoleAuthor},
+			{AuthorId: 2, Role: constants.AuthorRoleTranslator},
+		}
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		authorRepository.Mock.On("GetByIds", mock.Anything, []int{1, 2}).Return([]authors.Domain{authorFromDB, {ID: 2, Name: "Someone"}}, nil).Once()
+		authorRepository.Mock.On("ReplaceBookCredits", mock.Anything, bookFromDB.ID, mock.AnythingOfType("[]authors.Credit")).Return(nil).Once()
+		authorRepository.Mock.On("GetCreditsByBookId", mock.Anything, bookFromDB.ID).Return([]authors.Credit{creditFromDB}, nil).Once()
+
+		result, statusCode, err := authorUsecase.SetBookAuthors(context.Background(), bookFromDB.ID, credits)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []authors.Credit{creditFromDB}, result)
+		assert.Equal(t, 2, credits[1].Position)
+		assert.Equal(t, bookFromDB.ID, credits[1].BookId)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Book doesn't exist", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, 2).Return(books.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := authorUsecase.SetBookAuthors(context.Background(), 2, []authors.Credit{{AuthorId: 1, Role: constants.AuthorRoleAuthor}})
+
+			assert.Equal(t, errors.New("book not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Duplicate credit", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+
+			_, statusCode, err := authorUsecase.SetBookAuthors(context.Background(), bookFromDB.ID, []authors.Credit{
+				{AuthorId: 1, Role: constants.AuthorRoleAuthor},
+				{AuthorId: 1, Role: constants.AuthorRoleAuthor},
+			})
+
+			assert.Equal(t, errors.New("author with id 1 is credited as author more than once"), err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("No author role", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+
+			_, statusCode, err := authorUsecase.SetBookAuthors(context.Background(), bookFromDB.ID, []authors.Credit{{AuthorId: 1, Role: constants.AuthorRoleEditor}})
+
+			assert.Equal(t, fmt.Errorf("book needs at least one credit with role %s", constants.AuthorRoleAuthor), err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Author doesn't exist", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			authorRepository.Mock.On("GetByIds", mock.Anything, []int{3}).Return([]authors.Domain{}, nil).Once()
+
+			_, statusCode, err := authorUsecase.SetBookAuthors(context.Background(), bookFromDB.ID, []authors.Credit{{AuthorId: 3, Role: constants.AuthorRoleAuthor}})
+
+			assert.Equal(t, errors.New("author with id 3 not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+	})
+}
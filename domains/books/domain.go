@@ -2,33 +2,314 @@ package books
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
 	"time"
+
+	"github.com/snykk/golib_backend/constants"
 )
 
+// ErrBlobNotFound is returned when nothing is stored under the requested key
+var ErrBlobNotFound = errors.New("blob not found")
+
 type Domain struct {
-	ID          int
-	Title       string
-	Description string
-	Author      string
-	Publisher   string
-	ISBN        string
-	Rating      *float64
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              int
+	Title           string
+	Subtitle        string
+	Description     string
+	Author          string
+	Publisher       string
+	PublisherId     *int
+	ISBN            string
+	Edition         string
+	Language        string
+	Format          string
+	Series          string
+	SeriesPosition  int
+	PageCount       int
+	PublicationDate *time.Time
+	Rating          *float64
+	Cover           string
+	Categories      []Category
+	Tags            []string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Category is the part of a category a book carries around, the tree itself is managed by the categories domain
+type Category struct {
+	ID       int
+	Name     string
+	ParentId *int
+}
+
+// Patch carries the members of a merge-patch document, nil leaves a field unchanged and null arrives as an empty string
+type Patch struct {
+	Title           *string
+	Subtitle        *string
+	Description     *string
+	Author          *string
+	Publisher       *string
+	ISBN            *string
+	Edition         *string
+	Language        *string
+	Format          *string
+	Series          *string
+	SeriesPosition  *int
+	PageCount       *int
+	PublicationDate *string
+}
+
+// Version is one entry in the history of a book, Snapshot holds the book as it was right after the change
+type Version struct {
+	ID         int
+	BookId     int
+	Version    int
+	Action     string
+	UserId     *int
+	Changes    []FieldChange
+	Snapshot   Domain
+	RevertedTo *int
+	CreatedAt  time.Time
+}
+
+// FieldChange is a single field changed by a version, From is nil for the fields a new book filled in
+type FieldChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+type Tag struct {
+	Name  string
+	Books int
+}
+
+type Query struct {
+	Page      int
+	Limit     int
+	Sort      string
+	Order     string
+	Author    string
+	Publisher string
+	ISBN      string
+	MinRating *float64
+	MaxRating *float64
+	Category  int
+	Tags      []string
+
+	Language      string
+	Format        string
+	Series        string
+	MinPages      *int
+	MaxPages      *int
+	PublishedFrom *time.Time
+	PublishedTo   *time.Time
+}
+
+// ApplyDefaults fills in the paging and ordering the client left out and caps the page size
+func (q *Query) ApplyDefaults() {
+	if q.Page < 1 {
+		q.Page = constants.DefaultBookPage
+	}
+	if q.Limit < 1 {
+		q.Limit = constants.DefaultBookLimit
+	}
+	if q.Limit > constants.MaxBookLimit {
+		q.Limit = constants.MaxBookLimit
+	}
+	if q.Sort == "" {
+		q.Sort = constants.DefaultBookSort
+	}
+	if q.Order == "" {
+		q.Order = constants.DefaultBookOrder
+	}
+}
+
+// CacheKey identifies a listing so that every distinct query gets its own cache entry, it is built after
+// ApplyDefaults so a left out page and page 1 share one
+func (q *Query) CacheKey() string {
+	key := fmt.Sprintf("page=%d&limit=%d&sort=%s&order=%s&author=%s&publisher=%s&isbn=%s", q.Page, q.Limit, q.Sort, q.Order, q.Author, q.Publisher, q.ISBN)
+	if q.MinRating != nil {
+		key += fmt.Sprintf("&min_rating=%g", *q.MinRating)
+	}
+	if q.MaxRating != nil {
+		key += fmt.Sprintf("&max_rating=%g", *q.MaxRating)
+	}
+
+	if q.Category != 0 {
+		key += fmt.Sprintf("&category=%d", q.Category)
+	}
+	for _, tag := range q.Tags {
+		key += "&tag=" + strings.ToLower(tag)
+	}
+
+	key += fmt.Sprintf("&language=%s&format=%s&series=%s", strings.ToLower(q.Language), q.Format, strings.ToLower(q.Series))
+	if q.MinPages != nil {
+		key += fmt.Sprintf("&min_pages=%d", *q.MinPages)
+	}
+	if q.MaxPages != nil {
+		key += fmt.Sprintf("&max_pages=%d", *q.MaxPages)
+	}
+	if q.PublishedFrom != nil {
+		key += "&published_from=" + q.PublishedFrom.Format(constants.PublicationDateLayout)
+	}
+	if q.PublishedTo != nil {
+		key += "&published_to=" + q.PublishedTo.Format(constants.PublicationDateLayout)
+	}
+
+	return key
+}
+
+type SearchQuery struct {
+	Keyword string
+	Page    int
+	Limit   int
+}
+
+type SearchResult struct {
+	Book           Domain
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+}
+
+type Suggestion struct {
+	Value string
+	Field string
+}
+
+type ImportRow struct {
+	Line  int
+	Book  Domain
+	Error string
+}
+
+type ImportResult struct {
+	Line   int
+	Status string
+	BookID int
+	ISBN   string
+	Error  string
+}
+
+// MetadataRecord is a book as an external catalog describes it, whatever the catalog left out stays empty
+type MetadataRecord struct {
+	Line  int
+	Book  Domain
+	Error string
+}
+
+// MetadataResult tells what an import did, or would do on a dry run, with one record, Fields lists what an enrichment filled in
+type MetadataResult struct {
+	Line   int
+	Status string
+	BookID int
+	ISBN   string
+	Fields []string
+	Error  string
+}
+
+// Similar is a book related to another one by the words they share, Score is their cosine similarity
+type Similar struct {
+	Book  Domain
+	Score float64
+}
+
+// RatingCount is how many reviews rated a book with Rating
+type RatingCount struct {
+	Rating  int
+	Reviews int
+}
+
+// RatingMonth sums up the reviews a book got within the month starting at Month
+type RatingMonth struct {
+	Month     time.Time
+	Reviews   int
+	RatingSum int
+}
+
+// Stats describes how a book was rated, Histogram holds every possible rating even the ones nobody gave
+type Stats struct {
+	BookId    int
+	Reviews   int
+	Average   float64
+	Median    float64
+	StdDev    float64
+	Histogram []RatingCount
+	Months    []RatingMonth
 }
 
 type Usecase interface {
-	GetAll(ctx context.Context) (domains []Domain, statusCode int, err error)
+	GetAll(ctx context.Context, query *Query) (domains []Domain, total int, statusCode int, err error)
+	Search(ctx context.Context, query *SearchQuery) (results []SearchResult, total int, statusCode int, err error)
+	Suggest(ctx context.Context, prefix string, limit int) (suggestions []Suggestion, statusCode int, err error)
 	Store(ctx context.Context, book *Domain) (domain Domain, statusCode int, err error)
+	Import(ctx context.Context, rows []ImportRow) (results []ImportResult, created int, statusCode int, err error)
+	ImportMetadata(ctx context.Context, records []MetadataRecord, dryRun bool) (results []MetadataResult, statusCode int, err error)
+	Export(ctx context.Context, fn func(book Domain) error) (statusCode int, err error)
+	UploadCover(ctx context.Context, id int, data []byte) (domain Domain, statusCode int, err error)
+	OpenCover(ctx context.Context, key string) (cover io.ReadCloser, statusCode int, err error)
 	GetById(ctx context.Context, id int) (domain Domain, statusCode int, err error)
+	GetByISBN(ctx context.Context, isbn string) (domain Domain, statusCode int, err error)
 	Update(ctx context.Context, book *Domain, id int) (domain Domain, statusCode int, err error)
+	Patch(ctx context.Context, id int, patch *Patch) (domain Domain, changed []string, statusCode int, err error)
 	Delete(ctx context.Context, id int) (statusCode int, err error)
+	GetHistory(ctx context.Context, id int) (versions []Version, statusCode int, err error)
+	Revert(ctx context.Context, id int, version int) (domain Domain, statusCode int, err error)
+	GetTags(ctx context.Context) (tags []Tag, statusCode int, err error)
+	AddTags(ctx context.Context, id int, tags []string) (domain Domain, statusCode int, err error)
+	RemoveTag(ctx context.Context, id int, tag string) (domain Domain, statusCode int, err error)
+	GetSimilar(ctx context.Context, id int, limit int) (similar []Similar, statusCode int, err error)
+	GetStats(ctx context.Context, id int) (stats Stats, statusCode int, err error)
+	RebuildSimilarityIndex(ctx context.Context) error
 }
 
 type Repository interface {
-	GetAll(ctx context.Context) ([]Domain, error)
+	GetAll(ctx context.Context, query *Query) ([]Domain, int, error)
+	Search(ctx context.Context, query *SearchQuery) ([]SearchResult, int, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
 	Store(ctx context.Context, book *Domain) (Domain, error)
+	StoreBatch(ctx context.Context, books []Domain) (stored []Domain, failures []error, err error)
 	GetById(ctx context.Context, id int) (Domain, error)
+	GetByISBN(ctx context.Context, isbn string) (Domain, error)
+	GetByISBNs(ctx context.Context, isbns []string) ([]Domain, error)
+	GetByIds(ctx context.Context, ids []int) ([]Domain, error)
+	Stream(ctx context.Context, fn func(book Domain) error) error
 	Update(ctx context.Context, book *Domain) (err error)
+	Patch(ctx context.Context, book *Domain, fields []string) error
+	UpdateCover(ctx context.Context, id int, cover string) error
 	Delete(ctx context.Context, id int) error
+	GetVersions(ctx context.Context, id int) ([]Version, error)
+	GetVersion(ctx context.Context, id int, version int) (Version, error)
+	Revert(ctx context.Context, book *Domain, version int) error
+	GetTags(ctx context.Context) ([]Tag, error)
+	AddTags(ctx context.Context, id int, tags []string) error
+	RemoveTag(ctx context.Context, id int, tag string) error
+	GetRatingStats(ctx context.Context, id int) ([]RatingCount, []RatingMonth, error)
+}
+
+// BlobStorage keeps binary objects such as book covers under slash separated keys
+type BlobStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// ThumbnailKey derives where a thumbnail of the given size is stored next to the original cover
+func ThumbnailKey(coverKey string, size string) string {
+	return strings.TrimSuffix(coverKey, path.Ext(coverKey)) + "_" + size + ".jpg"
+}
+
+// CoverKeys lists the original cover together with all of its thumbnails
+func CoverKeys(coverKey string) []string {
+	keys := []string{coverKey}
+	for _, size := range constants.ListCoverThumbnailSize {
+		keys = append(keys, ThumbnailKey(coverKey, size))
+	}
+
+	return keys
 }
@@ -0,0 +1,206 @@
+package books
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true, "for": true,
+	"from": true, "has": true, "he": true, "her": true, "his": true, "in": true, "is": true, "it": true, "its": true,
+	"of": true, "on": true, "or": true, "she": true, "that": true, "the": true, "their": true, "they": true,
+	"this": true, "to": true, "was": true, "were": true, "will": true, "with": true, "you": true, "your": true,
+}
+
+type similarBook struct {
+	id    int
+	score float64
+}
+
+// similarityIndex keeps the term frequencies of every book's title, description and author in memory, so related
+// books are ranked by TF-IDF cosine similarity without a query per book. It's built in the background when the server
+// starts and rebuilt periodically, in between every book written through the usecase is indexed right away. Changes
+// made elsewhere (author renames, restores from the trash) are picked up by the next rebuild.
+type similarityIndex struct {
+	mu    sync.RWMutex
+	terms map[int]map[string]float64
+	df    map[string]int
+	// pending holds the writes made while a rebuild reads the catalogue, a nil book stands for a removed one
+	pending map[int]*Domain
+}
+
+func newSimilarityIndex() *similarityIndex {
+	return &similarityIndex{
+		terms: make(map[int]map[string]float64),
+		df:    make(map[string]int),
+	}
+}
+
+// beginRebuild starts recording the writes a rebuild reading the catalogue from now on might miss
+func (idx *similarityIndex) beginRebuild() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.pending = make(map[int]*Domain)
+}
+
+// cancelRebuild stops recording the writes when reading the catalogue failed
+func (idx *similarityIndex) cancelRebuild() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.pending = nil
+}
+
+// rebuild replaces the index with the given books, the writes recorded since beginRebuild are applied on top
+func (idx *similarityIndex) rebuild(books []Domain) {
+	rebuilt := &similarityIndex{
+		terms: make(map[int]map[string]float64, len(books)),
+		df:    make(map[string]int),
+	}
+	for _, book := range books {
+		rebuilt.index(book)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for id, book := range idx.pending {
+		rebuilt.forget(id)
+		if book != nil {
+			rebuilt.index(*book)
+		}
+	}
+	idx.terms = rebuilt.terms
+	idx.df = rebuilt.df
+	idx.pending = nil
+}
+
+// upsert indexes a stored or edited book
+func (idx *similarityIndex) upsert(book Domain) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.pending != nil {
+		idx.pending[book.ID] = &book
+	}
+	idx.forget(book.ID)
+	idx.index(book)
+}
+
+func (idx *similarityIndex) remove(id int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.pending != nil {
+		idx.pending[id] = nil
+	}
+	idx.forget(id)
+}
+
+func (idx *similarityIndex) index(book Domain) {
+	counts := termCounts(book)
+	idx.terms[book.ID] = counts
+	for term := range counts {
+		idx.df[term]++
+	}
+}
+
+func (idx *similarityIndex) forget(id int) {
+	for term := range idx.terms[id] {
+		idx.df[term]--
+		if idx.df[term] <= 0 {
+			delete(idx.df, term)
+		}
+	}
+	delete(idx.terms, id)
+}
+
+// similar ranks the other indexed books by their cosine similarity to the given one, books sharing no words are left out
+func (idx *similarityIndex) similar(id int, limit int) []similarBook {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	target, ok := idx.terms[id]
+	if !ok {
+		return nil
+	}
+
+	total := float64(len(idx.terms))
+	weigh := func(term string, count float64) float64 {
+		return (1 + math.Log(count)) * (math.Log((1+total)/(1+float64(idx.df[term]))) + 1)
+	}
+
+	targetWeights := make(map[string]float64, len(target))
+	var targetNorm float64
+	for term, count := range target {
+		weight := weigh(term, count)
+		targetWeights[term] = weight
+		targetNorm += weight * weight
+	}
+	if targetNorm == 0 {
+		return nil
+	}
+
+	var ranked []similarBook
+	for otherId, other := range idx.terms {
+		if otherId == id {
+			continue
+		}
+
+		var dot, norm float64
+		for term, count := range other {
+			weight := weigh(term, count)
+			norm += weight * weight
+			dot += weight * targetWeights[term]
+		}
+		if dot == 0 {
+			continue
+		}
+
+		ranked = append(ranked, similarBook{id: otherId, score: dot / math.Sqrt(targetNorm*norm)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].id < ranked[j].id
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked
+}
+
+func termCounts(book Domain) map[string]float64 {
+	counts := make(map[string]float64)
+	for _, text := range []string{book.Title, book.Description, book.Author} {
+		for _, term := range tokenize(text) {
+			counts[term]++
+		}
+	}
+
+	return counts
+}
+
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < 2 || stopWords[word] {
+			continue
+		}
+		terms = append(terms, word)
+	}
+
+	return terms
+}
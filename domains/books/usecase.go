@@ -1,39 +1,467 @@
 package books
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
 	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/helpers"
 )
 
+// languagePattern matches the two and three letter ISO 639 language codes
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
 type bookUsecase struct {
-	repo Repository
+	repo       Repository
+	storage    BlobStorage
+	similarity *similarityIndex
 }
 
-func NewBookUsecase(repo Repository) Usecase {
+func NewBookUsecase(repo Repository, storage BlobStorage) Usecase {
 	return &bookUsecase{
 		repo,
+		storage,
+		newSimilarityIndex(),
+	}
+}
+
+func (uc *bookUsecase) GetAll(ctx context.Context, query *Query) ([]Domain, int, int, error) {
+	query.ApplyDefaults()
+
+	if query.ISBN != "" {
+		isbn, err := helpers.NormalizeISBN(query.ISBN)
+		if err != nil {
+			return []Domain{}, 0, http.StatusBadRequest, err
+		}
+		query.ISBN = isbn
+	}
+
+	if query.MinRating != nil && query.MaxRating != nil && *query.MinRating > *query.MaxRating {
+		return []Domain{}, 0, http.StatusBadRequest, errors.New("min_rating can't be greater than max_rating")
+	}
+	if query.MinPages != nil && query.MaxPages != nil && *query.MinPages > *query.MaxPages {
+		return []Domain{}, 0, http.StatusBadRequest, errors.New("min_pages can't be greater than max_pages")
+	}
+	if query.PublishedFrom != nil && query.PublishedTo != nil && query.PublishedFrom.After(*query.PublishedTo) {
+		return []Domain{}, 0, http.StatusBadRequest, errors.New("published_from can't be after published_to")
+	}
+
+	query.Language = strings.ToLower(strings.TrimSpace(query.Language))
+	query.Format = strings.ToLower(strings.TrimSpace(query.Format))
+	query.Series = strings.TrimSpace(query.Series)
+
+	for i, tag := range query.Tags {
+		query.Tags[i] = normalizeTag(tag)
+	}
+
+	books, total, err := uc.repo.GetAll(ctx, query)
+
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, err
+	}
+
+	return books, total, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) Search(ctx context.Context, query *SearchQuery) ([]SearchResult, int, int, error) {
+	query.Keyword = strings.TrimSpace(query.Keyword)
+	if query.Keyword == "" {
+		return []SearchResult{}, 0, http.StatusBadRequest, errors.New("search keyword is required")
 	}
+
+	if query.Page < 1 {
+		query.Page = constants.DefaultBookPage
+	}
+	if query.Limit < 1 {
+		query.Limit = constants.DefaultBookLimit
+	}
+	if query.Limit > constants.MaxBookLimit {
+		query.Limit = constants.MaxBookLimit
+	}
+
+	results, total, err := uc.repo.Search(ctx, query)
+	if err != nil {
+		return []SearchResult{}, 0, http.StatusInternalServerError, err
+	}
+
+	return results, total, http.StatusOK, nil
 }
 
-func (uc *bookUsecase) GetAll(ctx context.Context) ([]Domain, int, error) {
-	books, err := uc.repo.GetAll(ctx)
+func (uc *bookUsecase) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, int, error) {
+	prefix = strings.TrimSpace(prefix)
+	if len([]rune(prefix)) < constants.MinSuggestPrefixLength {
+		return []Suggestion{}, http.StatusBadRequest, fmt.Errorf("prefix must be at least %d characters", constants.MinSuggestPrefixLength)
+	}
 
+	if limit < 1 {
+		limit = constants.DefaultSuggestLimit
+	}
+	if limit > constants.MaxSuggestLimit {
+		limit = constants.MaxSuggestLimit
+	}
+
+	// typeahead is only useful while the user is still typing, so give up instead of queueing behind slow queries
+	ctx, cancel := context.WithTimeout(ctx, constants.SuggestTimeout)
+	defer cancel()
+
+	suggestions, err := uc.repo.Suggest(ctx, prefix, limit)
 	if err != nil {
-		return []Domain{}, http.StatusInternalServerError, err
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return []Suggestion{}, http.StatusGatewayTimeout, errors.New("suggestion took too long, please try again")
+		}
+		return []Suggestion{}, http.StatusInternalServerError, err
 	}
 
-	return books, http.StatusOK, nil
+	return suggestions, http.StatusOK, nil
 }
 
 func (uc *bookUsecase) Store(ctx context.Context, book *Domain) (Domain, int, error) {
+	isbn, err := helpers.NormalizeISBN(book.ISBN)
+	if err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+	book.ISBN = isbn
+
+	if err := validateBibliographic(book); err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+
+	if _, err := uc.repo.GetByISBN(ctx, book.ISBN); err == nil {
+		return Domain{}, http.StatusConflict, fmt.Errorf("book with isbn %s already exists", book.ISBN)
+	}
+
 	result, err := uc.repo.Store(ctx, book)
 	if err != nil {
 		return result, http.StatusInternalServerError, err
 	}
+	uc.similarity.upsert(result)
+
 	return result, http.StatusCreated, nil
 }
 
+func (uc *bookUsecase) Import(ctx context.Context, rows []ImportRow) ([]ImportResult, int, int, error) {
+	results := make([]ImportResult, len(rows))
+	firstLineOfISBN := make(map[string]int)
+	var isbns []string
+
+	for i, row := range rows {
+		results[i] = ImportResult{Line: row.Line, Status: constants.BookImportFailed, ISBN: row.Book.ISBN, Error: row.Error}
+		if row.Error != "" {
+			continue
+		}
+
+		isbn, err := helpers.NormalizeISBN(row.Book.ISBN)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].ISBN = isbn
+
+		if err := validateBibliographic(&rows[i].Book); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		if line, ok := firstLineOfISBN[isbn]; ok {
+			results[i].Error = fmt.Sprintf("isbn %s is duplicated on line %d", isbn, line)
+			continue
+		}
+		firstLineOfISBN[isbn] = row.Line
+		isbns = append(isbns, isbn)
+	}
+
+	if len(isbns) == 0 {
+		return results, 0, http.StatusOK, nil
+	}
+
+	existingBooks, err := uc.repo.GetByISBNs(ctx, isbns)
+	if err != nil {
+		return []ImportResult{}, 0, http.StatusInternalServerError, err
+	}
+	existing := make(map[string]bool, len(existingBooks))
+	for _, book := range existingBooks {
+		existing[book.ISBN] = true
+	}
+
+	var pending []Domain
+	var pendingIndexes []int
+	for i, row := range rows {
+		if results[i].Error != "" {
+			continue
+		}
+		if existing[results[i].ISBN] {
+			results[i].Error = fmt.Sprintf("book with isbn %s already exists", results[i].ISBN)
+			continue
+		}
+
+		book := row.Book
+		book.ISBN = results[i].ISBN
+		pending = append(pending, book)
+		pendingIndexes = append(pendingIndexes, i)
+	}
+
+	if len(pending) == 0 {
+		return results, 0, http.StatusOK, nil
+	}
+
+	stored, failures, err := uc.repo.StoreBatch(ctx, pending)
+	if err != nil {
+		return []ImportResult{}, 0, http.StatusInternalServerError, err
+	}
+
+	created := 0
+	for i, book := range stored {
+		result := &results[pendingIndexes[i]]
+		if failures[i] != nil {
+			result.Error = failures[i].Error()
+			continue
+		}
+		uc.similarity.upsert(book)
+		result.Status = constants.BookImportCreated
+		result.BookID = book.ID
+		created++
+	}
+
+	return results, created, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) ImportMetadata(ctx context.Context, records []MetadataRecord, dryRun bool) ([]MetadataResult, int, error) {
+	results := make([]MetadataResult, len(records))
+	firstLineOfISBN := make(map[string]int)
+	var isbns []string
+
+	for i, record := range records {
+		results[i] = MetadataResult{Line: record.Line, Status: constants.BookImportFailed, ISBN: record.Book.ISBN, Error: record.Error}
+		if record.Error != "" {
+			continue
+		}
+
+		isbn, err := helpers.NormalizeISBN(record.Book.ISBN)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].ISBN = isbn
+
+		if line, ok := firstLineOfISBN[isbn]; ok {
+			results[i].Error = fmt.Sprintf("isbn %s is duplicated on line %d", isbn, line)
+			continue
+		}
+		firstLineOfISBN[isbn] = record.Line
+		isbns = append(isbns, isbn)
+	}
+
+	if len(isbns) == 0 {
+		return results, http.StatusOK, nil
+	}
+
+	existingBooks, err := uc.repo.GetByISBNs(ctx, isbns)
+	if err != nil {
+		return []MetadataResult{}, http.StatusInternalServerError, err
+	}
+	existing := make(map[string]Domain, len(existingBooks))
+	for _, book := range existingBooks {
+		existing[book.ISBN] = book
+	}
+
+	var pending []Domain
+	var pendingIndexes []int
+	for i, record := range records {
+		if results[i].Error != "" {
+			continue
+		}
+
+		metadata := record.Book
+		metadata.ISBN = results[i].ISBN
+
+		if book, ok := existing[metadata.ISBN]; ok {
+			results[i].BookID = book.ID
+
+			fields := enrich(&book, metadata)
+			if len(fields) == 0 {
+				results[i].Status = constants.BookImportUnchanged
+				continue
+			}
+			if err := validateMergedBook(&book); err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+
+			if !dryRun {
+				// each book is patched on its own, a failure is reported on its line without undoing the others
+				if err := uc.repo.Patch(ctx, &book, fields); err != nil {
+					results[i].Error = err.Error()
+					continue
+				}
+				uc.similarity.upsert(book)
+			}
+			results[i].Status = constants.BookImportEnriched
+			results[i].Fields = fields
+			continue
+		}
+
+		if err := validateMergedBook(&metadata); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		pending = append(pending, metadata)
+		pendingIndexes = append(pendingIndexes, i)
+	}
+
+	if len(pending) == 0 {
+		return results, http.StatusOK, nil
+	}
+
+	if dryRun {
+		for _, i := range pendingIndexes {
+			results[i].Status = constants.BookImportCreated
+		}
+		return results, http.StatusOK, nil
+	}
+
+	// the books enriched above are already saved, so a failed batch is reported on its lines rather than as a
+	// bare error that would hide what did go through
+	stored, failures, err := uc.repo.StoreBatch(ctx, pending)
+	if err != nil {
+		for _, i := range pendingIndexes {
+			results[i].Error = err.Error()
+		}
+		return results, http.StatusOK, nil
+	}
+
+	for i, book := range stored {
+		result := &results[pendingIndexes[i]]
+		if failures[i] != nil {
+			result.Error = failures[i].Error()
+			continue
+		}
+		uc.similarity.upsert(book)
+		result.Status = constants.BookImportCreated
+		result.BookID = book.ID
+	}
+
+	return results, http.StatusOK, nil
+}
+
+// enrich fills in what a book is missing from the metadata, whatever the library already has is never overwritten
+func enrich(book *Domain, metadata Domain) []string {
+	fields := []string{}
+	if book.Description == "" && metadata.Description != "" {
+		book.Description = metadata.Description
+		fields = append(fields, "description")
+	}
+	if book.Publisher == "" && metadata.Publisher != "" {
+		book.Publisher = metadata.Publisher
+		fields = append(fields, "publisher")
+	}
+	if book.PageCount == 0 && metadata.PageCount > 0 {
+		book.PageCount = metadata.PageCount
+		fields = append(fields, "page_count")
+	}
+	if book.Subtitle == "" && metadata.Subtitle != "" {
+		book.Subtitle = metadata.Subtitle
+		fields = append(fields, "subtitle")
+	}
+	if book.Edition == "" && metadata.Edition != "" {
+		book.Edition = metadata.Edition
+		fields = append(fields, "edition")
+	}
+	if book.Language == "" && metadata.Language != "" {
+		book.Language = metadata.Language
+		fields = append(fields, "language")
+	}
+	if book.PublicationDate == nil && metadata.PublicationDate != nil {
+		book.PublicationDate = metadata.PublicationDate
+		fields = append(fields, "publication_date")
+	}
+
+	return fields
+}
+
+func (uc *bookUsecase) Export(ctx context.Context, fn func(book Domain) error) (int, error) {
+	if err := uc.repo.Stream(ctx, fn); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *bookUsecase) UploadCover(ctx context.Context, id int, data []byte) (Domain, int, error) {
+	book, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	contentType, err := helpers.SniffImage(data)
+	if err != nil {
+		return Domain{}, http.StatusUnsupportedMediaType, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Domain{}, http.StatusUnsupportedMediaType, fmt.Errorf("cover image is corrupted: %w", err)
+	}
+
+	// the key changes with the content, so served covers can be cached forever
+	sum := sha256.Sum256(data)
+	coverKey := fmt.Sprintf("books/%d/%x.%s", id, sum[:8], constants.MapperCoverContentTypeToExtension[contentType])
+
+	blobs := map[string][]byte{coverKey: data}
+	for _, size := range constants.ListCoverThumbnailSize {
+		thumbnail, err := helpers.GenerateThumbnail(img, constants.MapperCoverThumbnailSizeToWidth[size])
+		if err != nil {
+			return Domain{}, http.StatusInternalServerError, err
+		}
+		blobs[ThumbnailKey(coverKey, size)] = thumbnail
+	}
+
+	var stored []string
+	for key, blob := range blobs {
+		if err := uc.storage.Put(ctx, key, bytes.NewReader(blob)); err != nil {
+			_ = uc.storage.Delete(ctx, stored...)
+			return Domain{}, http.StatusInternalServerError, err
+		}
+		stored = append(stored, key)
+	}
+
+	if err := uc.repo.UpdateCover(ctx, id, coverKey); err != nil {
+		_ = uc.storage.Delete(ctx, stored...)
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	// the previous cover is unreachable now, failing to clean it up only leaves an orphan behind
+	if book.Cover != "" && book.Cover != coverKey {
+		_ = uc.storage.Delete(ctx, CoverKeys(book.Cover)...)
+	}
+
+	book.Cover = coverKey
+	return book, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) OpenCover(ctx context.Context, key string) (io.ReadCloser, int, error) {
+	cover, err := uc.storage.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, http.StatusNotFound, errors.New("cover not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return cover, http.StatusOK, nil
+}
+
 func (uc *bookUsecase) GetById(ctx context.Context, id int) (Domain, int, error) {
 	result, err := uc.repo.GetById(ctx, id)
 
@@ -44,8 +472,37 @@ func (uc *bookUsecase) GetById(ctx context.Context, id int) (Domain, int, error)
 	return result, http.StatusOK, nil
 }
 
+func (uc *bookUsecase) GetByISBN(ctx context.Context, isbn string) (Domain, int, error) {
+	isbn, err := helpers.NormalizeISBN(isbn)
+	if err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+
+	result, err := uc.repo.GetByISBN(ctx, isbn)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	return result, http.StatusOK, nil
+}
+
 func (uc *bookUsecase) Update(ctx context.Context, book *Domain, id int) (Domain, int, error) {
 	book.ID = id
+
+	isbn, err := helpers.NormalizeISBN(book.ISBN)
+	if err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+	book.ISBN = isbn
+
+	if err := validateBibliographic(book); err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+
+	if existing, err := uc.repo.GetByISBN(ctx, book.ISBN); err == nil && existing.ID != id {
+		return Domain{}, http.StatusConflict, fmt.Errorf("book with isbn %s already exists", book.ISBN)
+	}
+
 	if err := uc.repo.Update(ctx, book); err != nil {
 		return Domain{}, http.StatusInternalServerError, err
 	}
@@ -54,10 +511,202 @@ func (uc *bookUsecase) Update(ctx context.Context, book *Domain, id int) (Domain
 	if err != nil {
 		return Domain{}, http.StatusNotFound, err
 	}
+	uc.similarity.upsert(newBook)
 
 	return newBook, http.StatusOK, err
 }
 
+func (uc *bookUsecase) Patch(ctx context.Context, id int, patch *Patch) (Domain, []string, int, error) {
+	book, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("book not found")
+	}
+
+	if patch.ISBN != nil && *patch.ISBN != "" {
+		isbn, err := helpers.NormalizeISBN(*patch.ISBN)
+		if err != nil {
+			return Domain{}, nil, http.StatusBadRequest, err
+		}
+		patch.ISBN = &isbn
+	}
+	if patch.Language != nil {
+		language := strings.ToLower(strings.TrimSpace(*patch.Language))
+		patch.Language = &language
+	}
+	if patch.Format != nil {
+		format := strings.ToLower(strings.TrimSpace(*patch.Format))
+		patch.Format = &format
+	}
+
+	changed := []string{}
+	apply := func(field string, current *string, value *string) {
+		if value != nil && *value != *current {
+			*current = *value
+			changed = append(changed, field)
+		}
+	}
+	applyInt := func(field string, current *int, value *int) {
+		if value != nil && *value != *current {
+			*current = *value
+			changed = append(changed, field)
+		}
+	}
+	apply("title", &book.Title, patch.Title)
+	apply("subtitle", &book.Subtitle, patch.Subtitle)
+	apply("description", &book.Description, patch.Description)
+	apply("author", &book.Author, patch.Author)
+	apply("publisher", &book.Publisher, patch.Publisher)
+	apply("isbn", &book.ISBN, patch.ISBN)
+	apply("edition", &book.Edition, patch.Edition)
+	apply("language", &book.Language, patch.Language)
+	apply("format", &book.Format, patch.Format)
+	apply("series", &book.Series, patch.Series)
+	applyInt("series_position", &book.SeriesPosition, patch.SeriesPosition)
+	applyInt("page_count", &book.PageCount, patch.PageCount)
+
+	if patch.PublicationDate != nil {
+		var publicationDate *time.Time
+		if *patch.PublicationDate != "" {
+			date, err := time.Parse(constants.PublicationDateLayout, *patch.PublicationDate)
+			if err != nil {
+				return Domain{}, nil, http.StatusBadRequest, fmt.Errorf("publication_date must be formatted as %s", constants.PublicationDateLayout)
+			}
+			publicationDate = &date
+		}
+
+		if !sameDate(book.PublicationDate, publicationDate) {
+			book.PublicationDate = publicationDate
+			changed = append(changed, "publication_date")
+		}
+	}
+
+	// the patched book has to be as valid as a freshly stored one
+	if err := validatePatchedBook(&book); err != nil {
+		return Domain{}, nil, http.StatusBadRequest, err
+	}
+
+	if len(changed) == 0 {
+		return book, changed, http.StatusOK, nil
+	}
+
+	for _, field := range changed {
+		switch field {
+		case "isbn":
+			if existing, err := uc.repo.GetByISBN(ctx, book.ISBN); err == nil && existing.ID != id {
+				return Domain{}, nil, http.StatusConflict, fmt.Errorf("book with isbn %s already exists", book.ISBN)
+			}
+		case "publisher":
+			// a new publisher name no longer matches the linked publisher record
+			if book.PublisherId != nil {
+				book.PublisherId = nil
+				changed = append(changed, "publisher_id")
+			}
+		}
+	}
+
+	if err := uc.repo.Patch(ctx, &book, changed); err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	newBook, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("book not found")
+	}
+	uc.similarity.upsert(newBook)
+
+	return newBook, changed, http.StatusOK, nil
+}
+
+// validatePatchedBook holds a patched book to the same required fields as a book that is stored or updated whole
+func validatePatchedBook(book *Domain) error {
+	if strings.TrimSpace(book.Description) == "" {
+		return errors.New("description can't be empty")
+	}
+	if strings.TrimSpace(book.Publisher) == "" {
+		return errors.New("publisher can't be empty")
+	}
+
+	return validateMergedBook(book)
+}
+
+// validateMergedBook checks a book merged with imported metadata, catalogue records often come without a
+// description or publisher so only the fields identifying the book are required
+func validateMergedBook(book *Domain) error {
+	if strings.TrimSpace(book.Title) == "" {
+		return errors.New("title can't be empty")
+	}
+	if strings.TrimSpace(book.Author) == "" {
+		return errors.New("author can't be empty")
+	}
+	if book.ISBN == "" {
+		return errors.New("isbn can't be empty")
+	}
+	if utf8.RuneCountInString(book.Title) > constants.MaxBookTitleLength {
+		return fmt.Errorf("title can't be longer than %d characters", constants.MaxBookTitleLength)
+	}
+	if utf8.RuneCountInString(book.Author) > constants.MaxBookAuthorLength {
+		return fmt.Errorf("author can't be longer than %d characters", constants.MaxBookAuthorLength)
+	}
+	if utf8.RuneCountInString(book.Publisher) > constants.MaxBookPublisherLength {
+		return fmt.Errorf("publisher can't be longer than %d characters", constants.MaxBookPublisherLength)
+	}
+
+	return validateBibliographic(book)
+}
+
+// validateBibliographic trims the bibliographic fields, lowercases the coded ones and checks them against their limits
+func validateBibliographic(book *Domain) error {
+	book.Subtitle = strings.TrimSpace(book.Subtitle)
+	book.Edition = strings.TrimSpace(book.Edition)
+	book.Language = strings.ToLower(strings.TrimSpace(book.Language))
+	book.Format = strings.ToLower(strings.TrimSpace(book.Format))
+	book.Series = strings.TrimSpace(book.Series)
+
+	if utf8.RuneCountInString(book.Subtitle) > constants.MaxBookSubtitleLength {
+		return fmt.Errorf("subtitle can't be longer than %d characters", constants.MaxBookSubtitleLength)
+	}
+	if utf8.RuneCountInString(book.Edition) > constants.MaxBookEditionLength {
+		return fmt.Errorf("edition can't be longer than %d characters", constants.MaxBookEditionLength)
+	}
+	if utf8.RuneCountInString(book.Series) > constants.MaxBookSeriesLength {
+		return fmt.Errorf("series can't be longer than %d characters", constants.MaxBookSeriesLength)
+	}
+	if book.Language != "" && !languagePattern.MatchString(book.Language) {
+		return errors.New("language must be an ISO 639-1 or 639-2 code")
+	}
+	if err := validateFormat(book.Format); err != nil {
+		return err
+	}
+	if book.PageCount < 0 || book.PageCount > constants.MaxBookPageCount {
+		return fmt.Errorf("page_count must be between 0 and %d", constants.MaxBookPageCount)
+	}
+	if book.SeriesPosition < 0 {
+		return errors.New("series_position can't be negative")
+	}
+
+	return nil
+}
+
+func validateFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, val := range constants.ListBookFormat {
+		if format == val {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("format must be one of [%s]", strings.Join(constants.ListBookFormat, ", "))
+}
+
+func sameDate(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 func (uc *bookUsecase) Delete(ctx context.Context, id int) (int, error) {
 	_, err := uc.repo.GetById(ctx, id)
 	if err != nil { // check wheter data is exists or not
@@ -67,6 +716,285 @@ func (uc *bookUsecase) Delete(ctx context.Context, id int) (int, error) {
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
+	uc.similarity.remove(id)
 
 	return http.StatusOK, nil
 }
+
+func (uc *bookUsecase) GetHistory(ctx context.Context, id int) ([]Version, int, error) {
+	versions, err := uc.repo.GetVersions(ctx, id)
+	if err != nil {
+		return []Version{}, http.StatusInternalServerError, err
+	}
+
+	// a deleted book keeps its history, only a book that never existed has none
+	if len(versions) == 0 {
+		return []Version{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	return versions, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) Revert(ctx context.Context, id int, version int) (Domain, int, error) {
+	book, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	target, err := uc.repo.GetVersion(ctx, id, version)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, fmt.Errorf("version %d of book with id %d not found", version, id)
+	}
+
+	// the cover isn't reverted, its files are removed as soon as a new cover replaces them
+	book.Title = target.Snapshot.Title
+	book.Description = target.Snapshot.Description
+	book.Author = target.Snapshot.Author
+	book.Publisher = target.Snapshot.Publisher
+	book.PublisherId = target.Snapshot.PublisherId
+	book.Subtitle = target.Snapshot.Subtitle
+	book.Edition = target.Snapshot.Edition
+	book.Language = target.Snapshot.Language
+	book.Format = target.Snapshot.Format
+	book.Series = target.Snapshot.Series
+	book.SeriesPosition = target.Snapshot.SeriesPosition
+	book.PageCount = target.Snapshot.PageCount
+	book.PublicationDate = target.Snapshot.PublicationDate
+
+	if target.Snapshot.ISBN != book.ISBN {
+		if existing, err := uc.repo.GetByISBN(ctx, target.Snapshot.ISBN); err == nil && existing.ID != id {
+			return Domain{}, http.StatusConflict, fmt.Errorf("book with isbn %s already exists", target.Snapshot.ISBN)
+		}
+		book.ISBN = target.Snapshot.ISBN
+	}
+
+	if err := uc.repo.Revert(ctx, &book, version); err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	newBook, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+	uc.similarity.upsert(newBook)
+
+	return newBook, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) GetTags(ctx context.Context) ([]Tag, int, error) {
+	tags, err := uc.repo.GetTags(ctx)
+	if err != nil {
+		return []Tag{}, http.StatusInternalServerError, err
+	}
+
+	return tags, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) AddTags(ctx context.Context, id int, tags []string) (Domain, int, error) {
+	book, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	current := make(map[string]bool, len(book.Tags))
+	for _, tag := range book.Tags {
+		current[tag] = true
+	}
+
+	var added []string
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" {
+			return Domain{}, http.StatusBadRequest, errors.New("tag can't be empty")
+		}
+		if len([]rune(tag)) > constants.MaxTagLength {
+			return Domain{}, http.StatusBadRequest, fmt.Errorf("tag %q is longer than %d characters", tag, constants.MaxTagLength)
+		}
+		if current[tag] {
+			continue
+		}
+		current[tag] = true
+		added = append(added, tag)
+	}
+	if len(current) > constants.MaxBookTags {
+		return Domain{}, http.StatusBadRequest, fmt.Errorf("book can't have more than %d tags", constants.MaxBookTags)
+	}
+
+	if len(added) > 0 {
+		if err := uc.repo.AddTags(ctx, id, added); err != nil {
+			return Domain{}, http.StatusInternalServerError, err
+		}
+	}
+
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) RemoveTag(ctx context.Context, id int, tag string) (Domain, int, error) {
+	book, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	tag = normalizeTag(tag)
+	tagged := false
+	for _, val := range book.Tags {
+		if val == tag {
+			tagged = true
+			break
+		}
+	}
+	if !tagged {
+		return Domain{}, http.StatusNotFound, fmt.Errorf("book isn't tagged with %q", tag)
+	}
+
+	if err := uc.repo.RemoveTag(ctx, id, tag); err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	return result, http.StatusOK, nil
+}
+
+// GetSimilar ranks the books whose title, description and author read the closest to the given one. The index is
+// built in the background when the server starts, until then only the books written since are ranked.
+func (uc *bookUsecase) GetSimilar(ctx context.Context, id int, limit int) ([]Similar, int, error) {
+	if limit < 1 {
+		limit = constants.DefaultSimilarLimit
+	}
+	if limit > constants.MaxSimilarLimit {
+		limit = constants.MaxSimilarLimit
+	}
+
+	book, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return []Similar{}, http.StatusNotFound, errors.New("book not found")
+	}
+	uc.similarity.upsert(book)
+
+	// books deleted outside this usecase stay indexed until it expires, so a few more are ranked than needed
+	ranked := uc.similarity.similar(id, 2*limit)
+	if len(ranked) == 0 {
+		return []Similar{}, http.StatusOK, nil
+	}
+
+	ids := make([]int, 0, len(ranked))
+	for _, candidate := range ranked {
+		ids = append(ids, candidate.id)
+	}
+	candidates, err := uc.repo.GetByIds(ctx, ids)
+	if err != nil {
+		return []Similar{}, http.StatusInternalServerError, err
+	}
+	byId := make(map[int]Domain, len(candidates))
+	for _, candidate := range candidates {
+		byId[candidate.ID] = candidate
+	}
+
+	var similar []Similar
+	for _, candidate := range ranked {
+		book, ok := byId[candidate.id]
+		if !ok {
+			continue
+		}
+		similar = append(similar, Similar{Book: book, Score: candidate.score})
+		if len(similar) == limit {
+			break
+		}
+	}
+
+	return similar, http.StatusOK, nil
+}
+
+func (uc *bookUsecase) RebuildSimilarityIndex(ctx context.Context) error {
+	uc.similarity.beginRebuild()
+
+	var all []Domain
+	if err := uc.repo.Stream(ctx, func(book Domain) error {
+		all = append(all, book)
+		return nil
+	}); err != nil {
+		// the index is kept as it is, the writes recorded meanwhile are already in it
+		uc.similarity.cancelRebuild()
+		return err
+	}
+	uc.similarity.rebuild(all)
+
+	return nil
+}
+
+func (uc *bookUsecase) GetStats(ctx context.Context, id int) (Stats, int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return Stats{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	counts, months, err := uc.repo.GetRatingStats(ctx, id)
+	if err != nil {
+		return Stats{}, http.StatusInternalServerError, err
+	}
+
+	return summarizeRatings(id, counts, months), http.StatusOK, nil
+}
+
+// summarizeRatings derives the statistics of a book from its histogram, which is all the median needs
+// and as good as every single rating for the average and the standard deviation
+func summarizeRatings(id int, counts []RatingCount, months []RatingMonth) Stats {
+	stats := Stats{BookId: id, Months: months}
+	if stats.Months == nil {
+		stats.Months = []RatingMonth{}
+	}
+
+	reviewsByRating := make(map[int]int, len(counts))
+	var sum float64
+	for _, count := range counts {
+		reviewsByRating[count.Rating] += count.Reviews
+		stats.Reviews += count.Reviews
+		sum += float64(count.Rating * count.Reviews)
+	}
+	for rating := constants.MinRating; rating <= constants.MaxRating; rating++ {
+		stats.Histogram = append(stats.Histogram, RatingCount{Rating: rating, Reviews: reviewsByRating[rating]})
+	}
+
+	if stats.Reviews == 0 {
+		return stats
+	}
+	stats.Average = sum / float64(stats.Reviews)
+
+	var squares float64
+	for _, count := range counts {
+		deviation := float64(count.Rating) - stats.Average
+		squares += deviation * deviation * float64(count.Reviews)
+	}
+	stats.StdDev = math.Sqrt(squares / float64(stats.Reviews))
+
+	// the median sits between the ratings at both middle positions, which are the same one for an odd count
+	sorted := make([]RatingCount, len(counts))
+	copy(sorted, counts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Rating < sorted[j].Rating })
+	ratingAt := func(position int) int {
+		seen := 0
+		for _, count := range sorted {
+			seen += count.Reviews
+			if seen >= position {
+				return count.Rating
+			}
+		}
+		return sorted[len(sorted)-1].Rating
+	}
+	stats.Median = float64(ratingAt((stats.Reviews+1)/2)+ratingAt(stats.Reviews/2+1)) / 2
+
+	return stats
+}
+
+// normalizeTag makes "Science  Fiction" and "science fiction" the same tag
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
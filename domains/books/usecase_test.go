@@ -1,13 +1,20 @@
 package books_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"image"
+	"image/png"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/snykk/golib_backend/constants"
 	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	storageMocks "github.com/snykk/golib_backend/datasources/storage/mocks"
 	"github.com/snykk/golib_backend/domains/books"
 	"github.com/snykk/golib_backend/http/controllers/books/requests"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +23,7 @@ import (
 
 var (
 	bookRepository  *bookMocks.Repository
+	blobStorage     *storageMocks.BlobStorage
 	bookUsecase     books.Usecase
 	booksDataFromDB []books.Domain
 	bookDataFromDB  books.Domain
@@ -23,7 +31,8 @@ var (
 
 func setup(t *testing.T) {
 	bookRepository = bookMocks.NewRepository(t)
-	bookUsecase = books.NewBookUsecase(bookRepository)
+	blobStorage = storageMocks.NewBlobStorage(t)
+	bookUsecase = books.NewBookUsecase(bookRepository, blobStorage)
 	booksDataFromDB = []books.Domain{
 		{
 			ID:          1,
@@ -31,7 +40,7 @@ func setup(t *testing.T) {
 			Description: "lorem ipsum doler sit amet",
 			Author:      "James Clear",
 			Publisher:   "Gramedia",
-			ISBN:        "1111111111111",
+			ISBN:        "9780735211292",
 			Rating:      new(float64),
 			CreatedAt:   time.Now(),
 		},
@@ -41,7 +50,7 @@ func setup(t *testing.T) {
 			Description: "lorem ipsum doler sit amet",
 			Author:      "Tere Liye",
 			Publisher:   "Gramedia",
-			ISBN:        "1111111111111",
+			ISBN:        "9780735211292",
 			Rating:      new(float64),
 			CreatedAt:   time.Now(),
 		},
@@ -57,7 +66,7 @@ func TestStore(t *testing.T) {
 		Description: "lorem ipsum doler sit amet",
 		Author:      "James Clear",
 		Publisher:   "Gramedia",
-		ISBN:        "1111111111111",
+		ISBN:        "9780735211292",
 	}
 	t.Run("When Success Store Book Data", func(t *testing.T) {
 		booksFromDB := books.Domain{
@@ -66,10 +75,11 @@ func TestStore(t *testing.T) {
 			Description: "lorem ipsum doler sit amet",
 			Author:      "James Clear",
 			Publisher:   "Gramedia",
-			ISBN:        "1111111111111",
+			ISBN:        "9780735211292",
 			CreatedAt:   time.Now(),
 		}
 
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(books.Domain{}, errors.New("record not found")).Once()
 		bookRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(booksFromDB, nil).Once()
 		result, statusCode, err := bookUsecase.Store(context.Background(), req.ToDomain())
 
@@ -80,11 +90,12 @@ func TestStore(t *testing.T) {
 		assert.Equal(t, "lorem ipsum doler sit amet", result.Description)
 		assert.Equal(t, "James Clear", result.Author)
 		assert.Equal(t, "Gramedia", result.Publisher)
-		assert.Equal(t, "1111111111111", result.ISBN)
+		assert.Equal(t, "9780735211292", result.ISBN)
 		assert.NotNil(t, result.CreatedAt)
 	})
 
 	t.Run("When Failure", func(t *testing.T) {
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(books.Domain{}, errors.New("record not found")).Once()
 		bookRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(books.Domain{}, errors.New("create book failed")).Once()
 		result, statusCode, err := bookUsecase.Store(context.Background(), req.ToDomain())
 
@@ -92,14 +103,76 @@ func TestStore(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, statusCode)
 		assert.Equal(t, 0, result.ID)
 	})
+	t.Run("When Failure Invalid ISBN", func(t *testing.T) {
+		invalidReq := req
+		invalidReq.ISBN = "9780735211293"
+		_, statusCode, err := bookUsecase.Store(context.Background(), invalidReq.ToDomain())
 
+		assert.Equal(t, errors.New("isbn checksum is invalid"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+	t.Run("When Failure ISBN Already Exists", func(t *testing.T) {
+		isbn10Req := req
+		isbn10Req.ISBN = "0-7352-1129-9"
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(bookDataFromDB, nil).Once()
+		_, statusCode, err := bookUsecase.Store(context.Background(), isbn10Req.ToDomain())
+
+		assert.Equal(t, errors.New("book with isbn 9780735211292 already exists"), err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+	t.Run("When Success Bibliographic Fields Are Normalized", func(t *testing.T) {
+		bibliographicReq := req
+		bibliographicReq.Subtitle = " Tiny Changes, Remarkable Results "
+		bibliographicReq.Language = "EN"
+		bibliographicReq.Format = "Hardcover"
+		bibliographicReq.PageCount = 320
+		bibliographicReq.PublicationDate = "2018-10-16"
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(books.Domain{}, errors.New("record not found")).Once()
+		bookRepository.Mock.On("Store", mock.Anything, mock.MatchedBy(func(book *books.Domain) bool {
+			return book.Subtitle == "Tiny Changes, Remarkable Results" && book.Language == "en" && book.Format == constants.BookFormatHardcover &&
+				book.PageCount == 320 && book.PublicationDate != nil && book.PublicationDate.Year() == 2018
+		})).Return(bookDataFromDB, nil).Once()
+
+		_, statusCode, err := bookUsecase.Store(context.Background(), bibliographicReq.ToDomain())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+	})
+	t.Run("When Failure Invalid Bibliographic Fields", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(book *books.Domain)
+			err    string
+		}{
+			{"Language", func(book *books.Domain) { book.Language = "english" }, "language must be an ISO 639-1 or 639-2 code"},
+			{"Format", func(book *books.Domain) { book.Format = "scroll" }, "format must be one of [hardcover, paperback, ebook, audio]"},
+			{"Page Count", func(book *books.Domain) { book.PageCount = -1 }, fmt.Sprintf("page_count must be between 0 and %d", constants.MaxBookPageCount)},
+			{"Series Position", func(book *books.Domain) { book.SeriesPosition = -2 }, "series_position can't be negative"},
+			{"Edition", func(book *books.Domain) { book.Edition = strings.Repeat("a", constants.MaxBookEditionLength+1) }, fmt.Sprintf("edition can't be longer than %d characters", constants.MaxBookEditionLength)},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				book := req.ToDomain()
+				test.modify(book)
+
+				_, statusCode, err := bookUsecase.Store(context.Background(), book)
+
+				assert.EqualError(t, err, test.err)
+				assert.Equal(t, http.StatusBadRequest, statusCode)
+			})
+		}
+	})
 }
 
 func TestGetAll(t *testing.T) {
 	setup(t)
 	t.Run("When Success Get Books Data", func(t *testing.T) {
-		bookRepository.Mock.On("GetAll", mock.Anything).Return(booksDataFromDB, nil).Once()
-		result, statusCode, err := bookUsecase.GetAll(context.Background())
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return(booksDataFromDB, len(booksDataFromDB), nil).Once()
+		result, total, statusCode, err := bookUsecase.GetAll(context.Background(), &books.Query{})
+
+		t.Run("Check Total", func(t *testing.T) {
+			assert.Equal(t, len(booksDataFromDB), total)
+		})
 
 		t.Run("Check Book 1", func(t *testing.T) {
 			assert.Nil(t, err)
@@ -127,13 +200,73 @@ func TestGetAll(t *testing.T) {
 	})
 
 	t.Run("When Failure Get Books Data", func(t *testing.T) {
-		bookRepository.Mock.On("GetAll", mock.Anything).Return([]books.Domain{}, errors.New("get all books failed")).Once()
-		result, statusCode, err := bookUsecase.GetAll(context.Background())
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return([]books.Domain{}, 0, errors.New("get all books failed")).Once()
+		result, _, statusCode, err := bookUsecase.GetAll(context.Background(), &books.Query{})
 
 		assert.NotNil(t, err)
 		assert.Equal(t, http.StatusInternalServerError, statusCode)
 		assert.Equal(t, []books.Domain{}, result)
 	})
+
+	t.Run("When Query Uses Defaults", func(t *testing.T) {
+		query := books.Query{Limit: 1000}
+		bookRepository.Mock.On("GetAll", mock.Anything, &query).Return(booksDataFromDB, len(booksDataFromDB), nil).Once()
+		_, _, statusCode, err := bookUsecase.GetAll(context.Background(), &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, query.Page)
+		assert.Equal(t, 100, query.Limit)
+		assert.Equal(t, "created_at", query.Sort)
+		assert.Equal(t, "desc", query.Order)
+	})
+
+	t.Run("When Filtering By Category And Tag", func(t *testing.T) {
+		query := books.Query{Category: 2, Tags: []string{"  Science   Fiction "}}
+		bookRepository.Mock.On("GetAll", mock.Anything, &query).Return(booksDataFromDB, len(booksDataFromDB), nil).Once()
+		_, _, statusCode, err := bookUsecase.GetAll(context.Background(), &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []string{"science fiction"}, query.Tags)
+	})
+
+	t.Run("When Rating Range Is Invalid", func(t *testing.T) {
+		minRating, maxRating := 8.0, 3.0
+		_, _, statusCode, err := bookUsecase.GetAll(context.Background(), &books.Query{MinRating: &minRating, MaxRating: &maxRating})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Filtering By Bibliographic Fields", func(t *testing.T) {
+		minPages, maxPages := 100, 400
+		query := books.Query{Language: " EN ", Format: "Ebook", Series: " Dune ", MinPages: &minPages, MaxPages: &maxPages}
+		bookRepository.Mock.On("GetAll", mock.Anything, &query).Return(booksDataFromDB, len(booksDataFromDB), nil).Once()
+		_, _, statusCode, err := bookUsecase.GetAll(context.Background(), &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "en", query.Language)
+		assert.Equal(t, constants.BookFormatEbook, query.Format)
+		assert.Equal(t, "Dune", query.Series)
+	})
+
+	t.Run("When Page Range Is Invalid", func(t *testing.T) {
+		minPages, maxPages := 400, 100
+		_, _, statusCode, err := bookUsecase.GetAll(context.Background(), &books.Query{MinPages: &minPages, MaxPages: &maxPages})
+
+		assert.EqualError(t, err, "min_pages can't be greater than max_pages")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Publication Range Is Invalid", func(t *testing.T) {
+		from, to := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, _, statusCode, err := bookUsecase.GetAll(context.Background(), &books.Query{PublishedFrom: &from, PublishedTo: &to})
+
+		assert.EqualError(t, err, "published_from can't be after published_to")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
 }
 
 func TestGetById(t *testing.T) {
@@ -159,6 +292,35 @@ func TestGetById(t *testing.T) {
 	})
 }
 
+func TestGetByISBN(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Book By ISBN", func(t *testing.T) {
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(bookDataFromDB, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetByISBN(context.Background(), "978-0-7352-1129-2")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, bookDataFromDB, result)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Invalid ISBN", func(t *testing.T) {
+			_, statusCode, err := bookUsecase.GetByISBN(context.Background(), "12345")
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Book Not Found", func(t *testing.T) {
+			bookRepository.Mock.On("GetByISBN", mock.Anything, "9780345472328").Return(books.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := bookUsecase.GetByISBN(context.Background(), "9780345472328")
+
+			assert.Equal(t, errors.New("book not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+	})
+}
+
 func TestDelete(t *testing.T) {
 	setup(t)
 	t.Run("When Success Delete Book Data", func(t *testing.T) {
@@ -198,6 +360,7 @@ func TestUpdate(t *testing.T) {
 	t.Run("When Success Update Book", func(t *testing.T) {
 		updatedBookFromDB := bookDataFromDB
 		updatedBookFromDB.UpdatedAt = time.Now()
+		bookRepository.Mock.On("GetByISBN", mock.Anything, bookDataFromDB.ISBN).Return(bookDataFromDB, nil).Once()
 		bookRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(updatedBookFromDB, nil).Once()
 		bookRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(nil).Once()
 
@@ -208,4 +371,772 @@ func TestUpdate(t *testing.T) {
 		assert.Nil(t, err)
 		assert.NotNil(t, result.UpdatedAt)
 	})
+	t.Run("When Failure ISBN Belongs To Another Book", func(t *testing.T) {
+		otherBook := booksDataFromDB[1]
+		otherBook.ISBN = "9780345472328"
+		bookRepository.Mock.On("GetByISBN", mock.Anything, otherBook.ISBN).Return(otherBook, nil).Once()
+
+		_, statusCode, err := bookUsecase.Update(context.Background(), &books.Domain{Title: "Atomic Habits", ISBN: otherBook.ISBN}, bookDataFromDB.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestPatch(t *testing.T) {
+	setup(t)
+	t.Run("When Success Patch Book", func(t *testing.T) {
+		publisherId := 3
+		linkedBook := bookDataFromDB
+		linkedBook.PublisherId = &publisherId
+		description := "a book about habits"
+		publisher := "Penguin"
+		patchedBook := bookDataFromDB
+		patchedBook.Description = description
+		patchedBook.Publisher = publisher
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(linkedBook, nil).Once()
+		bookRepository.Mock.On("Patch", mock.Anything, mock.MatchedBy(func(book *books.Domain) bool {
+			return book.Description == description && book.Publisher == publisher && book.PublisherId == nil
+		}), []string{"description", "publisher", "publisher_id"}).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(patchedBook, nil).Once()
+
+		result, changed, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{Description: &description, Publisher: &publisher})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []string{"description", "publisher", "publisher_id"}, changed)
+		assert.Equal(t, patchedBook, result)
+	})
+	t.Run("When Failure Required Field Is Emptied", func(t *testing.T) {
+		empty := ""
+		for field, patch := range map[string]*books.Patch{
+			"description": {Description: &empty},
+			"publisher":   {Publisher: &empty},
+		} {
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, _, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, patch)
+
+			assert.Equal(t, fmt.Errorf("%s can't be empty", field), err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		}
+	})
+	t.Run("When Success ISBN Is Normalized", func(t *testing.T) {
+		isbn := "978-0-7352-1129-2"
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+		_, changed, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{ISBN: &isbn})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Empty(t, changed)
+	})
+	t.Run("When Success Patch Bibliographic Fields", func(t *testing.T) {
+		publishedBook := bookDataFromDB
+		publishedBook.Language = "en"
+		publicationDate := time.Date(2018, 10, 16, 0, 0, 0, 0, time.UTC)
+		publishedBook.PublicationDate = &publicationDate
+		language := "EN"
+		format := "paperback"
+		pageCount := 320
+		empty := ""
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(publishedBook, nil).Once()
+		bookRepository.Mock.On("Patch", mock.Anything, mock.MatchedBy(func(book *books.Domain) bool {
+			return book.Format == constants.BookFormatPaperback && book.PageCount == pageCount && book.PublicationDate == nil
+		}), []string{"format", "page_count", "publication_date"}).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(publishedBook, nil).Once()
+
+		_, changed, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{Language: &language, Format: &format, PageCount: &pageCount, PublicationDate: &empty})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []string{"format", "page_count", "publication_date"}, changed)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Invalid Publication Date", func(t *testing.T) {
+			date := "16/10/2018"
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, _, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{PublicationDate: &date})
+
+			assert.EqualError(t, err, "publication_date must be formatted as 2006-01-02")
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Invalid Format", func(t *testing.T) {
+			format := "scroll"
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, _, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{Format: &format})
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Book Not Found", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+			_, _, statusCode, err := bookUsecase.Patch(context.Background(), 99, &books.Patch{})
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Null Title", func(t *testing.T) {
+			empty := ""
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, _, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{Title: &empty})
+
+			assert.EqualError(t, err, "title can't be empty")
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("ISBN Belongs To Another Book", func(t *testing.T) {
+			otherBook := booksDataFromDB[1]
+			otherBook.ISBN = "9780345472328"
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+			bookRepository.Mock.On("GetByISBN", mock.Anything, otherBook.ISBN).Return(otherBook, nil).Once()
+
+			_, _, statusCode, err := bookUsecase.Patch(context.Background(), bookDataFromDB.ID, &books.Patch{ISBN: &otherBook.ISBN})
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get History", func(t *testing.T) {
+		adminId := 1
+		versions := []books.Version{
+			{BookId: 1, Version: 2, Action: constants.BookVersionUpdated, UserId: &adminId, Changes: []books.FieldChange{{Field: "title", From: "Atomic Habit", To: "Atomic Habits"}}},
+			{BookId: 1, Version: 1, Action: constants.BookVersionCreated},
+		}
+		bookRepository.Mock.On("GetVersions", mock.Anything, 1).Return(versions, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetHistory(context.Background(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, versions, result)
+	})
+	t.Run("When Failure Book Never Existed", func(t *testing.T) {
+		bookRepository.Mock.On("GetVersions", mock.Anything, 99).Return([]books.Version{}, nil).Once()
+
+		_, statusCode, err := bookUsecase.GetHistory(context.Background(), 99)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestRevert(t *testing.T) {
+	setup(t)
+	t.Run("When Success Revert Book", func(t *testing.T) {
+		previous := bookDataFromDB
+		previous.Title = "Atomic Habit"
+		previous.Cover = "books/1/old.jpg"
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("GetVersion", mock.Anything, 1, 1).Return(books.Version{BookId: 1, Version: 1, Snapshot: previous}, nil).Once()
+		bookRepository.Mock.On("Revert", mock.Anything, mock.MatchedBy(func(book *books.Domain) bool {
+			return book.Title == "Atomic Habit" && book.Cover == bookDataFromDB.Cover
+		}), 1).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(previous, nil).Once()
+
+		result, statusCode, err := bookUsecase.Revert(context.Background(), 1, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "Atomic Habit", result.Title)
+	})
+	t.Run("When Success Revert To Backfilled Version", func(t *testing.T) {
+		current := bookDataFromDB
+		current.Subtitle = "Second Edition Notes"
+		current.Edition = "2nd"
+		current.Language = "id"
+		current.Format = constants.BookFormatEbook
+		current.Series = "Habits"
+		current.SeriesPosition = 2
+		current.PageCount = 400
+		current.PublicationDate = nil
+		published := time.Date(2018, 10, 16, 0, 0, 0, 0, time.UTC)
+		backfilled := bookDataFromDB
+		backfilled.Subtitle = "An Easy & Proven Way to Build Good Habits"
+		backfilled.Edition = "1st"
+		backfilled.Language = "en"
+		backfilled.Format = constants.BookFormatHardcover
+		backfilled.Series = ""
+		backfilled.SeriesPosition = 0
+		backfilled.PageCount = 320
+		backfilled.PublicationDate = &published
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(current, nil).Once()
+		bookRepository.Mock.On("GetVersion", mock.Anything, 1, 1).Return(books.Version{BookId: 1, Version: 1, Action: constants.BookVersionCreated, Snapshot: backfilled}, nil).Once()
+		bookRepository.Mock.On("Revert", mock.Anything, mock.MatchedBy(func(book *books.Domain) bool {
+			return book.Subtitle == backfilled.Subtitle && book.Edition == "1st" && book.Language == "en" &&
+				book.Format == constants.BookFormatHardcover && book.Series == "" && book.SeriesPosition == 0 &&
+				book.PageCount == 320 && book.PublicationDate != nil && book.PublicationDate.Equal(published)
+		}), 1).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(backfilled, nil).Once()
+
+		result, statusCode, err := bookUsecase.Revert(context.Background(), 1, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 320, result.PageCount)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Version Not Found", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, 1).Return(bookDataFromDB, nil).Once()
+			bookRepository.Mock.On("GetVersion", mock.Anything, 1, 9).Return(books.Version{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := bookUsecase.Revert(context.Background(), 1, 9)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("ISBN Taken By Another Book", func(t *testing.T) {
+			previous := bookDataFromDB
+			previous.ISBN = "9780345472328"
+			bookRepository.Mock.On("GetById", mock.Anything, 1).Return(bookDataFromDB, nil).Once()
+			bookRepository.Mock.On("GetVersion", mock.Anything, 1, 1).Return(books.Version{BookId: 1, Version: 1, Snapshot: previous}, nil).Once()
+			bookRepository.Mock.On("GetByISBN", mock.Anything, previous.ISBN).Return(booksDataFromDB[1], nil).Once()
+
+			_, statusCode, err := bookUsecase.Revert(context.Background(), 1, 1)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestSearch(t *testing.T) {
+	setup(t)
+	t.Run("When Success Search Books", func(t *testing.T) {
+		searchResults := []books.SearchResult{
+			{
+				Book:           bookDataFromDB,
+				Rank:           0.6,
+				TitleHighlight: "<mark>Atomic</mark> Habits",
+				Snippet:        "lorem ipsum doler sit amet",
+			},
+		}
+		query := books.SearchQuery{Keyword: "  atomic  "}
+		bookRepository.Mock.On("Search", mock.Anything, &query).Return(searchResults, 1, nil).Once()
+
+		result, total, statusCode, err := bookUsecase.Search(context.Background(), &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, searchResults, result)
+		assert.Equal(t, "atomic", query.Keyword)
+		assert.Equal(t, 1, query.Page)
+		assert.Equal(t, 10, query.Limit)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Keyword is blank", func(t *testing.T) {
+			_, _, statusCode, err := bookUsecase.Search(context.Background(), &books.SearchQuery{Keyword: "   "})
+
+			assert.Equal(t, errors.New("search keyword is required"), err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Repository error", func(t *testing.T) {
+			bookRepository.Mock.On("Search", mock.Anything, mock.AnythingOfType("*books.SearchQuery")).Return([]books.SearchResult{}, 0, errors.New("search failed")).Once()
+
+			_, _, statusCode, err := bookUsecase.Search(context.Background(), &books.SearchQuery{Keyword: "atomic"})
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusInternalServerError, statusCode)
+		})
+	})
+}
+
+func TestSuggest(t *testing.T) {
+	setup(t)
+	t.Run("When Success Suggest Books", func(t *testing.T) {
+		suggestions := []books.Suggestion{
+			{Value: "Atomic Habits", Field: "title"},
+		}
+		bookRepository.Mock.On("Suggest", mock.Anything, "ato", constants.DefaultSuggestLimit).Return(suggestions, nil).Once()
+
+		result, statusCode, err := bookUsecase.Suggest(context.Background(), " ato ", 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, suggestions, result)
+	})
+	t.Run("When Success Limit Is Clamped", func(t *testing.T) {
+		bookRepository.Mock.On("Suggest", mock.Anything, "james", constants.MaxSuggestLimit).Return([]books.Suggestion{}, nil).Once()
+
+		_, statusCode, err := bookUsecase.Suggest(context.Background(), "james", 1000)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Prefix is too short", func(t *testing.T) {
+			_, statusCode, err := bookUsecase.Suggest(context.Background(), " a ", 0)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Repository error", func(t *testing.T) {
+			bookRepository.Mock.On("Suggest", mock.Anything, "ato", constants.DefaultSuggestLimit).Return([]books.Suggestion{}, errors.New("suggest failed")).Once()
+
+			_, statusCode, err := bookUsecase.Suggest(context.Background(), "ato", 0)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusInternalServerError, statusCode)
+		})
+	})
+}
+
+func TestImport(t *testing.T) {
+	setup(t)
+	t.Run("When Success Import Books", func(t *testing.T) {
+		rows := []books.ImportRow{
+			{Line: 2, Book: books.Domain{Title: "Atomic Habits", ISBN: "0-7352-1129-9"}},
+			{Line: 3, Book: books.Domain{Title: "Atomic Habits", ISBN: "9780735211292"}},
+			{Line: 4, Book: books.Domain{Title: "Mindset", ISBN: "9780345472328"}},
+			{Line: 5, Error: "Key: 'BookRequest.Title' Error:Field validation for 'Title' failed on the 'required' tag"},
+			{Line: 6, Book: books.Domain{Title: "Selena", ISBN: "12345"}},
+		}
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292", "9780345472328"}).Return([]books.Domain{{ID: 2, ISBN: "9780345472328"}}, nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, mock.AnythingOfType("[]books.Domain")).Return([]books.Domain{{ID: 3, ISBN: "9780735211292"}}, []error{nil}, nil).Once()
+
+		results, created, statusCode, err := bookUsecase.Import(context.Background(), rows)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 5, len(results))
+		assert.Equal(t, books.ImportResult{Line: 2, Status: constants.BookImportCreated, BookID: 3, ISBN: "9780735211292"}, results[0])
+		assert.Equal(t, "isbn 9780735211292 is duplicated on line 2", results[1].Error)
+		assert.Equal(t, "book with isbn 9780345472328 already exists", results[2].Error)
+		assert.Equal(t, constants.BookImportFailed, results[3].Status)
+		assert.NotEmpty(t, results[4].Error)
+	})
+	t.Run("When Success Failed Rows Are Reported Per Line", func(t *testing.T) {
+		rows := []books.ImportRow{
+			{Line: 2, Book: books.Domain{Title: "Atomic Habits", ISBN: "9780735211292"}},
+			{Line: 3, Book: books.Domain{Title: "Mindset", ISBN: "9780345472328"}},
+		}
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292", "9780345472328"}).Return([]books.Domain{}, nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, mock.AnythingOfType("[]books.Domain")).Return([]books.Domain{{ID: 3, ISBN: "9780735211292"}, {}}, []error{nil, errors.New("value too long for type character varying(255)")}, nil).Once()
+
+		results, created, statusCode, err := bookUsecase.Import(context.Background(), rows)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, books.ImportResult{Line: 2, Status: constants.BookImportCreated, BookID: 3, ISBN: "9780735211292"}, results[0])
+		assert.Equal(t, books.ImportResult{Line: 3, Status: constants.BookImportFailed, ISBN: "9780345472328", Error: "value too long for type character varying(255)"}, results[1])
+	})
+	t.Run("When Failure Batch Insert Failed", func(t *testing.T) {
+		rows := []books.ImportRow{
+			{Line: 1, Book: books.Domain{Title: "Atomic Habits", ISBN: "9780735211292"}},
+		}
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292"}).Return([]books.Domain{}, nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, mock.AnythingOfType("[]books.Domain")).Return([]books.Domain{}, []error{}, errors.New("insert failed")).Once()
+
+		_, created, statusCode, err := bookUsecase.Import(context.Background(), rows)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, created)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+	})
+}
+
+func TestImportMetadata(t *testing.T) {
+	setup(t)
+	published := time.Date(2018, 10, 16, 0, 0, 0, 0, time.UTC)
+	records := []books.MetadataRecord{
+		{Line: 1, Book: books.Domain{Title: "Atomic Habits", Author: "James Clear", Description: "Tiny changes, remarkable results", Publisher: "Avery", ISBN: "0-7352-1129-9", PageCount: 320, PublicationDate: &published}},
+		{Line: 2, Book: books.Domain{Title: "Mindset", Author: "Carol Dweck", Publisher: "Ballantine", ISBN: "9780345472328"}},
+		{Line: 3, Book: books.Domain{Title: "Selena", ISBN: "9786020332956"}},
+		{Line: 4, Book: books.Domain{Title: "Laskar Pelangi", Author: "Andrea Hirata", ISBN: "9789793062792"}},
+		{Line: 5, Error: "invalid json: unexpected end of JSON input"},
+	}
+	existing := []books.Domain{
+		{ID: 1, Title: "Atomic Habits", Author: "James Clear", Description: "lorem ipsum doler sit amet", ISBN: "9780735211292"},
+		{ID: 2, Title: "Mindset", Author: "Carol Dweck", Publisher: "Gramedia", ISBN: "9780345472328"},
+	}
+
+	t.Run("When Success Import Metadata", func(t *testing.T) {
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292", "9780345472328", "9786020332956", "9789793062792"}).Return(existing, nil).Once()
+		bookRepository.Mock.On("Patch", mock.Anything, mock.MatchedBy(func(book *books.Domain) bool {
+			return book.ID == 1 && book.Description == "lorem ipsum doler sit amet" && book.Publisher == "Avery" && book.PageCount == 320
+		}), []string{"publisher", "page_count", "publication_date"}).Return(nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, []books.Domain{{Title: "Laskar Pelangi", Author: "Andrea Hirata", ISBN: "9789793062792"}}).Return([]books.Domain{{ID: 3, ISBN: "9789793062792"}}, []error{nil}, nil).Once()
+
+		results, statusCode, err := bookUsecase.ImportMetadata(context.Background(), records, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, books.MetadataResult{Line: 1, Status: constants.BookImportEnriched, BookID: 1, ISBN: "9780735211292", Fields: []string{"publisher", "page_count", "publication_date"}}, results[0])
+		assert.Equal(t, books.MetadataResult{Line: 2, Status: constants.BookImportUnchanged, BookID: 2, ISBN: "9780345472328"}, results[1])
+		assert.Equal(t, "author can't be empty", results[2].Error)
+		assert.Equal(t, books.MetadataResult{Line: 4, Status: constants.BookImportCreated, BookID: 3, ISBN: "9789793062792"}, results[3])
+		assert.Equal(t, constants.BookImportFailed, results[4].Status)
+	})
+	t.Run("When Success Failed Writes Are Reported Per Line", func(t *testing.T) {
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, mock.Anything).Return(existing, nil).Once()
+		bookRepository.Mock.On("Patch", mock.Anything, mock.AnythingOfType("*books.Domain"), mock.Anything).Return(errors.New("patch failed")).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, mock.Anything).Return([]books.Domain{}, []error{}, errors.New("batch failed")).Once()
+
+		results, statusCode, err := bookUsecase.ImportMetadata(context.Background(), records, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, books.MetadataResult{Line: 1, Status: constants.BookImportFailed, BookID: 1, ISBN: "9780735211292", Error: "patch failed"}, results[0])
+		assert.Equal(t, constants.BookImportUnchanged, results[1].Status)
+		assert.Equal(t, books.MetadataResult{Line: 4, Status: constants.BookImportFailed, ISBN: "9789793062792", Error: "batch failed"}, results[3])
+	})
+	t.Run("When Success Dry Run", func(t *testing.T) {
+		setup(t)
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, mock.Anything).Return(existing, nil).Once()
+
+		results, statusCode, err := bookUsecase.ImportMetadata(context.Background(), records, true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.BookImportEnriched, results[0].Status)
+		assert.Equal(t, books.MetadataResult{Line: 4, Status: constants.BookImportCreated, ISBN: "9789793062792"}, results[3])
+		bookRepository.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+	})
+}
+
+func TestExport(t *testing.T) {
+	setup(t)
+	t.Run("When Success Export Books", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(func(ctx context.Context, fn func(books.Domain) error) error {
+			for _, book := range booksDataFromDB {
+				if err := fn(book); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+
+		var exported []books.Domain
+		statusCode, err := bookUsecase.Export(context.Background(), func(book books.Domain) error {
+			exported = append(exported, book)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, booksDataFromDB, exported)
+	})
+	t.Run("When Failure Stream Failed", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(errors.New("stream failed")).Once()
+
+		statusCode, err := bookUsecase.Export(context.Background(), func(book books.Domain) error { return nil })
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+	})
+}
+
+func TestGetSimilar(t *testing.T) {
+	setup(t)
+	corpus := []books.Domain{
+		{ID: 1, Title: "Atomic Habits", Description: "tiny habits compound into remarkable results", Author: "James Clear", ISBN: "9780735211292"},
+		{ID: 2, Title: "Selena", Description: "a girl discovers the world of magic", Author: "Tere Liye", ISBN: "9780345472328"},
+		{ID: 3, Title: "The Power of Habit", Description: "why habits shape what we do in life and business", Author: "Charles Duhigg", ISBN: "9780812981605"},
+	}
+	streamCorpus := func(ctx context.Context, fn func(books.Domain) error) error {
+		for _, book := range corpus {
+			if err := fn(book); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	t.Run("When Success Get Similar Books", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(streamCorpus).Once()
+		assert.Nil(t, bookUsecase.RebuildSimilarityIndex(context.Background()))
+
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(corpus[0], nil).Once()
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{3}).Return([]books.Domain{corpus[2]}, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetSimilar(context.Background(), 1, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Len(t, result, 1)
+		assert.Equal(t, corpus[2], result[0].Book)
+		assert.Greater(t, result[0].Score, 0.0)
+	})
+	t.Run("When Success Edited Book Is Reindexed", func(t *testing.T) {
+		edited := corpus[1]
+		edited.Description = "a girl builds magic habits"
+		bookRepository.Mock.On("GetByISBN", mock.Anything, edited.ISBN).Return(corpus[1], nil).Once()
+		bookRepository.Mock.On("Update", mock.Anything, &edited).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, edited.ID).Return(edited, nil).Once()
+
+		_, _, err := bookUsecase.Update(context.Background(), &edited, edited.ID)
+		assert.Nil(t, err)
+
+		// the index is already built, so books aren't streamed again
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(corpus[0], nil).Once()
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{2, 3}).Return([]books.Domain{corpus[2], edited}, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetSimilar(context.Background(), 1, 5)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Len(t, result, 2)
+		assert.Equal(t, edited, result[0].Book)
+		assert.Equal(t, 3, result[1].Book.ID)
+	})
+	t.Run("When Success Stored Book Is Indexed Without A Rebuild", func(t *testing.T) {
+		stored := books.Domain{ID: 4, Title: "Tiny Habits", Description: "small habits that change everything", Author: "BJ Fogg", Publisher: "Harvest", ISBN: "9780358003328"}
+		bookRepository.Mock.On("GetByISBN", mock.Anything, stored.ISBN).Return(books.Domain{}, errors.New("record not found")).Once()
+		bookRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(stored, nil).Once()
+
+		_, _, err := bookUsecase.Store(context.Background(), &books.Domain{Title: stored.Title, Description: stored.Description, Author: stored.Author, Publisher: stored.Publisher, ISBN: stored.ISBN})
+		assert.Nil(t, err)
+
+		bookRepository.Mock.On("GetById", mock.Anything, stored.ID).Return(stored, nil).Once()
+		bookRepository.Mock.On("GetByIds", mock.Anything, mock.MatchedBy(func(ids []int) bool {
+			return len(ids) > 0 && ids[0] != stored.ID
+		})).Return([]books.Domain{corpus[0], corpus[2]}, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetSimilar(context.Background(), stored.ID, 5)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.NotEmpty(t, result)
+		// the catalogue was only read by the first rebuild
+		bookRepository.AssertNumberOfCalls(t, "Stream", 1)
+	})
+	t.Run("When Success Book Stored During A Rebuild Stays Indexed", func(t *testing.T) {
+		setup(t)
+		stored := books.Domain{ID: 4, Title: "Tiny Habits", Description: "small habits that change everything", Author: "BJ Fogg", Publisher: "Harvest", ISBN: "9780358003328"}
+		bookRepository.Mock.On("GetByISBN", mock.Anything, stored.ISBN).Return(books.Domain{}, errors.New("record not found")).Once()
+		bookRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(stored, nil).Once()
+		// the book is stored after the rebuild started reading, so the catalogue it reads doesn't have it
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(func(ctx context.Context, fn func(books.Domain) error) error {
+			if _, _, err := bookUsecase.Store(ctx, &books.Domain{Title: stored.Title, Description: stored.Description, Author: stored.Author, Publisher: stored.Publisher, ISBN: stored.ISBN}); err != nil {
+				return err
+			}
+			return streamCorpus(ctx, fn)
+		}).Once()
+
+		assert.Nil(t, bookUsecase.RebuildSimilarityIndex(context.Background()))
+
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(corpus[0], nil).Once()
+		bookRepository.Mock.On("GetByIds", mock.Anything, mock.MatchedBy(func(ids []int) bool {
+			return len(ids) == 2
+		})).Return([]books.Domain{corpus[2], stored}, nil).Once()
+
+		result, _, err := bookUsecase.GetSimilar(context.Background(), 1, 5)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+	})
+	t.Run("When Success Index Starts Empty", func(t *testing.T) {
+		setup(t)
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(corpus[0], nil).Once()
+
+		result, statusCode, err := bookUsecase.GetSimilar(context.Background(), 1, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Empty(t, result)
+		bookRepository.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything)
+	})
+	t.Run("When Failure Book Not Found", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := bookUsecase.GetSimilar(context.Background(), 99, 0)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Rating Stats", func(t *testing.T) {
+		months := []books.RatingMonth{{Month: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Reviews: 4, RatingSum: 36}}
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("GetRatingStats", mock.Anything, bookDataFromDB.ID).Return([]books.RatingCount{
+			{Rating: 10, Reviews: 1}, {Rating: 8, Reviews: 1}, {Rating: 9, Reviews: 2},
+		}, months, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetStats(context.Background(), bookDataFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 4, result.Reviews)
+		assert.Equal(t, 9.0, result.Average)
+		assert.Equal(t, 9.0, result.Median)
+		assert.InDelta(t, 0.7071, result.StdDev, 0.0001)
+		assert.Len(t, result.Histogram, constants.MaxRating)
+		assert.Equal(t, books.RatingCount{Rating: 9, Reviews: 2}, result.Histogram[8])
+		assert.Equal(t, months, result.Months)
+	})
+	t.Run("When Success Median Between Two Ratings", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("GetRatingStats", mock.Anything, bookDataFromDB.ID).Return([]books.RatingCount{
+			{Rating: 2, Reviews: 1}, {Rating: 10, Reviews: 1},
+		}, []books.RatingMonth{}, nil).Once()
+
+		result, _, err := bookUsecase.GetStats(context.Background(), bookDataFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 6.0, result.Median)
+		assert.Equal(t, 4.0, result.StdDev)
+	})
+	t.Run("When Success No Reviews Yet", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("GetRatingStats", mock.Anything, bookDataFromDB.ID).Return(nil, nil, nil).Once()
+
+		result, statusCode, err := bookUsecase.GetStats(context.Background(), bookDataFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 0, result.Reviews)
+		assert.Len(t, result.Histogram, constants.MaxRating)
+		assert.Equal(t, []books.RatingMonth{}, result.Months)
+	})
+	t.Run("When Failure Book Not Found", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := bookUsecase.GetStats(context.Background(), 99)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func newCoverImage(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func TestUploadCover(t *testing.T) {
+	setup(t)
+	t.Run("When Success Upload Cover", func(t *testing.T) {
+		oldCover := bookDataFromDB
+		oldCover.Cover = "books/1/0000000000000000.jpg"
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(oldCover, nil).Once()
+		blobStorage.Mock.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil).Times(3)
+		bookRepository.Mock.On("UpdateCover", mock.Anything, bookDataFromDB.ID, mock.AnythingOfType("string")).Return(nil).Once()
+		blobStorage.Mock.On("Delete", mock.Anything, "books/1/0000000000000000.jpg", "books/1/0000000000000000_small.jpg", "books/1/0000000000000000_medium.jpg").Return(nil).Once()
+
+		result, statusCode, err := bookUsecase.UploadCover(context.Background(), bookDataFromDB.ID, newCoverImage(800, 1200))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Regexp(t, `^books/1/[0-9a-f]{16}\.png$`, result.Cover)
+		blobStorage.Mock.AssertCalled(t, "Put", mock.Anything, books.ThumbnailKey(result.Cover, constants.CoverSmall), mock.Anything)
+		blobStorage.Mock.AssertCalled(t, "Put", mock.Anything, books.ThumbnailKey(result.Cover, constants.CoverMedium), mock.Anything)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Book Not Found", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := bookUsecase.UploadCover(context.Background(), 99, newCoverImage(10, 10))
+
+			assert.Equal(t, errors.New("book not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Unsupported Image Type", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, statusCode, err := bookUsecase.UploadCover(context.Background(), bookDataFromDB.ID, []byte("GIF89a not really a cover"))
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusUnsupportedMediaType, statusCode)
+		})
+		t.Run("Storage Failed", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+			blobStorage.Mock.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(errors.New("disk full")).Once()
+			blobStorage.Mock.On("Delete", mock.Anything).Return(nil).Once()
+
+			_, statusCode, err := bookUsecase.UploadCover(context.Background(), bookDataFromDB.ID, newCoverImage(10, 10))
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusInternalServerError, statusCode)
+		})
+	})
+}
+
+func TestAddTags(t *testing.T) {
+	setup(t)
+	t.Run("When Success Add Tags", func(t *testing.T) {
+		tagged := bookDataFromDB
+		tagged.Tags = []string{"productivity"}
+		result := bookDataFromDB
+		result.Tags = []string{"productivity", "self help"}
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(tagged, nil).Once()
+		bookRepository.Mock.On("AddTags", mock.Anything, bookDataFromDB.ID, []string{"self help"}).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(result, nil).Once()
+
+		book, statusCode, err := bookUsecase.AddTags(context.Background(), bookDataFromDB.ID, []string{"Productivity", " Self  Help", "self help"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, result.Tags, book.Tags)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Book doesn't exist", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, 3).Return(books.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := bookUsecase.AddTags(context.Background(), 3, []string{"classic"})
+
+			assert.Equal(t, errors.New("book not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Tag too long", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, statusCode, err := bookUsecase.AddTags(context.Background(), bookDataFromDB.ID, []string{strings.Repeat("a", constants.MaxTagLength+1)})
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Too many tags", func(t *testing.T) {
+			tags := make([]string, constants.MaxBookTags+1)
+			for i := range tags {
+				tags[i] = fmt.Sprintf("tag %d", i)
+			}
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			_, statusCode, err := bookUsecase.AddTags(context.Background(), bookDataFromDB.ID, tags)
+
+			assert.Equal(t, fmt.Errorf("book can't have more than %d tags", constants.MaxBookTags), err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+	})
+}
+
+func TestRemoveTag(t *testing.T) {
+	setup(t)
+	tagged := bookDataFromDB
+	tagged.Tags = []string{"productivity"}
+	t.Run("When Success Remove Tag", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(tagged, nil).Once()
+		bookRepository.Mock.On("RemoveTag", mock.Anything, bookDataFromDB.ID, "productivity").Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+		book, statusCode, err := bookUsecase.RemoveTag(context.Background(), bookDataFromDB.ID, "Productivity")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Empty(t, book.Tags)
+	})
+	t.Run("When Failure Book Isn't Tagged", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(tagged, nil).Once()
+
+		_, statusCode, err := bookUsecase.RemoveTag(context.Background(), bookDataFromDB.ID, "classic")
+
+		assert.Equal(t, errors.New(`book isn't tagged with "classic"`), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
 }
@@ -0,0 +1,39 @@
+package categories
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type Domain struct {
+	ID        int
+	Name      string
+	ParentId  *int
+	Children  []Domain
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Usecase interface {
+	Store(ctx context.Context, category *Domain) (domain Domain, statusCode int, err error)
+	GetTree(ctx context.Context) (tree []Domain, statusCode int, err error)
+	GetById(ctx context.Context, id int) (domain Domain, statusCode int, err error)
+	Update(ctx context.Context, category *Domain, id int) (domain Domain, bookIds []int, statusCode int, err error)
+	Delete(ctx context.Context, id int) (statusCode int, err error)
+	GetBooks(ctx context.Context, id int, query *books.Query) (domains []books.Domain, total int, statusCode int, err error)
+	SetBookCategories(ctx context.Context, bookId int, categoryIds []int) (domain books.Domain, statusCode int, err error)
+}
+
+type Repository interface {
+	Store(ctx context.Context, category *Domain) (Domain, error)
+	GetAll(ctx context.Context) ([]Domain, error)
+	GetById(ctx context.Context, id int) (Domain, error)
+	GetByName(ctx context.Context, name string, parentId *int) (Domain, error)
+	GetByIds(ctx context.Context, ids []int) ([]Domain, error)
+	Update(ctx context.Context, category *Domain) (bookIds []int, err error)
+	Delete(ctx context.Context, id int) error
+	CountBooks(ctx context.Context, id int) (int, error)
+	ReplaceBookCategories(ctx context.Context, bookId int, categoryIds []int) error
+}
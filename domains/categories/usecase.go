@@ -0,0 +1,208 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type categoryUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+}
+
+func NewCategoryUsecase(repo Repository, bookRepo books.Repository) Usecase {
+	return &categoryUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+	}
+}
+
+func (uc *categoryUsecase) Store(ctx context.Context, category *Domain) (Domain, int, error) {
+	category.Name = strings.Join(strings.Fields(category.Name), " ")
+	if category.ParentId != nil {
+		if _, err := uc.repo.GetById(ctx, *category.ParentId); err != nil {
+			return Domain{}, http.StatusNotFound, errors.New("parent category not found")
+		}
+	}
+
+	if _, err := uc.repo.GetByName(ctx, category.Name, category.ParentId); err == nil {
+		return Domain{}, http.StatusConflict, fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	result, err := uc.repo.Store(ctx, category)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *categoryUsecase) GetTree(ctx context.Context) ([]Domain, int, error) {
+	all, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return []Domain{}, http.StatusInternalServerError, err
+	}
+
+	return buildTree(all, nil), http.StatusOK, nil
+}
+
+func (uc *categoryUsecase) GetById(ctx context.Context, id int) (Domain, int, error) {
+	all, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	for _, category := range all {
+		if category.ID == id {
+			category.Children = buildTree(all, &category.ID)
+			return category, http.StatusOK, nil
+		}
+	}
+
+	return Domain{}, http.StatusNotFound, errors.New("category not found")
+}
+
+func (uc *categoryUsecase) Update(ctx context.Context, category *Domain, id int) (Domain, []int, int, error) {
+	all, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	parents := make(map[int]*int, len(all))
+	for _, val := range all {
+		parents[val.ID] = val.ParentId
+	}
+	if _, ok := parents[id]; !ok {
+		return Domain{}, nil, http.StatusNotFound, errors.New("category not found")
+	}
+
+	if category.ParentId != nil {
+		if _, ok := parents[*category.ParentId]; !ok {
+			return Domain{}, nil, http.StatusNotFound, errors.New("parent category not found")
+		}
+		// walking up from the new parent must never reach the category itself, or the tree would turn into a loop
+		for ancestor := category.ParentId; ancestor != nil; ancestor = parents[*ancestor] {
+			if *ancestor == id {
+				return Domain{}, nil, http.StatusBadRequest, errors.New("category can't be moved below itself")
+			}
+		}
+	}
+
+	category.ID = id
+	category.Name = strings.Join(strings.Fields(category.Name), " ")
+	if existing, err := uc.repo.GetByName(ctx, category.Name, category.ParentId); err == nil && existing.ID != id {
+		return Domain{}, nil, http.StatusConflict, fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	bookIds, err := uc.repo.Update(ctx, category)
+	if err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	result, statusCode, err := uc.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, statusCode, err
+	}
+
+	return result, bookIds, http.StatusOK, nil
+}
+
+func (uc *categoryUsecase) Delete(ctx context.Context, id int) (int, error) {
+	category, statusCode, err := uc.GetById(ctx, id)
+	if err != nil {
+		return statusCode, err
+	}
+	if len(category.Children) > 0 {
+		return http.StatusConflict, fmt.Errorf("category still has %d subcategories", len(category.Children))
+	}
+
+	total, err := uc.repo.CountBooks(ctx, id)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if total > 0 {
+		return http.StatusConflict, fmt.Errorf("category still has %d books", total)
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *categoryUsecase) GetBooks(ctx context.Context, id int, query *books.Query) ([]books.Domain, int, int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return []books.Domain{}, 0, http.StatusNotFound, errors.New("category not found")
+	}
+
+	query.ApplyDefaults()
+	query.Category = id
+
+	result, total, err := uc.bookRepo.GetAll(ctx, query)
+	if err != nil {
+		return []books.Domain{}, 0, http.StatusInternalServerError, err
+	}
+
+	return result, total, http.StatusOK, nil
+}
+
+func (uc *categoryUsecase) SetBookCategories(ctx context.Context, bookId int, categoryIds []int) (books.Domain, int, error) {
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return books.Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	seen := make(map[int]bool, len(categoryIds))
+	var ids []int
+	for _, id := range categoryIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) > 0 {
+		found, err := uc.repo.GetByIds(ctx, ids)
+		if err != nil {
+			return books.Domain{}, http.StatusInternalServerError, err
+		}
+		exists := make(map[int]bool, len(found))
+		for _, category := range found {
+			exists[category.ID] = true
+		}
+		for _, id := range ids {
+			if !exists[id] {
+				return books.Domain{}, http.StatusNotFound, fmt.Errorf("category with id %d not found", id)
+			}
+		}
+	}
+
+	if err := uc.repo.ReplaceBookCategories(ctx, bookId, ids); err != nil {
+		return books.Domain{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.bookRepo.GetById(ctx, bookId)
+	if err != nil {
+		return books.Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	return result, http.StatusOK, nil
+}
+
+// buildTree nests the flat list of categories below the given parent, nil being the root
+func buildTree(all []Domain, parentId *int) []Domain {
+	var tree []Domain
+	for _, category := range all {
+		if (parentId == nil && category.ParentId == nil) || (parentId != nil && category.ParentId != nil && *category.ParentId == *parentId) {
+			category.Children = buildTree(all, &category.ID)
+			tree = append(tree, category)
+		}
+	}
+
+	return tree
+}
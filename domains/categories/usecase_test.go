@@ -0,0 +1,219 @@
+package categories_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	categoryMocks "github.com/snykk/golib_backend/datasources/databases/categories/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/categories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	categoryRepository *categoryMocks.Repository
+	bookRepository     *bookMocks.Repository
+	categoryUsecase    categories.Usecase
+	categoriesFromDB   []categories.Domain
+	bookFromDB         books.Domain
+)
+
+func setup(t *testing.T) {
+	categoryRepository = categoryMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	categoryUsecase = categories.NewCategoryUsecase(categoryRepository, bookRepository)
+
+	root, child := 1, 2
+	categoriesFromDB = []categories.Domain{
+		{ID: 1, Name: "Fiction", CreatedAt: time.Now()},
+		{ID: 2, Name: "Fantasy", ParentId: &root, CreatedAt: time.Now()},
+		{ID: 3, Name: "High Fantasy", ParentId: &child, CreatedAt: time.Now()},
+		{ID: 4, Name: "Non Fiction", CreatedAt: time.Now()},
+	}
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	t.Run("When Success Store Category", func(t *testing.T) {
+		parent := 1
+		req := categories.Domain{Name: " Science  Fiction", ParentId: &parent}
+		categoryRepository.Mock.On("GetById", mock.Anything, parent).Return(categoriesFromDB[0], nil).Once()
+		categoryRepository.Mock.On("GetByName", mock.Anything, "Science Fiction", &parent).Return(categories.Domain{}, errors.New("record not found")).Once()
+		categoryRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*categories.Domain")).Return(categories.Domain{ID: 5, Name: "Science Fiction", ParentId: &parent}, nil).Once()
+
+		result, statusCode, err := categoryUsecase.Store(context.Background(), &req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, "Science Fiction", result.Name)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Parent doesn't exist", func(t *testing.T) {
+			parent := 9
+			categoryRepository.Mock.On("GetById", mock.Anything, parent).Return(categories.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := categoryUsecase.Store(context.Background(), &categories.Domain{Name: "Horror", ParentId: &parent})
+
+			assert.Equal(t, errors.New("parent category not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Sibling with the same name", func(t *testing.T) {
+			categoryRepository.Mock.On("GetByName", mock.Anything, "Fiction", (*int)(nil)).Return(categoriesFromDB[0], nil).Once()
+
+			_, statusCode, err := categoryUsecase.Store(context.Background(), &categories.Domain{Name: "Fiction"})
+
+			assert.Equal(t, errors.New("category Fiction already exists"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestGetTree(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Category Tree", func(t *testing.T) {
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+
+		tree, statusCode, err := categoryUsecase.GetTree(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Len(t, tree, 2)
+		assert.Equal(t, "Fantasy", tree[0].Children[0].Name)
+		assert.Equal(t, "High Fantasy", tree[0].Children[0].Children[0].Name)
+		assert.Empty(t, tree[1].Children)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	setup(t)
+	t.Run("When Success Move Category To Root", func(t *testing.T) {
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+		categoryRepository.Mock.On("GetByName", mock.Anything, "Fantasy", (*int)(nil)).Return(categories.Domain{}, errors.New("record not found")).Once()
+		categoryRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*categories.Domain")).Return([]int{1}, nil).Once()
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+
+		result, bookIds, statusCode, err := categoryUsecase.Update(context.Background(), &categories.Domain{Name: "Fantasy"}, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 2, result.ID)
+		assert.Equal(t, []int{1}, bookIds)
+	})
+	t.Run("When Failure Category Moved Below Its Descendant", func(t *testing.T) {
+		descendant := 3
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+
+		_, _, statusCode, err := categoryUsecase.Update(context.Background(), &categories.Domain{Name: "Fiction", ParentId: &descendant}, 1)
+
+		assert.Equal(t, errors.New("category can't be moved below itself"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+	t.Run("When Failure Category Not Found", func(t *testing.T) {
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+
+		_, _, statusCode, err := categoryUsecase.Update(context.Background(), &categories.Domain{Name: "Horror"}, 9)
+
+		assert.Equal(t, errors.New("category not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	setup(t)
+	t.Run("When Success Delete Category", func(t *testing.T) {
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+		categoryRepository.Mock.On("CountBooks", mock.Anything, 4).Return(0, nil).Once()
+		categoryRepository.Mock.On("Delete", mock.Anything, 4).Return(nil).Once()
+
+		statusCode, err := categoryUsecase.Delete(context.Background(), 4)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Category has subcategories", func(t *testing.T) {
+			categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+
+			statusCode, err := categoryUsecase.Delete(context.Background(), 1)
+
+			assert.Equal(t, errors.New("category still has 1 subcategories"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Category has books", func(t *testing.T) {
+			categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+			categoryRepository.Mock.On("CountBooks", mock.Anything, 3).Return(2, nil).Once()
+
+			statusCode, err := categoryUsecase.Delete(context.Background(), 3)
+
+			assert.Equal(t, errors.New("category still has 2 books"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Category Books", func(t *testing.T) {
+		query := books.Query{}
+		categoryRepository.Mock.On("GetById", mock.Anything, 1).Return(categoriesFromDB[0], nil).Once()
+		bookRepository.Mock.On("GetAll", mock.Anything, &query).Return([]books.Domain{bookFromDB}, 1, nil).Once()
+
+		result, total, statusCode, err := categoryUsecase.GetBooks(context.Background(), 1, &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []books.Domain{bookFromDB}, result)
+		assert.Equal(t, 1, query.Category)
+		assert.Equal(t, 1, query.Page)
+	})
+}
+
+func TestSetBookCategories(t *testing.T) {
+	setup(t)
+	t.Run("When Success Set Book Categories", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		categoryRepository.Mock.On("GetByIds", mock.Anything, []int{2, 4}).Return([]categories.Domain{categoriesFromDB[1], categoriesFromDB[3]}, nil).Once()
+		categoryRepository.Mock.On("ReplaceBookCategories", mock.Anything, bookFromDB.ID, []int{2, 4}).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+
+		_, statusCode, err := categoryUsecase.SetBookCategories(context.Background(), bookFromDB.ID, []int{2, 4, 2})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Success Clear Book Categories", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		categoryRepository.Mock.On("ReplaceBookCategories", mock.Anything, bookFromDB.ID, []int(nil)).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+
+		_, statusCode, err := categoryUsecase.SetBookCategories(context.Background(), bookFromDB.ID, []int{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure Category Not Found", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		categoryRepository.Mock.On("GetByIds", mock.Anything, []int{9}).Return([]categories.Domain{}, nil).Once()
+
+		_, statusCode, err := categoryUsecase.SetBookCategories(context.Background(), bookFromDB.ID, []int{9})
+
+		assert.Equal(t, errors.New("category with id 9 not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
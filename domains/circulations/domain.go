@@ -0,0 +1,103 @@
+package circulations
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/users"
+)
+
+var (
+	// ErrCopyUnavailable is returned when another checkout or hold claimed the copy first
+	ErrCopyUnavailable = errors.New("copy is no longer available")
+	// ErrHoldClosed is returned when the hold was fulfilled, cancelled or expired in the meantime
+	ErrHoldClosed = errors.New("hold is no longer active")
+)
+
+type CopyDomain struct {
+	ID        int
+	BookId    int
+	Book      books.Domain
+	Barcode   string
+	Condition string
+	Location  string
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type LoanDomain struct {
+	ID           int
+	CopyId       int
+	Copy         CopyDomain
+	UserId       int
+	User         users.Domain
+	CheckedOutAt time.Time
+	DueAt        time.Time
+	ReturnedAt   *time.Time
+	Renewals     int
+	Fine         int
+	FinePaid     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type HoldDomain struct {
+	ID        int
+	BookId    int
+	Book      books.Domain
+	UserId    int
+	User      users.Domain
+	CopyId    int
+	Status    string
+	Position  int
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Usecase interface {
+	StoreCopy(ctx context.Context, bookCopy *CopyDomain) (domain CopyDomain, statusCode int, err error)
+	GetCopiesByBookId(ctx context.Context, bookId int) (domains []CopyDomain, statusCode int, err error)
+	UpdateCopy(ctx context.Context, bookCopy *CopyDomain, id int) (domain CopyDomain, statusCode int, err error)
+	DeleteCopy(ctx context.Context, id int) (statusCode int, err error)
+	Checkout(ctx context.Context, barcode string, userId int) (domain LoanDomain, statusCode int, err error)
+	Renew(ctx context.Context, loanId, userId int) (domain LoanDomain, statusCode int, err error)
+	Return(ctx context.Context, barcode string) (domain LoanDomain, statusCode int, err error)
+	GetActiveLoans(ctx context.Context, overdueOnly bool) (domains []LoanDomain, statusCode int, err error)
+	GetLoansByUserId(ctx context.Context, userId int) (domains []LoanDomain, statusCode int, err error)
+	PayFine(ctx context.Context, loanId int) (domain LoanDomain, statusCode int, err error)
+	PlaceHold(ctx context.Context, bookId, userId int) (domain HoldDomain, statusCode int, err error)
+	CancelHold(ctx context.Context, holdId, userId int) (statusCode int, err error)
+	GetHoldsByBookId(ctx context.Context, bookId int) (domains []HoldDomain, statusCode int, err error)
+	GetHoldsByUserId(ctx context.Context, userId int) (domains []HoldDomain, statusCode int, err error)
+	ExpireHolds(ctx context.Context) (expired int, err error)
+}
+
+type Repository interface {
+	StoreCopy(ctx context.Context, bookCopy *CopyDomain, nextHold *HoldDomain) (CopyDomain, error)
+	GetCopyById(ctx context.Context, id int) (CopyDomain, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (CopyDomain, error)
+	GetCopiesByBookId(ctx context.Context, bookId int) ([]CopyDomain, error)
+	UpdateCopy(ctx context.Context, bookCopy *CopyDomain) error
+	DeleteCopy(ctx context.Context, id int) error
+	Checkout(ctx context.Context, loan *LoanDomain, hold *HoldDomain, nextHold *HoldDomain) (LoanDomain, error)
+	Return(ctx context.Context, loan *LoanDomain, nextHold *HoldDomain) error
+	GetLoanById(ctx context.Context, id int) (LoanDomain, error)
+	GetActiveLoanByCopyId(ctx context.Context, copyId int) (LoanDomain, error)
+	GetActiveLoans(ctx context.Context) ([]LoanDomain, error)
+	GetLoansByUserId(ctx context.Context, userId int) ([]LoanDomain, error)
+	CountActiveLoansByUserId(ctx context.Context, userId int) (int, error)
+	GetUnpaidFineByUserId(ctx context.Context, userId int) (int, error)
+	UpdateLoan(ctx context.Context, loan *LoanDomain) error
+	StoreHold(ctx context.Context, hold *HoldDomain) (HoldDomain, error)
+	GetHoldById(ctx context.Context, id int) (HoldDomain, error)
+	GetReadyHoldByCopyId(ctx context.Context, copyId int) (HoldDomain, error)
+	GetActiveHoldsByBookId(ctx context.Context, bookId int) ([]HoldDomain, error)
+	GetHoldsByUserId(ctx context.Context, userId int) ([]HoldDomain, error)
+	GetExpiredHolds(ctx context.Context, at time.Time) ([]HoldDomain, error)
+	CancelHold(ctx context.Context, hold *HoldDomain, nextHold *HoldDomain) error
+	ExpireHold(ctx context.Context, hold *HoldDomain, nextHold *HoldDomain) error
+}
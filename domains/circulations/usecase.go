@@ -0,0 +1,510 @@
+package circulations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/users"
+)
+
+type circulationUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+	userRepo users.Repository
+}
+
+func NewCirculationUsecase(repo Repository, bookRepo books.Repository, userRepo users.Repository) Usecase {
+	return &circulationUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *circulationUsecase) StoreCopy(ctx context.Context, bookCopy *CopyDomain) (CopyDomain, int, error) {
+	if _, err := uc.bookRepo.GetById(ctx, bookCopy.BookId); err != nil {
+		return CopyDomain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	if _, err := uc.repo.GetCopyByBarcode(ctx, bookCopy.Barcode); err == nil {
+		return CopyDomain{}, http.StatusConflict, errors.New("barcode is already registered")
+	}
+
+	holds, err := uc.repo.GetActiveHoldsByBookId(ctx, bookCopy.BookId)
+	if err != nil {
+		return CopyDomain{}, http.StatusInternalServerError, err
+	}
+
+	// the first reader in the queue gets the new copy, the repository fills in its id
+	bookCopy.Status = constants.CopyAvailable
+	result, err := uc.repo.StoreCopy(ctx, bookCopy, nextWaitingHold(holds, 0, time.Now()))
+	if err != nil {
+		return CopyDomain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *circulationUsecase) GetCopiesByBookId(ctx context.Context, bookId int) ([]CopyDomain, int, error) {
+	copies, err := uc.repo.GetCopiesByBookId(ctx, bookId)
+	if err != nil {
+		return []CopyDomain{}, http.StatusInternalServerError, err
+	}
+
+	return copies, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) UpdateCopy(ctx context.Context, bookCopy *CopyDomain, id int) (CopyDomain, int, error) {
+	beforeUpdate, err := uc.repo.GetCopyById(ctx, id)
+	if err != nil {
+		return CopyDomain{}, http.StatusNotFound, errors.New("copy not found")
+	}
+
+	if bookCopy.Barcode != beforeUpdate.Barcode {
+		if _, err := uc.repo.GetCopyByBarcode(ctx, bookCopy.Barcode); err == nil {
+			return CopyDomain{}, http.StatusConflict, errors.New("barcode is already registered")
+		}
+	}
+
+	// a copy can't be moved to another book and its status is only driven by circulation
+	bookCopy.ID = id
+	bookCopy.BookId = beforeUpdate.BookId
+	bookCopy.Status = beforeUpdate.Status
+	if err := uc.repo.UpdateCopy(ctx, bookCopy); err != nil {
+		return CopyDomain{}, http.StatusInternalServerError, err
+	}
+
+	afterUpdate, err := uc.repo.GetCopyById(ctx, id)
+	if err != nil {
+		return CopyDomain{}, http.StatusNotFound, errors.New("copy not found")
+	}
+
+	return afterUpdate, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) DeleteCopy(ctx context.Context, id int) (int, error) {
+	bookCopy, err := uc.repo.GetCopyById(ctx, id)
+	if err != nil {
+		return http.StatusNotFound, errors.New("copy not found")
+	}
+
+	if bookCopy.Status != constants.CopyAvailable {
+		return http.StatusConflict, fmt.Errorf("copy with status %s can't be deleted", bookCopy.Status)
+	}
+
+	if err = uc.repo.DeleteCopy(ctx, id); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) Checkout(ctx context.Context, barcode string, userId int) (LoanDomain, int, error) {
+	bookCopy, err := uc.repo.GetCopyByBarcode(ctx, barcode)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("copy not found")
+	}
+
+	if _, err := uc.userRepo.GetById(ctx, userId); err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("user not found")
+	}
+
+	activeLoans, err := uc.repo.CountActiveLoansByUserId(ctx, userId)
+	if err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+	if activeLoans >= constants.MaxActiveLoans {
+		return LoanDomain{}, http.StatusForbidden, fmt.Errorf("user has reached the maximum of %d active loans", constants.MaxActiveLoans)
+	}
+
+	unpaidFine, err := uc.repo.GetUnpaidFineByUserId(ctx, userId)
+	if err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+	if unpaidFine > 0 {
+		return LoanDomain{}, http.StatusForbidden, fmt.Errorf("user has unpaid fines of %d", unpaidFine)
+	}
+
+	now := time.Now()
+	var hold, nextHold *HoldDomain
+	switch bookCopy.Status {
+	case constants.CopyOnLoan:
+		return LoanDomain{}, http.StatusConflict, errors.New("copy is already on loan")
+	case constants.CopyOnHold:
+		readyHold, err := uc.repo.GetReadyHoldByCopyId(ctx, bookCopy.ID)
+		if err != nil || readyHold.UserId != userId {
+			return LoanDomain{}, http.StatusConflict, errors.New("copy is reserved for another user")
+		}
+		hold = &readyHold
+	default:
+		// fulfil the user's hold on this book, if any. A hold that's ready on another copy is fulfilled by this one
+		// as well, the copy it kept aside goes on to the next reader in the queue
+		holds, err := uc.repo.GetActiveHoldsByBookId(ctx, bookCopy.BookId)
+		if err != nil {
+			return LoanDomain{}, http.StatusInternalServerError, err
+		}
+		for i := range holds {
+			if holds[i].UserId != userId {
+				continue
+			}
+			hold = &holds[i]
+			if hold.Status == constants.HoldReady {
+				nextHold = nextWaitingHold(holds, hold.CopyId, now)
+			}
+			break
+		}
+	}
+
+	loan := LoanDomain{
+		CopyId:       bookCopy.ID,
+		UserId:       userId,
+		CheckedOutAt: now,
+		DueAt:        now.Add(constants.LoanPeriod),
+	}
+
+	result, err := uc.repo.Checkout(ctx, &loan, hold, nextHold)
+	if errors.Is(err, ErrCopyUnavailable) || errors.Is(err, ErrHoldClosed) {
+		return LoanDomain{}, http.StatusConflict, err
+	}
+	if err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *circulationUsecase) Renew(ctx context.Context, loanId, userId int) (LoanDomain, int, error) {
+	loan, err := uc.repo.GetLoanById(ctx, loanId)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("loan not found")
+	}
+
+	if loan.UserId != userId {
+		return LoanDomain{}, http.StatusUnauthorized, errors.New("you don't have access to renew this loan")
+	}
+
+	if loan.ReturnedAt != nil {
+		return LoanDomain{}, http.StatusBadRequest, errors.New("loan has already been returned")
+	}
+
+	now := time.Now()
+	if now.After(loan.DueAt) {
+		return LoanDomain{}, http.StatusBadRequest, errors.New("overdue loan can't be renewed")
+	}
+
+	if loan.Renewals >= constants.MaxRenewals {
+		return LoanDomain{}, http.StatusBadRequest, fmt.Errorf("loan has reached the maximum of %d renewals", constants.MaxRenewals)
+	}
+
+	holds, err := uc.repo.GetActiveHoldsByBookId(ctx, loan.Copy.BookId)
+	if err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+	for _, hold := range holds {
+		if hold.Status == constants.HoldWaiting {
+			return LoanDomain{}, http.StatusConflict, errors.New("book has pending holds")
+		}
+	}
+
+	loan.DueAt = now.Add(constants.LoanPeriod)
+	loan.Renewals++
+	if err := uc.repo.UpdateLoan(ctx, &loan); err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	afterUpdate, err := uc.repo.GetLoanById(ctx, loanId)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("loan not found")
+	}
+
+	return afterUpdate, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) Return(ctx context.Context, barcode string) (LoanDomain, int, error) {
+	bookCopy, err := uc.repo.GetCopyByBarcode(ctx, barcode)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("copy not found")
+	}
+
+	loan, err := uc.repo.GetActiveLoanByCopyId(ctx, bookCopy.ID)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("copy is not on loan")
+	}
+
+	now := time.Now()
+	loan.ReturnedAt = &now
+	loan.Fine = calculateFine(loan.DueAt, now)
+
+	holds, err := uc.repo.GetActiveHoldsByBookId(ctx, bookCopy.BookId)
+	if err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	if err := uc.repo.Return(ctx, &loan, nextWaitingHold(holds, bookCopy.ID, now)); err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	afterReturn, err := uc.repo.GetLoanById(ctx, loan.ID)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("loan not found")
+	}
+
+	return afterReturn, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) GetActiveLoans(ctx context.Context, overdueOnly bool) ([]LoanDomain, int, error) {
+	loans, err := uc.repo.GetActiveLoans(ctx)
+	if err != nil {
+		return []LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	now := time.Now()
+	var result []LoanDomain
+	for _, loan := range loans {
+		if overdueOnly && !now.After(loan.DueAt) {
+			continue
+		}
+		loan.Fine = calculateFine(loan.DueAt, now)
+		result = append(result, loan)
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) GetLoansByUserId(ctx context.Context, userId int) ([]LoanDomain, int, error) {
+	loans, err := uc.repo.GetLoansByUserId(ctx, userId)
+	if err != nil {
+		return []LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	now := time.Now()
+	for i := range loans {
+		// show the fine accrued so far for loans that are still out
+		if loans[i].ReturnedAt == nil {
+			loans[i].Fine = calculateFine(loans[i].DueAt, now)
+		}
+	}
+
+	return loans, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) PayFine(ctx context.Context, loanId int) (LoanDomain, int, error) {
+	loan, err := uc.repo.GetLoanById(ctx, loanId)
+	if err != nil {
+		return LoanDomain{}, http.StatusNotFound, errors.New("loan not found")
+	}
+
+	if loan.ReturnedAt == nil {
+		return LoanDomain{}, http.StatusBadRequest, errors.New("fine can only be paid after the copy is returned")
+	}
+
+	if loan.Fine == 0 || loan.FinePaid {
+		return LoanDomain{}, http.StatusBadRequest, errors.New("loan has no outstanding fine")
+	}
+
+	loan.FinePaid = true
+	if err := uc.repo.UpdateLoan(ctx, &loan); err != nil {
+		return LoanDomain{}, http.StatusInternalServerError, err
+	}
+
+	return loan, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) PlaceHold(ctx context.Context, bookId, userId int) (HoldDomain, int, error) {
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return HoldDomain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	holds, err := uc.repo.GetActiveHoldsByBookId(ctx, bookId)
+	if err != nil {
+		return HoldDomain{}, http.StatusInternalServerError, err
+	}
+	for _, hold := range holds {
+		if hold.UserId == userId {
+			return HoldDomain{}, http.StatusConflict, errors.New("user already has an active hold on this book")
+		}
+	}
+
+	loans, err := uc.repo.GetLoansByUserId(ctx, userId)
+	if err != nil {
+		return HoldDomain{}, http.StatusInternalServerError, err
+	}
+	for _, loan := range loans {
+		if loan.ReturnedAt == nil && loan.Copy.BookId == bookId {
+			return HoldDomain{}, http.StatusConflict, errors.New("user is currently borrowing this book")
+		}
+	}
+
+	hold := HoldDomain{
+		BookId: bookId,
+		UserId: userId,
+		Status: constants.HoldWaiting,
+	}
+
+	// reserve a copy straight away when one is on the shelf and nobody is queueing
+	if len(holds) == 0 {
+		copies, err := uc.repo.GetCopiesByBookId(ctx, bookId)
+		if err != nil {
+			return HoldDomain{}, http.StatusInternalServerError, err
+		}
+		for _, bookCopy := range copies {
+			if bookCopy.Status == constants.CopyAvailable {
+				expiresAt := time.Now().Add(constants.HoldPickupPeriod)
+				hold.CopyId = bookCopy.ID
+				hold.Status = constants.HoldReady
+				hold.ExpiresAt = &expiresAt
+				break
+			}
+		}
+	}
+
+	result, err := uc.repo.StoreHold(ctx, &hold)
+	if errors.Is(err, ErrCopyUnavailable) {
+		return HoldDomain{}, http.StatusConflict, err
+	}
+	if err != nil {
+		return HoldDomain{}, http.StatusInternalServerError, err
+	}
+	result.Position = len(holds) + 1
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *circulationUsecase) CancelHold(ctx context.Context, holdId, userId int) (int, error) {
+	hold, err := uc.repo.GetHoldById(ctx, holdId)
+	if err != nil {
+		return http.StatusNotFound, errors.New("hold not found")
+	}
+
+	if hold.UserId != userId {
+		return http.StatusUnauthorized, errors.New("you don't have access to cancel this hold")
+	}
+
+	if hold.Status != constants.HoldWaiting && hold.Status != constants.HoldReady {
+		return http.StatusBadRequest, errors.New("hold is no longer active")
+	}
+
+	var nextHold *HoldDomain
+	if hold.Status == constants.HoldReady {
+		holds, err := uc.repo.GetActiveHoldsByBookId(ctx, hold.BookId)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		nextHold = nextWaitingHold(holds, hold.CopyId, time.Now())
+	}
+
+	err = uc.repo.CancelHold(ctx, &hold, nextHold)
+	if errors.Is(err, ErrHoldClosed) {
+		return http.StatusBadRequest, err
+	}
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) GetHoldsByBookId(ctx context.Context, bookId int) ([]HoldDomain, int, error) {
+	holds, err := uc.repo.GetActiveHoldsByBookId(ctx, bookId)
+	if err != nil {
+		return []HoldDomain{}, http.StatusInternalServerError, err
+	}
+
+	for i := range holds {
+		holds[i].Position = i + 1
+	}
+
+	return holds, http.StatusOK, nil
+}
+
+func (uc *circulationUsecase) GetHoldsByUserId(ctx context.Context, userId int) ([]HoldDomain, int, error) {
+	holds, err := uc.repo.GetHoldsByUserId(ctx, userId)
+	if err != nil {
+		return []HoldDomain{}, http.StatusInternalServerError, err
+	}
+
+	for i := range holds {
+		if holds[i].Status != constants.HoldWaiting && holds[i].Status != constants.HoldReady {
+			continue
+		}
+
+		queue, err := uc.repo.GetActiveHoldsByBookId(ctx, holds[i].BookId)
+		if err != nil {
+			return []HoldDomain{}, http.StatusInternalServerError, err
+		}
+		for index, val := range queue {
+			if val.ID == holds[i].ID {
+				holds[i].Position = index + 1
+				break
+			}
+		}
+	}
+
+	return holds, http.StatusOK, nil
+}
+
+// ExpireHolds ends the ready holds nobody picked up in time, their copies go on to the next reader in the queue
+func (uc *circulationUsecase) ExpireHolds(ctx context.Context) (int, error) {
+	now := time.Now()
+	holds, err := uc.repo.GetExpiredHolds(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range holds {
+		queue, err := uc.repo.GetActiveHoldsByBookId(ctx, holds[i].BookId)
+		if err != nil {
+			return expired, err
+		}
+
+		err = uc.repo.ExpireHold(ctx, &holds[i], nextWaitingHold(queue, holds[i].CopyId, now))
+		if errors.Is(err, ErrHoldClosed) {
+			// picked up or cancelled since it was read
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// nextWaitingHold picks the first waiting hold in the queue and marks it ready for pickup of the given copy
+func nextWaitingHold(holds []HoldDomain, copyId int, now time.Time) *HoldDomain {
+	for i := range holds {
+		if holds[i].Status != constants.HoldWaiting {
+			continue
+		}
+
+		expiresAt := now.Add(constants.HoldPickupPeriod)
+		holds[i].CopyId = copyId
+		holds[i].Status = constants.HoldReady
+		holds[i].ExpiresAt = &expiresAt
+		return &holds[i]
+	}
+
+	return nil
+}
+
+func calculateFine(dueAt, at time.Time) int {
+	if !at.After(dueAt) {
+		return 0
+	}
+
+	// every started day past the due date counts
+	daysLate := int(at.Sub(dueAt).Hours()/24) + 1
+	fine := daysLate * constants.FinePerDay
+	if fine > constants.MaxFine {
+		return constants.MaxFine
+	}
+
+	return fine
+}
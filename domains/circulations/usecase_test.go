@@ -0,0 +1,576 @@
+package circulations_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	circulationMocks "github.com/snykk/golib_backend/datasources/databases/circulations/mocks"
+	userMocks "github.com/snykk/golib_backend/datasources/databases/users/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"github.com/snykk/golib_backend/domains/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	circulationRepository *circulationMocks.Repository
+	bookRepository        *bookMocks.Repository
+	userRepository        *userMocks.Repository
+	circulationUsecase    circulations.Usecase
+	bookFromDB            books.Domain
+	userFromDB            users.Domain
+	copyFromDB            circulations.CopyDomain
+	loanFromDB            circulations.LoanDomain
+	holdFromDB            circulations.HoldDomain
+)
+
+func setup(t *testing.T) {
+	circulationRepository = circulationMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	userRepository = userMocks.NewRepository(t)
+	circulationUsecase = circulations.NewCirculationUsecase(circulationRepository, bookRepository, userRepository)
+
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "1111111111111",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+	userFromDB = users.Domain{
+		ID:          1,
+		FullName:    "patrick star",
+		Username:    "itsmepatrick",
+		Email:       "najibfikri13@gmail.com",
+		Password:    "11111",
+		Role:        "user",
+		Gender:      "male",
+		IsActivated: true,
+	}
+	copyFromDB = circulations.CopyDomain{
+		ID:        1,
+		BookId:    bookFromDB.ID,
+		Book:      bookFromDB,
+		Barcode:   "GLB-000001",
+		Condition: "good",
+		Location:  "Shelf A1",
+		Status:    constants.CopyAvailable,
+		CreatedAt: time.Now(),
+	}
+	loanFromDB = circulations.LoanDomain{
+		ID:           1,
+		CopyId:       copyFromDB.ID,
+		Copy:         copyFromDB,
+		UserId:       userFromDB.ID,
+		User:         userFromDB,
+		CheckedOutAt: time.Now(),
+		DueAt:        time.Now().Add(constants.LoanPeriod),
+		CreatedAt:    time.Now(),
+	}
+	holdFromDB = circulations.HoldDomain{
+		ID:        1,
+		BookId:    bookFromDB.ID,
+		Book:      bookFromDB,
+		UserId:    2,
+		Status:    constants.HoldWaiting,
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestStoreCopy(t *testing.T) {
+	setup(t)
+	req := circulations.CopyDomain{
+		BookId:    bookFromDB.ID,
+		Barcode:   "GLB-000001",
+		Condition: "good",
+		Location:  "Shelf A1",
+	}
+	t.Run("When Success Store Copy", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, req.Barcode).Return(circulations.CopyDomain{}, errors.New("record not found")).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+		circulationRepository.Mock.On("StoreCopy", mock.Anything, mock.AnythingOfType("*circulations.CopyDomain"), (*circulations.HoldDomain)(nil)).Return(copyFromDB, nil).Once()
+
+		result, statusCode, err := circulationUsecase.StoreCopy(context.Background(), &req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, copyFromDB, result)
+		assert.Equal(t, constants.CopyAvailable, req.Status)
+	})
+	t.Run("When Success Store Copy For Waiting Hold", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, req.Barcode).Return(circulations.CopyDomain{}, errors.New("record not found")).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{holdFromDB}, nil).Once()
+		circulationRepository.Mock.On("StoreCopy", mock.Anything, mock.AnythingOfType("*circulations.CopyDomain"), mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+			return hold.ID == holdFromDB.ID && hold.Status == constants.HoldReady && hold.ExpiresAt != nil
+		})).Return(copyFromDB, nil).Once()
+
+		_, statusCode, err := circulationUsecase.StoreCopy(context.Background(), &req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Book doesn't exist", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(books.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := circulationUsecase.StoreCopy(context.Background(), &req)
+
+			assert.Equal(t, errors.New("book not found"), err)
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Barcode already registered", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, req.Barcode).Return(copyFromDB, nil).Once()
+
+			_, statusCode, err := circulationUsecase.StoreCopy(context.Background(), &req)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestDeleteCopy(t *testing.T) {
+	setup(t)
+	t.Run("When Success Delete Copy", func(t *testing.T) {
+		circulationRepository.Mock.On("GetCopyById", mock.Anything, copyFromDB.ID).Return(copyFromDB, nil).Once()
+		circulationRepository.Mock.On("DeleteCopy", mock.Anything, copyFromDB.ID).Return(nil).Once()
+
+		statusCode, err := circulationUsecase.DeleteCopy(context.Background(), copyFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure Copy On Loan", func(t *testing.T) {
+		onLoan := copyFromDB
+		onLoan.Status = constants.CopyOnLoan
+		circulationRepository.Mock.On("GetCopyById", mock.Anything, copyFromDB.ID).Return(onLoan, nil).Once()
+
+		statusCode, err := circulationUsecase.DeleteCopy(context.Background(), copyFromDB.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestCheckout(t *testing.T) {
+	setup(t)
+	t.Run("When Success Checkout", func(t *testing.T) {
+		t.Run("Available copy", func(t *testing.T) {
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+			circulationRepository.Mock.On("Checkout", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain"), (*circulations.HoldDomain)(nil), (*circulations.HoldDomain)(nil)).Return(loanFromDB, nil).Once()
+
+			result, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusCreated, statusCode)
+			assert.Equal(t, loanFromDB, result)
+		})
+		t.Run("Copy on hold for the user", func(t *testing.T) {
+			onHold := copyFromDB
+			onHold.Status = constants.CopyOnHold
+			readyHold := holdFromDB
+			readyHold.UserId = userFromDB.ID
+			readyHold.Status = constants.HoldReady
+			readyHold.CopyId = copyFromDB.ID
+
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(onHold, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetReadyHoldByCopyId", mock.Anything, copyFromDB.ID).Return(readyHold, nil).Once()
+			circulationRepository.Mock.On("Checkout", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain"), &readyHold, (*circulations.HoldDomain)(nil)).Return(loanFromDB, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusCreated, statusCode)
+		})
+		t.Run("Another copy of a book held for the user", func(t *testing.T) {
+			readyHold := holdFromDB
+			readyHold.UserId = userFromDB.ID
+			readyHold.Status = constants.HoldReady
+			readyHold.CopyId = copyFromDB.ID + 1
+			nextHold := holdFromDB
+			nextHold.ID = readyHold.ID + 1
+			nextHold.UserId = userFromDB.ID + 1
+			nextHold.Status = constants.HoldWaiting
+
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{readyHold, nextHold}, nil).Once()
+			circulationRepository.Mock.On("Checkout", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain"), mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+				return hold.ID == readyHold.ID && hold.Status == constants.HoldReady && hold.CopyId == readyHold.CopyId
+			}), mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+				return hold.ID == nextHold.ID && hold.Status == constants.HoldReady && hold.CopyId == readyHold.CopyId && hold.ExpiresAt != nil
+			})).Return(loanFromDB, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusCreated, statusCode)
+		})
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Copy already on loan", func(t *testing.T) {
+			onLoan := copyFromDB
+			onLoan.Status = constants.CopyOnLoan
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(onLoan, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.Equal(t, errors.New("copy is already on loan"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Copy claimed by a concurrent checkout", func(t *testing.T) {
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+			circulationRepository.Mock.On("Checkout", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain"), (*circulations.HoldDomain)(nil), (*circulations.HoldDomain)(nil)).Return(circulations.LoanDomain{}, circulations.ErrCopyUnavailable).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.Equal(t, circulations.ErrCopyUnavailable, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Copy reserved for another user", func(t *testing.T) {
+			onHold := copyFromDB
+			onHold.Status = constants.CopyOnHold
+			readyHold := holdFromDB
+			readyHold.Status = constants.HoldReady
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(onHold, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetReadyHoldByCopyId", mock.Anything, copyFromDB.ID).Return(readyHold, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.Equal(t, errors.New("copy is reserved for another user"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Loan limit reached", func(t *testing.T) {
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(constants.MaxActiveLoans, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusForbidden, statusCode)
+		})
+		t.Run("Unpaid fines", func(t *testing.T) {
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+			circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+			circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(2000, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Checkout(context.Background(), copyFromDB.Barcode, userFromDB.ID)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusForbidden, statusCode)
+		})
+	})
+}
+
+func TestRenew(t *testing.T) {
+	setup(t)
+	t.Run("When Success Renew", func(t *testing.T) {
+		renewed := loanFromDB
+		renewed.Renewals = 1
+		circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(loanFromDB, nil).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+		circulationRepository.Mock.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain")).Return(nil).Once()
+		circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(renewed, nil).Once()
+
+		result, statusCode, err := circulationUsecase.Renew(context.Background(), loanFromDB.ID, userFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, result.Renewals)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Not the borrower", func(t *testing.T) {
+			circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(loanFromDB, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Renew(context.Background(), loanFromDB.ID, 99)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusUnauthorized, statusCode)
+		})
+		t.Run("Renewal limit reached", func(t *testing.T) {
+			maxed := loanFromDB
+			maxed.Renewals = constants.MaxRenewals
+			circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(maxed, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Renew(context.Background(), loanFromDB.ID, userFromDB.ID)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Book has pending holds", func(t *testing.T) {
+			circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(loanFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{holdFromDB}, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Renew(context.Background(), loanFromDB.ID, userFromDB.ID)
+
+			assert.Equal(t, errors.New("book has pending holds"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestReturn(t *testing.T) {
+	setup(t)
+	t.Run("When Success Return", func(t *testing.T) {
+		t.Run("Overdue loan with waiting hold", func(t *testing.T) {
+			overdue := loanFromDB
+			overdue.DueAt = time.Now().Add(-3*24*time.Hour - time.Hour)
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveLoanByCopyId", mock.Anything, copyFromDB.ID).Return(overdue, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{holdFromDB}, nil).Once()
+			circulationRepository.Mock.On("Return", mock.Anything, mock.MatchedBy(func(loan *circulations.LoanDomain) bool {
+				return loan.ReturnedAt != nil && loan.Fine == 4*constants.FinePerDay
+			}), mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+				return hold.ID == holdFromDB.ID && hold.Status == constants.HoldReady && hold.CopyId == copyFromDB.ID
+			})).Return(nil).Once()
+			circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(loanFromDB, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Return(context.Background(), copyFromDB.Barcode)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+		})
+		t.Run("On time without holds", func(t *testing.T) {
+			circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveLoanByCopyId", mock.Anything, copyFromDB.ID).Return(loanFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+			circulationRepository.Mock.On("Return", mock.Anything, mock.MatchedBy(func(loan *circulations.LoanDomain) bool {
+				return loan.Fine == 0
+			}), (*circulations.HoldDomain)(nil)).Return(nil).Once()
+			circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(loanFromDB, nil).Once()
+
+			_, statusCode, err := circulationUsecase.Return(context.Background(), copyFromDB.Barcode)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+		})
+	})
+	t.Run("When Failure Copy Not On Loan", func(t *testing.T) {
+		circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+		circulationRepository.Mock.On("GetActiveLoanByCopyId", mock.Anything, copyFromDB.ID).Return(circulations.LoanDomain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := circulationUsecase.Return(context.Background(), copyFromDB.Barcode)
+
+		assert.Equal(t, errors.New("copy is not on loan"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestPayFine(t *testing.T) {
+	setup(t)
+	t.Run("When Success Pay Fine", func(t *testing.T) {
+		returnedAt := time.Now()
+		returned := loanFromDB
+		returned.ReturnedAt = &returnedAt
+		returned.Fine = 3000
+		circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(returned, nil).Once()
+		circulationRepository.Mock.On("UpdateLoan", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain")).Return(nil).Once()
+
+		result, statusCode, err := circulationUsecase.PayFine(context.Background(), loanFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.True(t, result.FinePaid)
+	})
+	t.Run("When Failure No Outstanding Fine", func(t *testing.T) {
+		returnedAt := time.Now()
+		returned := loanFromDB
+		returned.ReturnedAt = &returnedAt
+		circulationRepository.Mock.On("GetLoanById", mock.Anything, loanFromDB.ID).Return(returned, nil).Once()
+
+		_, statusCode, err := circulationUsecase.PayFine(context.Background(), loanFromDB.ID)
+
+		assert.Equal(t, errors.New("loan has no outstanding fine"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestPlaceHold(t *testing.T) {
+	setup(t)
+	t.Run("When Success Place Hold", func(t *testing.T) {
+		t.Run("Copy available", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+			circulationRepository.Mock.On("GetLoansByUserId", mock.Anything, userFromDB.ID).Return([]circulations.LoanDomain{}, nil).Once()
+			circulationRepository.Mock.On("GetCopiesByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.CopyDomain{copyFromDB}, nil).Once()
+			circulationRepository.Mock.On("StoreHold", mock.Anything, mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+				return hold.Status == constants.HoldReady && hold.CopyId == copyFromDB.ID && hold.ExpiresAt != nil
+			})).Return(holdFromDB, nil).Once()
+
+			result, statusCode, err := circulationUsecase.PlaceHold(context.Background(), bookFromDB.ID, userFromDB.ID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusCreated, statusCode)
+			assert.Equal(t, 1, result.Position)
+		})
+		t.Run("Queue behind other readers", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{holdFromDB}, nil).Once()
+			circulationRepository.Mock.On("GetLoansByUserId", mock.Anything, userFromDB.ID).Return([]circulations.LoanDomain{}, nil).Once()
+			circulationRepository.Mock.On("StoreHold", mock.Anything, mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+				return hold.Status == constants.HoldWaiting
+			})).Return(holdFromDB, nil).Once()
+
+			result, statusCode, err := circulationUsecase.PlaceHold(context.Background(), bookFromDB.ID, userFromDB.ID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusCreated, statusCode)
+			assert.Equal(t, 2, result.Position)
+		})
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Already holding the book", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{holdFromDB}, nil).Once()
+
+			_, statusCode, err := circulationUsecase.PlaceHold(context.Background(), bookFromDB.ID, holdFromDB.UserId)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Currently borrowing the book", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+			circulationRepository.Mock.On("GetLoansByUserId", mock.Anything, userFromDB.ID).Return([]circulations.LoanDomain{loanFromDB}, nil).Once()
+
+			_, statusCode, err := circulationUsecase.PlaceHold(context.Background(), bookFromDB.ID, userFromDB.ID)
+
+			assert.Equal(t, errors.New("user is currently borrowing this book"), err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Copy claimed by a checkout first", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+			circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+			circulationRepository.Mock.On("GetLoansByUserId", mock.Anything, userFromDB.ID).Return([]circulations.LoanDomain{}, nil).Once()
+			circulationRepository.Mock.On("GetCopiesByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.CopyDomain{copyFromDB}, nil).Once()
+			circulationRepository.Mock.On("StoreHold", mock.Anything, mock.AnythingOfType("*circulations.HoldDomain")).Return(circulations.HoldDomain{}, circulations.ErrCopyUnavailable).Once()
+
+			_, statusCode, err := circulationUsecase.PlaceHold(context.Background(), bookFromDB.ID, userFromDB.ID)
+
+			assert.Equal(t, circulations.ErrCopyUnavailable, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestCancelHold(t *testing.T) {
+	setup(t)
+	t.Run("When Success Cancel Ready Hold", func(t *testing.T) {
+		readyHold := holdFromDB
+		readyHold.Status = constants.HoldReady
+		readyHold.CopyId = copyFromDB.ID
+		nextHold := holdFromDB
+		nextHold.ID = 2
+		nextHold.UserId = 3
+
+		circulationRepository.Mock.On("GetHoldById", mock.Anything, readyHold.ID).Return(readyHold, nil).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{readyHold, nextHold}, nil).Once()
+		circulationRepository.Mock.On("CancelHold", mock.Anything, mock.AnythingOfType("*circulations.HoldDomain"), mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+			return hold.ID == nextHold.ID && hold.Status == constants.HoldReady && hold.CopyId == copyFromDB.ID
+		})).Return(nil).Once()
+
+		statusCode, err := circulationUsecase.CancelHold(context.Background(), readyHold.ID, readyHold.UserId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure Not The Owner", func(t *testing.T) {
+		circulationRepository.Mock.On("GetHoldById", mock.Anything, holdFromDB.ID).Return(holdFromDB, nil).Once()
+
+		statusCode, err := circulationUsecase.CancelHold(context.Background(), holdFromDB.ID, userFromDB.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+	})
+	t.Run("When Failure Hold Closed Meanwhile", func(t *testing.T) {
+		circulationRepository.Mock.On("GetHoldById", mock.Anything, holdFromDB.ID).Return(holdFromDB, nil).Once()
+		circulationRepository.Mock.On("CancelHold", mock.Anything, mock.AnythingOfType("*circulations.HoldDomain"), (*circulations.HoldDomain)(nil)).Return(circulations.ErrHoldClosed).Once()
+
+		statusCode, err := circulationUsecase.CancelHold(context.Background(), holdFromDB.ID, holdFromDB.UserId)
+
+		assert.Equal(t, circulations.ErrHoldClosed, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestExpireHolds(t *testing.T) {
+	setup(t)
+	t.Run("When Success Expire Holds", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		expiredHold := holdFromDB
+		expiredHold.Status = constants.HoldReady
+		expiredHold.CopyId = copyFromDB.ID
+		expiredHold.ExpiresAt = &expiresAt
+		pickedUpHold := expiredHold
+		pickedUpHold.ID = 3
+		waitingHold := holdFromDB
+		waitingHold.ID = 2
+		waitingHold.UserId = 3
+
+		circulationRepository.Mock.On("GetExpiredHolds", mock.Anything, mock.AnythingOfType("time.Time")).Return([]circulations.HoldDomain{expiredHold, pickedUpHold}, nil).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{expiredHold, waitingHold}, nil).Once()
+		circulationRepository.Mock.On("ExpireHold", mock.Anything, mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+			return hold.ID == expiredHold.ID
+		}), mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+			return hold.ID == waitingHold.ID && hold.Status == constants.HoldReady && hold.CopyId == copyFromDB.ID
+		})).Return(nil).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{pickedUpHold}, nil).Once()
+		circulationRepository.Mock.On("ExpireHold", mock.Anything, mock.MatchedBy(func(hold *circulations.HoldDomain) bool {
+			return hold.ID == pickedUpHold.ID
+		}), (*circulations.HoldDomain)(nil)).Return(circulations.ErrHoldClosed).Once()
+
+		expired, err := circulationUsecase.ExpireHolds(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, expired)
+	})
+}
+
+func TestGetHoldsByBookId(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Queue", func(t *testing.T) {
+		secondHold := holdFromDB
+		secondHold.ID = 2
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{holdFromDB, secondHold}, nil).Once()
+
+		result, statusCode, err := circulationUsecase.GetHoldsByBookId(context.Background(), bookFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, result[0].Position)
+		assert.Equal(t, 2, result[1].Position)
+	})
+}
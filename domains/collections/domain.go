@@ -0,0 +1,60 @@
+package collections
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/rankings"
+)
+
+// Domain is a featured collection curated by the admins, it shows up on the home screen while now is
+// within its window, a missing bound leaves that side of the window open
+type Domain struct {
+	ID          int
+	Title       string
+	Description string
+	Position    int
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	BookIds     []int
+	Books       []books.Domain
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (d *Domain) IsActive(now time.Time) bool {
+	if d.StartsAt != nil && now.Before(*d.StartsAt) {
+		return false
+	}
+	if d.EndsAt != nil && !now.Before(*d.EndsAt) {
+		return false
+	}
+
+	return true
+}
+
+// Home is everything the home screen shows
+type Home struct {
+	Collections []Domain
+	Newest      []books.Domain
+	TopRated    []rankings.Ranking
+}
+
+type Usecase interface {
+	GetAll(ctx context.Context) (domains []Domain, statusCode int, err error)
+	GetById(ctx context.Context, id int) (domain Domain, statusCode int, err error)
+	Store(ctx context.Context, collection *Domain) (domain Domain, statusCode int, err error)
+	Update(ctx context.Context, id int, collection *Domain) (domain Domain, statusCode int, err error)
+	Delete(ctx context.Context, id int) (statusCode int, err error)
+	GetHome(ctx context.Context) (home Home, statusCode int, err error)
+}
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]Domain, error)
+	GetActive(ctx context.Context, now time.Time) ([]Domain, error)
+	GetById(ctx context.Context, id int) (Domain, error)
+	Store(ctx context.Context, domain *Domain) (Domain, error)
+	Update(ctx context.Context, domain *Domain) error
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,174 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/rankings"
+)
+
+type collectionUsecase struct {
+	repo        Repository
+	bookRepo    books.Repository
+	rankingRepo rankings.Repository
+}
+
+func NewCollectionUsecase(repo Repository, bookRepo books.Repository, rankingRepo rankings.Repository) Usecase {
+	return &collectionUsecase{
+		repo:        repo,
+		bookRepo:    bookRepo,
+		rankingRepo: rankingRepo,
+	}
+}
+
+func (uc *collectionUsecase) GetAll(ctx context.Context) ([]Domain, int, error) {
+	collections, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return []Domain{}, http.StatusInternalServerError, err
+	}
+
+	return collections, http.StatusOK, nil
+}
+
+func (uc *collectionUsecase) GetById(ctx context.Context, id int) (Domain, int, error) {
+	collection, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("collection not found")
+	}
+
+	return collection, http.StatusOK, nil
+}
+
+func (uc *collectionUsecase) Store(ctx context.Context, collection *Domain) (Domain, int, error) {
+	if statusCode, err := uc.validate(ctx, collection); err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	result, err := uc.repo.Store(ctx, collection)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *collectionUsecase) Update(ctx context.Context, id int, collection *Domain) (Domain, int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("collection not found")
+	}
+
+	if statusCode, err := uc.validate(ctx, collection); err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	collection.ID = id
+	if err := uc.repo.Update(ctx, collection); err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *collectionUsecase) Delete(ctx context.Context, id int) (int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return http.StatusNotFound, errors.New("collection not found")
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *collectionUsecase) GetHome(ctx context.Context) (Home, int, error) {
+	active, err := uc.repo.GetActive(ctx, time.Now())
+	if err != nil {
+		return Home{}, http.StatusInternalServerError, err
+	}
+
+	// a collection whose books all went to the trash has nothing left to show
+	home := Home{Collections: make([]Domain, 0, len(active))}
+	for _, collection := range active {
+		if len(collection.Books) > 0 {
+			home.Collections = append(home.Collections, collection)
+		}
+	}
+
+	home.Newest, _, err = uc.bookRepo.GetAll(ctx, &books.Query{
+		Page:  1,
+		Limit: constants.HomeNewestLimit,
+		Sort:  "created_at",
+		Order: "desc",
+	})
+	if err != nil {
+		return Home{}, http.StatusInternalServerError, err
+	}
+
+	home.TopRated, err = uc.rankingRepo.GetByKind(ctx, constants.RankingTop, constants.HomeTopRatedLimit)
+	if err != nil {
+		return Home{}, http.StatusInternalServerError, err
+	}
+
+	return home, http.StatusOK, nil
+}
+
+// validate tidies up the collection and checks every book it features exists
+func (uc *collectionUsecase) validate(ctx context.Context, collection *Domain) (int, error) {
+	collection.Title = strings.TrimSpace(collection.Title)
+	collection.Description = strings.TrimSpace(collection.Description)
+
+	if collection.Title == "" {
+		return http.StatusBadRequest, errors.New("title can't be empty")
+	}
+	if utf8.RuneCountInString(collection.Title) > constants.MaxCollectionTitleLength {
+		return http.StatusBadRequest, fmt.Errorf("title can't be longer than %d characters", constants.MaxCollectionTitleLength)
+	}
+	if utf8.RuneCountInString(collection.Description) > constants.MaxCollectionDescriptionLength {
+		return http.StatusBadRequest, fmt.Errorf("description can't be longer than %d characters", constants.MaxCollectionDescriptionLength)
+	}
+	if collection.StartsAt != nil && collection.EndsAt != nil && !collection.EndsAt.After(*collection.StartsAt) {
+		return http.StatusBadRequest, errors.New("ends_at must be after starts_at")
+	}
+	if len(collection.BookIds) == 0 {
+		return http.StatusBadRequest, errors.New("a collection needs at least one book")
+	}
+	if len(collection.BookIds) > constants.MaxCollectionBooks {
+		return http.StatusBadRequest, fmt.Errorf("a collection features at most %d books", constants.MaxCollectionBooks)
+	}
+
+	seen := make(map[int]bool, len(collection.BookIds))
+	for _, id := range collection.BookIds {
+		if seen[id] {
+			return http.StatusBadRequest, fmt.Errorf("book with id %d is listed more than once", id)
+		}
+		seen[id] = true
+	}
+
+	found, err := uc.bookRepo.GetByIds(ctx, collection.BookIds)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	for _, book := range found {
+		delete(seen, book.ID)
+	}
+	for _, id := range collection.BookIds {
+		if seen[id] {
+			return http.StatusNotFound, fmt.Errorf("book with id %d not found", id)
+		}
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,183 @@
+package collections_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	collectionMocks "github.com/snykk/golib_backend/datasources/databases/collections/mocks"
+	rankingMocks "github.com/snykk/golib_backend/datasources/databases/rankings/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/collections"
+	"github.com/snykk/golib_backend/domains/rankings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	collectionRepository *collectionMocks.Repository
+	bookRepository       *bookMocks.Repository
+	rankingRepository    *rankingMocks.Repository
+	collectionUsecase    collections.Usecase
+	collectionFromDB     collections.Domain
+	booksFromDB          []books.Domain
+)
+
+func setup(t *testing.T) {
+	collectionRepository = collectionMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	rankingRepository = rankingMocks.NewRepository(t)
+	collectionUsecase = collections.NewCollectionUsecase(collectionRepository, bookRepository, rankingRepository)
+
+	booksFromDB = []books.Domain{
+		{
+			ID:        1,
+			Title:     "Laskar Pelangi",
+			Author:    "Andrea Hirata",
+			Publisher: "Bentang Pustaka",
+			ISBN:      "9789793062792",
+			CreatedAt: time.Now(),
+		},
+		{
+			ID:        2,
+			Title:     "Atomic Habits",
+			Author:    "James Clear",
+			Publisher: "Gramedia",
+			ISBN:      "9780735211292",
+			CreatedAt: time.Now(),
+		},
+	}
+
+	collectionFromDB = collections.Domain{
+		ID:        1,
+		Title:     "Staff picks",
+		Position:  1,
+		BookIds:   []int{2, 1},
+		Books:     []books.Domain{booksFromDB[1], booksFromDB[0]},
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestIsActive(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	t.Run("When Window Is Open", func(t *testing.T) {
+		assert.True(t, (&collections.Domain{}).IsActive(now))
+		assert.True(t, (&collections.Domain{StartsAt: &before, EndsAt: &after}).IsActive(now))
+	})
+	t.Run("When Window Hasn't Opened Or Is Over", func(t *testing.T) {
+		assert.False(t, (&collections.Domain{StartsAt: &after}).IsActive(now))
+		assert.False(t, (&collections.Domain{EndsAt: &now}).IsActive(now))
+	})
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	t.Run("When Success Store Collection", func(t *testing.T) {
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{2, 1}).Return(booksFromDB, nil).Once()
+		collectionRepository.Mock.On("Store", mock.Anything, &collections.Domain{Title: "Staff picks", Position: 1, BookIds: []int{2, 1}}).Return(collectionFromDB, nil).Once()
+
+		result, statusCode, err := collectionUsecase.Store(context.Background(), &collections.Domain{Title: " Staff picks ", Position: 1, BookIds: []int{2, 1}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, collectionFromDB, result)
+	})
+
+	t.Run("When Failure Unknown Book", func(t *testing.T) {
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{2, 9}).Return([]books.Domain{booksFromDB[1]}, nil).Once()
+
+		_, statusCode, err := collectionUsecase.Store(context.Background(), &collections.Domain{Title: "Staff picks", BookIds: []int{2, 9}})
+
+		assert.Equal(t, errors.New("book with id 9 not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+
+	t.Run("When Failure Duplicate Book", func(t *testing.T) {
+		_, statusCode, err := collectionUsecase.Store(context.Background(), &collections.Domain{Title: "Staff picks", BookIds: []int{2, 2}})
+
+		assert.Equal(t, errors.New("book with id 2 is listed more than once"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Window Ends Before It Starts", func(t *testing.T) {
+		startsAt := time.Now()
+		endsAt := startsAt.Add(-time.Hour)
+
+		_, statusCode, err := collectionUsecase.Store(context.Background(), &collections.Domain{Title: "Ramadan reads", StartsAt: &startsAt, EndsAt: &endsAt, BookIds: []int{1}})
+
+		assert.Equal(t, errors.New("ends_at must be after starts_at"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	setup(t)
+	t.Run("When Success Update Collection", func(t *testing.T) {
+		collectionRepository.Mock.On("GetById", mock.Anything, 1).Return(collectionFromDB, nil).Once()
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{1}).Return(booksFromDB[:1], nil).Once()
+		collectionRepository.Mock.On("Update", mock.Anything, &collections.Domain{ID: 1, Title: "Staff picks", BookIds: []int{1}}).Return(nil).Once()
+		collectionRepository.Mock.On("GetById", mock.Anything, 1).Return(collectionFromDB, nil).Once()
+
+		_, statusCode, err := collectionUsecase.Update(context.Background(), 1, &collections.Domain{Title: "Staff picks", BookIds: []int{1}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Collection Doesn't Exist", func(t *testing.T) {
+		collectionRepository.Mock.On("GetById", mock.Anything, 9).Return(collections.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := collectionUsecase.Update(context.Background(), 9, &collections.Domain{Title: "Staff picks", BookIds: []int{1}})
+
+		assert.Equal(t, errors.New("collection not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	setup(t)
+	t.Run("When Success Delete Collection", func(t *testing.T) {
+		collectionRepository.Mock.On("GetById", mock.Anything, 1).Return(collectionFromDB, nil).Once()
+		collectionRepository.Mock.On("Delete", mock.Anything, 1).Return(nil).Once()
+
+		statusCode, err := collectionUsecase.Delete(context.Background(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+}
+
+func TestGetHome(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Home", func(t *testing.T) {
+		emptied := collections.Domain{ID: 2, Title: "Gone to the trash"}
+		topRated := []rankings.Ranking{{Kind: constants.RankingTop, BookId: 1, Book: booksFromDB[0], Score: 8.4, Rank: 1}}
+		collectionRepository.Mock.On("GetActive", mock.Anything, mock.AnythingOfType("time.Time")).Return([]collections.Domain{collectionFromDB, emptied}, nil).Once()
+		bookRepository.Mock.On("GetAll", mock.Anything, &books.Query{Page: 1, Limit: constants.HomeNewestLimit, Sort: "created_at", Order: "desc"}).Return(booksFromDB, 2, nil).Once()
+		rankingRepository.Mock.On("GetByKind", mock.Anything, constants.RankingTop, constants.HomeTopRatedLimit).Return(topRated, nil).Once()
+
+		home, statusCode, err := collectionUsecase.GetHome(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []collections.Domain{collectionFromDB}, home.Collections)
+		assert.Equal(t, booksFromDB, home.Newest)
+		assert.Equal(t, topRated, home.TopRated)
+	})
+
+	t.Run("When Failure Get Active Collections", func(t *testing.T) {
+		collectionRepository.Mock.On("GetActive", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, errors.New("connection refused")).Once()
+
+		_, statusCode, err := collectionUsecase.GetHome(context.Background())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+	})
+}
@@ -0,0 +1,49 @@
+package feeds
+
+import (
+	"context"
+
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/reviews"
+)
+
+// Page is the position of a feed document within the whole feed
+type Page struct {
+	Page  int
+	Limit int
+	Total int
+}
+
+// LastPage is never below 1 so an empty feed still links to itself
+func (p Page) LastPage() int {
+	if p.Total <= p.Limit {
+		return 1
+	}
+
+	return (p.Total + p.Limit - 1) / p.Limit
+}
+
+func (p Page) HasNext() bool {
+	return p.Page < p.LastPage()
+}
+
+func (p Page) HasPrevious() bool {
+	return p.Page > 1
+}
+
+type BookFeed struct {
+	Page
+	Sort  string
+	Books []books.Domain
+}
+
+type ReviewFeed struct {
+	Page
+	Book    books.Domain
+	Reviews []reviews.Domain
+}
+
+type Usecase interface {
+	GetBooks(ctx context.Context, sort string, page int) (feed BookFeed, statusCode int, err error)
+	GetBookReviews(ctx context.Context, bookId int, page int) (feed ReviewFeed, statusCode int, err error)
+}
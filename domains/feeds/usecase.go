@@ -0,0 +1,84 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/reviews"
+)
+
+type feedUsecase struct {
+	bookRepo   books.Repository
+	reviewRepo reviews.Repository
+}
+
+func NewFeedUsecase(bookRepo books.Repository, reviewRepo reviews.Repository) Usecase {
+	return &feedUsecase{
+		bookRepo:   bookRepo,
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (uc *feedUsecase) GetBooks(ctx context.Context, sort string, page int) (BookFeed, int, error) {
+	if err := validateSort(sort); err != nil {
+		return BookFeed{}, http.StatusBadRequest, err
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	query := &books.Query{Page: page, Limit: constants.FeedPageSize, Sort: "created_at", Order: "desc"}
+	if sort == constants.FeedSortTitle {
+		query.Sort, query.Order = "title", "asc"
+	}
+
+	domains, total, err := uc.bookRepo.GetAll(ctx, query)
+	if err != nil {
+		return BookFeed{}, http.StatusInternalServerError, err
+	}
+
+	feed := BookFeed{Page: Page{Page: page, Limit: constants.FeedPageSize, Total: total}, Sort: sort, Books: domains}
+	if page > feed.LastPage() {
+		return BookFeed{}, http.StatusNotFound, errors.New("feed page not found")
+	}
+
+	return feed, http.StatusOK, nil
+}
+
+func (uc *feedUsecase) GetBookReviews(ctx context.Context, bookId int, page int) (ReviewFeed, int, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	book, err := uc.bookRepo.GetById(ctx, bookId)
+	if err != nil {
+		return ReviewFeed{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	domains, total, err := uc.reviewRepo.GetRecentByBookId(ctx, bookId, page, constants.FeedPageSize)
+	if err != nil {
+		return ReviewFeed{}, http.StatusInternalServerError, err
+	}
+
+	feed := ReviewFeed{Page: Page{Page: page, Limit: constants.FeedPageSize, Total: total}, Book: book, Reviews: domains}
+	if page > feed.LastPage() {
+		return ReviewFeed{}, http.StatusNotFound, errors.New("feed page not found")
+	}
+
+	return feed, http.StatusOK, nil
+}
+
+func validateSort(sort string) error {
+	for _, s := range constants.ListFeedSort {
+		if s == sort {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("sort must be one of [%s]", strings.Join(constants.ListFeedSort, ", "))
+}
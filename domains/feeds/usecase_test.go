@@ -0,0 +1,134 @@
+package feeds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	reviewMocks "github.com/snykk/golib_backend/datasources/databases/reviews/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/feeds"
+	"github.com/snykk/golib_backend/domains/reviews"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	bookRepository   *bookMocks.Repository
+	reviewRepository *reviewMocks.Repository
+	feedUsecase      feeds.Usecase
+	booksFromDB      []books.Domain
+	reviewsFromDB    []reviews.Domain
+)
+
+func setup(t *testing.T) {
+	bookRepository = bookMocks.NewRepository(t)
+	reviewRepository = reviewMocks.NewRepository(t)
+	feedUsecase = feeds.NewFeedUsecase(bookRepository, reviewRepository)
+
+	booksFromDB = []books.Domain{
+		{
+			ID:        1,
+			Title:     "Laskar Pelangi",
+			Author:    "Andrea Hirata",
+			Publisher: "Bentang Pustaka",
+			ISBN:      "9789793062792",
+			CreatedAt: time.Now(),
+		},
+	}
+
+	reviewsFromDB = []reviews.Domain{
+		{
+			ID:        1,
+			Text:      "A warm story about friendship",
+			Rating:    9,
+			BookId:    1,
+			Book:      booksFromDB[0],
+			UserId:    1,
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+func TestPage(t *testing.T) {
+	t.Run("When Feed Is Empty", func(t *testing.T) {
+		page := feeds.Page{Page: 1, Limit: 20}
+		assert.Equal(t, 1, page.LastPage())
+		assert.False(t, page.HasNext())
+		assert.False(t, page.HasPrevious())
+	})
+	t.Run("When Feed Spans Several Pages", func(t *testing.T) {
+		page := feeds.Page{Page: 2, Limit: 20, Total: 41}
+		assert.Equal(t, 3, page.LastPage())
+		assert.True(t, page.HasNext())
+		assert.True(t, page.HasPrevious())
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Newest Books", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, &books.Query{Page: 1, Limit: constants.FeedPageSize, Sort: "created_at", Order: "desc"}).Return(booksFromDB, 1, nil).Once()
+
+		feed, statusCode, err := feedUsecase.GetBooks(context.Background(), constants.FeedSortNewest, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, booksFromDB, feed.Books)
+		assert.Equal(t, feeds.Page{Page: 1, Limit: constants.FeedPageSize, Total: 1}, feed.Page)
+	})
+
+	t.Run("When Success Get Books By Title", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, &books.Query{Page: 2, Limit: constants.FeedPageSize, Sort: "title", Order: "asc"}).Return(booksFromDB, 21, nil).Once()
+
+		feed, statusCode, err := feedUsecase.GetBooks(context.Background(), constants.FeedSortTitle, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.FeedSortTitle, feed.Sort)
+	})
+
+	t.Run("When Failure Page Past The End", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return([]books.Domain{}, 1, nil).Once()
+
+		_, statusCode, err := feedUsecase.GetBooks(context.Background(), constants.FeedSortNewest, 5)
+
+		assert.Equal(t, errors.New("feed page not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+
+	t.Run("When Failure Unknown Sort", func(t *testing.T) {
+		_, statusCode, err := feedUsecase.GetBooks(context.Background(), "rating", 1)
+
+		assert.Equal(t, errors.New("sort must be one of [newest, title]"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestGetBookReviews(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Book Reviews", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(booksFromDB[0], nil).Once()
+		reviewRepository.Mock.On("GetRecentByBookId", mock.Anything, 1, 1, constants.FeedPageSize).Return(reviewsFromDB, 1, nil).Once()
+
+		feed, statusCode, err := feedUsecase.GetBookReviews(context.Background(), 1, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, booksFromDB[0], feed.Book)
+		assert.Equal(t, reviewsFromDB, feed.Reviews)
+	})
+
+	t.Run("When Failure Book Doesn't Exist", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 9).Return(books.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := feedUsecase.GetBookReviews(context.Background(), 9, 1)
+
+		assert.Equal(t, errors.New("book not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
@@ -0,0 +1,130 @@
+package lists
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+// Domain is a reading list, public lists are listed for everyone, unlisted ones only open for those who know
+// the id and private ones for the owner and the co-editors
+type Domain struct {
+	ID          int
+	OwnerId     int
+	Owner       string
+	Title       string
+	Description string
+	Visibility  string
+	Books       int
+	Votes       int
+	Editors     []Editor
+	Entries     []Entry
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Editor struct {
+	UserId    int
+	Username  string
+	CreatedAt time.Time
+}
+
+// Entry is a book on a list, Position counts from 1
+type Entry struct {
+	ListId    int
+	BookId    int
+	Book      books.Domain
+	Position  int
+	Note      string
+	AddedBy   *int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EntryPatch changes an entry, nil leaves a field as it is
+type EntryPatch struct {
+	Note     *string
+	Position *int
+}
+
+type Query struct {
+	Page  int
+	Limit int
+	Sort  string
+}
+
+func (q *Query) ApplyDefaults() {
+	if q.Page < 1 {
+		q.Page = constants.DefaultReadingListPage
+	}
+	if q.Limit < 1 {
+		q.Limit = constants.DefaultReadingListLimit
+	}
+	if q.Limit > constants.MaxReadingListLimit {
+		q.Limit = constants.MaxReadingListLimit
+	}
+	if q.Sort == "" {
+		q.Sort = constants.ReadingListSortVotes
+	}
+}
+
+func (d *Domain) IsOwner(userId int) bool {
+	return d.OwnerId == userId
+}
+
+func (d *Domain) IsEditor(userId int) bool {
+	for _, editor := range d.Editors {
+		if editor.UserId == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *Domain) CanEdit(userId int) bool {
+	return d.IsOwner(userId) || d.IsEditor(userId)
+}
+
+func (d *Domain) CanView(userId int) bool {
+	return d.Visibility != constants.ReadingListPrivate || d.CanEdit(userId)
+}
+
+type Usecase interface {
+	GetAll(ctx context.Context, query *Query) (domains []Domain, total int, statusCode int, err error)
+	GetByUserId(ctx context.Context, userId int) (domains []Domain, statusCode int, err error)
+	GetById(ctx context.Context, userId int, id int) (domain Domain, statusCode int, err error)
+	Store(ctx context.Context, userId int, list *Domain) (domain Domain, statusCode int, err error)
+	Update(ctx context.Context, userId int, id int, list *Domain) (domain Domain, statusCode int, err error)
+	Delete(ctx context.Context, userId int, id int) (statusCode int, err error)
+	AddBook(ctx context.Context, userId int, id int, entry *Entry) (domain Entry, statusCode int, err error)
+	UpdateBook(ctx context.Context, userId int, id int, bookId int, patch *EntryPatch) (domain Entry, statusCode int, err error)
+	RemoveBook(ctx context.Context, userId int, id int, bookId int) (statusCode int, err error)
+	AddEditor(ctx context.Context, userId int, id int, editorId int) (statusCode int, err error)
+	RemoveEditor(ctx context.Context, userId int, id int, editorId int) (statusCode int, err error)
+	Vote(ctx context.Context, userId int, id int) (votes int, statusCode int, err error)
+	Unvote(ctx context.Context, userId int, id int) (votes int, statusCode int, err error)
+}
+
+type Repository interface {
+	GetAll(ctx context.Context, query *Query) ([]Domain, int, error)
+	GetByUserId(ctx context.Context, userId int) ([]Domain, error)
+	GetById(ctx context.Context, id int) (Domain, error)
+	GetEntries(ctx context.Context, id int) ([]Entry, error)
+	GetEntry(ctx context.Context, id int, bookId int) (Entry, error)
+	CountEntries(ctx context.Context, id int) (int, error)
+	Store(ctx context.Context, domain *Domain) (Domain, error)
+	Update(ctx context.Context, domain *Domain) error
+	Delete(ctx context.Context, id int) error
+	InsertEntry(ctx context.Context, entry *Entry) error
+	UpdateNote(ctx context.Context, id int, bookId int, note string) error
+	MoveEntry(ctx context.Context, id int, bookId int, position int) error
+	DeleteEntry(ctx context.Context, id int, bookId int) error
+	AddEditor(ctx context.Context, id int, userId int) error
+	RemoveEditor(ctx context.Context, id int, userId int) (removed bool, err error)
+	AddVote(ctx context.Context, id int, userId int) (added bool, err error)
+	RemoveVote(ctx context.Context, id int, userId int) (removed bool, err error)
+	CountVotes(ctx context.Context, id int) (int, error)
+}
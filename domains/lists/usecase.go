@@ -0,0 +1,370 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/users"
+)
+
+type listUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+	userRepo users.Repository
+}
+
+func NewListUsecase(repo Repository, bookRepo books.Repository, userRepo users.Repository) Usecase {
+	return &listUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *listUsecase) GetAll(ctx context.Context, query *Query) ([]Domain, int, int, error) {
+	query.ApplyDefaults()
+
+	lists, total, err := uc.repo.GetAll(ctx, query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, err
+	}
+
+	return lists, total, http.StatusOK, nil
+}
+
+func (uc *listUsecase) GetByUserId(ctx context.Context, userId int) ([]Domain, int, error) {
+	lists, err := uc.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		return []Domain{}, http.StatusInternalServerError, err
+	}
+
+	return lists, http.StatusOK, nil
+}
+
+// getList only hands out the lists the user is allowed to see, a private list of someone else doesn't exist for them
+func (uc *listUsecase) getList(ctx context.Context, userId int, id int) (Domain, int, error) {
+	list, err := uc.repo.GetById(ctx, id)
+	if err != nil || !list.CanView(userId) {
+		return Domain{}, http.StatusNotFound, errors.New("reading list not found")
+	}
+
+	return list, http.StatusOK, nil
+}
+
+func (uc *listUsecase) GetById(ctx context.Context, userId int, id int) (Domain, int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	list.Entries, err = uc.repo.GetEntries(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return list, http.StatusOK, nil
+}
+
+func (uc *listUsecase) Store(ctx context.Context, userId int, list *Domain) (Domain, int, error) {
+	if list.Visibility == "" {
+		list.Visibility = constants.ReadingListPublic
+	}
+	if err := normalizeList(list); err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+	list.OwnerId = userId
+
+	result, err := uc.repo.Store(ctx, list)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *listUsecase) Update(ctx context.Context, userId int, id int, list *Domain) (Domain, int, error) {
+	current, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+	if !current.CanEdit(userId) {
+		return Domain{}, http.StatusForbidden, errors.New("you can't edit this reading list")
+	}
+
+	if list.Visibility == "" {
+		list.Visibility = current.Visibility
+	}
+	if list.Visibility != current.Visibility && !current.IsOwner(userId) {
+		return Domain{}, http.StatusForbidden, errors.New("only the owner can change who sees the reading list")
+	}
+	if err := normalizeList(list); err != nil {
+		return Domain{}, http.StatusBadRequest, err
+	}
+
+	list.ID = id
+	if err := uc.repo.Update(ctx, list); err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *listUsecase) Delete(ctx context.Context, userId int, id int) (int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return statusCode, err
+	}
+	if !list.IsOwner(userId) {
+		return http.StatusForbidden, errors.New("only the owner can delete the reading list")
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *listUsecase) AddBook(ctx context.Context, userId int, id int, entry *Entry) (Entry, int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return Entry{}, statusCode, err
+	}
+	if !list.CanEdit(userId) {
+		return Entry{}, http.StatusForbidden, errors.New("you can't edit this reading list")
+	}
+
+	entry.Note = strings.TrimSpace(entry.Note)
+	if utf8.RuneCountInString(entry.Note) > constants.MaxReadingListNoteLength {
+		return Entry{}, http.StatusBadRequest, fmt.Errorf("note can't be longer than %d characters", constants.MaxReadingListNoteLength)
+	}
+
+	if _, err := uc.bookRepo.GetById(ctx, entry.BookId); err != nil {
+		return Entry{}, http.StatusNotFound, errors.New("book not found")
+	}
+	if _, err := uc.repo.GetEntry(ctx, id, entry.BookId); err == nil {
+		return Entry{}, http.StatusConflict, errors.New("book is already on this reading list")
+	}
+
+	count, err := uc.repo.CountEntries(ctx, id)
+	if err != nil {
+		return Entry{}, http.StatusInternalServerError, err
+	}
+	if count >= constants.MaxReadingListBooks {
+		return Entry{}, http.StatusBadRequest, fmt.Errorf("a reading list holds at most %d books", constants.MaxReadingListBooks)
+	}
+
+	// without a position the book goes last
+	if entry.Position < 1 || entry.Position > count+1 {
+		entry.Position = count + 1
+	}
+	entry.ListId = id
+	entry.AddedBy = &userId
+	if err := uc.repo.InsertEntry(ctx, entry); err != nil {
+		return Entry{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetEntry(ctx, id, entry.BookId)
+	if err != nil {
+		return Entry{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *listUsecase) UpdateBook(ctx context.Context, userId int, id int, bookId int, patch *EntryPatch) (Entry, int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return Entry{}, statusCode, err
+	}
+	if !list.CanEdit(userId) {
+		return Entry{}, http.StatusForbidden, errors.New("you can't edit this reading list")
+	}
+
+	entry, err := uc.repo.GetEntry(ctx, id, bookId)
+	if err != nil {
+		return Entry{}, http.StatusNotFound, errors.New("book isn't on this reading list")
+	}
+
+	if patch.Note != nil {
+		note := strings.TrimSpace(*patch.Note)
+		if utf8.RuneCountInString(note) > constants.MaxReadingListNoteLength {
+			return Entry{}, http.StatusBadRequest, fmt.Errorf("note can't be longer than %d characters", constants.MaxReadingListNoteLength)
+		}
+		if err := uc.repo.UpdateNote(ctx, id, bookId, note); err != nil {
+			return Entry{}, http.StatusInternalServerError, err
+		}
+	}
+
+	if patch.Position != nil {
+		count, err := uc.repo.CountEntries(ctx, id)
+		if err != nil {
+			return Entry{}, http.StatusInternalServerError, err
+		}
+		position := *patch.Position
+		if position < 1 || position > count {
+			return Entry{}, http.StatusBadRequest, fmt.Errorf("position must be between 1 and %d", count)
+		}
+		if position != entry.Position {
+			if err := uc.repo.MoveEntry(ctx, id, bookId, position); err != nil {
+				return Entry{}, http.StatusInternalServerError, err
+			}
+		}
+	}
+
+	result, err := uc.repo.GetEntry(ctx, id, bookId)
+	if err != nil {
+		return Entry{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *listUsecase) RemoveBook(ctx context.Context, userId int, id int, bookId int) (int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return statusCode, err
+	}
+	if !list.CanEdit(userId) {
+		return http.StatusForbidden, errors.New("you can't edit this reading list")
+	}
+
+	if _, err := uc.repo.GetEntry(ctx, id, bookId); err != nil {
+		return http.StatusNotFound, errors.New("book isn't on this reading list")
+	}
+
+	if err := uc.repo.DeleteEntry(ctx, id, bookId); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *listUsecase) AddEditor(ctx context.Context, userId int, id int, editorId int) (int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return statusCode, err
+	}
+	if !list.IsOwner(userId) {
+		return http.StatusForbidden, errors.New("only the owner can invite co-editors")
+	}
+	if list.IsOwner(editorId) {
+		return http.StatusBadRequest, errors.New("the owner already edits the reading list")
+	}
+	if list.IsEditor(editorId) {
+		return http.StatusConflict, errors.New("user already edits this reading list")
+	}
+	if len(list.Editors) >= constants.MaxReadingListEditors {
+		return http.StatusBadRequest, fmt.Errorf("a reading list can have at most %d co-editors", constants.MaxReadingListEditors)
+	}
+
+	if _, err := uc.userRepo.GetById(ctx, editorId); err != nil {
+		return http.StatusNotFound, errors.New("user not found")
+	}
+
+	if err := uc.repo.AddEditor(ctx, id, editorId); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *listUsecase) RemoveEditor(ctx context.Context, userId int, id int, editorId int) (int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return statusCode, err
+	}
+	// co-editors may step down themselves
+	if !list.IsOwner(userId) && userId != editorId {
+		return http.StatusForbidden, errors.New("only the owner can remove co-editors")
+	}
+
+	removed, err := uc.repo.RemoveEditor(ctx, id, editorId)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !removed {
+		return http.StatusNotFound, errors.New("user isn't a co-editor of this reading list")
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *listUsecase) Vote(ctx context.Context, userId int, id int) (int, int, error) {
+	list, statusCode, err := uc.getList(ctx, userId, id)
+	if err != nil {
+		return 0, statusCode, err
+	}
+	if list.IsOwner(userId) {
+		return 0, http.StatusBadRequest, errors.New("you can't upvote your own reading list")
+	}
+
+	added, err := uc.repo.AddVote(ctx, id, userId)
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+	if !added {
+		return 0, http.StatusConflict, errors.New("you already upvoted this reading list")
+	}
+
+	votes, err := uc.repo.CountVotes(ctx, id)
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+
+	return votes, http.StatusOK, nil
+}
+
+func (uc *listUsecase) Unvote(ctx context.Context, userId int, id int) (int, int, error) {
+	if _, statusCode, err := uc.getList(ctx, userId, id); err != nil {
+		return 0, statusCode, err
+	}
+
+	removed, err := uc.repo.RemoveVote(ctx, id, userId)
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+	if !removed {
+		return 0, http.StatusNotFound, errors.New("you haven't upvoted this reading list")
+	}
+
+	votes, err := uc.repo.CountVotes(ctx, id)
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+
+	return votes, http.StatusOK, nil
+}
+
+func normalizeList(list *Domain) error {
+	list.Title = strings.TrimSpace(list.Title)
+	list.Description = strings.TrimSpace(list.Description)
+
+	if list.Title == "" {
+		return errors.New("title can't be empty")
+	}
+	if utf8.RuneCountInString(list.Title) > constants.MaxReadingListTitleLength {
+		return fmt.Errorf("title can't be longer than %d characters", constants.MaxReadingListTitleLength)
+	}
+	if utf8.RuneCountInString(list.Description) > constants.MaxReadingListDescriptionLength {
+		return fmt.Errorf("description can't be longer than %d characters", constants.MaxReadingListDescriptionLength)
+	}
+	for _, visibility := range constants.ListReadingListVisibility {
+		if visibility == list.Visibility {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("visibility must be one of [%s]", strings.Join(constants.ListReadingListVisibility, ", "))
+}
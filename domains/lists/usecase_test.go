@@ -0,0 +1,342 @@
+package lists_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	listMocks "github.com/snykk/golib_backend/datasources/databases/lists/mocks"
+	userMocks "github.com/snykk/golib_backend/datasources/databases/users/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/lists"
+	"github.com/snykk/golib_backend/domains/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	listRepository *listMocks.Repository
+	bookRepository *bookMocks.Repository
+	userRepository *userMocks.Repository
+	listUsecase    lists.Usecase
+	listFromDB     lists.Domain
+	bookFromDB     books.Domain
+)
+
+func setup(t *testing.T) {
+	listRepository = listMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	userRepository = userMocks.NewRepository(t)
+	listUsecase = lists.NewListUsecase(listRepository, bookRepository, userRepository)
+
+	// user 1 owns the list and user 2 co-edits it
+	listFromDB = lists.Domain{
+		ID:          1,
+		OwnerId:     1,
+		Owner:       "itsmepatrick",
+		Title:       "Best Indonesian novels",
+		Description: "lorem ipsum doler sit amet",
+		Visibility:  constants.ReadingListPrivate,
+		Books:       2,
+		Votes:       3,
+		Editors:     []lists.Editor{{UserId: 2, Username: "johny"}},
+		CreatedAt:   time.Now(),
+	}
+
+	bookFromDB = books.Domain{
+		ID:        5,
+		Title:     "Laskar Pelangi",
+		Author:    "Andrea Hirata",
+		Publisher: "Bentang Pustaka",
+		ISBN:      "9789793062792",
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Public Lists", func(t *testing.T) {
+		listRepository.Mock.On("GetAll", mock.Anything, &lists.Query{Page: 1, Limit: constants.DefaultReadingListLimit, Sort: constants.ReadingListSortVotes}).Return([]lists.Domain{listFromDB}, 1, nil).Once()
+
+		result, total, statusCode, err := listUsecase.GetAll(context.Background(), &lists.Query{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []lists.Domain{listFromDB}, result)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Private List As Co-editor", func(t *testing.T) {
+		entries := []lists.Entry{{ListId: 1, BookId: 5, Book: bookFromDB, Position: 1}}
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("GetEntries", mock.Anything, 1).Return(entries, nil).Once()
+
+		result, statusCode, err := listUsecase.GetById(context.Background(), 2, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, entries, result.Entries)
+	})
+
+	t.Run("When Failure Private List Of Someone Else", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		_, statusCode, err := listUsecase.GetById(context.Background(), 3, 1)
+
+		assert.Equal(t, errors.New("reading list not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+
+	t.Run("When Success Unlisted List Of Someone Else", func(t *testing.T) {
+		unlisted := listFromDB
+		unlisted.Visibility = constants.ReadingListUnlisted
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(unlisted, nil).Once()
+		listRepository.Mock.On("GetEntries", mock.Anything, 1).Return([]lists.Entry{}, nil).Once()
+
+		_, statusCode, err := listUsecase.GetById(context.Background(), 3, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	t.Run("When Success Store List", func(t *testing.T) {
+		listRepository.Mock.On("Store", mock.Anything, &lists.Domain{OwnerId: 1, Title: "Best Indonesian novels", Visibility: constants.ReadingListPublic}).Return(listFromDB, nil).Once()
+
+		result, statusCode, err := listUsecase.Store(context.Background(), 1, &lists.Domain{Title: "  Best Indonesian novels  "})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, listFromDB, result)
+	})
+
+	t.Run("When Failure Blank Title", func(t *testing.T) {
+		_, statusCode, err := listUsecase.Store(context.Background(), 1, &lists.Domain{Title: "   "})
+
+		assert.Equal(t, errors.New("title can't be empty"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	setup(t)
+	t.Run("When Success Co-editor Renames List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("Update", mock.Anything, &lists.Domain{ID: 1, Title: "Indonesian classics", Visibility: constants.ReadingListPrivate}).Return(nil).Once()
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		_, statusCode, err := listUsecase.Update(context.Background(), 2, 1, &lists.Domain{Title: "Indonesian classics"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Co-editor Changes Visibility", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		_, statusCode, err := listUsecase.Update(context.Background(), 2, 1, &lists.Domain{Title: "Indonesian classics", Visibility: constants.ReadingListPublic})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, statusCode)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	setup(t)
+	t.Run("When Success Owner Deletes List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("Delete", mock.Anything, 1).Return(nil).Once()
+
+		statusCode, err := listUsecase.Delete(context.Background(), 1, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Co-editor Deletes List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		statusCode, err := listUsecase.Delete(context.Background(), 2, 1)
+
+		assert.Equal(t, errors.New("only the owner can delete the reading list"), err)
+		assert.Equal(t, http.StatusForbidden, statusCode)
+	})
+}
+
+func TestAddBook(t *testing.T) {
+	setup(t)
+	t.Run("When Success Add Book At The End", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 5).Return(bookFromDB, nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{}, errors.New("record not found")).Once()
+		listRepository.Mock.On("CountEntries", mock.Anything, 1).Return(2, nil).Once()
+		listRepository.Mock.On("InsertEntry", mock.Anything, mock.MatchedBy(func(entry *lists.Entry) bool {
+			return entry.ListId == 1 && entry.BookId == 5 && entry.Position == 3 && entry.Note == "a classic" && *entry.AddedBy == 2
+		})).Return(nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{ListId: 1, BookId: 5, Book: bookFromDB, Position: 3, Note: "a classic"}, nil).Once()
+
+		result, statusCode, err := listUsecase.AddBook(context.Background(), 2, 1, &lists.Entry{BookId: 5, Note: " a classic ", Position: 10})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, 3, result.Position)
+	})
+
+	t.Run("When Failure Book Already On List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 5).Return(bookFromDB, nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{ListId: 1, BookId: 5}, nil).Once()
+
+		_, statusCode, err := listUsecase.AddBook(context.Background(), 1, 1, &lists.Entry{BookId: 5})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Failure Viewer Adds Book", func(t *testing.T) {
+		public := listFromDB
+		public.Visibility = constants.ReadingListPublic
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(public, nil).Once()
+
+		_, statusCode, err := listUsecase.AddBook(context.Background(), 3, 1, &lists.Entry{BookId: 5})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, statusCode)
+	})
+}
+
+func TestUpdateBook(t *testing.T) {
+	setup(t)
+	t.Run("When Success Move Book", func(t *testing.T) {
+		position := 1
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{ListId: 1, BookId: 5, Position: 2}, nil).Once()
+		listRepository.Mock.On("CountEntries", mock.Anything, 1).Return(2, nil).Once()
+		listRepository.Mock.On("MoveEntry", mock.Anything, 1, 5, 1).Return(nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{ListId: 1, BookId: 5, Position: 1}, nil).Once()
+
+		result, statusCode, err := listUsecase.UpdateBook(context.Background(), 1, 1, 5, &lists.EntryPatch{Position: &position})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, result.Position)
+	})
+
+	t.Run("When Failure Position Out Of Range", func(t *testing.T) {
+		position := 3
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{ListId: 1, BookId: 5, Position: 2}, nil).Once()
+		listRepository.Mock.On("CountEntries", mock.Anything, 1).Return(2, nil).Once()
+
+		_, statusCode, err := listUsecase.UpdateBook(context.Background(), 1, 1, 5, &lists.EntryPatch{Position: &position})
+
+		assert.Equal(t, errors.New("position must be between 1 and 2"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestAddEditor(t *testing.T) {
+	setup(t)
+	t.Run("When Success Add Editor", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		userRepository.Mock.On("GetById", mock.Anything, 3).Return(users.Domain{ID: 3}, nil).Once()
+		listRepository.Mock.On("AddEditor", mock.Anything, 1, 3).Return(nil).Once()
+
+		statusCode, err := listUsecase.AddEditor(context.Background(), 1, 1, 3)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Already An Editor", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		statusCode, err := listUsecase.AddEditor(context.Background(), 1, 1, 2)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Failure Editor Invites Someone", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		statusCode, err := listUsecase.AddEditor(context.Background(), 2, 1, 3)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, statusCode)
+	})
+}
+
+func TestRemoveEditor(t *testing.T) {
+	setup(t)
+	t.Run("When Success Editor Steps Down", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("RemoveEditor", mock.Anything, 1, 2).Return(true, nil).Once()
+
+		statusCode, err := listUsecase.RemoveEditor(context.Background(), 2, 1, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+}
+
+func TestVote(t *testing.T) {
+	setup(t)
+	public := listFromDB
+	public.Visibility = constants.ReadingListPublic
+
+	t.Run("When Success Vote", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(public, nil).Once()
+		listRepository.Mock.On("AddVote", mock.Anything, 1, 3).Return(true, nil).Once()
+		listRepository.Mock.On("CountVotes", mock.Anything, 1).Return(4, nil).Once()
+
+		votes, statusCode, err := listUsecase.Vote(context.Background(), 3, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 4, votes)
+	})
+
+	t.Run("When Failure Voting Twice", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(public, nil).Once()
+		listRepository.Mock.On("AddVote", mock.Anything, 1, 3).Return(false, nil).Once()
+
+		_, statusCode, err := listUsecase.Vote(context.Background(), 3, 1)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Failure Voting Own List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(public, nil).Once()
+
+		_, statusCode, err := listUsecase.Vote(context.Background(), 1, 1)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestUnvote(t *testing.T) {
+	setup(t)
+	t.Run("When Failure Not Voted", func(t *testing.T) {
+		public := listFromDB
+		public.Visibility = constants.ReadingListPublic
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(public, nil).Once()
+		listRepository.Mock.On("RemoveVote", mock.Anything, 1, 3).Return(false, nil).Once()
+
+		_, statusCode, err := listUsecase.Unvote(context.Background(), 3, 1)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
@@ -0,0 +1,36 @@
+package publishers
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type Domain struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Usecase interface {
+	Store(ctx context.Context, publisher *Domain) (domain Domain, statusCode int, err error)
+	GetAll(ctx context.Context) (domains []Domain, statusCode int, err error)
+	GetById(ctx context.Context, id int) (domain Domain, statusCode int, err error)
+	Update(ctx context.Context, publisher *Domain, id int) (domain Domain, bookIds []int, statusCode int, err error)
+	Delete(ctx context.Context, id int) (statusCode int, err error)
+	GetBooks(ctx context.Context, publisherId int) (domains []books.Domain, statusCode int, err error)
+	AssignBook(ctx context.Context, bookId int, publisherId int) (domain books.Domain, statusCode int, err error)
+}
+
+type Repository interface {
+	Store(ctx context.Context, publisher *Domain) (Domain, error)
+	GetAll(ctx context.Context) ([]Domain, error)
+	GetById(ctx context.Context, id int) (Domain, error)
+	GetByName(ctx context.Context, name string) (Domain, error)
+	Update(ctx context.Context, publisher *Domain) (bookIds []int, err error)
+	Delete(ctx context.Context, id int) error
+	GetBooks(ctx context.Context, publisherId int) ([]books.Domain, error)
+	AssignBook(ctx context.Context, bookId int, publisher Domain) error
+}
@@ -0,0 +1,134 @@
+package publishers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type publisherUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+}
+
+func NewPublisherUsecase(repo Repository, bookRepo books.Repository) Usecase {
+	return &publisherUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+	}
+}
+
+func (uc *publisherUsecase) Store(ctx context.Context, publisher *Domain) (Domain, int, error) {
+	publisher.Name = strings.Join(strings.Fields(publisher.Name), " ")
+	if _, err := uc.repo.GetByName(ctx, publisher.Name); err == nil {
+		return Domain{}, http.StatusConflict, fmt.Errorf("publisher %s already exists", publisher.Name)
+	}
+
+	result, err := uc.repo.Store(ctx, publisher)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusCreated, nil
+}
+
+func (uc *publisherUsecase) GetAll(ctx context.Context) ([]Domain, int, error) {
+	result, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return []Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *publisherUsecase) GetById(ctx context.Context, id int) (Domain, int, error) {
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, http.StatusNotFound, errors.New("publisher not found")
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *publisherUsecase) Update(ctx context.Context, publisher *Domain, id int) (Domain, []int, int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("publisher not found")
+	}
+
+	publisher.ID = id
+	publisher.Name = strings.Join(strings.Fields(publisher.Name), " ")
+	if existing, err := uc.repo.GetByName(ctx, publisher.Name); err == nil && existing.ID != id {
+		return Domain{}, nil, http.StatusConflict, fmt.Errorf("publisher %s already exists", publisher.Name)
+	}
+
+	bookIds, err := uc.repo.Update(ctx, publisher)
+	if err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("publisher not found")
+	}
+
+	return result, bookIds, http.StatusOK, nil
+}
+
+func (uc *publisherUsecase) Delete(ctx context.Context, id int) (int, error) {
+	if _, err := uc.repo.GetById(ctx, id); err != nil {
+		return http.StatusNotFound, errors.New("publisher not found")
+	}
+
+	published, err := uc.repo.GetBooks(ctx, id)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if len(published) > 0 {
+		return http.StatusConflict, fmt.Errorf("publisher still has %d books", len(published))
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *publisherUsecase) GetBooks(ctx context.Context, publisherId int) ([]books.Domain, int, error) {
+	if _, err := uc.repo.GetById(ctx, publisherId); err != nil {
+		return []books.Domain{}, http.StatusNotFound, errors.New("publisher not found")
+	}
+
+	result, err := uc.repo.GetBooks(ctx, publisherId)
+	if err != nil {
+		return []books.Domain{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func (uc *publisherUsecase) AssignBook(ctx context.Context, bookId int, publisherId int) (books.Domain, int, error) {
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return books.Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	publisher, err := uc.repo.GetById(ctx, publisherId)
+	if err != nil {
+		return books.Domain{}, http.StatusNotFound, errors.New("publisher not found")
+	}
+
+	if err := uc.repo.AssignBook(ctx, bookId, publisher); err != nil {
+		return books.Domain{}, http.StatusInternalServerError, err
+	}
+
+	result, err := uc.bookRepo.GetById(ctx, bookId)
+	if err != nil {
+		return books.Domain{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	return result, http.StatusOK, nil
+}
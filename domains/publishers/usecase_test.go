@@ -0,0 +1,160 @@
+package publishers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	publisherMocks "github.com/snykk/golib_backend/datasources/databases/publishers/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/publishers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	publisherRepository *publisherMocks.Repository
+	bookRepository      *bookMocks.Repository
+	publisherUsecase    publishers.Usecase
+	publisherFromDB     publishers.Domain
+	bookFromDB          books.Domain
+)
+
+func setup(t *testing.T) {
+	publisherRepository = publisherMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	publisherUsecase = publishers.NewPublisherUsecase(publisherRepository, bookRepository)
+
+	publisherFromDB = publishers.Domain{
+		ID:        1,
+		Name:      "Gramedia",
+		CreatedAt: time.Now(),
+	}
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		PublisherId: &publisherFromDB.ID,
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	t.Run("When Success Store Publisher", func(t *testing.T) {
+		req := publishers.Domain{Name: " Gramedia "}
+		publisherRepository.Mock.On("GetByName", mock.Anything, "Gramedia").Return(publishers.Domain{}, errors.New("record not found")).Once()
+		publisherRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*publishers.Domain")).Return(publisherFromDB, nil).Once()
+
+		result, statusCode, err := publisherUsecase.Store(context.Background(), &req)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, publisherFromDB, result)
+	})
+	t.Run("When Failure Publisher Already Exists", func(t *testing.T) {
+		req := publishers.Domain{Name: "GRAMEDIA"}
+		publisherRepository.Mock.On("GetByName", mock.Anything, req.Name).Return(publisherFromDB, nil).Once()
+
+		_, statusCode, err := publisherUsecase.Store(context.Background(), &req)
+
+		assert.Equal(t, errors.New("publisher GRAMEDIA already exists"), err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	setup(t)
+	t.Run("When Success Update Publisher", func(t *testing.T) {
+		req := publishers.Domain{Name: "Gramedia Pustaka"}
+		updated := publisherFromDB
+		updated.Name = req.Name
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("GetByName", mock.Anything, req.Name).Return(publishers.Domain{}, errors.New("record not found")).Once()
+		publisherRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*publishers.Domain")).Return([]int{1}, nil).Once()
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(updated, nil).Once()
+
+		result, bookIds, statusCode, err := publisherUsecase.Update(context.Background(), &req, publisherFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, updated, result)
+		assert.Equal(t, []int{1}, bookIds)
+	})
+	t.Run("When Failure Publisher Not Found", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, 2).Return(publishers.Domain{}, errors.New("record not found")).Once()
+
+		_, _, statusCode, err := publisherUsecase.Update(context.Background(), &publishers.Domain{Name: "x"}, 2)
+
+		assert.Equal(t, errors.New("publisher not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	setup(t)
+	t.Run("When Success Delete Publisher", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("GetBooks", mock.Anything, publisherFromDB.ID).Return([]books.Domain{}, nil).Once()
+		publisherRepository.Mock.On("Delete", mock.Anything, publisherFromDB.ID).Return(nil).Once()
+
+		statusCode, err := publisherUsecase.Delete(context.Background(), publisherFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure Publisher Still Has Books", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("GetBooks", mock.Anything, publisherFromDB.ID).Return([]books.Domain{bookFromDB}, nil).Once()
+
+		statusCode, err := publisherUsecase.Delete(context.Background(), publisherFromDB.ID)
+
+		assert.Equal(t, errors.New("publisher still has 1 books"), err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Publisher Books", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("GetBooks", mock.Anything, publisherFromDB.ID).Return([]books.Domain{bookFromDB}, nil).Once()
+
+		result, statusCode, err := publisherUsecase.GetBooks(context.Background(), publisherFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []books.Domain{bookFromDB}, result)
+	})
+}
+
+func TestAssignBook(t *testing.T) {
+	setup(t)
+	t.Run("When Success Assign Book", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Twice()
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("AssignBook", mock.Anything, bookFromDB.ID, publisherFromDB).Return(nil).Once()
+
+		result, statusCode, err := publisherUsecase.AssignBook(context.Background(), bookFromDB.ID, publisherFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, bookFromDB, result)
+	})
+	t.Run("When Failure Publisher Not Found", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		publisherRepository.Mock.On("GetById", mock.Anything, 2).Return(publishers.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := publisherUsecase.AssignBook(context.Background(), bookFromDB.ID, 2)
+
+		assert.Equal(t, errors.New("publisher not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
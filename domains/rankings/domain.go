@@ -0,0 +1,45 @@
+package rankings
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+// Stat sums up the reviews a book got, the input of both rankings
+type Stat struct {
+	BookId  int
+	Reviews int
+	Average float64
+}
+
+// Ranking is a book's place in the top or trending list, for the top list Score is its Bayesian average
+// and for the trending list the number of reviews it got within the window
+type Ranking struct {
+	Kind      string
+	BookId    int
+	Book      books.Domain
+	Score     float64
+	Reviews   int
+	Rank      int
+	CreatedAt time.Time
+}
+
+// Options are the knobs of the rankings, PriorMean of zero uses the average rating of every review
+type Options struct {
+	PriorWeight    float64
+	PriorMean      float64
+	TrendingWindow time.Duration
+}
+
+type Usecase interface {
+	Refresh(ctx context.Context) (err error)
+	GetByKind(ctx context.Context, kind string, limit int) (rankings []Ranking, statusCode int, err error)
+}
+
+type Repository interface {
+	GetStats(ctx context.Context, since time.Time) ([]Stat, error)
+	Replace(ctx context.Context, rankings []Ranking) error
+	GetByKind(ctx context.Context, kind string, limit int) ([]Ranking, error)
+}
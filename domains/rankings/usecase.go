@@ -0,0 +1,143 @@
+package rankings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+)
+
+type rankingUsecase struct {
+	repo    Repository
+	options Options
+}
+
+func NewRankingUsecase(repo Repository, options Options) Usecase {
+	return &rankingUsecase{
+		repo:    repo,
+		options: options,
+	}
+}
+
+func (uc *rankingUsecase) Refresh(ctx context.Context) error {
+	allTime, err := uc.repo.GetStats(ctx, time.Time{})
+	if err != nil {
+		return err
+	}
+	recent, err := uc.repo.GetStats(ctx, time.Now().Add(-uc.options.TrendingWindow))
+	if err != nil {
+		return err
+	}
+
+	rankings := append(rankTop(allTime, uc.options.PriorWeight, uc.options.PriorMean), rankTrending(recent)...)
+	return uc.repo.Replace(ctx, rankings)
+}
+
+func (uc *rankingUsecase) GetByKind(ctx context.Context, kind string, limit int) ([]Ranking, int, error) {
+	if err := validateKind(kind); err != nil {
+		return []Ranking{}, http.StatusBadRequest, err
+	}
+
+	if limit < 1 {
+		limit = constants.DefaultRankingLimit
+	}
+	if limit > constants.MaxRankingLimit {
+		limit = constants.MaxRankingLimit
+	}
+
+	rankings, err := uc.repo.GetByKind(ctx, kind, limit)
+	if err != nil {
+		return []Ranking{}, http.StatusInternalServerError, err
+	}
+
+	return rankings, http.StatusOK, nil
+}
+
+// rankTop orders books by their Bayesian average, every book starts out with priorWeight virtual reviews of priorMean
+// so a handful of enthusiastic reviews can't outrank a book that many readers agree on
+func rankTop(stats []Stat, priorWeight float64, priorMean float64) []Ranking {
+	if priorMean <= 0 {
+		var sum float64
+		var reviews int
+		for _, stat := range stats {
+			sum += stat.Average * float64(stat.Reviews)
+			reviews += stat.Reviews
+		}
+		if reviews > 0 {
+			priorMean = sum / float64(reviews)
+		}
+	}
+
+	rankings := make([]Ranking, 0, len(stats))
+	for _, stat := range stats {
+		if stat.Reviews == 0 {
+			continue
+		}
+		reviews := float64(stat.Reviews)
+		score := (reviews*stat.Average + priorWeight*priorMean) / (reviews + priorWeight)
+		rankings = append(rankings, Ranking{Kind: constants.RankingTop, BookId: stat.BookId, Score: score, Reviews: stat.Reviews})
+	}
+
+	return ranked(rankings, func(a, b Ranking) bool {
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Reviews > b.Reviews
+	})
+}
+
+// rankTrending orders books by how many reviews they got within the window, the better rated first on a tie
+func rankTrending(stats []Stat) []Ranking {
+	rankings := make([]Ranking, 0, len(stats))
+	averages := make(map[int]float64, len(stats))
+	for _, stat := range stats {
+		if stat.Reviews == 0 {
+			continue
+		}
+		averages[stat.BookId] = stat.Average
+		rankings = append(rankings, Ranking{Kind: constants.RankingTrending, BookId: stat.BookId, Score: float64(stat.Reviews), Reviews: stat.Reviews})
+	}
+
+	return ranked(rankings, func(a, b Ranking) bool {
+		if a.Reviews != b.Reviews {
+			return a.Reviews > b.Reviews
+		}
+		return averages[a.BookId] > averages[b.BookId]
+	})
+}
+
+// ranked sorts the rankings with the book id as the last tie breaker, keeps the first MaxRankingLimit and numbers them
+func ranked(rankings []Ranking, less func(a, b Ranking) bool) []Ranking {
+	sort.Slice(rankings, func(i, j int) bool {
+		if less(rankings[i], rankings[j]) {
+			return true
+		}
+		if less(rankings[j], rankings[i]) {
+			return false
+		}
+		return rankings[i].BookId < rankings[j].BookId
+	})
+
+	if len(rankings) > constants.MaxRankingLimit {
+		rankings = rankings[:constants.MaxRankingLimit]
+	}
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+	}
+
+	return rankings
+}
+
+func validateKind(kind string) error {
+	for _, k := range constants.ListRankingKind {
+		if k == kind {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("ranking must be one of [%s]", strings.Join(constants.ListRankingKind, ", "))
+}
@@ -0,0 +1,115 @@
+package rankings_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	rankingMocks "github.com/snykk/golib_backend/datasources/databases/rankings/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/rankings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	rankingRepository *rankingMocks.Repository
+	rankingUsecase    rankings.Usecase
+	allTimeStats      []rankings.Stat
+	recentStats       []rankings.Stat
+)
+
+func setup(t *testing.T) {
+	rankingRepository = rankingMocks.NewRepository(t)
+	rankingUsecase = rankings.NewRankingUsecase(rankingRepository, rankings.Options{PriorWeight: 10, TrendingWindow: 7 * 24 * time.Hour})
+
+	// a single perfect review shouldn't beat fifty good ones
+	allTimeStats = []rankings.Stat{
+		{BookId: 1, Reviews: 1, Average: 10},
+		{BookId: 2, Reviews: 50, Average: 8.5},
+		{BookId: 3, Reviews: 5, Average: 6},
+	}
+	recentStats = []rankings.Stat{
+		{BookId: 1, Reviews: 1, Average: 10},
+		{BookId: 3, Reviews: 5, Average: 6},
+	}
+}
+
+func isAllTime(since time.Time) bool {
+	return since.IsZero()
+}
+
+func isWithinWindow(since time.Time) bool {
+	return !since.IsZero() && time.Since(since) > 6*24*time.Hour
+}
+
+func TestRefresh(t *testing.T) {
+	setup(t)
+	t.Run("When Success Refresh Rankings", func(t *testing.T) {
+		rankingRepository.Mock.On("GetStats", mock.Anything, mock.MatchedBy(isAllTime)).Return(allTimeStats, nil).Once()
+		rankingRepository.Mock.On("GetStats", mock.Anything, mock.MatchedBy(isWithinWindow)).Return(recentStats, nil).Once()
+
+		var stored []rankings.Ranking
+		rankingRepository.Mock.On("Replace", mock.Anything, mock.AnythingOfType("[]rankings.Ranking")).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]rankings.Ranking)
+		}).Return(nil).Once()
+
+		err := rankingUsecase.Refresh(context.Background())
+
+		assert.Nil(t, err)
+		assert.Len(t, stored, 5)
+
+		top := stored[:3]
+		assert.Equal(t, []int{2, 1, 3}, []int{top[0].BookId, top[1].BookId, top[2].BookId})
+		assert.Equal(t, []int{1, 2, 3}, []int{top[0].Rank, top[1].Rank, top[2].Rank})
+		assert.Equal(t, constants.RankingTop, top[0].Kind)
+		assert.InDelta(t, 8.467, top[0].Score, 0.001)
+
+		trending := stored[3:]
+		assert.Equal(t, []int{3, 1}, []int{trending[0].BookId, trending[1].BookId})
+		assert.Equal(t, constants.RankingTrending, trending[0].Kind)
+		assert.Equal(t, 5, trending[0].Reviews)
+	})
+	t.Run("When Success Configured Prior Mean", func(t *testing.T) {
+		rankingUsecase = rankings.NewRankingUsecase(rankingRepository, rankings.Options{PriorWeight: 1, PriorMean: 5, TrendingWindow: 7 * 24 * time.Hour})
+		rankingRepository.Mock.On("GetStats", mock.Anything, mock.MatchedBy(isAllTime)).Return(allTimeStats[:1], nil).Once()
+		rankingRepository.Mock.On("GetStats", mock.Anything, mock.MatchedBy(isWithinWindow)).Return([]rankings.Stat{}, nil).Once()
+		rankingRepository.Mock.On("Replace", mock.Anything, []rankings.Ranking{
+			{Kind: constants.RankingTop, BookId: 1, Score: 7.5, Reviews: 1, Rank: 1},
+		}).Return(nil).Once()
+
+		err := rankingUsecase.Refresh(context.Background())
+
+		assert.Nil(t, err)
+	})
+	t.Run("When Failure Get Stats", func(t *testing.T) {
+		rankingRepository.Mock.On("GetStats", mock.Anything, mock.MatchedBy(isAllTime)).Return(nil, errors.New("connection refused")).Once()
+
+		err := rankingUsecase.Refresh(context.Background())
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetByKind(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Top Books", func(t *testing.T) {
+		top := []rankings.Ranking{{Kind: constants.RankingTop, BookId: 2, Book: books.Domain{ID: 2, Title: "Atomic Habits"}, Score: 8.47, Reviews: 50, Rank: 1}}
+		rankingRepository.Mock.On("GetByKind", mock.Anything, constants.RankingTop, constants.DefaultRankingLimit).Return(top, nil).Once()
+
+		result, statusCode, err := rankingUsecase.GetByKind(context.Background(), constants.RankingTop, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, top, result)
+	})
+	t.Run("When Failure Unknown Ranking", func(t *testing.T) {
+		_, statusCode, err := rankingUsecase.GetByKind(context.Background(), "newest", 0)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
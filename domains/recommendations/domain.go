@@ -0,0 +1,37 @@
+package recommendations
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+// Rating is a single review rating, the input of the collaborative filtering
+type Rating struct {
+	UserId int
+	BookId int
+	Rating int
+}
+
+// Recommendation is a book picked for a user, Score is the rating the user is predicted to give it
+type Recommendation struct {
+	UserId    int
+	BookId    int
+	Book      books.Domain
+	Score     float64
+	Rank      int
+	CreatedAt time.Time
+}
+
+type Usecase interface {
+	Refresh(ctx context.Context) (stored int, err error)
+	GetByUserId(ctx context.Context, userId int, limit int) (recommendations []Recommendation, source string, statusCode int, err error)
+}
+
+type Repository interface {
+	GetRatings(ctx context.Context) ([]Rating, error)
+	Replace(ctx context.Context, recommendations []Recommendation) error
+	GetByUserId(ctx context.Context, userId int, limit int) ([]Recommendation, error)
+	GetTopRated(ctx context.Context, userId int, limit int) ([]books.Domain, error)
+}
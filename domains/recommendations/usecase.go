@@ -0,0 +1,198 @@
+package recommendations
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/snykk/golib_backend/constants"
+)
+
+type recommendationUsecase struct {
+	repo Repository
+}
+
+func NewRecommendationUsecase(repo Repository) Usecase {
+	return &recommendationUsecase{
+		repo: repo,
+	}
+}
+
+func (uc *recommendationUsecase) Refresh(ctx context.Context) (int, error) {
+	ratings, err := uc.repo.GetRatings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	recommendations := computeRecommendations(ratings, constants.MaxStoredRecommendations)
+	if err := uc.repo.Replace(ctx, recommendations); err != nil {
+		return 0, err
+	}
+
+	return len(recommendations), nil
+}
+
+func (uc *recommendationUsecase) GetByUserId(ctx context.Context, userId int, limit int) ([]Recommendation, string, int, error) {
+	if limit < 1 {
+		limit = constants.DefaultRecommendationLimit
+	}
+	if limit > constants.MaxRecommendationLimit {
+		limit = constants.MaxRecommendationLimit
+	}
+
+	recommendations, err := uc.repo.GetByUserId(ctx, userId, limit)
+	if err != nil {
+		return []Recommendation{}, "", http.StatusInternalServerError, err
+	}
+	if len(recommendations) > 0 {
+		return recommendations, constants.RecommendationPersonalized, http.StatusOK, nil
+	}
+
+	// users without reviews, or whose reviews overlap with nobody else's, start out with the best rated books
+	topRated, err := uc.repo.GetTopRated(ctx, userId, limit)
+	if err != nil {
+		return []Recommendation{}, "", http.StatusInternalServerError, err
+	}
+
+	recommendations = make([]Recommendation, 0, len(topRated))
+	for i, book := range topRated {
+		var score float64
+		if book.Rating != nil {
+			score = *book.Rating
+		}
+		recommendations = append(recommendations, Recommendation{UserId: userId, BookId: book.ID, Book: book, Score: score, Rank: i + 1})
+	}
+
+	return recommendations, constants.RecommendationTopRated, http.StatusOK, nil
+}
+
+type bookPair struct {
+	a int
+	b int
+}
+
+type pairStats struct {
+	dot   float64
+	normA float64
+	normB float64
+	users int
+}
+
+// computeRecommendations scores the books each user hasn't reviewed with item-item collaborative filtering.
+// Two books are similar when the users who reviewed both rated them above or below their own average alike
+// (adjusted cosine), and a book is predicted from how the user rated the books most similar to it. Only books
+// predicted above the user's own average are kept, at most perUser of them.
+func computeRecommendations(ratings []Rating, perUser int) []Recommendation {
+	byUser := make(map[int]map[int]float64)
+	for _, rating := range ratings {
+		if byUser[rating.UserId] == nil {
+			byUser[rating.UserId] = make(map[int]float64)
+		}
+		byUser[rating.UserId][rating.BookId] = float64(rating.Rating)
+	}
+
+	means := make(map[int]float64, len(byUser))
+	for userId, items := range byUser {
+		var sum float64
+		for _, rating := range items {
+			sum += rating
+		}
+		means[userId] = sum / float64(len(items))
+	}
+
+	stats := make(map[bookPair]*pairStats)
+	for userId, items := range byUser {
+		bookIds := make([]int, 0, len(items))
+		for bookId := range items {
+			bookIds = append(bookIds, bookId)
+		}
+		sort.Ints(bookIds)
+
+		for x := 0; x < len(bookIds); x++ {
+			for y := x + 1; y < len(bookIds); y++ {
+				pair := bookPair{a: bookIds[x], b: bookIds[y]}
+				deviationA := items[pair.a] - means[userId]
+				deviationB := items[pair.b] - means[userId]
+
+				s, ok := stats[pair]
+				if !ok {
+					s = &pairStats{}
+					stats[pair] = s
+				}
+				s.dot += deviationA * deviationB
+				s.normA += deviationA * deviationA
+				s.normB += deviationB * deviationB
+				s.users++
+			}
+		}
+	}
+
+	neighbours := make(map[int]map[int]float64)
+	for pair, s := range stats {
+		if s.users < constants.MinCoRaters || s.normA == 0 || s.normB == 0 {
+			continue
+		}
+		similarity := s.dot / math.Sqrt(s.normA*s.normB)
+		if similarity <= 0 {
+			continue
+		}
+
+		if neighbours[pair.a] == nil {
+			neighbours[pair.a] = make(map[int]float64)
+		}
+		if neighbours[pair.b] == nil {
+			neighbours[pair.b] = make(map[int]float64)
+		}
+		neighbours[pair.a][pair.b] = similarity
+		neighbours[pair.b][pair.a] = similarity
+	}
+
+	var result []Recommendation
+	for userId, items := range byUser {
+		weighted := make(map[int]float64)
+		weights := make(map[int]float64)
+		for ratedId, rating := range items {
+			for candidateId, similarity := range neighbours[ratedId] {
+				if _, reviewed := items[candidateId]; reviewed {
+					continue
+				}
+				weighted[candidateId] += similarity * (rating - means[userId])
+				weights[candidateId] += similarity
+			}
+		}
+
+		var picks []Recommendation
+		for candidateId, weight := range weighted {
+			if weight <= 0 {
+				continue
+			}
+			score := math.Min(means[userId]+weight/weights[candidateId], 10)
+			picks = append(picks, Recommendation{UserId: userId, BookId: candidateId, Score: score})
+		}
+
+		sort.Slice(picks, func(i, j int) bool {
+			if picks[i].Score != picks[j].Score {
+				return picks[i].Score > picks[j].Score
+			}
+			return picks[i].BookId < picks[j].BookId
+		})
+		if len(picks) > perUser {
+			picks = picks[:perUser]
+		}
+		for i := range picks {
+			picks[i].Rank = i + 1
+		}
+
+		result = append(result, picks...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].UserId != result[j].UserId {
+			return result[i].UserId < result[j].UserId
+		}
+		return result[i].Rank < result[j].Rank
+	})
+
+	return result
+}
@@ -0,0 +1,113 @@
+package recommendations_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	recommendationMocks "github.com/snykk/golib_backend/datasources/databases/recommendations/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/recommendations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	recommendationRepository *recommendationMocks.Repository
+	recommendationUsecase    recommendations.Usecase
+	ratingsFromDB            []recommendations.Rating
+	bookFromDB               books.Domain
+)
+
+func setup(t *testing.T) {
+	recommendationRepository = recommendationMocks.NewRepository(t)
+	recommendationUsecase = recommendations.NewRecommendationUsecase(recommendationRepository)
+
+	// the first two readers like books 1 and 2 and dislike book 3, the third one shares their taste but hasn't read book 2
+	ratingsFromDB = []recommendations.Rating{
+		{UserId: 1, BookId: 1, Rating: 9}, {UserId: 1, BookId: 2, Rating: 8}, {UserId: 1, BookId: 3, Rating: 2},
+		{UserId: 2, BookId: 1, Rating: 8}, {UserId: 2, BookId: 2, Rating: 9}, {UserId: 2, BookId: 3, Rating: 3},
+		{UserId: 3, BookId: 1, Rating: 10}, {UserId: 3, BookId: 3, Rating: 1},
+	}
+
+	rating := 8.5
+	bookFromDB = books.Domain{
+		ID:          2,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      &rating,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	setup(t)
+	t.Run("When Success Refresh Recommendations", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetRatings", mock.Anything).Return(ratingsFromDB, nil).Once()
+		recommendationRepository.Mock.On("Replace", mock.Anything, mock.MatchedBy(func(recs []recommendations.Recommendation) bool {
+			return len(recs) == 1 && recs[0].UserId == 3 && recs[0].BookId == 2 && recs[0].Rank == 1 && math.Abs(recs[0].Score-10) < 1e-9
+		})).Return(nil).Once()
+
+		stored, err := recommendationUsecase.Refresh(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, stored)
+	})
+	t.Run("When Success Not Enough Co-Raters", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetRatings", mock.Anything).Return(ratingsFromDB[3:], nil).Once()
+		recommendationRepository.Mock.On("Replace", mock.Anything, []recommendations.Recommendation(nil)).Return(nil).Once()
+
+		stored, err := recommendationUsecase.Refresh(context.Background())
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, stored)
+	})
+	t.Run("When Failure Get Ratings", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetRatings", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := recommendationUsecase.Refresh(context.Background())
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetByUserId(t *testing.T) {
+	setup(t)
+	t.Run("When Success Personalized", func(t *testing.T) {
+		stored := []recommendations.Recommendation{{UserId: 3, BookId: 2, Book: bookFromDB, Score: 10, Rank: 1}}
+		recommendationRepository.Mock.On("GetByUserId", mock.Anything, 3, constants.DefaultRecommendationLimit).Return(stored, nil).Once()
+
+		result, source, statusCode, err := recommendationUsecase.GetByUserId(context.Background(), 3, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.RecommendationPersonalized, source)
+		assert.Equal(t, stored, result)
+	})
+	t.Run("When Success Cold Start", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetByUserId", mock.Anything, 4, constants.MaxRecommendationLimit).Return([]recommendations.Recommendation{}, nil).Once()
+		recommendationRepository.Mock.On("GetTopRated", mock.Anything, 4, constants.MaxRecommendationLimit).Return([]books.Domain{bookFromDB}, nil).Once()
+
+		result, source, statusCode, err := recommendationUsecase.GetByUserId(context.Background(), 4, 500)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, constants.RecommendationTopRated, source)
+		assert.Equal(t, []recommendations.Recommendation{{UserId: 4, BookId: 2, Book: bookFromDB, Score: 8.5, Rank: 1}}, result)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetByUserId", mock.Anything, 5, constants.DefaultRecommendationLimit).Return(nil, errors.New("connection refused")).Once()
+
+		_, _, statusCode, err := recommendationUsecase.GetByUserId(context.Background(), 5, 0)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+	})
+}
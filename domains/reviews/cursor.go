@@ -0,0 +1,48 @@
+package reviews
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor is the position of the last review of a page, it holds every column a sort order can key on
+// so the next page starts right after it no matter how many reviews were written in between
+type Cursor struct {
+	Sort         string    `json:"s"`
+	ID           int       `json:"i"`
+	Rating       int       `json:"r"`
+	HelpfulCount int       `json:"h"`
+	CreatedAt    time.Time `json:"c"`
+}
+
+func CursorOf(sort string, review Domain) Cursor {
+	return Cursor{
+		Sort:         sort,
+		ID:           review.ID,
+		Rating:       review.Rating,
+		HelpfulCount: review.HelpfulCount,
+		CreatedAt:    review.CreatedAt,
+	}
+}
+
+// Encode turns the cursor into the opaque token handed to clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+	var cursor Cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, errors.New("cursor is invalid")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID < 1 {
+		return Cursor{}, errors.New("cursor is invalid")
+	}
+
+	return cursor, nil
+}
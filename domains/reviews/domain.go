@@ -2,42 +2,80 @@ package reviews
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/snykk/golib_backend/constants"
 	"github.com/snykk/golib_backend/domains/books"
 	"github.com/snykk/golib_backend/domains/users"
 )
 
 type Domain struct {
-	ID        int
-	Text      string
-	Rating    int
-	BookId    int
-	Book      books.Domain
-	UserId    int
-	User      users.Domain
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           int
+	Text         string
+	Rating       int
+	HelpfulCount int // upvotes minus downvotes, kept up to date by every vote
+	BookId       int
+	Book         books.Domain
+	UserId       int
+	User         users.Domain
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Query selects a page of reviews, Cursor is the token of the previous page and After the position it decodes to
+type Query struct {
+	Sort   string
+	Limit  int
+	Cursor string
+	After  *Cursor
+}
+
+// ApplyDefaults fills in the ordering and page size the client left out and caps the page size
+func (q *Query) ApplyDefaults() {
+	if q.Limit < 1 {
+		q.Limit = constants.DefaultReviewLimit
+	}
+	if q.Limit > constants.MaxReviewLimit {
+		q.Limit = constants.MaxReviewLimit
+	}
+	if q.Sort == "" {
+		q.Sort = constants.DefaultReviewSort
+	}
+}
+
+// CacheKey identifies a page so that every distinct query gets its own cache entry, it is built after
+// ApplyDefaults so a left out limit and the default limit share one
+func (q *Query) CacheKey() string {
+	return fmt.Sprintf("limit=%d&sort=%s&cursor=%s", q.Limit, q.Sort, q.Cursor)
 }
 
 type Usecase interface {
 	Store(ctx context.Context, review *Domain, userId int) (domain Domain, statusCode int, err error)
-	GetAll(ctx context.Context) (domains []Domain, statusCode int, err error)
+	GetAll(ctx context.Context, query *Query) (domains []Domain, nextCursor string, statusCode int, err error)
 	GetById(ctx context.Context, id int) (domain Domain, statusCode int, err error)
-	GetByBookId(ctx context.Context, bookId int) (domains []Domain, statusCode int, err error)
-	GetByUserId(ctx context.Context, userId int) (domains []Domain, statusCode int, err error)
+	GetByBookId(ctx context.Context, bookId int, query *Query) (domains []Domain, nextCursor string, statusCode int, err error)
+	GetByUserId(ctx context.Context, userId int, query *Query) (domains []Domain, nextCursor string, statusCode int, err error)
 	Update(ctx context.Context, review *Domain, userId, reviewId int) (domain Domain, statusCode int, err error)
 	Delete(ctx context.Context, userId, reviewId int) (bookId int, statusCode int, err error)
 	GetUserReview(ctx context.Context, bookId, userId int) (domain Domain, statusCode int, err error)
+	Vote(ctx context.Context, userId, reviewId int, vote string) (helpfulCount int, statusCode int, err error)
+	Unvote(ctx context.Context, userId, reviewId int) (helpfulCount int, statusCode int, err error)
 }
 
 type Repository interface {
 	Store(ctx context.Context, domain *Domain) (Domain, error)
-	GetAll(ctx context.Context) ([]Domain, error)
+	// GetAll, GetByBookId and GetByUserId return up to Limit+1 reviews after query.After,
+	// the extra review only tells the caller that another page follows
+	GetAll(ctx context.Context, query *Query) ([]Domain, error)
 	GetById(ctx context.Context, id int) (Domain, error)
-	GetByBookId(ctx context.Context, bookId int) ([]Domain, error)
-	GetByUserId(ctx context.Context, userId int) ([]Domain, error)
+	GetByBookId(ctx context.Context, bookId int, query *Query) ([]Domain, error)
+	GetRecentByBookId(ctx context.Context, bookId, page, limit int) ([]Domain, int, error)
+	GetByUserId(ctx context.Context, userId int, query *Query) ([]Domain, error)
 	Update(ctx context.Context, domain *Domain) error
 	Delete(ctx context.Context, domain *Domain) (bookId int, err error)
 	GetUserReview(ctx context.Context, bookId, userId int) (Domain, error)
+	// Vote records the vote of a user or replaces the one they cast before, value is 1 for helpful and -1 for not helpful
+	Vote(ctx context.Context, reviewId, userId, value int) (helpfulCount int, err error)
+	Unvote(ctx context.Context, reviewId, userId int) (helpfulCount int, removed bool, err error)
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/snykk/golib_backend/constants"
 )
 
 type reviewUsecase struct {
@@ -26,14 +28,10 @@ func (uc *reviewUsecase) Store(ctx context.Context, domain *Domain, userId int)
 	return review, http.StatusCreated, nil
 }
 
-func (uc *reviewUsecase) GetAll(ctx context.Context) ([]Domain, int, error) {
-	domains, err := uc.repo.GetAll(ctx)
-
-	if err != nil {
-		return []Domain{}, http.StatusInternalServerError, err
-	}
-
-	return domains, http.StatusOK, nil
+func (uc *reviewUsecase) GetAll(ctx context.Context, query *Query) ([]Domain, string, int, error) {
+	return uc.paginate(query, func() ([]Domain, error) {
+		return uc.repo.GetAll(ctx, query)
+	})
 }
 
 func (uc *reviewUsecase) GetById(ctx context.Context, id int) (Domain, int, error) {
@@ -46,24 +44,47 @@ func (uc *reviewUsecase) GetById(ctx context.Context, id int) (Domain, int, erro
 	return domain, http.StatusOK, nil
 }
 
-func (uc *reviewUsecase) GetByBookId(ctx context.Context, bookId int) ([]Domain, int, error) {
-	domain, err := uc.repo.GetByBookId(ctx, bookId)
-
-	if err != nil {
-		return []Domain{}, http.StatusNotFound, fmt.Errorf("review with book id %d is not exists", bookId)
-	}
+func (uc *reviewUsecase) GetByBookId(ctx context.Context, bookId int, query *Query) ([]Domain, string, int, error) {
+	return uc.paginate(query, func() ([]Domain, error) {
+		return uc.repo.GetByBookId(ctx, bookId, query)
+	})
+}
 
-	return domain, http.StatusOK, nil
+func (uc *reviewUsecase) GetByUserId(ctx context.Context, userId int, query *Query) ([]Domain, string, int, error) {
+	return uc.paginate(query, func() ([]Domain, error) {
+		return uc.repo.GetByUserId(ctx, userId, query)
+	})
 }
 
-func (uc *reviewUsecase) GetByUserId(ctx context.Context, userId int) ([]Domain, int, error) {
-	domain, err := uc.repo.GetByUserId(ctx, userId)
+// paginate decodes the cursor of the query, lets fetch read the page after it and hands out the cursor of the next page,
+// which stays empty on the last page
+func (uc *reviewUsecase) paginate(query *Query, fetch func() ([]Domain, error)) ([]Domain, string, int, error) {
+	query.ApplyDefaults()
+
+	if query.Cursor != "" {
+		cursor, err := DecodeCursor(query.Cursor)
+		if err != nil {
+			return []Domain{}, "", http.StatusBadRequest, err
+		}
+		// the position of a review is only meaningful in the order it was read in
+		if cursor.Sort != query.Sort {
+			return []Domain{}, "", http.StatusBadRequest, fmt.Errorf("cursor was issued for sort %s", cursor.Sort)
+		}
+		query.After = &cursor
+	}
 
+	domains, err := fetch()
 	if err != nil {
-		return []Domain{}, http.StatusNotFound, fmt.Errorf("review with book id %d is not exists", userId)
+		return []Domain{}, "", http.StatusInternalServerError, err
 	}
 
-	return domain, http.StatusOK, nil
+	var nextCursor string
+	if len(domains) > query.Limit {
+		domains = domains[:query.Limit]
+		nextCursor = CursorOf(query.Sort, domains[len(domains)-1]).Encode()
+	}
+
+	return domains, nextCursor, http.StatusOK, nil
 }
 
 func (uc *reviewUsecase) Update(ctx context.Context, domain *Domain, userId, reviewId int) (Domain, int, error) {
@@ -113,3 +134,41 @@ func (uc *reviewUsecase) GetUserReview(ctx context.Context, bookId, userId int)
 	}
 	return userReview, http.StatusOK, err
 }
+
+func (uc *reviewUsecase) Vote(ctx context.Context, userId, reviewId int, vote string) (int, int, error) {
+	value, ok := constants.MapperReviewVoteToValue[vote]
+	if !ok {
+		return 0, http.StatusBadRequest, fmt.Errorf("vote must be one of [%s, %s]", constants.ReviewVoteUp, constants.ReviewVoteDown)
+	}
+
+	review, err := uc.repo.GetById(ctx, reviewId)
+	if err != nil {
+		return 0, http.StatusNotFound, errors.New("review not found")
+	}
+	if review.UserId == userId {
+		return 0, http.StatusBadRequest, errors.New("you can't vote on your own review")
+	}
+
+	helpfulCount, err := uc.repo.Vote(ctx, reviewId, userId, value)
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+
+	return helpfulCount, http.StatusOK, nil
+}
+
+func (uc *reviewUsecase) Unvote(ctx context.Context, userId, reviewId int) (int, int, error) {
+	if _, err := uc.repo.GetById(ctx, reviewId); err != nil {
+		return 0, http.StatusNotFound, errors.New("review not found")
+	}
+
+	helpfulCount, removed, err := uc.repo.Unvote(ctx, reviewId, userId)
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+	if !removed {
+		return 0, http.StatusNotFound, errors.New("you haven't voted on this review")
+	}
+
+	return helpfulCount, http.StatusOK, nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/snykk/golib_backend/constants"
 	reviewMocks "github.com/snykk/golib_backend/datasources/databases/reviews/mocks"
 	"github.com/snykk/golib_backend/domains/books"
 	"github.com/snykk/golib_backend/domains/reviews"
@@ -99,23 +100,76 @@ func TestStore(t *testing.T) {
 func TestGetAll(t *testing.T) {
 	setup(t)
 	t.Run("When Success Get reviews Data", func(t *testing.T) {
-		reviewRepository.Mock.On("GetAll", mock.Anything).Return(reviewsDataFromDB, nil).Once()
-		result, statusCode, err := reviewUsecase.GetAll(context.Background())
+		query := reviews.Query{}
+		reviewRepository.Mock.On("GetAll", mock.Anything, &query).Return(reviewsDataFromDB, nil).Once()
+		result, nextCursor, statusCode, err := reviewUsecase.GetAll(context.Background(), &query)
 
 		assert.Nil(t, err)
 		assert.NotEqual(t, 0, result[0].ID)
 		assert.Equal(t, http.StatusOK, statusCode)
 		assert.Equal(t, reviewsDataFromDB, result)
+		assert.Empty(t, nextCursor)
+		assert.Equal(t, constants.DefaultReviewLimit, query.Limit)
+		assert.Equal(t, constants.ReviewSortNewest, query.Sort)
+	})
+
+	t.Run("When Success Next Page Follows", func(t *testing.T) {
+		secondReview := reviewDataFromDB
+		secondReview.ID = 2
+		secondReview.Rating = 7
+		query := reviews.Query{Limit: 1, Sort: constants.ReviewSortHighestRating}
+		reviewRepository.Mock.On("GetAll", mock.Anything, &query).Return([]reviews.Domain{reviewDataFromDB, secondReview}, nil).Once()
+
+		result, nextCursor, statusCode, err := reviewUsecase.GetAll(context.Background(), &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []reviews.Domain{reviewDataFromDB}, result)
+
+		cursor, err := reviews.DecodeCursor(nextCursor)
+		assert.Nil(t, err)
+		assert.Equal(t, reviewDataFromDB.ID, cursor.ID)
+		assert.Equal(t, reviewDataFromDB.Rating, cursor.Rating)
+		assert.Equal(t, constants.ReviewSortHighestRating, cursor.Sort)
+	})
+
+	t.Run("When Success Cursor Is Passed On", func(t *testing.T) {
+		token := reviews.CursorOf(constants.ReviewSortOldest, reviewDataFromDB).Encode()
+		reviewRepository.Mock.On("GetAll", mock.Anything, mock.MatchedBy(func(query *reviews.Query) bool {
+			return query.After != nil && query.After.ID == reviewDataFromDB.ID && query.After.CreatedAt.Equal(reviewDataFromDB.CreatedAt)
+		})).Return([]reviews.Domain{}, nil).Once()
+
+		result, nextCursor, statusCode, err := reviewUsecase.GetAll(context.Background(), &reviews.Query{Sort: constants.ReviewSortOldest, Cursor: token})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Empty(t, result)
+		assert.Empty(t, nextCursor)
 	})
 
 	t.Run("When Failure Get reviews Data", func(t *testing.T) {
-		reviewRepository.Mock.On("GetAll", mock.Anything).Return([]reviews.Domain{}, errors.New("get all reviews failed")).Once()
-		result, statusCode, err := reviewUsecase.GetAll(context.Background())
+		reviewRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{}, errors.New("get all reviews failed")).Once()
+		result, _, statusCode, err := reviewUsecase.GetAll(context.Background(), &reviews.Query{})
 
 		assert.NotNil(t, err)
 		assert.Equal(t, []reviews.Domain{}, result)
 		assert.Equal(t, http.StatusInternalServerError, statusCode)
 	})
+
+	t.Run("When Failure Invalid Cursor", func(t *testing.T) {
+		_, _, statusCode, err := reviewUsecase.GetAll(context.Background(), &reviews.Query{Cursor: "not-a-cursor"})
+
+		assert.EqualError(t, err, "cursor is invalid")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Cursor Of Another Sort", func(t *testing.T) {
+		token := reviews.CursorOf(constants.ReviewSortNewest, reviewDataFromDB).Encode()
+		_, _, statusCode, err := reviewUsecase.GetAll(context.Background(), &reviews.Query{Sort: constants.ReviewSortMostHelpful, Cursor: token})
+
+		assert.EqualError(t, err, "cursor was issued for sort newest")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
 }
 
 func TestGetById(t *testing.T) {
@@ -130,7 +184,7 @@ func TestGetById(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
-	t.Run("When Failure Review doesn't exist", func(t *testing.T) {
+	t.Run("When Failure Reviews Can't Be Read", func(t *testing.T) {
 		reviewRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(reviews.Domain{}, errors.New("review not found")).Once()
 
 		result, statusCode, err := reviewUsecase.GetById(context.Background(), reviewDataFromDB.ID)
@@ -217,46 +271,46 @@ func TestUpdate(t *testing.T) {
 func TestGetByBookId(t *testing.T) {
 	setup(t)
 	t.Run("When Success Get review Data", func(t *testing.T) {
-		reviewRepository.Mock.On("GetByBookId", mock.Anything, mock.AnythingOfType("int")).Return([]reviews.Domain{reviewDataFromDB}, nil).Once()
+		reviewRepository.Mock.On("GetByBookId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{reviewDataFromDB}, nil).Once()
 
-		result, statusCode, err := reviewUsecase.GetByBookId(context.Background(), reviewDataFromDB.BookId)
+		result, _, statusCode, err := reviewUsecase.GetByBookId(context.Background(), reviewDataFromDB.BookId, &reviews.Query{})
 
 		assert.Equal(t, []reviews.Domain{reviewDataFromDB}, result)
 		assert.Equal(t, http.StatusOK, statusCode)
 		assert.Nil(t, err)
 	})
 
-	t.Run("When Failure Review doesn't exist", func(t *testing.T) {
-		reviewRepository.Mock.On("GetByBookId", mock.Anything, mock.AnythingOfType("int")).Return([]reviews.Domain{}, errors.New("review doesn't exist")).Once()
+	t.Run("When Failure Reviews Can't Be Read", func(t *testing.T) {
+		reviewRepository.Mock.On("GetByBookId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{}, errors.New("review doesn't exist")).Once()
 
-		result, statusCode, err := reviewUsecase.GetByBookId(context.Background(), reviewDataFromDB.BookId)
+		result, _, statusCode, err := reviewUsecase.GetByBookId(context.Background(), reviewDataFromDB.BookId, &reviews.Query{})
 
 		assert.Equal(t, []reviews.Domain{}, result)
 		assert.NotNil(t, err)
-		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
 	})
 }
 
 func TestGetByUserId(t *testing.T) {
 	setup(t)
 	t.Run("When Success Get review Data", func(t *testing.T) {
-		reviewRepository.Mock.On("GetByUserId", mock.Anything, mock.AnythingOfType("int")).Return([]reviews.Domain{reviewDataFromDB}, nil).Once()
+		reviewRepository.Mock.On("GetByUserId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{reviewDataFromDB}, nil).Once()
 
-		result, statusCode, err := reviewUsecase.GetByUserId(context.Background(), reviewDataFromDB.UserId)
+		result, _, statusCode, err := reviewUsecase.GetByUserId(context.Background(), reviewDataFromDB.UserId, &reviews.Query{})
 
 		assert.Equal(t, []reviews.Domain{reviewDataFromDB}, result)
 		assert.Equal(t, http.StatusOK, statusCode)
 		assert.Nil(t, err)
 	})
 
-	t.Run("When Failure Review doesn't exist", func(t *testing.T) {
-		reviewRepository.Mock.On("GetByUserId", mock.Anything, mock.AnythingOfType("int")).Return([]reviews.Domain{}, errors.New("review doesn't exist")).Once()
+	t.Run("When Failure Reviews Can't Be Read", func(t *testing.T) {
+		reviewRepository.Mock.On("GetByUserId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{}, errors.New("review doesn't exist")).Once()
 
-		result, statusCode, err := reviewUsecase.GetByUserId(context.Background(), reviewDataFromDB.UserId)
+		result, _, statusCode, err := reviewUsecase.GetByUserId(context.Background(), reviewDataFromDB.UserId, &reviews.Query{})
 
 		assert.NotNil(t, err)
 		assert.Equal(t, []reviews.Domain{}, result)
-		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
 	})
 }
 
@@ -282,3 +336,75 @@ func TestGetUserReview(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, statusCode)
 	})
 }
+
+func TestVote(t *testing.T) {
+	setup(t)
+	voterId := userFromDB.ID + 1
+	t.Run("When Success Vote On Review", func(t *testing.T) {
+		reviewRepository.Mock.On("GetById", mock.Anything, reviewDataFromDB.ID).Return(reviewDataFromDB, nil).Once()
+		reviewRepository.Mock.On("Vote", mock.Anything, reviewDataFromDB.ID, voterId, -1).Return(-1, nil).Once()
+
+		helpfulCount, statusCode, err := reviewUsecase.Vote(context.Background(), voterId, reviewDataFromDB.ID, constants.ReviewVoteDown)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, -1, helpfulCount)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Invalid Vote", func(t *testing.T) {
+			_, statusCode, err := reviewUsecase.Vote(context.Background(), voterId, reviewDataFromDB.ID, "sideways")
+
+			assert.EqualError(t, err, "vote must be one of [up, down]")
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Review Not Found", func(t *testing.T) {
+			reviewRepository.Mock.On("GetById", mock.Anything, 99).Return(reviews.Domain{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := reviewUsecase.Vote(context.Background(), voterId, 99, constants.ReviewVoteUp)
+
+			assert.EqualError(t, err, "review not found")
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("Own Review", func(t *testing.T) {
+			reviewRepository.Mock.On("GetById", mock.Anything, reviewDataFromDB.ID).Return(reviewDataFromDB, nil).Once()
+
+			_, statusCode, err := reviewUsecase.Vote(context.Background(), reviewDataFromDB.UserId, reviewDataFromDB.ID, constants.ReviewVoteUp)
+
+			assert.EqualError(t, err, "you can't vote on your own review")
+			assert.Equal(t, http.StatusBadRequest, statusCode)
+		})
+		t.Run("Vote Failed", func(t *testing.T) {
+			reviewRepository.Mock.On("GetById", mock.Anything, reviewDataFromDB.ID).Return(reviewDataFromDB, nil).Once()
+			reviewRepository.Mock.On("Vote", mock.Anything, reviewDataFromDB.ID, voterId, 1).Return(0, errors.New("failed")).Once()
+
+			_, statusCode, err := reviewUsecase.Vote(context.Background(), voterId, reviewDataFromDB.ID, constants.ReviewVoteUp)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusInternalServerError, statusCode)
+		})
+	})
+}
+
+func TestUnvote(t *testing.T) {
+	setup(t)
+	voterId := userFromDB.ID + 1
+	t.Run("When Success Withdraw Vote", func(t *testing.T) {
+		reviewRepository.Mock.On("GetById", mock.Anything, reviewDataFromDB.ID).Return(reviewDataFromDB, nil).Once()
+		reviewRepository.Mock.On("Unvote", mock.Anything, reviewDataFromDB.ID, voterId).Return(0, true, nil).Once()
+
+		helpfulCount, statusCode, err := reviewUsecase.Unvote(context.Background(), voterId, reviewDataFromDB.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 0, helpfulCount)
+	})
+	t.Run("When Failure Never Voted", func(t *testing.T) {
+		reviewRepository.Mock.On("GetById", mock.Anything, reviewDataFromDB.ID).Return(reviewDataFromDB, nil).Once()
+		reviewRepository.Mock.On("Unvote", mock.Anything, reviewDataFromDB.ID, voterId).Return(3, false, nil).Once()
+
+		_, statusCode, err := reviewUsecase.Unvote(context.Background(), voterId, reviewDataFromDB.ID)
+
+		assert.EqualError(t, err, "you haven't voted on this review")
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
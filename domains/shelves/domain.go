@@ -0,0 +1,79 @@
+package shelves
+
+import (
+	"context"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+// Entry is a book a user keeps track of, on one of the built-in status shelves or added to one of their own shelves
+type Entry struct {
+	UserId      int
+	BookId      int
+	Book        books.Domain
+	Status      string
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	CurrentPage *int
+	TotalPages  *int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Shelf is a shelf a user made for themselves, Books counts what is on it
+type Shelf struct {
+	ID        int
+	UserId    int
+	Name      string
+	Books     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Query struct {
+	Page  int
+	Limit int
+}
+
+func (q *Query) ApplyDefaults() {
+	if q.Page < 1 {
+		q.Page = constants.DefaultShelfPage
+	}
+	if q.Limit < 1 {
+		q.Limit = constants.DefaultShelfLimit
+	}
+	if q.Limit > constants.MaxShelfLimit {
+		q.Limit = constants.MaxShelfLimit
+	}
+}
+
+type Usecase interface {
+	GetShelves(ctx context.Context, userId int) (statuses map[string]int, shelves []Shelf, statusCode int, err error)
+	GetBooks(ctx context.Context, userId int, shelf string, query *Query) (entries []Entry, total int, statusCode int, err error)
+	SetStatus(ctx context.Context, userId int, bookId int, entry *Entry) (domain Entry, statusCode int, err error)
+	RemoveBook(ctx context.Context, userId int, bookId int) (statusCode int, err error)
+	CreateShelf(ctx context.Context, userId int, name string) (domain Shelf, statusCode int, err error)
+	RenameShelf(ctx context.Context, userId int, shelfId int, name string) (domain Shelf, statusCode int, err error)
+	DeleteShelf(ctx context.Context, userId int, shelfId int) (statusCode int, err error)
+	AddToShelf(ctx context.Context, userId int, shelfId int, bookId int) (statusCode int, err error)
+	RemoveFromShelf(ctx context.Context, userId int, shelfId int, bookId int) (statusCode int, err error)
+}
+
+type Repository interface {
+	CountStatuses(ctx context.Context, userId int) (map[string]int, error)
+	GetEntries(ctx context.Context, userId int, status string, query *Query) ([]Entry, int, error)
+	GetEntry(ctx context.Context, userId int, bookId int) (Entry, error)
+	SaveEntry(ctx context.Context, entry *Entry) (Entry, error)
+	DeleteEntry(ctx context.Context, userId int, bookId int) error
+	GetShelves(ctx context.Context, userId int) ([]Shelf, error)
+	GetShelf(ctx context.Context, userId int, id int) (Shelf, error)
+	GetShelfByName(ctx context.Context, userId int, name string) (Shelf, error)
+	StoreShelf(ctx context.Context, shelf *Shelf) (Shelf, error)
+	UpdateShelf(ctx context.Context, shelf *Shelf) error
+	DeleteShelf(ctx context.Context, id int) error
+	GetShelfBooks(ctx context.Context, shelfId int, query *Query) ([]Entry, int, error)
+	AddShelfBook(ctx context.Context, shelfId int, bookId int) error
+	RemoveShelfBook(ctx context.Context, shelfId int, bookId int) (removed bool, err error)
+}
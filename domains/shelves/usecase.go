@@ -0,0 +1,273 @@
+package shelves
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type shelfUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+}
+
+func NewShelfUsecase(repo Repository, bookRepo books.Repository) Usecase {
+	return &shelfUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+	}
+}
+
+func (uc *shelfUsecase) GetShelves(ctx context.Context, userId int) (map[string]int, []Shelf, int, error) {
+	statuses, err := uc.repo.CountStatuses(ctx, userId)
+	if err != nil {
+		return nil, []Shelf{}, http.StatusInternalServerError, err
+	}
+
+	shelves, err := uc.repo.GetShelves(ctx, userId)
+	if err != nil {
+		return nil, []Shelf{}, http.StatusInternalServerError, err
+	}
+
+	return statuses, shelves, http.StatusOK, nil
+}
+
+func (uc *shelfUsecase) GetBooks(ctx context.Context, userId int, shelf string, query *Query) ([]Entry, int, int, error) {
+	query.ApplyDefaults()
+
+	if isStatus(shelf) {
+		entries, total, err := uc.repo.GetEntries(ctx, userId, shelf, query)
+		if err != nil {
+			return []Entry{}, 0, http.StatusInternalServerError, err
+		}
+		return entries, total, http.StatusOK, nil
+	}
+
+	shelfId, err := strconv.Atoi(shelf)
+	if err != nil {
+		return []Entry{}, 0, http.StatusBadRequest, fmt.Errorf("shelf must be one of [%s] or the id of one of your shelves", strings.Join(constants.ListShelfStatus, ", "))
+	}
+	if _, err := uc.repo.GetShelf(ctx, userId, shelfId); err != nil {
+		return []Entry{}, 0, http.StatusNotFound, errors.New("shelf not found")
+	}
+
+	entries, total, err := uc.repo.GetShelfBooks(ctx, shelfId, query)
+	if err != nil {
+		return []Entry{}, 0, http.StatusInternalServerError, err
+	}
+
+	return entries, total, http.StatusOK, nil
+}
+
+func (uc *shelfUsecase) SetStatus(ctx context.Context, userId int, bookId int, entry *Entry) (Entry, int, error) {
+	if !isStatus(entry.Status) {
+		return Entry{}, http.StatusBadRequest, fmt.Errorf("status must be one of [%s]", strings.Join(constants.ListShelfStatus, ", "))
+	}
+
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return Entry{}, http.StatusNotFound, errors.New("book not found")
+	}
+
+	// fields left out of the request keep what was tracked before
+	existing, _ := uc.repo.GetEntry(ctx, userId, bookId)
+	entry.UserId = userId
+	entry.BookId = bookId
+	if entry.StartedAt == nil {
+		entry.StartedAt = existing.StartedAt
+	}
+	if entry.FinishedAt == nil {
+		entry.FinishedAt = existing.FinishedAt
+	}
+	if entry.CurrentPage == nil {
+		entry.CurrentPage = existing.CurrentPage
+	}
+	if entry.TotalPages == nil {
+		entry.TotalPages = existing.TotalPages
+	}
+	if entry.CurrentPage == nil {
+		entry.CurrentPage = new(int)
+	}
+
+	now := time.Now()
+	switch entry.Status {
+	case constants.ShelfWantToRead:
+		entry.StartedAt = nil
+		entry.FinishedAt = nil
+		entry.CurrentPage = new(int)
+	case constants.ShelfReading:
+		if entry.StartedAt == nil {
+			entry.StartedAt = &now
+		}
+		entry.FinishedAt = nil
+	case constants.ShelfRead:
+		if entry.FinishedAt == nil {
+			entry.FinishedAt = &now
+		}
+		if entry.TotalPages != nil {
+			lastPage := *entry.TotalPages
+			entry.CurrentPage = &lastPage
+		}
+	}
+
+	if err := validateEntry(entry, now); err != nil {
+		return Entry{}, http.StatusBadRequest, err
+	}
+
+	result, err := uc.repo.SaveEntry(ctx, entry)
+	if err != nil {
+		return Entry{}, http.StatusInternalServerError, err
+	}
+
+	return result, http.StatusOK, nil
+}
+
+func validateEntry(entry *Entry, now time.Time) error {
+	if entry.StartedAt != nil && entry.StartedAt.After(now) {
+		return errors.New("started_at can't be in the future")
+	}
+	if entry.FinishedAt != nil && entry.FinishedAt.After(now) {
+		return errors.New("finished_at can't be in the future")
+	}
+	if entry.StartedAt != nil && entry.FinishedAt != nil && entry.FinishedAt.Before(*entry.StartedAt) {
+		return errors.New("finished_at can't be before started_at")
+	}
+	if *entry.CurrentPage < 0 {
+		return errors.New("current_page can't be negative")
+	}
+	if entry.TotalPages != nil && *entry.CurrentPage > *entry.TotalPages {
+		return fmt.Errorf("current_page can't be past the last page (%d)", *entry.TotalPages)
+	}
+
+	return nil
+}
+
+func (uc *shelfUsecase) RemoveBook(ctx context.Context, userId int, bookId int) (int, error) {
+	if _, err := uc.repo.GetEntry(ctx, userId, bookId); err != nil {
+		return http.StatusNotFound, errors.New("book isn't on any of your status shelves")
+	}
+
+	if err := uc.repo.DeleteEntry(ctx, userId, bookId); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *shelfUsecase) CreateShelf(ctx context.Context, userId int, name string) (Shelf, int, error) {
+	name, err := validateShelfName(name)
+	if err != nil {
+		return Shelf{}, http.StatusBadRequest, err
+	}
+
+	if _, err := uc.repo.GetShelfByName(ctx, userId, name); err == nil {
+		return Shelf{}, http.StatusConflict, fmt.Errorf("you already have a shelf named %q", name)
+	}
+
+	shelf, err := uc.repo.StoreShelf(ctx, &Shelf{UserId: userId, Name: name})
+	if err != nil {
+		return Shelf{}, http.StatusInternalServerError, err
+	}
+
+	return shelf, http.StatusCreated, nil
+}
+
+func (uc *shelfUsecase) RenameShelf(ctx context.Context, userId int, shelfId int, name string) (Shelf, int, error) {
+	shelf, err := uc.repo.GetShelf(ctx, userId, shelfId)
+	if err != nil {
+		return Shelf{}, http.StatusNotFound, errors.New("shelf not found")
+	}
+
+	name, err = validateShelfName(name)
+	if err != nil {
+		return Shelf{}, http.StatusBadRequest, err
+	}
+
+	if existing, err := uc.repo.GetShelfByName(ctx, userId, name); err == nil && existing.ID != shelfId {
+		return Shelf{}, http.StatusConflict, fmt.Errorf("you already have a shelf named %q", name)
+	}
+
+	shelf.Name = name
+	if err := uc.repo.UpdateShelf(ctx, &shelf); err != nil {
+		return Shelf{}, http.StatusInternalServerError, err
+	}
+
+	return shelf, http.StatusOK, nil
+}
+
+func (uc *shelfUsecase) DeleteShelf(ctx context.Context, userId int, shelfId int) (int, error) {
+	if _, err := uc.repo.GetShelf(ctx, userId, shelfId); err != nil {
+		return http.StatusNotFound, errors.New("shelf not found")
+	}
+
+	if err := uc.repo.DeleteShelf(ctx, shelfId); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *shelfUsecase) AddToShelf(ctx context.Context, userId int, shelfId int, bookId int) (int, error) {
+	if _, err := uc.repo.GetShelf(ctx, userId, shelfId); err != nil {
+		return http.StatusNotFound, errors.New("shelf not found")
+	}
+
+	if _, err := uc.bookRepo.GetById(ctx, bookId); err != nil {
+		return http.StatusNotFound, errors.New("book not found")
+	}
+
+	if err := uc.repo.AddShelfBook(ctx, shelfId, bookId); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (uc *shelfUsecase) RemoveFromShelf(ctx context.Context, userId int, shelfId int, bookId int) (int, error) {
+	if _, err := uc.repo.GetShelf(ctx, userId, shelfId); err != nil {
+		return http.StatusNotFound, errors.New("shelf not found")
+	}
+
+	removed, err := uc.repo.RemoveShelfBook(ctx, shelfId, bookId)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !removed {
+		return http.StatusNotFound, errors.New("book isn't on this shelf")
+	}
+
+	return http.StatusOK, nil
+}
+
+func isStatus(shelf string) bool {
+	for _, status := range constants.ListShelfStatus {
+		if status == shelf {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateShelfName trims the name and keeps it apart from the built-in status shelves
+func validateShelfName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", errors.New("shelf name can't be empty")
+	}
+	if utf8.RuneCountInString(name) > constants.MaxShelfNameLength {
+		return "", fmt.Errorf("shelf name can't be longer than %d characters", constants.MaxShelfNameLength)
+	}
+	if isStatus(strings.ReplaceAll(strings.ToLower(name), " ", "_")) {
+		return "", fmt.Errorf("%q is a built-in shelf", name)
+	}
+
+	return name, nil
+}
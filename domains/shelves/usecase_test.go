@@ -0,0 +1,405 @@
+package shelves_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	shelfMocks "github.com/snykk/golib_backend/datasources/databases/shelves/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/shelves"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	shelfRepository *shelfMocks.Repository
+	bookRepository  *bookMocks.Repository
+	shelfUsecase    shelves.Usecase
+	bookFromDB      books.Domain
+	shelfFromDB     shelves.Shelf
+)
+
+func setup(t *testing.T) {
+	shelfRepository = shelfMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	shelfUsecase = shelves.NewShelfUsecase(shelfRepository, bookRepository)
+
+	bookFromDB = books.Domain{
+		ID:        2,
+		Title:     "Atomic Habits",
+		Author:    "James Clear",
+		Publisher: "Gramedia",
+		ISBN:      "9780735211292",
+		CreatedAt: time.Now(),
+	}
+
+	shelfFromDB = shelves.Shelf{
+		ID:        3,
+		UserId:    1,
+		Name:      "Favorites",
+		Books:     2,
+		CreatedAt: time.Now(),
+	}
+}
+
+// expectSaveEntry stores whatever entry it is given
+func expectSaveEntry() *mock.Call {
+	return shelfRepository.Mock.On("SaveEntry", mock.Anything, mock.AnythingOfType("*shelves.Entry")).Return(func(ctx context.Context, entry *shelves.Entry) shelves.Entry {
+		return *entry
+	}, nil)
+}
+
+func TestGetShelves(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Shelves", func(t *testing.T) {
+		counts := map[string]int{constants.ShelfWantToRead: 2, constants.ShelfReading: 1, constants.ShelfRead: 0}
+		shelfRepository.Mock.On("CountStatuses", mock.Anything, 1).Return(counts, nil).Once()
+		shelfRepository.Mock.On("GetShelves", mock.Anything, 1).Return([]shelves.Shelf{shelfFromDB}, nil).Once()
+
+		statuses, result, statusCode, err := shelfUsecase.GetShelves(context.Background(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, counts, statuses)
+		assert.Equal(t, []shelves.Shelf{shelfFromDB}, result)
+	})
+
+	t.Run("When Failure Counting Statuses", func(t *testing.T) {
+		shelfRepository.Mock.On("CountStatuses", mock.Anything, 1).Return(nil, errors.New("count failed")).Once()
+
+		_, _, statusCode, err := shelfUsecase.GetShelves(context.Background(), 1)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Books On A Status Shelf", func(t *testing.T) {
+		entries := []shelves.Entry{{UserId: 1, BookId: 2, Book: bookFromDB, Status: constants.ShelfReading}}
+		shelfRepository.Mock.On("GetEntries", mock.Anything, 1, constants.ShelfReading, &shelves.Query{Page: constants.DefaultShelfPage, Limit: constants.DefaultShelfLimit}).Return(entries, 1, nil).Once()
+
+		result, total, statusCode, err := shelfUsecase.GetBooks(context.Background(), 1, constants.ShelfReading, &shelves.Query{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, entries, result)
+	})
+
+	t.Run("When Success Get Books On A Custom Shelf", func(t *testing.T) {
+		entries := []shelves.Entry{{BookId: 2, Book: bookFromDB}}
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("GetShelfBooks", mock.Anything, 3, &shelves.Query{Page: 2, Limit: constants.MaxShelfLimit}).Return(entries, 21, nil).Once()
+
+		result, total, statusCode, err := shelfUsecase.GetBooks(context.Background(), 1, "3", &shelves.Query{Page: 2, Limit: 500})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 21, total)
+		assert.Equal(t, entries, result)
+	})
+
+	t.Run("When Failure Shelf Is Unknown", func(t *testing.T) {
+		result, _, statusCode, err := shelfUsecase.GetBooks(context.Background(), 1, "finished", &shelves.Query{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, []shelves.Entry{}, result)
+	})
+
+	t.Run("When Failure Shelf Belongs To Someone Else", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 9).Return(shelves.Shelf{}, errors.New("record not found")).Once()
+
+		_, _, statusCode, err := shelfUsecase.GetBooks(context.Background(), 1, "9", &shelves.Query{})
+
+		assert.Equal(t, errors.New("shelf not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestSetStatus(t *testing.T) {
+	setup(t)
+	started := time.Now().AddDate(0, 0, -10)
+	finished := time.Now().AddDate(0, 0, -2)
+	total := 320
+	page := 40
+
+	t.Run("When Success Start Reading", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{}, errors.New("record not found")).Once()
+		expectSaveEntry().Once()
+
+		result, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfReading, CurrentPage: &page, TotalPages: &total})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, result.UserId)
+		assert.Equal(t, 2, result.BookId)
+		assert.NotNil(t, result.StartedAt)
+		assert.Nil(t, result.FinishedAt)
+		assert.Equal(t, &page, result.CurrentPage)
+	})
+
+	t.Run("When Success Update Keeps Tracked Fields", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{UserId: 1, BookId: 2, Status: constants.ShelfReading, StartedAt: &started, CurrentPage: &page, TotalPages: &total}, nil).Once()
+		expectSaveEntry().Once()
+
+		result, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfReading})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, &started, result.StartedAt)
+		assert.Equal(t, &page, result.CurrentPage)
+	})
+
+	t.Run("When Success Marking Read Again Keeps Finish Date", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{UserId: 1, BookId: 2, Status: constants.ShelfRead, StartedAt: &started, FinishedAt: &finished, CurrentPage: &total, TotalPages: &total}, nil).Once()
+		expectSaveEntry().Once()
+
+		result, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfRead})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, &finished, result.FinishedAt)
+	})
+
+	t.Run("When Success Finish Reading Keeps Start Date And Completes Pages", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{UserId: 1, BookId: 2, Status: constants.ShelfReading, StartedAt: &started, CurrentPage: &page, TotalPages: &total}, nil).Once()
+		expectSaveEntry().Once()
+
+		result, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfRead})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, &started, result.StartedAt)
+		assert.NotNil(t, result.FinishedAt)
+		assert.Equal(t, &total, result.CurrentPage)
+	})
+
+	t.Run("When Success Want To Read Clears Progress", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{StartedAt: &started, CurrentPage: &page, TotalPages: &total}, nil).Once()
+		expectSaveEntry().Once()
+
+		result, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfWantToRead})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Nil(t, result.StartedAt)
+		assert.Nil(t, result.FinishedAt)
+		assert.Equal(t, 0, *result.CurrentPage)
+		assert.Equal(t, &total, result.TotalPages)
+	})
+
+	t.Run("When Failure Page Past The End", func(t *testing.T) {
+		pastTheEnd := 400
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfReading, CurrentPage: &pastTheEnd, TotalPages: &total})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Finished Before Started", func(t *testing.T) {
+		finished := started.AddDate(0, 0, -1)
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{StartedAt: &started}, nil).Once()
+
+		_, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: constants.ShelfRead, FinishedAt: &finished})
+
+		assert.Equal(t, errors.New("finished_at can't be before started_at"), err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Status Is Unknown", func(t *testing.T) {
+		_, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 2, &shelves.Entry{Status: "abandoned"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Book Doesn't Exist", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := shelfUsecase.SetStatus(context.Background(), 1, 99, &shelves.Entry{Status: constants.ShelfRead})
+
+		assert.Equal(t, errors.New("book not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestRemoveBook(t *testing.T) {
+	setup(t)
+	t.Run("When Success Remove Book", func(t *testing.T) {
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{UserId: 1, BookId: 2}, nil).Once()
+		shelfRepository.Mock.On("DeleteEntry", mock.Anything, 1, 2).Return(nil).Once()
+
+		statusCode, err := shelfUsecase.RemoveBook(context.Background(), 1, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Book Isn't Tracked", func(t *testing.T) {
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(shelves.Entry{}, errors.New("record not found")).Once()
+
+		statusCode, err := shelfUsecase.RemoveBook(context.Background(), 1, 5)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestCreateShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Create Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelfByName", mock.Anything, 1, "Summer reads").Return(shelves.Shelf{}, errors.New("record not found")).Once()
+		shelfRepository.Mock.On("StoreShelf", mock.Anything, &shelves.Shelf{UserId: 1, Name: "Summer reads"}).Return(shelves.Shelf{ID: 4, UserId: 1, Name: "Summer reads"}, nil).Once()
+
+		result, statusCode, err := shelfUsecase.CreateShelf(context.Background(), 1, "  Summer   reads ")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, statusCode)
+		assert.Equal(t, "Summer reads", result.Name)
+	})
+
+	t.Run("When Failure Name Is Taken", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelfByName", mock.Anything, 1, "favorites").Return(shelfFromDB, nil).Once()
+
+		_, statusCode, err := shelfUsecase.CreateShelf(context.Background(), 1, "favorites")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("When Failure Name Is A Built-in Shelf", func(t *testing.T) {
+		_, statusCode, err := shelfUsecase.CreateShelf(context.Background(), 1, "Want to read")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+
+	t.Run("When Failure Name Is Too Long", func(t *testing.T) {
+		_, statusCode, err := shelfUsecase.CreateShelf(context.Background(), 1, "books I keep meaning to get around to")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestRenameShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Rename Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("GetShelfByName", mock.Anything, 1, "Classics").Return(shelves.Shelf{}, errors.New("record not found")).Once()
+		shelfRepository.Mock.On("UpdateShelf", mock.Anything, mock.AnythingOfType("*shelves.Shelf")).Return(nil).Once()
+
+		result, statusCode, err := shelfUsecase.RenameShelf(context.Background(), 1, 3, "Classics")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "Classics", result.Name)
+	})
+
+	t.Run("When Success Changing Only The Case", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("GetShelfByName", mock.Anything, 1, "FAVORITES").Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("UpdateShelf", mock.Anything, mock.AnythingOfType("*shelves.Shelf")).Return(nil).Once()
+
+		result, statusCode, err := shelfUsecase.RenameShelf(context.Background(), 1, 3, "FAVORITES")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "FAVORITES", result.Name)
+	})
+
+	t.Run("When Failure Shelf Doesn't Exist", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 8).Return(shelves.Shelf{}, errors.New("record not found")).Once()
+
+		_, statusCode, err := shelfUsecase.RenameShelf(context.Background(), 1, 8, "Classics")
+
+		assert.Equal(t, errors.New("shelf not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestDeleteShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Delete Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("DeleteShelf", mock.Anything, 3).Return(nil).Once()
+
+		statusCode, err := shelfUsecase.DeleteShelf(context.Background(), 1, 3)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Shelf Doesn't Exist", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 8).Return(shelves.Shelf{}, errors.New("record not found")).Once()
+
+		statusCode, err := shelfUsecase.DeleteShelf(context.Background(), 1, 8)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestAddToShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Add Book To Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("AddShelfBook", mock.Anything, 3, 2).Return(nil).Once()
+
+		statusCode, err := shelfUsecase.AddToShelf(context.Background(), 1, 3, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Book Doesn't Exist", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+		statusCode, err := shelfUsecase.AddToShelf(context.Background(), 1, 3, 99)
+
+		assert.Equal(t, errors.New("book not found"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
+
+func TestRemoveFromShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Remove Book From Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("RemoveShelfBook", mock.Anything, 3, 2).Return(true, nil).Once()
+
+		statusCode, err := shelfUsecase.RemoveFromShelf(context.Background(), 1, 3, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("When Failure Book Isn't On The Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("RemoveShelfBook", mock.Anything, 3, 5).Return(false, nil).Once()
+
+		statusCode, err := shelfUsecase.RemoveFromShelf(context.Background(), 1, 3, 5)
+
+		assert.Equal(t, errors.New("book isn't on this shelf"), err)
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+}
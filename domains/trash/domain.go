@@ -0,0 +1,40 @@
+package trash
+
+import (
+	"context"
+	"time"
+)
+
+// Item is a soft deleted book, user or review waiting in the trash
+type Item struct {
+	Type      string
+	ID        int
+	Label     string
+	ISBN      string
+	Cover     string
+	BookId    int
+	UserId    int
+	DeletedAt time.Time
+}
+
+type Query struct {
+	Type  string
+	Page  int
+	Limit int
+}
+
+type Usecase interface {
+	GetAll(ctx context.Context, query *Query) (items []Item, total int, statusCode int, err error)
+	Restore(ctx context.Context, itemType string, id int) (item Item, statusCode int, err error)
+	Purge(ctx context.Context, itemType string, id int) (item Item, statusCode int, err error)
+	PurgeExpired(ctx context.Context, before time.Time) (purged []Item, err error)
+}
+
+type Repository interface {
+	GetAll(ctx context.Context, query *Query) ([]Item, int, error)
+	GetById(ctx context.Context, itemType string, id int) (Item, error)
+	GetDeletedBefore(ctx context.Context, before time.Time) ([]Item, error)
+	CountCirculations(ctx context.Context, item *Item) (int, error)
+	Restore(ctx context.Context, item *Item) error
+	Purge(ctx context.Context, item *Item) error
+}
@@ -0,0 +1,161 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type trashUsecase struct {
+	repo     Repository
+	bookRepo books.Repository
+	storage  books.BlobStorage
+}
+
+func NewTrashUsecase(repo Repository, bookRepo books.Repository, storage books.BlobStorage) Usecase {
+	return &trashUsecase{
+		repo:     repo,
+		bookRepo: bookRepo,
+		storage:  storage,
+	}
+}
+
+func (uc *trashUsecase) GetAll(ctx context.Context, query *Query) ([]Item, int, int, error) {
+	if query.Type != "" {
+		if err := validateType(query.Type); err != nil {
+			return []Item{}, 0, http.StatusBadRequest, err
+		}
+	}
+
+	if query.Page < 1 {
+		query.Page = constants.DefaultTrashPage
+	}
+	if query.Limit < 1 {
+		query.Limit = constants.DefaultTrashLimit
+	}
+	if query.Limit > constants.MaxTrashLimit {
+		query.Limit = constants.MaxTrashLimit
+	}
+
+	items, total, err := uc.repo.GetAll(ctx, query)
+	if err != nil {
+		return []Item{}, 0, http.StatusInternalServerError, err
+	}
+
+	return items, total, http.StatusOK, nil
+}
+
+func (uc *trashUsecase) Restore(ctx context.Context, itemType string, id int) (Item, int, error) {
+	item, statusCode, err := uc.getItem(ctx, itemType, id)
+	if err != nil {
+		return Item{}, statusCode, err
+	}
+
+	switch item.Type {
+	case constants.TrashBooks:
+		// another book may have taken over the isbn while this one was in the trash
+		if existing, err := uc.bookRepo.GetByISBN(ctx, item.ISBN); err == nil {
+			return Item{}, http.StatusConflict, fmt.Errorf("isbn %s is already used by book with id %d", item.ISBN, existing.ID)
+		}
+	case constants.TrashReviews:
+		// a review can't come back while its book or author is still gone
+		if _, err := uc.repo.GetById(ctx, constants.TrashBooks, item.BookId); err == nil {
+			return Item{}, http.StatusConflict, fmt.Errorf("book with id %d is in the trash, restore it first", item.BookId)
+		}
+		if _, err := uc.repo.GetById(ctx, constants.TrashUsers, item.UserId); err == nil {
+			return Item{}, http.StatusConflict, fmt.Errorf("user with id %d is in the trash, restore it first", item.UserId)
+		}
+	}
+
+	if err := uc.repo.Restore(ctx, &item); err != nil {
+		return Item{}, http.StatusInternalServerError, err
+	}
+
+	return item, http.StatusOK, nil
+}
+
+func (uc *trashUsecase) Purge(ctx context.Context, itemType string, id int) (Item, int, error) {
+	item, statusCode, err := uc.getItem(ctx, itemType, id)
+	if err != nil {
+		return Item{}, statusCode, err
+	}
+
+	if statusCode, err := uc.purge(ctx, &item); err != nil {
+		return Item{}, statusCode, err
+	}
+
+	return item, http.StatusOK, nil
+}
+
+func (uc *trashUsecase) PurgeExpired(ctx context.Context, before time.Time) ([]Item, error) {
+	items, err := uc.repo.GetDeletedBefore(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var purged []Item
+	for i := range items {
+		statusCode, err := uc.purge(ctx, &items[i])
+		if statusCode == http.StatusConflict {
+			// circulation history outlives the retention, the item stays in the trash
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged = append(purged, items[i])
+	}
+
+	return purged, nil
+}
+
+func (uc *trashUsecase) getItem(ctx context.Context, itemType string, id int) (Item, int, error) {
+	if err := validateType(itemType); err != nil {
+		return Item{}, http.StatusBadRequest, err
+	}
+
+	item, err := uc.repo.GetById(ctx, itemType, id)
+	if err != nil {
+		return Item{}, http.StatusNotFound, fmt.Errorf("%s with id %d not found in the trash", strings.TrimSuffix(itemType, "s"), id)
+	}
+
+	return item, http.StatusOK, nil
+}
+
+func (uc *trashUsecase) purge(ctx context.Context, item *Item) (int, error) {
+	if item.Type != constants.TrashReviews {
+		circulations, err := uc.repo.CountCirculations(ctx, item)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if circulations > 0 {
+			return http.StatusConflict, fmt.Errorf("%s still has %d circulation records and can't be purged", strings.TrimSuffix(item.Type, "s"), circulations)
+		}
+	}
+
+	if err := uc.repo.Purge(ctx, item); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	// the record is gone for good, a cover that can't be removed only leaves an orphan behind
+	if item.Cover != "" {
+		_ = uc.storage.Delete(ctx, books.CoverKeys(item.Cover)...)
+	}
+
+	return http.StatusOK, nil
+}
+
+func validateType(itemType string) error {
+	for _, t := range constants.ListTrashType {
+		if t == itemType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("type must be one of [%s]", strings.Join(constants.ListTrashType, ", "))
+}
@@ -0,0 +1,143 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	trashMocks "github.com/snykk/golib_backend/datasources/databases/trash/mocks"
+	storageMocks "github.com/snykk/golib_backend/datasources/storage/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/trash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	trashRepository *trashMocks.Repository
+	bookRepository  *bookMocks.Repository
+	blobStorage     *storageMocks.BlobStorage
+	trashUsecase    trash.Usecase
+	bookItem        trash.Item
+	userItem        trash.Item
+	reviewItem      trash.Item
+)
+
+func setup(t *testing.T) {
+	trashRepository = trashMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	blobStorage = storageMocks.NewBlobStorage(t)
+	trashUsecase = trash.NewTrashUsecase(trashRepository, bookRepository, blobStorage)
+
+	deletedAt := time.Now().Add(-time.Hour)
+	bookItem = trash.Item{Type: constants.TrashBooks, ID: 1, Label: "Atomic Habits", ISBN: "9780735211292", Cover: "books/1/abc.jpg", DeletedAt: deletedAt}
+	userItem = trash.Item{Type: constants.TrashUsers, ID: 2, Label: "johny", DeletedAt: deletedAt}
+	reviewItem = trash.Item{Type: constants.TrashReviews, ID: 3, Label: "great book", BookId: 1, UserId: 2, DeletedAt: deletedAt}
+}
+
+func TestGetAll(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Trash", func(t *testing.T) {
+		query := trash.Query{Type: constants.TrashBooks}
+		trashRepository.Mock.On("GetAll", mock.Anything, &trash.Query{Type: constants.TrashBooks, Page: constants.DefaultTrashPage, Limit: constants.DefaultTrashLimit}).Return([]trash.Item{bookItem}, 1, nil).Once()
+
+		result, total, statusCode, err := trashUsecase.GetAll(context.Background(), &query)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []trash.Item{bookItem}, result)
+	})
+	t.Run("When Failure Unknown Type", func(t *testing.T) {
+		_, _, statusCode, err := trashUsecase.GetAll(context.Background(), &trash.Query{Type: "copies"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	setup(t)
+	t.Run("When Success Restore Book", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, bookItem.ID).Return(bookItem, nil).Once()
+		bookRepository.Mock.On("GetByISBN", mock.Anything, bookItem.ISBN).Return(books.Domain{}, errors.New("record not found")).Once()
+		trashRepository.Mock.On("Restore", mock.Anything, &bookItem).Return(nil).Once()
+
+		result, statusCode, err := trashUsecase.Restore(context.Background(), constants.TrashBooks, bookItem.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, bookItem, result)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Not In Trash", func(t *testing.T) {
+			trashRepository.Mock.On("GetById", mock.Anything, constants.TrashUsers, 99).Return(trash.Item{}, errors.New("record not found")).Once()
+
+			_, statusCode, err := trashUsecase.Restore(context.Background(), constants.TrashUsers, 99)
+
+			assert.EqualError(t, err, "user with id 99 not found in the trash")
+			assert.Equal(t, http.StatusNotFound, statusCode)
+		})
+		t.Run("ISBN Taken By Another Book", func(t *testing.T) {
+			trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, bookItem.ID).Return(bookItem, nil).Once()
+			bookRepository.Mock.On("GetByISBN", mock.Anything, bookItem.ISBN).Return(books.Domain{ID: 7, ISBN: bookItem.ISBN}, nil).Once()
+
+			_, statusCode, err := trashUsecase.Restore(context.Background(), constants.TrashBooks, bookItem.ID)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+		t.Run("Review Of Trashed Book", func(t *testing.T) {
+			trashRepository.Mock.On("GetById", mock.Anything, constants.TrashReviews, reviewItem.ID).Return(reviewItem, nil).Once()
+			trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, reviewItem.BookId).Return(bookItem, nil).Once()
+
+			_, statusCode, err := trashUsecase.Restore(context.Background(), constants.TrashReviews, reviewItem.ID)
+
+			assert.EqualError(t, err, "book with id 1 is in the trash, restore it first")
+			assert.Equal(t, http.StatusConflict, statusCode)
+		})
+	})
+}
+
+func TestPurge(t *testing.T) {
+	setup(t)
+	t.Run("When Success Purge Book", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, bookItem.ID).Return(bookItem, nil).Once()
+		trashRepository.Mock.On("CountCirculations", mock.Anything, &bookItem).Return(0, nil).Once()
+		trashRepository.Mock.On("Purge", mock.Anything, &bookItem).Return(nil).Once()
+		blobStorage.Mock.On("Delete", mock.Anything, "books/1/abc.jpg", "books/1/abc_small.jpg", "books/1/abc_medium.jpg").Return(nil).Once()
+
+		_, statusCode, err := trashUsecase.Purge(context.Background(), constants.TrashBooks, bookItem.ID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+	t.Run("When Failure User Has Loans", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashUsers, userItem.ID).Return(userItem, nil).Once()
+		trashRepository.Mock.On("CountCirculations", mock.Anything, &userItem).Return(2, nil).Once()
+
+		_, statusCode, err := trashUsecase.Purge(context.Background(), constants.TrashUsers, userItem.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
+func TestPurgeExpired(t *testing.T) {
+	setup(t)
+	t.Run("When Success Skips Items With Circulations", func(t *testing.T) {
+		before := time.Now()
+		trashRepository.Mock.On("GetDeletedBefore", mock.Anything, before).Return([]trash.Item{reviewItem, userItem}, nil).Once()
+		trashRepository.Mock.On("Purge", mock.Anything, &reviewItem).Return(nil).Once()
+		trashRepository.Mock.On("CountCirculations", mock.Anything, &userItem).Return(1, nil).Once()
+
+		purged, err := trashUsecase.PurgeExpired(context.Background(), before)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []trash.Item{reviewItem}, purged)
+	})
+}
@@ -16,15 +16,24 @@ type Domain struct {
 	Gender      string
 	IsActivated bool
 	Reviews     int
+	Shelves     map[string]int
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+// Patch carries the members of a merge-patch document, nil leaves a field unchanged and null arrives as an empty string
+type Patch struct {
+	FullName *string
+	Username *string
+	Gender   *string
+}
+
 type Usecase interface {
 	Store(ctx context.Context, user *Domain) (domain Domain, statusCode int, err error)
 	GetAll(ctx context.Context) (domains []Domain, statusCode int, err error)
 	GetById(ctx context.Context, id int, idClaims int) (domain Domain, statusCode int, err error)
 	Update(ctx context.Context, user *Domain, id int) (domain Domain, statusCode int, err error)
+	Patch(ctx context.Context, id int, patch *Patch) (domain Domain, changed []string, statusCode int, err error)
 	Delete(ctx context.Context, id int) (statusCode int, err error)
 	Login(ctx context.Context, user *Domain) (domain Domain, statusCode int, err error)
 	ActivateUser(ctx context.Context, email string) (statusCode int, err error)
@@ -40,7 +49,10 @@ type Repository interface {
 	GetAll(ctx context.Context) ([]Domain, error)
 	GetById(ctx context.Context, id int) (Domain, error)
 	Update(ctx context.Context, domain *Domain) (err error)
+	Patch(ctx context.Context, domain *Domain, fields []string) error
 	Delete(ctx context.Context, id int) (err error)
 	GetByEmail(ctx context.Context, domain *Domain) (Domain, error)
+	GetByUsername(ctx context.Context, username string) (Domain, error)
 	UpdateEmail(ctx context.Context, domain *Domain) (err error)
+	CountShelves(ctx context.Context, userId int) (map[string]int, error)
 }
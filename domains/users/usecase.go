@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/snykk/golib_backend/constants"
 	"github.com/snykk/golib_backend/helpers"
@@ -87,6 +89,11 @@ func (uc *userUsecase) GetById(ctx context.Context, id int, idClaims int) (Domai
 		user.Password = ""
 	}
 
+	user.Shelves, err = uc.repo.CountShelves(ctx, user.ID)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
 	return user, http.StatusOK, nil
 }
 
@@ -106,6 +113,60 @@ func (uc *userUsecase) Update(ctx context.Context, domain *Domain, id int) (Doma
 	return newUserFromDB, http.StatusOK, nil
 }
 
+func (uc *userUsecase) Patch(ctx context.Context, id int, patch *Patch) (Domain, []string, int, error) {
+	user, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, http.StatusNotFound, errors.New("user not found")
+	}
+
+	changed := []string{}
+	apply := func(field string, current *string, value *string) {
+		if value != nil && *value != *current {
+			*current = *value
+			changed = append(changed, field)
+		}
+	}
+	apply("fullname", &user.FullName, patch.FullName)
+	apply("username", &user.Username, patch.Username)
+	apply("gender", &user.Gender, patch.Gender)
+
+	// the patched user has to be as valid as a freshly registered one
+	if strings.TrimSpace(user.FullName) == "" {
+		return Domain{}, nil, http.StatusBadRequest, errors.New("fullname can't be empty")
+	}
+	if strings.TrimSpace(user.Username) == "" {
+		return Domain{}, nil, http.StatusBadRequest, errors.New("username can't be empty")
+	}
+	if utf8.RuneCountInString(user.FullName) > constants.MaxFullNameLength {
+		return Domain{}, nil, http.StatusBadRequest, fmt.Errorf("fullname can't be longer than %d characters", constants.MaxFullNameLength)
+	}
+	if utf8.RuneCountInString(user.Username) > constants.MaxUsernameLength {
+		return Domain{}, nil, http.StatusBadRequest, fmt.Errorf("username can't be longer than %d characters", constants.MaxUsernameLength)
+	}
+	if err := helpers.IsGenderValid(user.Gender); err != nil {
+		return Domain{}, nil, http.StatusBadRequest, err
+	}
+
+	if len(changed) == 0 {
+		return user, changed, http.StatusOK, nil
+	}
+
+	if existing, err := uc.repo.GetByUsername(ctx, user.Username); err == nil && existing.ID != id {
+		return Domain{}, nil, http.StatusConflict, fmt.Errorf("username %s is already taken", user.Username)
+	}
+
+	if err := uc.repo.Patch(ctx, &user, changed); err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	newUserFromDB, err := uc.repo.GetById(ctx, id)
+	if err != nil {
+		return Domain{}, nil, http.StatusInternalServerError, err
+	}
+
+	return newUserFromDB, changed, http.StatusOK, nil
+}
+
 func (uc *userUsecase) Delete(ctx context.Context, id int) (int, error) {
 	err := uc.repo.Delete(ctx, id)
 	if err != nil {
@@ -120,6 +181,11 @@ func (uc *userUsecase) GetByEmail(ctx context.Context, email string) (Domain, in
 		return Domain{}, http.StatusNotFound, errors.New("email not found")
 	}
 
+	user.Shelves, err = uc.repo.CountShelves(ctx, user.ID)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
 	return user, http.StatusOK, nil
 }
 
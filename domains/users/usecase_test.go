@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/snykk/golib_backend/constants"
 	repositoryMocks "github.com/snykk/golib_backend/datasources/databases/users/mocks"
 	"github.com/snykk/golib_backend/domains/users"
 	"github.com/snykk/golib_backend/helpers"
@@ -22,6 +23,7 @@ var (
 	userUsecase     users.Usecase
 	usersDataFromDB []users.Domain
 	userDataFromDB  users.Domain
+	shelfCounts     map[string]int
 )
 
 func setup(t *testing.T) {
@@ -67,6 +69,12 @@ func setup(t *testing.T) {
 		IsActivated: false,
 		CreatedAt:   time.Now(),
 	}
+
+	shelfCounts = map[string]int{
+		constants.ShelfWantToRead: 2,
+		constants.ShelfReading:    1,
+		constants.ShelfRead:       4,
+	}
 }
 
 func TestStore(t *testing.T) {
@@ -147,10 +155,13 @@ func TestGetById(t *testing.T) {
 	t.Run("When Success Get User Data By Id", func(t *testing.T) {
 		t.Run("With User Itself", func(t *testing.T) {
 			userRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(userDataFromDB, nil).Once()
+			userRepository.Mock.On("CountShelves", mock.Anything, userDataFromDB.ID).Return(shelfCounts, nil).Once()
 
 			result, statusCode, err := userUsecase.GetById(context.Background(), userDataFromDB.ID, userDataFromDB.ID)
 
-			assert.Equal(t, userDataFromDB, result)
+			expected := userDataFromDB
+			expected.Shelves = shelfCounts
+			assert.Equal(t, expected, result)
 			assert.Equal(t, http.StatusOK, statusCode)
 			assert.Nil(t, err)
 			assert.NotEqual(t, "", result.Password)
@@ -159,10 +170,13 @@ func TestGetById(t *testing.T) {
 		t.Run("With Strangers", func(t *testing.T) {
 			userDataFromDB.Password = ""
 			userRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(userDataFromDB, nil).Once()
+			userRepository.Mock.On("CountShelves", mock.Anything, userDataFromDB.ID).Return(shelfCounts, nil).Once()
 
 			result, statusCode, err := userUsecase.GetById(context.Background(), userDataFromDB.ID, userDataFromDB.ID+1)
 
-			assert.Equal(t, userDataFromDB, result)
+			expected := userDataFromDB
+			expected.Shelves = shelfCounts
+			assert.Equal(t, expected, result)
 			assert.Equal(t, http.StatusOK, statusCode)
 			assert.Nil(t, err)
 			assert.Equal(t, "", result.Password)
@@ -178,6 +192,17 @@ func TestGetById(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, statusCode)
 		assert.Equal(t, errors.New("user not found"), err)
 	})
+
+	t.Run("When Failure Counting Shelves", func(t *testing.T) {
+		userRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("CountShelves", mock.Anything, userDataFromDB.ID).Return(nil, errors.New("count failed")).Once()
+
+		result, statusCode, err := userUsecase.GetById(context.Background(), userDataFromDB.ID, userDataFromDB.ID)
+
+		assert.Equal(t, users.Domain{}, result)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+		assert.NotNil(t, err)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -219,6 +244,69 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestPatch(t *testing.T) {
+	setup(t)
+	t.Run("When Success Patch User", func(t *testing.T) {
+		fullName := "patrick star edited"
+		patched := userDataFromDB
+		patched.FullName = fullName
+
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("GetByUsername", mock.Anything, userDataFromDB.Username).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("Patch", mock.Anything, mock.AnythingOfType("*users.Domain"), []string{"fullname"}).Return(nil).Once()
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(patched, nil).Once()
+
+		result, changed, statusCode, err := userUsecase.Patch(context.Background(), userDataFromDB.ID, &users.Patch{FullName: &fullName})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, []string{"fullname"}, changed)
+		assert.Equal(t, fullName, result.FullName)
+	})
+	t.Run("When Success Nothing Changed", func(t *testing.T) {
+		username := userDataFromDB.Username
+
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(userDataFromDB, nil).Once()
+
+		_, changed, statusCode, err := userUsecase.Patch(context.Background(), userDataFromDB.ID, &users.Patch{Username: &username})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Empty(t, changed)
+	})
+	t.Run("When Failure Null Required Field", func(t *testing.T) {
+		empty := ""
+
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(userDataFromDB, nil).Once()
+
+		_, _, statusCode, err := userUsecase.Patch(context.Background(), userDataFromDB.ID, &users.Patch{Username: &empty})
+
+		assert.EqualError(t, err, "username can't be empty")
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+	t.Run("When Failure Invalid Gender", func(t *testing.T) {
+		gender := "unknown"
+
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(userDataFromDB, nil).Once()
+
+		_, _, statusCode, err := userUsecase.Patch(context.Background(), userDataFromDB.ID, &users.Patch{Gender: &gender})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+	t.Run("When Failure Username Taken", func(t *testing.T) {
+		username := usersDataFromDB[1].Username
+
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("GetByUsername", mock.Anything, username).Return(usersDataFromDB[1], nil).Once()
+
+		_, _, statusCode, err := userUsecase.Patch(context.Background(), userDataFromDB.ID, &users.Patch{Username: &username})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+}
+
 func TestLogin(t *testing.T) {
 	setup(t)
 	t.Run("When Success Login", func(t *testing.T) {
@@ -282,11 +370,14 @@ func TestGetByEmail(t *testing.T) {
 	setup(t)
 	t.Run("When Success Get User Data By Email", func(t *testing.T) {
 		userRepository.Mock.On("GetByEmail", mock.Anything, mock.AnythingOfType("*users.Domain")).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("CountShelves", mock.Anything, userDataFromDB.ID).Return(shelfCounts, nil).Once()
 
 		result, statusCode, err := userUsecase.GetByEmail(context.Background(), "najibfikri13@gmail.com")
 
+		expected := userDataFromDB
+		expected.Shelves = shelfCounts
 		assert.Nil(t, err)
-		assert.Equal(t, userDataFromDB, result)
+		assert.Equal(t, expected, result)
 		assert.Equal(t, http.StatusOK, statusCode)
 	})
 
@@ -0,0 +1,20 @@
+package helpers
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor remembers which user is performing the request, so writes deep down can be attributed to them
+func WithActor(ctx context.Context, userId int) context.Context {
+	return context.WithValue(ctx, actorKey{}, userId)
+}
+
+// ActorFrom returns the user performing the request, nil when the system is acting on its own
+func ActorFrom(ctx context.Context) *int {
+	userId, ok := ctx.Value(actorKey{}).(int)
+	if !ok {
+		return nil
+	}
+
+	return &userId
+}
@@ -0,0 +1,20 @@
+package helpers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/snykk/golib_backend/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActor(t *testing.T) {
+	t.Run("When Request Has An Actor", func(t *testing.T) {
+		ctx := helpers.WithActor(context.Background(), 7)
+
+		assert.Equal(t, 7, *helpers.ActorFrom(ctx))
+	})
+	t.Run("When System Is Acting", func(t *testing.T) {
+		assert.Nil(t, helpers.ActorFrom(context.Background()))
+	})
+}
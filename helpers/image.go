@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"strings"
+
+	// register the decoders used by image.Decode
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+
+	"golang.org/x/image/draw"
+
+	"github.com/snykk/golib_backend/constants"
+)
+
+// SniffImage detects the real content type from the leading bytes instead of trusting the client,
+// and rejects images whose dimensions would blow up memory once decoded
+func SniffImage(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := constants.MapperCoverContentTypeToExtension[contentType]; !ok {
+		return "", fmt.Errorf("cover must be one of [%s]", strings.Join(constants.ListCoverContentType, ", "))
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("cover image is corrupted: %w", err)
+	}
+	if config.Width > constants.MaxCoverDimension || config.Height > constants.MaxCoverDimension {
+		return "", fmt.Errorf("cover can't be larger than %dx%d pixels", constants.MaxCoverDimension, constants.MaxCoverDimension)
+	}
+
+	return contentType, nil
+}
+
+// GenerateThumbnail scales the image down to the given width keeping its aspect ratio, smaller images are never enlarged.
+// JPEG has no alpha channel, so transparent covers are laid over white instead of turning black
+func GenerateThumbnail(img image.Image, width int) ([]byte, error) {
+	bounds := img.Bounds()
+	target := image.Rect(0, 0, bounds.Dx(), bounds.Dy())
+	if bounds.Dx() > width {
+		height := bounds.Dy() * width / bounds.Dx()
+		if height < 1 {
+			height = 1
+		}
+		target = image.Rect(0, 0, width, height)
+	}
+
+	flattened := image.NewRGBA(target)
+	draw.Draw(flattened, target, image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(flattened, target, img, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flattened, &jpeg.Options{Quality: constants.CoverThumbnailQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
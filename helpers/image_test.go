@@ -0,0 +1,70 @@
+package helpers_test
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/snykk/golib_backend/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSniffImage(t *testing.T) {
+	t.Run("When Success", func(t *testing.T) {
+		var buf bytes.Buffer
+		png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 20, 30)))
+
+		contentType, err := helpers.SniffImage(buf.Bytes())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "image/png", contentType)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Not An Image", func(t *testing.T) {
+			_, err := helpers.SniffImage([]byte("%PDF-1.4"))
+
+			assert.NotNil(t, err)
+		})
+		t.Run("Too Large", func(t *testing.T) {
+			var buf bytes.Buffer
+			png.Encode(&buf, image.NewGray(image.Rect(0, 0, 6001, 1)))
+
+			_, err := helpers.SniffImage(buf.Bytes())
+
+			assert.NotNil(t, err)
+		})
+	})
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	t.Run("Scaled Down", func(t *testing.T) {
+		thumbnail, err := helpers.GenerateThumbnail(image.NewRGBA(image.Rect(0, 0, 600, 900)), 150)
+		assert.Nil(t, err)
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+		assert.Nil(t, err)
+		assert.Equal(t, 150, config.Width)
+		assert.Equal(t, 225, config.Height)
+	})
+	t.Run("Never Enlarged", func(t *testing.T) {
+		thumbnail, err := helpers.GenerateThumbnail(image.NewRGBA(image.Rect(0, 0, 100, 80)), 400)
+		assert.Nil(t, err)
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+		assert.Nil(t, err)
+		assert.Equal(t, 100, config.Width)
+	})
+	t.Run("Transparent Laid Over White", func(t *testing.T) {
+		thumbnail, err := helpers.GenerateThumbnail(image.NewNRGBA(image.Rect(0, 0, 40, 60)), 20)
+		assert.Nil(t, err)
+
+		img, err := jpeg.Decode(bytes.NewReader(thumbnail))
+		assert.Nil(t, err)
+		r, g, b, _ := img.At(10, 15).RGBA()
+		assert.Greater(t, r>>8, uint32(250))
+		assert.Greater(t, g>>8, uint32(250))
+		assert.Greater(t, b>>8, uint32(250))
+	})
+}
@@ -0,0 +1,73 @@
+package helpers
+
+import (
+	"errors"
+	"strings"
+)
+
+// NormalizeISBN strips hyphens and spaces, validates the checksum and converts ISBN-10 into ISBN-13
+func NormalizeISBN(isbn string) (string, error) {
+	isbn = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+
+	switch len(isbn) {
+	case 10:
+		if !isISBN10Valid(isbn) {
+			return "", errors.New("isbn checksum is invalid")
+		}
+		return convertISBN10To13(isbn), nil
+	case 13:
+		if !isISBN13Valid(isbn) {
+			return "", errors.New("isbn checksum is invalid")
+		}
+		return isbn, nil
+	default:
+		return "", errors.New("isbn must be 10 or 13 digits long")
+	}
+}
+
+func isISBN10Valid(isbn string) bool {
+	sum := 0
+	for i, r := range isbn {
+		var digit int
+		switch {
+		case r >= '0' && r <= '9':
+			digit = int(r - '0')
+		case r == 'X' && i == 9: // X stands for 10 and is only allowed as the check digit
+			digit = 10
+		default:
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+
+	return sum%11 == 0
+}
+
+func isISBN13Valid(isbn string) bool {
+	for _, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return isbn13CheckDigit(isbn[:12]) == isbn[12]
+}
+
+func convertISBN10To13(isbn string) string {
+	prefixed := "978" + isbn[:9]
+	return prefixed + string(isbn13CheckDigit(prefixed))
+}
+
+// isbn13CheckDigit expects the first 12 digits of an ISBN-13
+func isbn13CheckDigit(digits string) byte {
+	sum := 0
+	for i, r := range digits {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(r-'0') * weight
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
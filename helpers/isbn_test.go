@@ -0,0 +1,59 @@
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/snykk/golib_backend/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	t.Run("When Success", func(t *testing.T) {
+		t.Run("ISBN-13", func(t *testing.T) {
+			isbn, err := helpers.NormalizeISBN("9780735211292")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "9780735211292", isbn)
+		})
+		t.Run("ISBN-13 With Hyphens", func(t *testing.T) {
+			isbn, err := helpers.NormalizeISBN("978-0-7352-1129-2")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "9780735211292", isbn)
+		})
+		t.Run("ISBN-10 Is Converted", func(t *testing.T) {
+			isbn, err := helpers.NormalizeISBN("0-7352-1129-9")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "9780735211292", isbn)
+		})
+		t.Run("ISBN-10 With X Check Digit", func(t *testing.T) {
+			isbn, err := helpers.NormalizeISBN("080442957x")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "9780804429573", isbn)
+		})
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Wrong Length", func(t *testing.T) {
+			_, err := helpers.NormalizeISBN("111111")
+
+			assert.NotNil(t, err)
+		})
+		t.Run("Invalid ISBN-13 Checksum", func(t *testing.T) {
+			_, err := helpers.NormalizeISBN("9780735211293")
+
+			assert.NotNil(t, err)
+		})
+		t.Run("Invalid ISBN-10 Checksum", func(t *testing.T) {
+			_, err := helpers.NormalizeISBN("0735211291")
+
+			assert.NotNil(t, err)
+		})
+		t.Run("Non Digit Character", func(t *testing.T) {
+			_, err := helpers.NormalizeISBN("97807352112X2")
+
+			assert.NotNil(t, err)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PatchString is a merge-patch member that tells an absent member apart from an explicit null
+type PatchString struct {
+	Set   bool
+	Null  bool
+	Value string
+}
+
+func (p *PatchString) UnmarshalJSON(data []byte) error {
+	p.Set = true
+	if string(data) == "null" {
+		p.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &p.Value)
+}
+
+// Ptr returns nil for an absent member and an empty string for a member that was set to null
+func (p PatchString) Ptr() *string {
+	if !p.Set {
+		return nil
+	}
+
+	value := p.Value
+	return &value
+}
+
+// PatchInt is the integer counterpart of PatchString
+type PatchInt struct {
+	Set   bool
+	Null  bool
+	Value int
+}
+
+func (p *PatchInt) UnmarshalJSON(data []byte) error {
+	p.Set = true
+	if string(data) == "null" {
+		p.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &p.Value)
+}
+
+// Ptr returns nil for an absent member and zero for a member that was set to null
+func (p PatchInt) Ptr() *int {
+	if !p.Set {
+		return nil
+	}
+
+	value := p.Value
+	return &value
+}
+
+// DecodeMergePatch reads an RFC 7396 merge-patch document into dst, the document has to be a JSON object
+// and may only carry the members dst knows about
+func DecodeMergePatch(r io.Reader, dst interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '{' {
+		return errors.New("merge patch document must be a JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		if strings.HasPrefix(err.Error(), "json: unknown field ") {
+			return fmt.Errorf("field %s can't be patched", strings.TrimPrefix(err.Error(), "json: unknown field "))
+		}
+		return fmt.Errorf("invalid merge patch document: %w", err)
+	}
+	if decoder.More() {
+		return errors.New("merge patch document must hold a single JSON object")
+	}
+
+	return nil
+}
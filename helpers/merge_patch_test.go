@@ -0,0 +1,52 @@
+package helpers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/snykk/golib_backend/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+type patchDocument struct {
+	Title       helpers.PatchString `json:"title"`
+	Description helpers.PatchString `json:"description"`
+	Author      helpers.PatchString `json:"author"`
+	PageCount   helpers.PatchInt    `json:"page_count"`
+}
+
+func TestDecodeMergePatch(t *testing.T) {
+	t.Run("When Success", func(t *testing.T) {
+		var doc patchDocument
+		err := helpers.DecodeMergePatch(strings.NewReader(`{"title":"Atomic Habits","description":null,"page_count":320}`), &doc)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Atomic Habits", *doc.Title.Ptr())
+		assert.True(t, doc.Description.Set)
+		assert.True(t, doc.Description.Null)
+		assert.Equal(t, "", *doc.Description.Ptr())
+		assert.False(t, doc.Author.Set)
+		assert.Nil(t, doc.Author.Ptr())
+		assert.Equal(t, 320, *doc.PageCount.Ptr())
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Not An Object", func(t *testing.T) {
+			var doc patchDocument
+			err := helpers.DecodeMergePatch(strings.NewReader(`["title"]`), &doc)
+
+			assert.NotNil(t, err)
+		})
+		t.Run("Unknown Field", func(t *testing.T) {
+			var doc patchDocument
+			err := helpers.DecodeMergePatch(strings.NewReader(`{"rating":10}`), &doc)
+
+			assert.EqualError(t, err, `field "rating" can't be patched`)
+		})
+		t.Run("Wrong Type", func(t *testing.T) {
+			var doc patchDocument
+			err := helpers.DecodeMergePatch(strings.NewReader(`{"title":12}`), &doc)
+
+			assert.NotNil(t, err)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package helpers
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/snykk/golib_backend/constants"
 )
@@ -23,6 +24,14 @@ func IsGenderValid(gender string) error {
 	return nil
 }
 
+func IsCopyConditionValid(condition string) error {
+	if !isArrayContains(constants.ListCopyCondition, condition) {
+		return fmt.Errorf("condition must be one of [%s]", strings.Join(constants.ListCopyCondition, ", "))
+	}
+
+	return nil
+}
+
 func isArrayContains(arr []string, str string) bool {
 	for _, item := range arr {
 		if item == str {
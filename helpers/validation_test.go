@@ -70,3 +70,16 @@ func TestIsRatingValid(t *testing.T) {
 		})
 	})
 }
+
+func TestIsCopyConditionValid(t *testing.T) {
+	t.Run("When Success", func(t *testing.T) {
+		err := helpers.IsCopyConditionValid("good")
+
+		assert.Nil(t, err)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		err := helpers.IsCopyConditionValid("broken")
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,209 @@
+package authors
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/authors"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/authors/requests"
+	"github.com/snykk/golib_backend/http/controllers/authors/responses"
+)
+
+type AuthorController struct {
+	authorUsecase  authors.Usecase
+	ristrettoCache cache.RistrettoCache
+}
+
+func NewAuthorController(authorUsecase authors.Usecase, ristrettoCache cache.RistrettoCache) AuthorController {
+	return AuthorController{
+		authorUsecase:  authorUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+func (c *AuthorController) Store(ctx *gin.Context) {
+	var authorRequest requests.AuthorRequest
+	if err := ctx.ShouldBindJSON(&authorRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	author, statusCode, err := c.authorUsecase.Store(ctxx, authorRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("authors")
+
+	controllers.NewSuccessResponse(ctx, statusCode, "author inserted successfully", gin.H{
+		"author": responses.FromDomain(author),
+	})
+}
+
+func (c *AuthorController) GetAll(ctx *gin.Context) {
+	if val := c.ristrettoCache.Get("authors"); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, "author data fetched successfully", gin.H{
+			"authors": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	listOfAuthors, statusCode, err := c.authorUsecase.GetAll(ctxx)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	authorResponses := responses.ToResponseList(listOfAuthors)
+
+	if authorResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "author data is empty", []int{})
+		return
+	}
+
+	go c.ristrettoCache.Set("authors", authorResponses)
+
+	controllers.NewSuccessResponse(ctx, statusCode, "author data fetched successfully", gin.H{
+		"authors": authorResponses,
+	})
+}
+
+func (c *AuthorController) GetById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if val := c.ristrettoCache.Get(fmt.Sprintf("author/%d", id)); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, fmt.Sprintf("author data with id %d fetched successfully", id), gin.H{
+			"author": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	author, statusCode, err := c.authorUsecase.GetById(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	authorResponse := responses.FromDomain(author)
+
+	go c.ristrettoCache.Set(fmt.Sprintf("author/%d", id), authorResponse)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("author data with id %d fetched successfully", id), gin.H{
+		"author": authorResponse,
+	})
+}
+
+func (c *AuthorController) Update(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var authorRequest requests.AuthorRequest
+	if err := ctx.ShouldBindJSON(&authorRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	author, bookIds, statusCode, err := c.authorUsecase.Update(ctxx, authorRequest.ToDomain(), id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// the author text of every credited book changed along with the name
+	keys := []string{"authors", fmt.Sprintf("author/%d", id), "books"}
+	for _, bookId := range bookIds {
+		keys = append(keys, fmt.Sprintf("book/%d", bookId))
+	}
+	go c.ristrettoCache.Del(keys...)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("author data with id %d updated successfully", id), gin.H{
+		"author": responses.FromDomain(author),
+	})
+}
+
+func (c *AuthorController) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.authorUsecase.Delete(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("authors", fmt.Sprintf("author/%d", id))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("author data with id %d deleted successfully", id), nil)
+}
+
+func (c *AuthorController) GetBooks(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	credits, statusCode, err := c.authorUsecase.GetBooks(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	creditResponses := responses.ToBookCreditResponseList(credits)
+
+	if creditResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with author id %d is empty", id), []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with author id %d fetched successfully", id), gin.H{
+		"books": creditResponses,
+	})
+}
+
+func (c *AuthorController) GetBookAuthors(ctx *gin.Context) {
+	bookId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	credits, statusCode, err := c.authorUsecase.GetBookAuthors(ctxx, bookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	creditResponses := responses.ToAuthorCreditResponseList(credits)
+
+	if creditResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("author data with book id %d is empty", bookId), []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("author data with book id %d fetched successfully", bookId), gin.H{
+		"authors": creditResponses,
+	})
+}
+
+func (c *AuthorController) SetBookAuthors(ctx *gin.Context) {
+	bookId, _ := strconv.Atoi(ctx.Param("id"))
+	var bookAuthorsRequest requests.BookAuthorsRequest
+	if err := ctx.ShouldBindJSON(&bookAuthorsRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	credits, statusCode, err := c.authorUsecase.SetBookAuthors(ctxx, bookId, bookAuthorsRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", bookId))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("author data with book id %d updated successfully", bookId), gin.H{
+		"authors": responses.ToAuthorCreditResponseList(credits),
+	})
+}
@@ -0,0 +1,266 @@
+package authors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	authorMocks "github.com/snykk/golib_backend/datasources/databases/authors/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	"github.com/snykk/golib_backend/domains/authors"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/authors"
+	"github.com/snykk/golib_backend/http/controllers/authors/requests"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	authorRepository *authorMocks.Repository
+	bookRepository   *bookMocks.Repository
+	ristrettoMock    *cacheMocks.RistrettoCache
+	authorUsecase    authors.Usecase
+	authorController controllers.AuthorController
+	s                *gin.Engine
+	authorFromDB     authors.Domain
+	bookFromDB       books.Domain
+	creditFromDB     authors.Credit
+)
+
+func setup(t *testing.T) {
+	authorRepository = authorMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	authorUsecase = authors.NewAuthorUsecase(authorRepository, bookRepository)
+	authorController = controllers.NewAuthorController(authorUsecase, ristrettoMock)
+
+	authorFromDB = authors.Domain{
+		ID:        1,
+		Name:      "James Clear",
+		Bio:       "writer and speaker",
+		CreatedAt: time.Now(),
+	}
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+	creditFromDB = authors.Credit{
+		BookId:   bookFromDB.ID,
+		Book:     bookFromDB,
+		AuthorId: authorFromDB.ID,
+		Author:   authorFromDB,
+		Role:     constants.AuthorRoleAuthor,
+		Position: 1,
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  true,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/authors", authorController.Store)
+	t.Run("When Success Store Author", func(t *testing.T) {
+		req := requests.AuthorRequest{
+			Name: authorFromDB.Name,
+			Bio:  authorFromDB.Bio,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		authorRepository.Mock.On("GetByName", mock.Anything, authorFromDB.Name).Return(authors.Domain{}, errors.New("record not found")).Once()
+		authorRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*authors.Domain")).Return(authorFromDB, nil).Once()
+		ristrettoMock.Mock.On("Del", "authors").Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/authors", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "author inserted successfully")
+	})
+	t.Run("When Failure Author Already Exists", func(t *testing.T) {
+		req := requests.AuthorRequest{
+			Name: authorFromDB.Name,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		authorRepository.Mock.On("GetByName", mock.Anything, authorFromDB.Name).Return(authorFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/authors", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, body, "already exists")
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/authors", authorController.GetAll)
+	t.Run("When Success Get Authors", func(t *testing.T) {
+		authorRepository.Mock.On("GetAll", mock.Anything).Return([]authors.Domain{authorFromDB}, nil).Once()
+		ristrettoMock.Mock.On("Get", "authors").Return(nil).Once()
+		ristrettoMock.Mock.On("Set", "authors", mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/authors", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "author data fetched successfully")
+		assert.Contains(t, body, authorFromDB.Name)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/authors/:id/books", authorController.GetBooks)
+	t.Run("When Success Get Author Books", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, authorFromDB.ID).Return(authorFromDB, nil).Once()
+		authorRepository.Mock.On("GetCreditsByAuthorId", mock.Anything, authorFromDB.ID).Return([]authors.Credit{creditFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/authors/%d/books", authorFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, bookFromDB.Title)
+		assert.Contains(t, body, `"role":"author"`)
+	})
+	t.Run("When Failure Author Not Found", func(t *testing.T) {
+		authorRepository.Mock.On("GetById", mock.Anything, 2).Return(authors.Domain{}, errors.New("record not found")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/authors/2/books", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "author not found")
+	})
+}
+
+func TestSetBookAuthors(t *testing.T) {
+	setup(t)
+	// Define route
+	s.PUT("/books/:id/authors", authorController.SetBookAuthors)
+	t.Run("When Success Set Book Authors", func(t *testing.T) {
+		req := requests.BookAuthorsRequest{
+			Authors: []requests.BookAuthorRequest{{AuthorId: authorFromDB.ID, Role: constants.AuthorRoleAuthor}},
+		}
+		reqBody, _ := json.Marshal(req)
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		authorRepository.Mock.On("GetByIds", mock.Anything, []int{authorFromDB.ID}).Return([]authors.Domain{authorFromDB}, nil).Once()
+		authorRepository.Mock.On("ReplaceBookCredits", mock.Anything, bookFromDB.ID, mock.AnythingOfType("[]authors.Credit")).Return(nil).Once()
+		authorRepository.Mock.On("GetCreditsByBookId", mock.Anything, bookFromDB.ID).Return([]authors.Credit{creditFromDB}, nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/books/%d/authors", bookFromDB.ID), bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, authorFromDB.Name)
+		assert.Contains(t, body, `"position":1`)
+	})
+	t.Run("When Failure Invalid Role", func(t *testing.T) {
+		req := requests.BookAuthorsRequest{
+			Authors: []requests.BookAuthorRequest{{AuthorId: authorFromDB.ID, Role: "illustrator"}},
+		}
+		reqBody, _ := json.Marshal(req)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/books/%d/authors", bookFromDB.ID), bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
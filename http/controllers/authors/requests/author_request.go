@@ -0,0 +1,15 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/authors"
+
+type AuthorRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	Bio  string `json:"bio"`
+}
+
+func (r *AuthorRequest) ToDomain() *authors.Domain {
+	return &authors.Domain{
+		Name: r.Name,
+		Bio:  r.Bio,
+	}
+}
@@ -0,0 +1,25 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/authors"
+
+type BookAuthorRequest struct {
+	AuthorId int    `json:"author_id" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=author editor translator"`
+}
+
+// BookAuthorsRequest replaces every credit of a book, the order of the list is the order the names are printed in
+type BookAuthorsRequest struct {
+	Authors []BookAuthorRequest `json:"authors" binding:"required,min=1,dive"`
+}
+
+func (r *BookAuthorsRequest) ToDomain() []authors.Credit {
+	credits := make([]authors.Credit, len(r.Authors))
+	for i, val := range r.Authors {
+		credits[i] = authors.Credit{
+			AuthorId: val.AuthorId,
+			Role:     val.Role,
+		}
+	}
+
+	return credits
+}
@@ -0,0 +1,35 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/authors"
+)
+
+type AuthorResponse struct {
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	Bio       string    `json:"bio"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromDomain(domain authors.Domain) AuthorResponse {
+	return AuthorResponse{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		Bio:       domain.Bio,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToResponseList(domains []authors.Domain) []AuthorResponse {
+	var result []AuthorResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package responses
+
+import (
+	"github.com/snykk/golib_backend/domains/authors"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+// AuthorCreditResponse is a credit seen from the book, listing who wrote it
+type AuthorCreditResponse struct {
+	Author   AuthorResponse `json:"author"`
+	Role     string         `json:"role"`
+	Position int            `json:"position"`
+}
+
+// BookCreditResponse is a credit seen from the author, listing what they worked on
+type BookCreditResponse struct {
+	Book bookRes.BookResponse `json:"book"`
+	Role string               `json:"role"`
+}
+
+func ToAuthorCreditResponseList(domains []authors.Credit) []AuthorCreditResponse {
+	var result []AuthorCreditResponse
+
+	for _, val := range domains {
+		result = append(result, AuthorCreditResponse{
+			Author:   FromDomain(val.Author),
+			Role:     val.Role,
+			Position: val.Position,
+		})
+	}
+
+	return result
+}
+
+func ToBookCreditResponseList(domains []authors.Credit) []BookCreditResponse {
+	var result []BookCreditResponse
+
+	for _, val := range domains {
+		result = append(result, BookCreditResponse{
+			Book: bookRes.FromDomain(val.Book),
+			Role: val.Role,
+		})
+	}
+
+	return result
+}
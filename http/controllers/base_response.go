@@ -10,6 +10,43 @@ type BaseResponse struct {
 	Status  bool        `json:"status"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalItems int `json:"total_items"`
+	TotalPages int `json:"total_pages"`
+}
+
+func NewPaginationMeta(page, limit, totalItems int) PaginationMeta {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = (totalItems + limit - 1) / limit
+	}
+
+	return PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+}
+
+// CursorMeta describes a page read by cursor, NextCursor is left out on the last page
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+func NewCursorMeta(limit int, nextCursor string) CursorMeta {
+	return CursorMeta{
+		Limit:      limit,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	}
 }
 
 func NewSuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
@@ -20,6 +57,15 @@ func NewSuccessResponse(c *gin.Context, statusCode int, message string, data int
 	})
 }
 
+func NewSuccessResponseWithMeta(c *gin.Context, statusCode int, message string, data interface{}, meta interface{}) {
+	c.JSON(statusCode, BaseResponse{
+		Status:  true,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
 func NewErrorResponse(c *gin.Context, statusCode int, err string) {
 	c.JSON(statusCode, BaseResponse{
 		Status:  false,
@@ -0,0 +1,232 @@
+package exports
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+const (
+	marcSubfieldDelimiter = 0x1F
+	marcFieldTerminator   = 0x1E
+	marcRecordTerminator  = 0x1D
+
+	marcLeaderLength         = 24
+	marcDirectoryEntryLength = 12
+	marcMaxRecordLength      = 99999
+	// the summary is the only unbounded field, keep it short enough for the record to fit the 5 digit length
+	marcMaxSummaryLength = 9000
+)
+
+type marcSubfield struct {
+	code  byte
+	value string
+}
+
+type marcField struct {
+	tag        string
+	control    string
+	indicators [2]byte
+	subfields  []marcSubfield
+}
+
+// bytes returns the field body as stored in binary MARC21, including the field terminator
+func (f marcField) bytes() []byte {
+	var buf bytes.Buffer
+	if f.tag < "010" {
+		buf.WriteString(f.control)
+	} else {
+		buf.Write(f.indicators[:])
+		for _, subfield := range f.subfields {
+			buf.WriteByte(marcSubfieldDelimiter)
+			buf.WriteByte(subfield.code)
+			buf.WriteString(subfield.value)
+		}
+	}
+	buf.WriteByte(marcFieldTerminator)
+
+	return buf.Bytes()
+}
+
+// marcFields maps a book onto a minimal bibliographic record, the fields stay in tag order
+func marcFields(book books.Domain) []marcField {
+	title := []marcSubfield{{'a', book.Title}}
+	if book.Subtitle != "" {
+		title = append(title, marcSubfield{'b', book.Subtitle})
+	}
+	publication := []marcSubfield{{'b', book.Publisher}}
+	if book.PublicationDate != nil {
+		publication = append(publication, marcSubfield{'c', strconv.Itoa(book.PublicationDate.Year())})
+	}
+
+	fields := []marcField{
+		{tag: "001", control: strconv.Itoa(book.ID)},
+		{tag: "005", control: book.UpdatedAt.UTC().Format("20060102150405") + ".0"},
+		{tag: "020", indicators: [2]byte{' ', ' '}, subfields: []marcSubfield{{'a', book.ISBN}}},
+		{tag: "100", indicators: [2]byte{'1', ' '}, subfields: []marcSubfield{{'a', book.Author}}},
+		{tag: "245", indicators: [2]byte{'1', '0'}, subfields: title},
+	}
+	if book.Edition != "" {
+		fields = append(fields, marcField{tag: "250", indicators: [2]byte{' ', ' '}, subfields: []marcSubfield{{'a', book.Edition}}})
+	}
+	fields = append(fields, marcField{tag: "264", indicators: [2]byte{' ', '1'}, subfields: publication})
+	if book.PageCount > 0 {
+		fields = append(fields, marcField{tag: "300", indicators: [2]byte{' ', ' '}, subfields: []marcSubfield{{'a', fmt.Sprintf("%d pages", book.PageCount)}}})
+	}
+	if book.Series != "" {
+		series := []marcSubfield{{'a', book.Series}}
+		if book.SeriesPosition > 0 {
+			series = append(series, marcSubfield{'v', strconv.Itoa(book.SeriesPosition)})
+		}
+		fields = append(fields, marcField{tag: "490", indicators: [2]byte{'0', ' '}, subfields: series})
+	}
+	if book.Description != "" {
+		fields = append(fields, marcField{tag: "520", indicators: [2]byte{' ', ' '}, subfields: []marcSubfield{{'a', truncateUTF8(book.Description, marcMaxSummaryLength)}}})
+	}
+
+	return fields
+}
+
+// marcLeader builds the 24 byte leader of a new, language material, monograph record encoded in UTF-8
+func marcLeader(recordLength, baseAddress int) string {
+	return fmt.Sprintf("%05dnam a22%05d i 4500", recordLength, baseAddress)
+}
+
+type marc21Writer struct {
+	writer io.Writer
+}
+
+func NewMARC21Writer(w io.Writer) Writer {
+	return &marc21Writer{writer: w}
+}
+
+func (w *marc21Writer) Write(book books.Domain) error {
+	fields := marcFields(book)
+
+	var directory, data bytes.Buffer
+	for _, field := range fields {
+		body := field.bytes()
+		fmt.Fprintf(&directory, "%s%04d%05d", field.tag, len(body), data.Len())
+		data.Write(body)
+	}
+	directory.WriteByte(marcFieldTerminator)
+
+	baseAddress := marcLeaderLength + directory.Len()
+	recordLength := baseAddress + data.Len() + 1
+	if recordLength > marcMaxRecordLength {
+		return fmt.Errorf("marc record of book with id %d exceeds %d bytes", book.ID, marcMaxRecordLength)
+	}
+
+	var record bytes.Buffer
+	record.Grow(recordLength)
+	record.WriteString(marcLeader(recordLength, baseAddress))
+	record.Write(directory.Bytes())
+	record.Write(data.Bytes())
+	record.WriteByte(marcRecordTerminator)
+
+	_, err := w.writer.Write(record.Bytes())
+	return err
+}
+
+func (w *marc21Writer) Close() error {
+	return nil
+}
+
+type marcXMLSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcXMLControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcXMLDataField struct {
+	Tag       string            `xml:"tag,attr"`
+	Ind1      string            `xml:"ind1,attr"`
+	Ind2      string            `xml:"ind2,attr"`
+	Subfields []marcXMLSubfield `xml:"subfield"`
+}
+
+type marcXMLRecord struct {
+	XMLName       xml.Name              `xml:"record"`
+	Leader        string                `xml:"leader"`
+	ControlFields []marcXMLControlField `xml:"controlfield"`
+	DataFields    []marcXMLDataField    `xml:"datafield"`
+}
+
+type marcXMLWriter struct {
+	writer        io.Writer
+	encoder       *xml.Encoder
+	headerWritten bool
+}
+
+func NewMARCXMLWriter(w io.Writer) Writer {
+	return &marcXMLWriter{writer: w, encoder: xml.NewEncoder(w)}
+}
+
+func (w *marcXMLWriter) writeHeader() error {
+	w.headerWritten = true
+	_, err := io.WriteString(w.writer, xml.Header+`<collection xmlns="http://www.loc.gov/MARC21/slim">`+"\n")
+	return err
+}
+
+func (w *marcXMLWriter) Write(book books.Domain) error {
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	record := marcXMLRecord{}
+	// lengths are meaningless in MARCXML, the leader keeps the same shape as the binary one
+	record.Leader = marcLeader(0, 0)
+	for _, field := range marcFields(book) {
+		if field.tag < "010" {
+			record.ControlFields = append(record.ControlFields, marcXMLControlField{Tag: field.tag, Value: field.control})
+			continue
+		}
+
+		dataField := marcXMLDataField{Tag: field.tag, Ind1: string(field.indicators[0]), Ind2: string(field.indicators[1])}
+		for _, subfield := range field.subfields {
+			dataField.Subfields = append(dataField.Subfields, marcXMLSubfield{Code: string(subfield.code), Value: subfield.value})
+		}
+		record.DataFields = append(record.DataFields, dataField)
+	}
+
+	if err := w.encoder.Encode(record); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.writer, "\n")
+	return err
+}
+
+func (w *marcXMLWriter) Close() error {
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w.writer, "</collection>\n")
+	return err
+}
+
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+
+	s = s[:maxBytes]
+	for !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
@@ -0,0 +1,95 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) writeHeader() error {
+	w.headerWritten = true
+	// same column names as the import, so an export can be fed back into POST /books/import
+	return w.writer.Write([]string{"id", "title", "author", "description", "publisher", "isbn", "rating", "created_at", "updated_at",
+		"subtitle", "edition", "language", "format", "series", "series_position", "page_count", "publication_date"})
+}
+
+func (w *csvWriter) Write(book books.Domain) error {
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	var rating string
+	if book.Rating != nil {
+		rating = strconv.FormatFloat(*book.Rating, 'f', 1, 64)
+	}
+
+	var publicationDate string
+	if book.PublicationDate != nil {
+		publicationDate = book.PublicationDate.Format(constants.PublicationDateLayout)
+	}
+
+	return w.writer.Write([]string{
+		strconv.Itoa(book.ID),
+		book.Title,
+		book.Author,
+		book.Description,
+		book.Publisher,
+		book.ISBN,
+		rating,
+		book.CreatedAt.Format(time.RFC3339),
+		book.UpdatedAt.Format(time.RFC3339),
+		book.Subtitle,
+		book.Edition,
+		book.Language,
+		book.Format,
+		book.Series,
+		strconv.Itoa(book.SeriesPosition),
+		strconv.Itoa(book.PageCount),
+		publicationDate,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	// an empty catalog still exports the header
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func NewNDJSONWriter(w io.Writer) Writer {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+func (w *ndjsonWriter) Write(book books.Domain) error {
+	// json.Encoder terminates every value with a newline
+	return w.encoder.Encode(responses.FromDomain(book))
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,72 @@
+package exports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatMARC21  = "marc21"
+	FormatMARCXML = "marcxml"
+)
+
+// Writer encodes books one at a time, so an export never holds more than a single book in memory
+type Writer interface {
+	Write(book books.Domain) error
+	Close() error
+}
+
+type format struct {
+	name        string
+	contentType string
+	extension   string
+	newWriter   func(w io.Writer) Writer
+}
+
+var formats = []format{
+	{name: FormatCSV, contentType: "text/csv", extension: "csv", newWriter: NewCSVWriter},
+	{name: FormatNDJSON, contentType: "application/x-ndjson", extension: "ndjson", newWriter: NewNDJSONWriter},
+	{name: FormatMARC21, contentType: "application/marc", extension: "mrc", newWriter: NewMARC21Writer},
+	{name: FormatMARCXML, contentType: "application/marcxml+xml", extension: "xml", newWriter: NewMARCXMLWriter},
+}
+
+// Negotiate picks the export format from the format query parameter first, then from the Accept header,
+// falling back to csv when the header names none of the formats. Only an unknown format parameter is an error,
+// a generic client sending "Accept: application/json" still gets its download.
+func Negotiate(formatName, accept string) (contentType string, extension string, newWriter func(w io.Writer) Writer, err error) {
+	if formatName != "" {
+		for _, f := range formats {
+			if f.name == formatName {
+				return f.contentType, f.extension, f.newWriter, nil
+			}
+		}
+		return "", "", nil, fmt.Errorf("format must be one of [%s]", strings.Join(formatNames(), ", "))
+	}
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.Split(mediaRange, ";")[0])
+		if mediaType == "*/*" || mediaType == "text/*" {
+			break
+		}
+		for _, f := range formats {
+			if f.contentType == mediaType {
+				return f.contentType, f.extension, f.newWriter, nil
+			}
+		}
+	}
+
+	return formats[0].contentType, formats[0].extension, formats[0].newWriter, nil
+}
+
+func formatNames() []string {
+	var names []string
+	for _, f := range formats {
+		names = append(names, f.name)
+	}
+	return names
+}
@@ -2,13 +2,21 @@ package books
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
 	"github.com/snykk/golib_backend/datasources/cache"
 	book "github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/helpers"
 	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/books/exports"
+	"github.com/snykk/golib_backend/http/controllers/books/imports"
 	"github.com/snykk/golib_backend/http/controllers/books/requests"
 	"github.com/snykk/golib_backend/http/controllers/books/responses"
 )
@@ -18,6 +26,12 @@ type BookController struct {
 	ristrettoCache cache.RistrettoCache
 }
 
+// bookPage is a cached page of the book listing
+type bookPage struct {
+	Books []responses.BookResponse
+	Meta  controllers.PaginationMeta
+}
+
 func NewBookController(bookUsecase book.Usecase, ristrettoCache cache.RistrettoCache) BookController {
 	return BookController{
 		bookUsecase:    bookUsecase,
@@ -47,32 +61,299 @@ func (c *BookController) Store(ctx *gin.Context) {
 	})
 }
 
+func (c *BookController) Import(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if fileHeader.Size > constants.MaxBookImportSize {
+		controllers.NewErrorResponse(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("import file can't be larger than %d MB", constants.MaxBookImportSize>>20))
+		return
+	}
+
+	format, err := requests.DetectBookImportFormat(ctx.PostForm("format"), fileHeader.Filename, fileHeader.Header.Get("Content-Type"))
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	rows, err := requests.ParseBookImport(file, format)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	results, created, statusCode, err := c.bookUsecase.Import(ctxx, requests.ToImportRows(rows))
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	if created > 0 {
+		go c.ristrettoCache.Del("books")
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("%d of %d books imported", created, len(results)), map[string]interface{}{
+		"summary": responses.BookImportSummary{
+			Total:   len(results),
+			Created: created,
+			Failed:  len(results) - created,
+		},
+		"results": responses.ToImportResponseList(results),
+	})
+}
+
+func (c *BookController) ImportMetadata(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if fileHeader.Size > constants.MaxMetadataImportSize {
+		controllers.NewErrorResponse(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("import file can't be larger than %d MB, use the import command for larger dumps", constants.MaxMetadataImportSize>>20))
+		return
+	}
+
+	format, err := imports.DetectFormat(ctx.PostForm("format"), fileHeader.Filename)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dryRun := false
+	if value := ctx.PostForm("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			controllers.NewErrorResponse(ctx, http.StatusBadRequest, "dry_run must be either true or false")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	reader, err := imports.NewReader(file, format)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	records, err := imports.ReadAll(reader, constants.MaxMetadataImportRecords)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	results, statusCode, err := c.bookUsecase.ImportMetadata(ctxx, records, dryRun)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	summary := responses.MetadataImportSummary{DryRun: dryRun}
+	summary.Add(results)
+
+	if !dryRun && summary.Created+summary.Enriched > 0 {
+		keys := []string{"books"}
+		for _, result := range results {
+			if result.Status == constants.BookImportEnriched {
+				keys = append(keys, fmt.Sprintf("book/%d", result.BookID))
+			}
+		}
+		go c.ristrettoCache.Del(keys...)
+	}
+
+	message := fmt.Sprintf("%d books created and %d enriched out of %d", summary.Created, summary.Enriched, summary.Total)
+	if dryRun {
+		message = fmt.Sprintf("dry run, %d books would be created and %d enriched out of %d", summary.Created, summary.Enriched, summary.Total)
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, message, map[string]interface{}{
+		"summary": summary,
+		"results": responses.ToMetadataImportResponseList(results),
+	})
+}
+
+func (c *BookController) Export(ctx *gin.Context) {
+	contentType, extension, newWriter, err := exports.Negotiate(ctx.Query("format"), ctx.GetHeader("Accept"))
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusNotAcceptable, err.Error())
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="books.%s"`, extension))
+
+	// the catalogue can take longer to stream than the server's write timeout allows, so the deadline keeps
+	// moving while rows go out and only a client that stops reading gets cut off
+	responseController := http.NewResponseController(ctx.Writer)
+	extendDeadline := func() {
+		_ = responseController.SetWriteDeadline(time.Now().Add(constants.BookExportWriteWindow))
+	}
+	extendDeadline()
+
+	writer := newWriter(ctx.Writer)
+	exported := 0
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.bookUsecase.Export(ctxx, func(b book.Domain) error {
+		if err := writer.Write(b); err != nil {
+			return err
+		}
+		exported++
+		if exported%constants.BookExportFlushEvery == 0 {
+			ctx.Writer.Flush()
+			extendDeadline()
+		}
+		return nil
+	})
+	if err != nil {
+		// once the body has started the status is already sent, the truncated stream is all the client gets
+		if !ctx.Writer.Written() {
+			ctx.Header("Content-Type", "")
+			ctx.Header("Content-Disposition", "")
+			controllers.NewErrorResponse(ctx, statusCode, err.Error())
+			return
+		}
+		_ = ctx.Error(err)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		_ = ctx.Error(err)
+	}
+	ctx.Status(statusCode)
+}
+
 func (c *BookController) GetAll(ctx *gin.Context) {
-	if val := c.ristrettoCache.Get("books"); val != nil {
-		controllers.NewSuccessResponse(ctx, http.StatusOK, "book data fetched successfully", map[string]interface{}{
-			"books": val,
-		})
+	var bookQueryRequest requests.BookQueryRequest
+	if err := ctx.ShouldBindQuery(&bookQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// each page is cached under its own key, prefixed with the generation kept under "books". Deleting "books"
+	// after a write starts a new generation, so the pages of the old one are never read again and age out by TTL.
+	query := bookQueryRequest.ToDomain()
+	query.ApplyDefaults()
+	cacheKey := fmt.Sprintf("books?generation=%d&%s", c.listGeneration(), query.CacheKey())
+	if page, ok := c.ristrettoCache.Get(cacheKey).(bookPage); ok {
+		controllers.NewSuccessResponseWithMeta(ctx, http.StatusOK, "book data fetched successfully", map[string]interface{}{
+			"books": page.Books,
+		}, page.Meta)
 		return
 	}
 
 	ctxx := ctx.Request.Context()
-	listOfBooks, statusCode, err := c.bookUsecase.GetAll(ctxx)
+	listOfBooks, total, statusCode, err := c.bookUsecase.GetAll(ctxx, query)
 	if err != nil {
 		controllers.NewErrorResponse(ctx, statusCode, err.Error())
 		return
 	}
 
 	bookResponses := responses.ToResponseList(listOfBooks)
+	meta := controllers.NewPaginationMeta(query.Page, query.Limit, total)
 
 	if bookResponses == nil {
-		controllers.NewSuccessResponse(ctx, statusCode, "book data is empty", []int{})
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, "book data is empty", []int{}, meta)
 		return
 	}
 
-	go c.ristrettoCache.Set("books", bookResponses)
+	go c.ristrettoCache.SetWithTTL(cacheKey, bookPage{Books: bookResponses, Meta: meta}, constants.BookListCacheTTL)
 
-	controllers.NewSuccessResponse(ctx, statusCode, "book data fetched successfully", map[string]interface{}{
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, "book data fetched successfully", map[string]interface{}{
 		"books": bookResponses,
+	}, meta)
+}
+
+// listGeneration reads the generation of the cached listing pages, starting a new one when a write dropped it
+func (c *BookController) listGeneration() int64 {
+	if generation, ok := c.ristrettoCache.Get("books").(int64); ok {
+		return generation
+	}
+
+	generation := time.Now().UnixNano()
+	c.ristrettoCache.Set("books", generation)
+	return generation
+}
+
+func (c *BookController) Search(ctx *gin.Context) {
+	var bookSearchRequest requests.BookSearchRequest
+	if err := ctx.ShouldBindQuery(&bookSearchRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	query := bookSearchRequest.ToDomain()
+	results, total, statusCode, err := c.bookUsecase.Search(ctxx, query)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	searchResponses := responses.ToSearchResponseList(results)
+
+	meta := controllers.NewPaginationMeta(query.Page, query.Limit, total)
+
+	if searchResponses == nil {
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("no book matches %q", bookSearchRequest.Q), []int{}, meta)
+		return
+	}
+
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, "book search success", map[string]interface{}{
+		"results": searchResponses,
+	}, meta)
+}
+
+func (c *BookController) Suggest(ctx *gin.Context) {
+	var bookSuggestRequest requests.BookSuggestRequest
+	if err := ctx.ShouldBindQuery(&bookSuggestRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cacheKey := bookSuggestRequest.CacheKey()
+	if val := c.ristrettoCache.Get(cacheKey); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, "book suggestions fetched successfully", map[string]interface{}{
+			"suggestions": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	suggestions, statusCode, err := c.bookUsecase.Suggest(ctxx, bookSuggestRequest.Prefix, bookSuggestRequest.Limit)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	suggestResponses := responses.ToSuggestResponseList(suggestions)
+
+	if suggestResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("no suggestion for %q", bookSuggestRequest.Prefix), []int{})
+		return
+	}
+
+	// suggestions are keyed per prefix and can't be dropped along with "books", so let them expire instead
+	go c.ristrettoCache.SetWithTTL(cacheKey, suggestResponses, constants.SuggestCacheTTL)
+
+	controllers.NewSuccessResponse(ctx, statusCode, "book suggestions fetched successfully", map[string]interface{}{
+		"suggestions": suggestResponses,
 	})
 }
 
@@ -102,6 +383,86 @@ func (c *BookController) GetById(ctx *gin.Context) {
 	})
 }
 
+func (c *BookController) GetByISBN(ctx *gin.Context) {
+	isbn := ctx.Param("isbn")
+
+	ctxx := ctx.Request.Context()
+	bookDomain, statusCode, err := c.bookUsecase.GetByISBN(ctxx, isbn)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with isbn %s fetched successfully", bookDomain.ISBN), map[string]interface{}{
+		"book": responses.FromDomain(bookDomain),
+	})
+}
+
+func (c *BookController) UploadCover(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	fileHeader, err := ctx.FormFile("cover")
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if fileHeader.Size > constants.MaxCoverSize {
+		controllers.NewErrorResponse(ctx, http.StatusRequestEntityTooLarge, fmt.Sprintf("cover can't be larger than %d MB", constants.MaxCoverSize>>20))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, constants.MaxCoverSize))
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	bookDomain, statusCode, err := c.bookUsecase.UploadCover(ctxx, id, data)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", id))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("cover of book with id %d uploaded successfully", id), map[string]interface{}{
+		"book": responses.FromDomain(bookDomain),
+	})
+}
+
+func (c *BookController) GetCover(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	contentType := "image/jpeg"
+	for mediaType, extension := range constants.MapperCoverContentTypeToExtension {
+		if path.Ext(key) == "."+extension {
+			contentType = mediaType
+		}
+	}
+
+	ctxx := ctx.Request.Context()
+	cover, statusCode, err := c.bookUsecase.OpenCover(ctxx, key)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+	defer cover.Close()
+
+	// cover keys change whenever the image does, so clients never have to revalidate
+	ctx.DataFromReader(statusCode, -1, contentType, cover, map[string]string{
+		"Cache-Control": "public, max-age=31536000, immutable",
+	})
+}
+
 func (c *BookController) Update(ctx *gin.Context) {
 	var bookUpdateRequest requests.BookRequest
 	id, _ := strconv.Atoi(ctx.Param("id"))
@@ -126,6 +487,38 @@ func (c *BookController) Update(ctx *gin.Context) {
 	})
 }
 
+func (c *BookController) Patch(ctx *gin.Context) {
+	if ctx.ContentType() != constants.MergePatchContentType {
+		ctx.Header("Accept-Patch", constants.MergePatchContentType)
+		controllers.NewErrorResponse(ctx, http.StatusUnsupportedMediaType, fmt.Sprintf("content type must be %s", constants.MergePatchContentType))
+		return
+	}
+
+	var bookPatchRequest requests.BookPatchRequest
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	if err := helpers.DecodeMergePatch(ctx.Request.Body, &bookPatchRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	newBook, changed, statusCode, err := c.bookUsecase.Patch(ctxx, id, bookPatchRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	if len(changed) > 0 {
+		go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", id))
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with id %d patched successfully", id), map[string]interface{}{
+		"book":    responses.FromDomain(newBook),
+		"changed": changed,
+	})
+}
+
 func (c *BookController) Delete(ctx *gin.Context) {
 	id, _ := strconv.Atoi(ctx.Param("id"))
 
@@ -140,3 +533,142 @@ func (c *BookController) Delete(ctx *gin.Context) {
 
 	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with id %d deleted successfully", id), nil)
 }
+
+func (c *BookController) GetHistory(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	versions, statusCode, err := c.bookUsecase.GetHistory(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("history of book with id %d fetched successfully", id), map[string]interface{}{
+		"versions": responses.ToVersionResponseList(versions),
+	})
+}
+
+func (c *BookController) GetSimilar(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var bookSimilarRequest requests.BookSimilarRequest
+	if err := ctx.ShouldBindQuery(&bookSimilarRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	similar, statusCode, err := c.bookUsecase.GetSimilar(ctxx, id, bookSimilarRequest.Limit)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	similarResponses := responses.ToSimilarResponseList(similar)
+
+	if similarResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "similar books are empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("books similar to book with id %d fetched successfully", id), map[string]interface{}{
+		"books": similarResponses,
+	})
+}
+
+func (c *BookController) GetStats(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	stats, statusCode, err := c.bookUsecase.GetStats(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("rating stats of book with id %d fetched successfully", id), map[string]interface{}{
+		"stats": responses.FromStatsDomain(stats),
+	})
+}
+
+func (c *BookController) Revert(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, "version must be a positive number")
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	book, statusCode, err := c.bookUsecase.Revert(ctxx, id, version)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", id))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d reverted to version %d successfully", id, version), map[string]interface{}{
+		"book": responses.FromDomain(book),
+	})
+}
+
+func (c *BookController) GetTags(ctx *gin.Context) {
+	ctxx := ctx.Request.Context()
+	tags, statusCode, err := c.bookUsecase.GetTags(ctxx)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	tagResponses := responses.ToTagResponseList(tags)
+
+	if tagResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "tag data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "tag data fetched successfully", gin.H{
+		"tags": tagResponses,
+	})
+}
+
+func (c *BookController) AddTags(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var bookTagsRequest requests.BookTagsRequest
+	if err := ctx.ShouldBindJSON(&bookTagsRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	book, statusCode, err := c.bookUsecase.AddTags(ctxx, id, bookTagsRequest.Tags)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", id))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("tags of book with id %d updated successfully", id), gin.H{
+		"book": responses.FromDomain(book),
+	})
+}
+
+func (c *BookController) RemoveTag(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	book, statusCode, err := c.bookUsecase.RemoveTag(ctxx, id, ctx.Param("tag"))
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", id))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("tags of book with id %d updated successfully", id), gin.H{
+		"book": responses.FromDomain(book),
+	})
+}
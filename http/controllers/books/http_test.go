@@ -2,11 +2,19 @@ package books_test
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +24,7 @@ import (
 	"github.com/snykk/golib_backend/constants"
 	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
 	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	storageMocks "github.com/snykk/golib_backend/datasources/storage/mocks"
 	"github.com/snykk/golib_backend/domains/books"
 	"github.com/snykk/golib_backend/domains/users"
 	"github.com/snykk/golib_backend/helpers"
@@ -33,6 +42,7 @@ var (
 	booksDataFromDB []books.Domain
 	bookDataFromDB  books.Domain
 	ristrettoMock   *cacheMocks.RistrettoCache
+	blobStorage     *storageMocks.BlobStorage
 	s               *gin.Engine
 	userDataFromDB  users.Domain
 )
@@ -40,7 +50,8 @@ var (
 func setup(t *testing.T) {
 	ristrettoMock = cacheMocks.NewRistrettoCache(t)
 	bookRepository = bookMocks.NewRepository(t)
-	bookUsecase = books.NewBookUsecase(bookRepository)
+	blobStorage = storageMocks.NewBlobStorage(t)
+	bookUsecase = books.NewBookUsecase(bookRepository, blobStorage)
 	bookController = controllers.NewBookController(bookUsecase, ristrettoMock)
 
 	booksDataFromDB = []books.Domain{
@@ -50,7 +61,7 @@ func setup(t *testing.T) {
 			Description: "lorem ipsum doler sit amet",
 			Author:      "James Clear",
 			Publisher:   "Gramedia",
-			ISBN:        "1111111111111",
+			ISBN:        "9780735211292",
 			Rating:      new(float64),
 			CreatedAt:   time.Now(),
 		},
@@ -60,7 +71,7 @@ func setup(t *testing.T) {
 			Description: "lorem ipsum doler sit amet",
 			Author:      "Tere Liye",
 			Publisher:   "Gramedia",
-			ISBN:        "1111111111111",
+			ISBN:        "9780735211292",
 			Rating:      new(float64),
 			CreatedAt:   time.Now(),
 		},
@@ -101,10 +112,11 @@ func TestStore(t *testing.T) {
 			Author:      "James Clear",
 			Description: "lorem ipsum doler sit amet",
 			Publisher:   "Gramedia",
-			ISBN:        "111111",
+			ISBN:        "9780735211292",
 		}
 		reqBody, _ := json.Marshal(req)
 
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(books.Domain{}, errors.New("record not found")).Once()
 		bookRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(bookDataFromDB, nil).Once()
 		ristrettoMock.Mock.On("Del", "books")
 
@@ -151,10 +163,11 @@ func TestStore(t *testing.T) {
 				Author:      "James Clear",
 				Description: "lorem ipsum doler sit amet",
 				Publisher:   "Gramedia",
-				ISBN:        "111111",
+				ISBN:        "9780735211292",
 			}
 			reqBody, _ := json.Marshal(req)
 
+			bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(books.Domain{}, errors.New("record not found")).Once()
 			bookRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(books.Domain{}, constants.ErrUnexpected).Once()
 
 			w := httptest.NewRecorder()
@@ -179,9 +192,11 @@ func TestGetAll(t *testing.T) {
 	s.GET("/books", bookController.GetAll)
 	t.Run("When Success", func(t *testing.T) {
 		t.Run("Fetched Book Data", func(t *testing.T) {
-			bookRepository.Mock.On("GetAll", mock.Anything).Return(booksDataFromDB, nil).Once()
+			bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return(booksDataFromDB, len(booksDataFromDB), nil).Once()
 			ristrettoMock.Mock.On("Get", "books").Return(nil).Once()
-			ristrettoMock.Mock.On("Set", "books", mock.Anything).Once()
+			ristrettoMock.Mock.On("Set", "books", mock.AnythingOfType("int64")).Once()
+			ristrettoMock.Mock.On("Get", mock.MatchedBy(isListingPage)).Return(nil).Once()
+			ristrettoMock.Mock.On("SetWithTTL", mock.MatchedBy(isListingPage), mock.Anything, constants.BookListCacheTTL).Once()
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodGet, "/books", nil)
@@ -201,8 +216,8 @@ func TestGetAll(t *testing.T) {
 			assert.Contains(t, body, "book data fetched successfully")
 		})
 		t.Run("Empty Data", func(t *testing.T) {
-			bookRepository.Mock.On("GetAll", mock.Anything).Return([]books.Domain{}, nil).Once()
-			ristrettoMock.Mock.On("Get", "books").Return(nil).Once()
+			bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return([]books.Domain{}, 0, nil).Once()
+			expectListingMiss()
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodGet, "/books", nil)
@@ -220,10 +235,112 @@ func TestGetAll(t *testing.T) {
 			assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 			assert.Contains(t, body, "book data is empty")
 		})
+		t.Run("Fetched Book Data With Query", func(t *testing.T) {
+			bookRepository.Mock.On("GetAll", mock.Anything, mock.MatchedBy(func(query *books.Query) bool {
+				return query.Page == 2 && query.Limit == 1 && query.Sort == "rating" && query.Order == "asc" && query.Author == "Clear"
+			})).Return(booksDataFromDB[:1], len(booksDataFromDB), nil).Once()
+			expectListingMiss()
+			ristrettoMock.Mock.On("SetWithTTL", mock.MatchedBy(isListingPage), mock.Anything, constants.BookListCacheTTL).Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/books?page=2&limit=1&sort=rating&order=asc&author=Clear", nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			body := w.Body.String()
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+			assert.Contains(t, body, `"total_items":2`)
+			assert.Contains(t, body, `"total_pages":2`)
+		})
+	})
+	t.Run("When Omitted Page Shares The First Page", func(t *testing.T) {
+		defaults := books.Query{}
+		defaults.ApplyDefaults()
+		cacheKey := "books?generation=1&" + defaults.CacheKey()
+		ristrettoMock.Mock.On("Get", "books").Return(int64(1)).Twice()
+		ristrettoMock.Mock.On("Get", cacheKey).Return(nil).Twice()
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return(booksDataFromDB, len(booksDataFromDB), nil).Twice()
+		ristrettoMock.Mock.On("SetWithTTL", cacheKey, mock.Anything, constants.BookListCacheTTL).Twice()
+
+		for _, target := range []string{"/books", "/books?page=1"} {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, target, nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusOK, w.Result().StatusCode, target)
+		}
+	})
+	t.Run("When Filtering By Category And Tags", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.MatchedBy(func(query *books.Query) bool {
+			return query.Category == 2 && len(query.Tags) == 2 && query.Tags[0] == "classic" && query.Tags[1] == "science fiction"
+		})).Return(booksDataFromDB[:1], 1, nil).Once()
+		expectListingMiss()
+		ristrettoMock.Mock.On("SetWithTTL", mock.MatchedBy(isListingPage), mock.Anything, constants.BookListCacheTTL).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books?category=2&tag=Classic&tag=science+fiction", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), `"categories":[]`)
+	})
+	t.Run("When Filtering By Bibliographic Fields", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.MatchedBy(func(query *books.Query) bool {
+			return query.Language == "en" && query.Format == constants.BookFormatEbook && query.Series == "Dune" && *query.MinPages == 100 &&
+				query.PublishedFrom != nil && query.PublishedFrom.Year() == 1965 && query.Sort == "publication_date"
+		})).Return(booksDataFromDB[:1], 1, nil).Once()
+		expectListingMiss()
+		ristrettoMock.Mock.On("SetWithTTL", mock.MatchedBy(isListingPage), mock.Anything, constants.BookListCacheTTL).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books?language=EN&format=ebook&series=Dune&min_pages=100&published_from=1965-08-01&sort=publication_date", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+	t.Run("When Invalid Bibliographic Filter", func(t *testing.T) {
+		for _, query := range []string{"format=scroll", "published_to=01-01-2020", "min_pages=-1"} {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/books?"+query, nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+	t.Run("When Invalid Query", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books?sort=isbn", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
 	})
 	t.Run("When Failure", func(t *testing.T) {
-		bookRepository.Mock.On("GetAll", mock.Anything).Return([]books.Domain{}, constants.ErrUnexpected).Once()
-		ristrettoMock.Mock.On("Get", "books").Return(nil).Once()
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return([]books.Domain{}, 0, constants.ErrUnexpected).Once()
+		expectListingMiss()
 
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/books", nil)
@@ -241,6 +358,17 @@ func TestGetAll(t *testing.T) {
 
 }
 
+// isListingPage matches the cache key of a listing page
+func isListingPage(key string) bool {
+	return strings.HasPrefix(key, "books?generation=")
+}
+
+// expectListingMiss finds the cached listing in generation 1 without the requested page in it
+func expectListingMiss() {
+	ristrettoMock.Mock.On("Get", "books").Return(int64(1)).Once()
+	ristrettoMock.Mock.On("Get", mock.MatchedBy(isListingPage)).Return(nil).Once()
+}
+
 func TestGetById(t *testing.T) {
 	setup(t)
 	// Define route
@@ -288,6 +416,43 @@ func TestGetById(t *testing.T) {
 	})
 }
 
+func TestGetByISBN(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/isbn/:isbn", bookController.GetByISBN)
+	t.Run("When Success Get Book By ISBN", func(t *testing.T) {
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(bookDataFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/isbn/0735211299", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "book data with isbn 9780735211292 fetched successfully")
+	})
+	t.Run("When Failure Invalid ISBN", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/isbn/111111", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "isbn must be 10 or 13 digits long")
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	setup(t)
 	// Define route
@@ -298,13 +463,14 @@ func TestUpdate(t *testing.T) {
 			Author:      "James Clear",
 			Description: "lorem ipsum doler sit amet",
 			Publisher:   "Gramedia",
-			ISBN:        "111111",
+			ISBN:        "9780735211292",
 		}
 
 		reqBody, _ := json.Marshal(req)
 
 		bookDataFromDB.Title = "Atomic Habits edited"
 
+		bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(bookDataFromDB, nil).Once()
 		bookRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(nil).Once()
 		bookRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(bookDataFromDB, nil).Once()
 		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything)
@@ -352,12 +518,13 @@ func TestUpdate(t *testing.T) {
 				Author:      "James Clear",
 				Description: "lorem ipsum doler sit amet",
 				Publisher:   "Gramedia",
-				ISBN:        "111111",
+				ISBN:        "9780735211292",
 			}
 			reqBody, _ := json.Marshal(req)
 
 			bookDataFromDB.Title = "Atomic Habits edited"
 
+			bookRepository.Mock.On("GetByISBN", mock.Anything, "9780735211292").Return(bookDataFromDB, nil).Once()
 			bookRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*books.Domain")).Return(constants.ErrUnexpected).Once()
 
 			w := httptest.NewRecorder()
@@ -376,6 +543,100 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestPatch(t *testing.T) {
+	setup(t)
+	// Define route
+	s.PATCH("/books/:id", bookController.Patch)
+	t.Run("When Success Patch book Data", func(t *testing.T) {
+		reqBody := []byte(`{"title":"Atomic Habits edited","description":"a book about habits"}`)
+
+		patchedBook := bookDataFromDB
+		patchedBook.Title = "Atomic Habits edited"
+		patchedBook.Description = "a book about habits"
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("Patch", mock.Anything, mock.AnythingOfType("*books.Domain"), []string{"title", "description"}).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(patchedBook, nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/books/%d", bookDataFromDB.ID), bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", constants.MergePatchContentType)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "patched successfully")
+		assert.Contains(t, body, `"changed":["title","description"]`)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("When Content Type Is Not Merge Patch", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/books/%d", bookDataFromDB.ID), bytes.NewReader([]byte(`{"title":"Atomic Habits"}`)))
+
+			r.Header.Set("Content-Type", "application/json")
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusUnsupportedMediaType, w.Result().StatusCode)
+		})
+		t.Run("When Field Can't Be Patched", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/books/%d", bookDataFromDB.ID), bytes.NewReader([]byte(`{"rating":10}`)))
+
+			r.Header.Set("Content-Type", constants.MergePatchContentType)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+		t.Run("When Required Field Is Nulled", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/books/%d", bookDataFromDB.ID), bytes.NewReader([]byte(`{"author":null}`)))
+
+			r.Header.Set("Content-Type", constants.MergePatchContentType)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "author can't be empty")
+		})
+		t.Run("When Description Is Nulled", func(t *testing.T) {
+			bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/books/%d", bookDataFromDB.ID), bytes.NewReader([]byte(`{"description":null}`)))
+
+			r.Header.Set("Content-Type", constants.MergePatchContentType)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "description can't be empty")
+		})
+	})
+}
+
 func TestDelete(t *testing.T) {
 	setup(t)
 	// Define route
@@ -418,3 +679,777 @@ func TestDelete(t *testing.T) {
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 	})
 }
+
+func TestSearch(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/search", bookController.Search)
+	t.Run("When Success Search Books", func(t *testing.T) {
+		searchResults := []books.SearchResult{
+			{
+				Book:           bookDataFromDB,
+				Rank:           0.6,
+				TitleHighlight: "<mark>Atomic</mark> Habits",
+				Snippet:        "lorem ipsum doler sit amet",
+			},
+		}
+		bookRepository.Mock.On("Search", mock.Anything, mock.AnythingOfType("*books.SearchQuery")).Return(searchResults, 1, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/search?q=atomic", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "book search success")
+		assert.Contains(t, body, `"score":0.6`)
+	})
+	t.Run("When Failure Keyword Is Missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/search", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "failed on the 'required' tag")
+	})
+}
+
+func TestSuggest(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/suggest", bookController.Suggest)
+	t.Run("When Success Suggest Books", func(t *testing.T) {
+		suggestions := []books.Suggestion{
+			{Value: "Atomic Habits", Field: "title"},
+		}
+		ristrettoMock.Mock.On("Get", "books/suggest/ato/0").Return(nil).Once()
+		ristrettoMock.Mock.On("SetWithTTL", "books/suggest/ato/0", mock.Anything, constants.SuggestCacheTTL).Once()
+		bookRepository.Mock.On("Suggest", mock.Anything, "Ato", constants.DefaultSuggestLimit).Return(suggestions, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/suggest?prefix=Ato", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "book suggestions fetched successfully")
+		assert.Contains(t, body, "Atomic Habits")
+	})
+	t.Run("When Success Fetched From Cache", func(t *testing.T) {
+		ristrettoMock.Mock.On("Get", "books/suggest/james/5").Return([]interface{}{map[string]string{"value": "James Clear", "field": "author"}}).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/suggest?prefix=James&limit=5", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "James Clear")
+	})
+	t.Run("When Failure Prefix Is Missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/suggest", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "failed on the 'required' tag")
+	})
+}
+
+func newImportRequest(filename, content string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/books/import", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestImport(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/books/import", bookController.Import)
+	t.Run("When Success Import CSV", func(t *testing.T) {
+		content := "title,author,description,publisher,isbn\n" +
+			"Atomic Habits,James Clear,lorem ipsum doler sit amet,Gramedia,978-0-7352-1129-2\n" +
+			",Carol Dweck,lorem ipsum doler sit amet,Gramedia,9780345472328\n"
+
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292"}).Return([]books.Domain{}, nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, mock.AnythingOfType("[]books.Domain")).Return([]books.Domain{bookDataFromDB}, []error{nil}, nil).Once()
+		ristrettoMock.Mock.On("Del", "books")
+
+		w := httptest.NewRecorder()
+
+		// Perform requests
+		s.ServeHTTP(w, newImportRequest("books.csv", content))
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "1 of 2 books imported")
+		assert.Contains(t, body, `"line":3`)
+		assert.Contains(t, body, "failed on the 'required' tag")
+	})
+	t.Run("When Success Import CSV With Bibliographic Columns", func(t *testing.T) {
+		content := "title,author,description,publisher,isbn,language,format,page_count,publication_date\n" +
+			"Atomic Habits,James Clear,lorem ipsum doler sit amet,Gramedia,9780735211292,EN,hardcover,320,2018-10-16\n" +
+			"Mindset,Carol Dweck,lorem ipsum doler sit amet,Gramedia,9780345472328,en,paperback,many,2006-02-28\n" +
+			"Dune,Frank Herbert,lorem ipsum doler sit amet,Chilton,9780441172719,en,paperback,412,1965\n"
+
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292"}).Return([]books.Domain{}, nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, mock.MatchedBy(func(pending []books.Domain) bool {
+			return len(pending) == 1 && pending[0].Language == "en" && pending[0].PageCount == 320 && pending[0].PublicationDate != nil
+		})).Return([]books.Domain{bookDataFromDB}, []error{nil}, nil).Once()
+		ristrettoMock.Mock.On("Del", "books")
+
+		w := httptest.NewRecorder()
+
+		// Perform requests
+		s.ServeHTTP(w, newImportRequest("books.csv", content))
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "1 of 3 books imported")
+		assert.Contains(t, body, "page_count must be a whole number")
+		assert.Contains(t, body, "failed on the 'datetime' tag")
+	})
+	t.Run("When Success Import NDJSON", func(t *testing.T) {
+		content := `{"title":"Atomic Habits","author":"James Clear","description":"lorem ipsum doler sit amet","publisher":"Gramedia","isbn":"9780735211292"}` + "\n\n" +
+			`{"title": "broken"` + "\n"
+
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292"}).Return([]books.Domain{bookDataFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+
+		// Perform requests
+		s.ServeHTTP(w, newImportRequest("books.ndjson", content))
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "0 of 2 books imported")
+		assert.Contains(t, body, "already exists")
+		assert.Contains(t, body, "invalid json")
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Unsupported Format", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			// Perform requests
+			s.ServeHTTP(w, newImportRequest("books.xlsx", "title"))
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "import format must be one of")
+		})
+		t.Run("Missing CSV Column", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			// Perform requests
+			s.ServeHTTP(w, newImportRequest("books.csv", "title,author\nAtomic Habits,James Clear\n"))
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "csv header must contain")
+		})
+	})
+}
+
+func newMetadataImportRequest(filename string, content []byte, dryRun string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write(content)
+	if dryRun != "" {
+		writer.WriteField("dry_run", dryRun)
+	}
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/books/import/metadata", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestImportMetadata(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/books/import/metadata", bookController.ImportMetadata)
+	t.Run("When Success Import ONIX", func(t *testing.T) {
+		content := `<?xml version="1.0" encoding="UTF-8"?>
+<ONIXMessage release="3.0" xmlns="http://ns.editeur.org/onix/3.0/reference">
+	<Header><Sender><SenderName>Penguin</SenderName></Sender></Header>
+	<Product>
+		<RecordReference>com.penguin.9780735211292</RecordReference>
+		<ProductIdentifier><ProductIDType>01</ProductIDType><IDValue>PRH-1129</IDValue></ProductIdentifier>
+		<ProductIdentifier><ProductIDType>15</ProductIDType><IDValue>9780735211292</IDValue></ProductIdentifier>
+		<DescriptiveDetail>
+			<TitleDetail><TitleType>01</TitleType><TitleElement><TitleElementLevel>01</TitleElementLevel><TitleText>Atomic Habits</TitleText></TitleElement></TitleDetail>
+			<Contributor><ContributorRole>A01</ContributorRole><PersonName>James Clear</PersonName></Contributor>
+			<Extent><ExtentType>00</ExtentType><ExtentValue>320</ExtentValue><ExtentUnit>03</ExtentUnit></Extent>
+		</DescriptiveDetail>
+		<CollateralDetail>
+			<TextContent><TextType>03</TextType><Text textformat="05"><p>Tiny changes, <em>remarkable</em> results</p></Text></TextContent>
+		</CollateralDetail>
+		<PublishingDetail>
+			<Publisher><PublishingRole>01</PublishingRole><PublisherName>Avery</PublisherName></Publisher>
+			<PublishingDate><PublishingDateRole>01</PublishingDateRole><Date dateformat="00">20181016</Date></PublishingDate>
+		</PublishingDetail>
+	</Product>
+</ONIXMessage>`
+
+		published := time.Date(2018, 10, 16, 0, 0, 0, 0, time.UTC)
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292"}).Return([]books.Domain{}, nil).Once()
+		bookRepository.Mock.On("StoreBatch", mock.Anything, []books.Domain{{
+			Title:           "Atomic Habits",
+			Author:          "James Clear",
+			Description:     "Tiny changes, remarkable results",
+			Publisher:       "Avery",
+			ISBN:            "9780735211292",
+			PageCount:       320,
+			PublicationDate: &published,
+		}}).Return([]books.Domain{bookDataFromDB}, []error{nil}, nil).Once()
+		ristrettoMock.Mock.On("Del", "books").Maybe()
+
+		w := httptest.NewRecorder()
+
+		// Perform requests
+		s.ServeHTTP(w, newMetadataImportRequest("catalog.xml", []byte(content), ""))
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "1 books created and 0 enriched out of 1")
+		assert.Contains(t, body, `"status":"created"`)
+	})
+	t.Run("When Success Dry Run Of Gzipped Open Library Dump", func(t *testing.T) {
+		content := "/type/edition\t/books/OL1M\t3\t2022-01-01T00:00:00\t" + `{"type":{"key":"/type/edition"},"title":"Atomic Habits","authors":[{"key":"/authors/OL1A"}],"publishers":["Avery"],"publish_date":"Oct 16, 2018","number_of_pages":320,"isbn_10":["0735211299"]}` + "\n" +
+			"/type/edition\t/books/OL2M\t1\t2022-01-01T00:00:00\t" + `{"type":{"key":"/type/edition"},"title":"No isbn at all"}` + "\n" +
+			"/type/author\t/authors/OL1A\t1\t2022-01-01T00:00:00\t" + `{"key":"/authors/OL1A","name":"James Clear"}` + "\n"
+		var gzipped bytes.Buffer
+		gzipWriter := gzip.NewWriter(&gzipped)
+		gzipWriter.Write([]byte(content))
+		gzipWriter.Close()
+
+		bookRepository.Mock.On("GetByISBNs", mock.Anything, []string{"9780735211292"}).Return([]books.Domain{bookDataFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+
+		// Perform requests
+		s.ServeHTTP(w, newMetadataImportRequest("ol_dump_editions.txt.gz", gzipped.Bytes(), "true"))
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "dry run, 0 books would be created and 1 enriched out of 1")
+		assert.Contains(t, body, `"fields":["page_count","publication_date"]`)
+		bookRepository.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Short Tag ONIX", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			// Perform requests
+			s.ServeHTTP(w, newMetadataImportRequest("catalog.onix", []byte(`<ONIXmessage><product></product></ONIXmessage>`), ""))
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "short tag ONIX isn't supported")
+		})
+		t.Run("Invalid Dry Run", func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			// Perform requests
+			s.ServeHTTP(w, newMetadataImportRequest("catalog.xml", []byte(`<ONIXMessage/>`), "maybe"))
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "dry_run must be either true or false")
+		})
+	})
+}
+
+func streamBooks(ctx context.Context, fn func(books.Domain) error) error {
+	for _, book := range booksDataFromDB {
+		if err := fn(book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/export", bookController.Export)
+	t.Run("When Success Export CSV", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(streamBooks).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/export?format=csv", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "text/csv", w.Result().Header.Get("Content-Type"))
+		assert.Contains(t, w.Result().Header.Get("Content-Disposition"), "books.csv")
+		assert.Equal(t, 3, len(lines))
+		assert.True(t, strings.HasPrefix(lines[0], "id,title,author,description,publisher,isbn"))
+		assert.True(t, strings.HasPrefix(lines[1], "1,Atomic Habits,James Clear"))
+	})
+	t.Run("When Success Export NDJSON", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(streamBooks).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/export", nil)
+		r.Header.Set("Accept", "application/x-ndjson")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "application/x-ndjson", w.Result().Header.Get("Content-Type"))
+		assert.Equal(t, 2, len(lines))
+		assert.Contains(t, lines[1], `"title":"Selena"`)
+	})
+	t.Run("When Success Export MARC21", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(streamBooks).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/export", nil)
+		r.Header.Set("Accept", "application/marc")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		records := strings.Split(strings.TrimSuffix(w.Body.String(), "\x1d"), "\x1d")
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, 2, len(records))
+		for _, record := range records {
+			recordLength, _ := strconv.Atoi(record[:5])
+			assert.Equal(t, len(record)+1, recordLength)
+			assert.Equal(t, "nam a22", record[5:12])
+			assert.Equal(t, "4500", record[20:24])
+		}
+		assert.Contains(t, records[0], "\x1faAtomic Habits\x1e")
+		assert.Contains(t, records[0], "\x1fa9780735211292\x1e")
+	})
+	t.Run("When Success Export Falls Back To CSV", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(streamBooks).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/export", nil)
+		r.Header.Set("Accept", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "text/csv", w.Result().Header.Get("Content-Type"))
+	})
+	t.Run("When Success Export MARCXML", func(t *testing.T) {
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(streamBooks).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/export?format=marcxml", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `<collection xmlns="http://www.loc.gov/MARC21/slim">`)
+		assert.Contains(t, body, `<datafield tag="245" ind1="1" ind2="0"><subfield code="a">Atomic Habits</subfield></datafield>`)
+		assert.True(t, strings.HasSuffix(body, "</collection>\n"))
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("Unsupported Format", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/books/export?format=xlsx", nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusNotAcceptable, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "format must be one of")
+		})
+		t.Run("Stream Failed", func(t *testing.T) {
+			bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(constants.ErrUnexpected).Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/books/export", nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+			assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		})
+	})
+}
+
+func TestUploadCover(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/books/:id/cover", bookController.UploadCover)
+	t.Run("When Success Upload Cover", func(t *testing.T) {
+		var cover bytes.Buffer
+		png.Encode(&cover, image.NewRGBA(image.Rect(0, 0, 300, 450)))
+
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("cover", "cover.png")
+		part.Write(cover.Bytes())
+		writer.Close()
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		blobStorage.Mock.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil).Times(3)
+		bookRepository.Mock.On("UpdateCover", mock.Anything, bookDataFromDB.ID, mock.AnythingOfType("string")).Return(nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/books/%d/cover", bookDataFromDB.ID), body)
+		r.Header.Set("Content-Type", writer.FormDataContentType())
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "uploaded successfully")
+		assert.Regexp(t, `"cover_url":"/covers/books/1/[0-9a-f]{16}\.png"`, w.Body.String())
+		assert.Regexp(t, `"small":"/covers/books/1/[0-9a-f]{16}_small\.jpg"`, w.Body.String())
+	})
+	t.Run("When Failure Cover Is Missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/books/%d/cover", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetCover(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/covers/*key", bookController.GetCover)
+	t.Run("When Success Get Cover", func(t *testing.T) {
+		blobStorage.Mock.On("Open", mock.Anything, "books/1/abcdef_small.jpg").Return(io.NopCloser(strings.NewReader("jpeg bytes")), nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/covers/books/1/abcdef_small.jpg", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "image/jpeg", w.Result().Header.Get("Content-Type"))
+		assert.Contains(t, w.Result().Header.Get("Cache-Control"), "immutable")
+		assert.Equal(t, "jpeg bytes", w.Body.String())
+	})
+	t.Run("When Failure Cover Not Found", func(t *testing.T) {
+		blobStorage.Mock.On("Open", mock.Anything, "books/1/missing.png").Return(nil, books.ErrBlobNotFound).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/covers/books/1/missing.png", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "cover not found")
+	})
+}
+
+func TestAddTags(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/books/:id/tags", bookController.AddTags)
+	t.Run("When Success Add Tags", func(t *testing.T) {
+		req := requests.BookTagsRequest{Tags: []string{"Productivity"}}
+		reqBody, _ := json.Marshal(req)
+
+		tagged := bookDataFromDB
+		tagged.Tags = []string{"productivity"}
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("AddTags", mock.Anything, bookDataFromDB.ID, []string{"productivity"}).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(tagged, nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/books/%d/tags", bookDataFromDB.ID), bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"tags":["productivity"]`)
+	})
+	t.Run("When Failure Empty Tags", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/books/%d/tags", bookDataFromDB.ID), bytes.NewReader([]byte(`{"tags":[]}`)))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/:id/history", bookController.GetHistory)
+	t.Run("When Success Get Book History", func(t *testing.T) {
+		adminId := 1
+		versions := []books.Version{
+			{BookId: 1, Version: 2, Action: constants.BookVersionUpdated, UserId: &adminId, Changes: []books.FieldChange{{Field: "title", From: "Atomic Habit", To: "Atomic Habits"}}, Snapshot: bookDataFromDB},
+			{BookId: 1, Version: 1, Action: constants.BookVersionCreated, Snapshot: bookDataFromDB},
+		}
+		bookRepository.Mock.On("GetVersions", mock.Anything, bookDataFromDB.ID).Return(versions, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/books/%d/history", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"changes":[{"field":"title","from":"Atomic Habit","to":"Atomic Habits"}]`)
+		assert.Contains(t, body, `"user_id":1`)
+	})
+}
+
+func TestGetSimilar(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/:id/similar", bookController.GetSimilar)
+	t.Run("When Success Get Similar Books", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("Stream", mock.Anything, mock.AnythingOfType("func(books.Domain) error")).Return(func(ctx context.Context, fn func(books.Domain) error) error {
+			for _, book := range booksDataFromDB {
+				if err := fn(book); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Once()
+		assert.Nil(t, bookUsecase.RebuildSimilarityIndex(context.Background()))
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{2}).Return(booksDataFromDB[1:], nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/books/%d/similar?limit=3", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, booksDataFromDB[1].Title)
+		assert.Contains(t, body, `"score":`)
+	})
+	t.Run("When Failure Invalid Limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/books/%d/similar?limit=100", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/:id/stats", bookController.GetStats)
+	t.Run("When Success Get Book Stats", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("GetRatingStats", mock.Anything, bookDataFromDB.ID).Return(
+			[]books.RatingCount{{Rating: 9, Reviews: 2}, {Rating: 6, Reviews: 1}},
+			[]books.RatingMonth{{Month: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Reviews: 3, RatingSum: 24}},
+			nil,
+		).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/books/%d/stats", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"reviews":3,"average":8,"median":9,"std_dev":1.41`)
+		assert.Contains(t, body, `{"rating":9,"reviews":2}`)
+		assert.Contains(t, body, `"months":[{"month":"2026-09","reviews":3,"average":8}]`)
+	})
+	t.Run("When Failure Book Not Found", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 99).Return(books.Domain{}, errors.New("record not found")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/99/stats", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestRevert(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/books/:id/history/:version/revert", bookController.Revert)
+	t.Run("When Success Revert Book", func(t *testing.T) {
+		previous := bookDataFromDB
+		previous.Title = "Atomic Habit"
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(bookDataFromDB, nil).Once()
+		bookRepository.Mock.On("GetVersion", mock.Anything, bookDataFromDB.ID, 1).Return(books.Version{BookId: 1, Version: 1, Snapshot: previous}, nil).Once()
+		bookRepository.Mock.On("Revert", mock.Anything, mock.AnythingOfType("*books.Domain"), 1).Return(nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, bookDataFromDB.ID).Return(previous, nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/books/%d/history/1/revert", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "reverted to version 1 successfully")
+	})
+	t.Run("When Failure Invalid Version", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/books/%d/history/latest/revert", bookDataFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetTags(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/tags", bookController.GetTags)
+	t.Run("When Success Get Tags", func(t *testing.T) {
+		bookRepository.Mock.On("GetTags", mock.Anything).Return([]books.Tag{{Name: "bestseller", Books: 2}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/tags", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), `{"name":"bestseller","books":2}`)
+	})
+}
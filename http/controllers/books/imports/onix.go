@@ -0,0 +1,277 @@
+package imports
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+// code lists of ONIX 3.0 that matter here, see the EDItEUR codelists
+const (
+	onixIDTypeISBN10 = "02"
+	onixIDTypeGTIN13 = "03"
+	onixIDTypeISBN13 = "15"
+
+	onixTitleTypeDistinctive = "01"
+	onixTitleLevelProduct    = "01"
+
+	onixContributorAuthor = "A01"
+
+	onixExtentUnitPages = "03"
+
+	onixTextShortDescription = "02"
+	onixTextDescription      = "03"
+
+	onixPublisherRole       = "01"
+	onixPublicationDateRole = "01"
+
+	onixDateFormatYearMonthDay = "00"
+	onixDateFormatYearMonth    = "01"
+	onixDateFormatYear         = "05"
+)
+
+// onixPageExtents are the extent types holding a page count, the main content count is the best guess of what readers mean
+var onixPageExtents = []string{"00", "11", "08"}
+
+type onixProduct struct {
+	Identifiers []struct {
+		Type  string `xml:"ProductIDType"`
+		Value string `xml:"IDValue"`
+	} `xml:"ProductIdentifier"`
+	Titles []struct {
+		Type     string `xml:"TitleType"`
+		Elements []struct {
+			Level         string `xml:"TitleElementLevel"`
+			Text          string `xml:"TitleText"`
+			Prefix        string `xml:"TitlePrefix"`
+			WithoutPrefix string `xml:"TitleWithoutPrefix"`
+		} `xml:"TitleElement"`
+	} `xml:"DescriptiveDetail>TitleDetail"`
+	Contributors []struct {
+		Roles          []string `xml:"ContributorRole"`
+		PersonName     string   `xml:"PersonName"`
+		NamesBeforeKey string   `xml:"NamesBeforeKey"`
+		KeyNames       string   `xml:"KeyNames"`
+		CorporateName  string   `xml:"CorporateName"`
+	} `xml:"DescriptiveDetail>Contributor"`
+	Extents []struct {
+		Type  string `xml:"ExtentType"`
+		Value string `xml:"ExtentValue"`
+		Unit  string `xml:"ExtentUnit"`
+	} `xml:"DescriptiveDetail>Extent"`
+	Texts []struct {
+		Type string `xml:"TextType"`
+		Text []struct {
+			Value string `xml:",innerxml"`
+		} `xml:"Text"`
+	} `xml:"CollateralDetail>TextContent"`
+	Publishers []struct {
+		Role string `xml:"PublishingRole"`
+		Name string `xml:"PublisherName"`
+	} `xml:"PublishingDetail>Publisher"`
+	Dates []struct {
+		Role string `xml:"PublishingDateRole"`
+		Date struct {
+			Format string `xml:"dateformat,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"Date"`
+	} `xml:"PublishingDetail>PublishingDate"`
+}
+
+func (p *onixProduct) isbn() string {
+	found := make(map[string]string, len(p.Identifiers))
+	for _, identifier := range p.Identifiers {
+		found[identifier.Type] = strings.TrimSpace(identifier.Value)
+	}
+
+	if isbn := found[onixIDTypeISBN13]; isbn != "" {
+		return isbn
+	}
+	// a GTIN-13 is an isbn as long as it is in the bookland range
+	if gtin := found[onixIDTypeGTIN13]; strings.HasPrefix(gtin, "978") || strings.HasPrefix(gtin, "979") {
+		return gtin
+	}
+
+	return found[onixIDTypeISBN10]
+}
+
+func (p *onixProduct) title() string {
+	for _, title := range p.Titles {
+		if title.Type != onixTitleTypeDistinctive {
+			continue
+		}
+		for _, element := range title.Elements {
+			if element.Level != onixTitleLevelProduct {
+				continue
+			}
+			if text := strings.TrimSpace(element.Text); text != "" {
+				return text
+			}
+			return strings.TrimSpace(element.Prefix + " " + element.WithoutPrefix)
+		}
+	}
+
+	return ""
+}
+
+func (p *onixProduct) author() string {
+	var authors []string
+	for _, contributor := range p.Contributors {
+		isAuthor := false
+		for _, role := range contributor.Roles {
+			isAuthor = isAuthor || role == onixContributorAuthor
+		}
+		if !isAuthor {
+			continue
+		}
+
+		name := strings.TrimSpace(contributor.PersonName)
+		if name == "" {
+			name = strings.TrimSpace(contributor.NamesBeforeKey + " " + contributor.KeyNames)
+		}
+		if name == "" {
+			name = strings.TrimSpace(contributor.CorporateName)
+		}
+		if name != "" {
+			authors = append(authors, name)
+		}
+	}
+
+	return strings.Join(authors, ", ")
+}
+
+func (p *onixProduct) pageCount() int {
+	for _, extentType := range onixPageExtents {
+		for _, extent := range p.Extents {
+			if extent.Type != extentType || extent.Unit != onixExtentUnitPages {
+				continue
+			}
+			if pages, err := strconv.Atoi(strings.TrimSpace(extent.Value)); err == nil && pages > 0 {
+				return pages
+			}
+		}
+	}
+
+	return 0
+}
+
+func (p *onixProduct) description() string {
+	for _, textType := range []string{onixTextDescription, onixTextShortDescription} {
+		for _, text := range p.Texts {
+			if text.Type == textType && len(text.Text) > 0 {
+				return plainText(text.Text[0].Value)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (p *onixProduct) publisher() string {
+	for _, publisher := range p.Publishers {
+		if publisher.Role == onixPublisherRole {
+			return strings.TrimSpace(publisher.Name)
+		}
+	}
+	if len(p.Publishers) > 0 {
+		return strings.TrimSpace(p.Publishers[0].Name)
+	}
+
+	return ""
+}
+
+func (p *onixProduct) publicationDate() *time.Time {
+	for _, date := range p.Dates {
+		if date.Role != onixPublicationDateRole {
+			continue
+		}
+
+		layout := "20060102"
+		switch date.Date.Format {
+		case onixDateFormatYearMonth:
+			layout = "200601"
+		case onixDateFormatYear:
+			layout = "2006"
+		case "", onixDateFormatYearMonthDay:
+		default:
+			return parsePublicationDate(date.Date.Value)
+		}
+
+		if parsed, err := time.Parse(layout, strings.TrimSpace(date.Date.Value)); err == nil {
+			return &parsed
+		}
+	}
+
+	return nil
+}
+
+type onixReader struct {
+	decoder  *xml.Decoder
+	started  bool
+	products int
+}
+
+func newONIXReader(r io.Reader) Reader {
+	return &onixReader{decoder: xml.NewDecoder(r)}
+}
+
+// Read returns one record per product, Line is the position of the product within the message
+func (r *onixReader) Read() (books.MetadataRecord, error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) && !r.started {
+				return books.MetadataRecord{}, errors.New("import file isn't an ONIX message")
+			}
+			return books.MetadataRecord{}, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if !r.started {
+			switch start.Name.Local {
+			case "ONIXMessage":
+				r.started = true
+				continue
+			case "ONIXmessage":
+				return books.MetadataRecord{}, errors.New("short tag ONIX isn't supported, please provide the reference tag version")
+			default:
+				return books.MetadataRecord{}, errors.New("import file isn't an ONIX message")
+			}
+		}
+
+		if start.Name.Local != "Product" {
+			if start.Name.Local != "Header" {
+				continue
+			}
+			if err := r.decoder.Skip(); err != nil {
+				return books.MetadataRecord{}, err
+			}
+			continue
+		}
+
+		var product onixProduct
+		if err := r.decoder.DecodeElement(&product, &start); err != nil {
+			return books.MetadataRecord{}, err
+		}
+		r.products++
+
+		return books.MetadataRecord{Line: r.products, Book: books.Domain{
+			Title:           product.title(),
+			Author:          product.author(),
+			Description:     product.description(),
+			Publisher:       product.publisher(),
+			ISBN:            product.isbn(),
+			PageCount:       product.pageCount(),
+			PublicationDate: product.publicationDate(),
+		}}, nil
+	}
+}
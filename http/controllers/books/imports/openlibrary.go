@@ -0,0 +1,204 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+const (
+	openLibraryEditionType = "/type/edition"
+	openLibraryAuthorType  = "/type/author"
+)
+
+type openLibraryKey struct {
+	Key string `json:"key"`
+}
+
+type openLibraryEdition struct {
+	Type          openLibraryKey   `json:"type"`
+	Title         string           `json:"title"`
+	Subtitle      string           `json:"subtitle"`
+	EditionName   string           `json:"edition_name"`
+	Languages     []openLibraryKey `json:"languages"`
+	Authors       []openLibraryKey `json:"authors"`
+	ByStatement   string           `json:"by_statement"`
+	Publishers    []string         `json:"publishers"`
+	PublishDate   string           `json:"publish_date"`
+	NumberOfPages int              `json:"number_of_pages"`
+	ISBN13        []string         `json:"isbn_13"`
+	ISBN10        []string         `json:"isbn_10"`
+	Description   json.RawMessage  `json:"description"`
+}
+
+type openLibraryAuthor struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// description is either a plain string or a typed text object
+func (e *openLibraryEdition) description() string {
+	if len(e.Description) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(e.Description, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+	var typed struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(e.Description, &typed); err == nil {
+		return strings.TrimSpace(typed.Value)
+	}
+
+	return ""
+}
+
+func (e *openLibraryEdition) isbn() string {
+	if len(e.ISBN13) > 0 {
+		return e.ISBN13[0]
+	}
+	if len(e.ISBN10) > 0 {
+		return e.ISBN10[0]
+	}
+
+	return ""
+}
+
+// splitOpenLibraryLine accepts the tab separated lines of the official dumps (type, key, revision, last modified, json)
+// as well as lines holding nothing but the json of a record
+func splitOpenLibraryLine(line string) (recordType string, document string) {
+	if strings.HasPrefix(line, "{") {
+		return "", line
+	}
+
+	columns := strings.Split(line, "\t")
+	if len(columns) < 5 {
+		return "", ""
+	}
+
+	return columns[0], columns[4]
+}
+
+// lineReader hands out the non blank lines of a dump together with their line number
+type lineReader struct {
+	reader *bufio.Reader
+	number int
+}
+
+func (r *lineReader) next() (string, error) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		r.number++
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// readOpenLibraryAuthors collects the name of every author record in the dump
+func readOpenLibraryAuthors(r io.Reader) (map[string]string, error) {
+	authors := make(map[string]string)
+	lines := &lineReader{reader: bufio.NewReader(r)}
+	for {
+		line, err := lines.next()
+		if errors.Is(err, io.EOF) {
+			return authors, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		recordType, document := splitOpenLibraryLine(line)
+		if recordType != openLibraryAuthorType && (recordType != "" || !strings.Contains(document, openLibraryAuthorType)) {
+			continue
+		}
+
+		var author openLibraryAuthor
+		if err := json.Unmarshal([]byte(document), &author); err == nil && author.Key != "" && author.Name != "" {
+			authors[author.Key] = strings.TrimSpace(author.Name)
+		}
+	}
+}
+
+type openLibraryReader struct {
+	lines   *lineReader
+	authors map[string]string
+}
+
+func newOpenLibraryReader(r io.Reader, authors map[string]string) Reader {
+	return &openLibraryReader{lines: &lineReader{reader: bufio.NewReader(r)}, authors: authors}
+}
+
+// Read skips everything but editions, editions without an isbn can't be matched with a book and are skipped too
+func (r *openLibraryReader) Read() (books.MetadataRecord, error) {
+	for {
+		line, err := r.lines.next()
+		if err != nil {
+			return books.MetadataRecord{}, err
+		}
+
+		recordType, document := splitOpenLibraryLine(line)
+		if document == "" || (recordType != "" && recordType != openLibraryEditionType) {
+			continue
+		}
+
+		var edition openLibraryEdition
+		if err := json.Unmarshal([]byte(document), &edition); err != nil {
+			return books.MetadataRecord{Line: r.lines.number, Error: fmt.Sprintf("invalid json: %s", err.Error())}, nil
+		}
+		if recordType == "" && edition.Type.Key != "" && edition.Type.Key != openLibraryEditionType {
+			continue
+		}
+		if edition.isbn() == "" {
+			continue
+		}
+
+		return books.MetadataRecord{Line: r.lines.number, Book: r.toDomain(&edition)}, nil
+	}
+}
+
+func (r *openLibraryReader) toDomain(edition *openLibraryEdition) books.Domain {
+	var authors []string
+	for _, author := range edition.Authors {
+		if name, ok := r.authors[author.Key]; ok {
+			authors = append(authors, name)
+		}
+	}
+	author := strings.Join(authors, ", ")
+	if author == "" {
+		author = strings.TrimSpace(strings.TrimSuffix(edition.ByStatement, "."))
+	}
+
+	book := books.Domain{
+		Title:           strings.TrimSpace(edition.Title),
+		Subtitle:        strings.TrimSpace(edition.Subtitle),
+		Edition:         strings.TrimSpace(edition.EditionName),
+		Author:          author,
+		Description:     edition.description(),
+		ISBN:            edition.isbn(),
+		PublicationDate: parsePublicationDate(edition.PublishDate),
+	}
+	if len(edition.Publishers) > 0 {
+		book.Publisher = strings.TrimSpace(edition.Publishers[0])
+	}
+	if len(edition.Languages) > 0 {
+		// languages are keyed by their MARC code, which is the ISO 639-2 code
+		book.Language = strings.TrimPrefix(edition.Languages[0].Key, "/languages/")
+	}
+	if edition.NumberOfPages > 0 {
+		book.PageCount = edition.NumberOfPages
+	}
+
+	return book
+}
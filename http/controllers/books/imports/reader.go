@@ -0,0 +1,165 @@
+package imports
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+// Reader hands out the records of a dump one at a time so a dump never has to fit in memory,
+// it returns io.EOF once every record was read
+type Reader interface {
+	Read() (books.MetadataRecord, error)
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// DetectFormat prefers the explicit format and falls back to the file extension, dumps may be gzipped
+func DetectFormat(format, filename string) (string, error) {
+	if format == "" {
+		name := strings.TrimSuffix(strings.ToLower(filename), ".gz")
+		switch filepath.Ext(name) {
+		case ".xml", ".onix":
+			format = constants.MetadataImportONIX
+		case ".txt", ".tsv", ".json", ".jsonl":
+			format = constants.MetadataImportOpenLibrary
+		}
+	}
+
+	switch format {
+	case constants.MetadataImportOpenLibrary, constants.MetadataImportONIX:
+		return format, nil
+	default:
+		return "", fmt.Errorf("import format must be one of [%s, %s]", constants.MetadataImportOpenLibrary, constants.MetadataImportONIX)
+	}
+}
+
+// NewReader opens a dump in the given format, the file is read twice for Open Library
+// since an edition only refers to its authors by key
+func NewReader(file io.ReadSeeker, format string) (Reader, error) {
+	if format == constants.MetadataImportONIX {
+		r, err := decompress(file)
+		if err != nil {
+			return nil, err
+		}
+		return newONIXReader(r), nil
+	}
+
+	r, err := decompress(file)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := readOpenLibraryAuthors(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r, err = decompress(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOpenLibraryReader(r, authors), nil
+}
+
+// ReadAll collects every record of a dump small enough to be uploaded
+func ReadAll(reader Reader, max int) ([]books.MetadataRecord, error) {
+	var records []books.MetadataRecord
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+		if len(records) > max {
+			return nil, fmt.Errorf("import file can't contain more than %d books", max)
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("import file doesn't contain any book")
+	}
+
+	return records, nil
+}
+
+func decompress(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if string(magic) == string(gzipMagic) {
+		return gzip.NewReader(buffered)
+	}
+
+	return buffered, nil
+}
+
+var publicationDateLayouts = []string{
+	"2006-01-02",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"2006-01",
+	"January 2006",
+	"Jan 2006",
+	"2006",
+}
+
+var yearPattern = regexp.MustCompile(`\b(1[0-9]{3}|20[0-9]{2})\b`)
+
+// parsePublicationDate understands the free-form dates catalogs are full of, a date known only to the month or year
+// lands on its first day and anything without even a year is left out
+func parsePublicationDate(value string) *time.Time {
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "."))
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range publicationDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return &date
+		}
+	}
+
+	if year := yearPattern.FindString(value); year != "" {
+		date, _ := time.Parse("2006", year)
+		return &date
+	}
+
+	return nil
+}
+
+var (
+	markupPattern     = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// plainText strips the markup a description may carry, either as XHTML or as escaped HTML
+func plainText(raw string) string {
+	text := strings.NewReplacer("<![CDATA[", "", "]]>", "").Replace(raw)
+	text = markupPattern.ReplaceAllString(text, " ")
+	text = html.UnescapeString(text)
+	text = markupPattern.ReplaceAllString(text, " ")
+
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,212 @@
+package requests
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+var bookImportColumns = []string{"title", "author", "description", "publisher", "isbn"}
+
+type BookImportRow struct {
+	Line  int
+	Book  BookRequest
+	Error string
+}
+
+// DetectBookImportFormat prefers the explicit format, then falls back to the file extension and content type
+func DetectBookImportFormat(format, filename, contentType string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".csv":
+			format = constants.BookImportCSV
+		case ".ndjson", ".jsonl":
+			format = constants.BookImportNDJSON
+		}
+	}
+	if format == "" {
+		switch {
+		case strings.HasPrefix(contentType, "text/csv"):
+			format = constants.BookImportCSV
+		case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/jsonl"):
+			format = constants.BookImportNDJSON
+		}
+	}
+
+	switch format {
+	case constants.BookImportCSV, constants.BookImportNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("import format must be one of [%s, %s]", constants.BookImportCSV, constants.BookImportNDJSON)
+	}
+}
+
+// ParseBookImport reads every row of the upload and validates it with the same rules as BookRequest,
+// a broken row is reported on its own line instead of failing the whole upload
+func ParseBookImport(r io.Reader, format string) ([]BookImportRow, error) {
+	var rows []BookImportRow
+	var err error
+	if format == constants.BookImportCSV {
+		rows, err = parseBookImportCSV(r)
+	} else {
+		rows, err = parseBookImportNDJSON(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("import file doesn't contain any book")
+	}
+	if len(rows) > constants.MaxBookImportRows {
+		return nil, fmt.Errorf("import file can't contain more than %d books", constants.MaxBookImportRows)
+	}
+
+	for i := range rows {
+		if rows[i].Error != "" {
+			continue
+		}
+		if err := binding.Validator.ValidateStruct(&rows[i].Book); err != nil {
+			rows[i].Error = err.Error()
+		}
+	}
+
+	return rows, nil
+}
+
+func parseBookImportCSV(r io.Reader) ([]BookImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columnIndex := make(map[string]int, len(header))
+	for i, column := range header {
+		columnIndex[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range bookImportColumns {
+		if _, ok := columnIndex[column]; !ok {
+			return nil, fmt.Errorf("csv header must contain [%s]", strings.Join(bookImportColumns, ", "))
+		}
+	}
+
+	// the bibliographic columns are optional, a header without them reads as empty values
+	field := func(record []string, column string) string {
+		if i, ok := columnIndex[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	intField := func(record []string, column string) (int, error) {
+		value := field(record, column)
+		if value == "" {
+			return 0, nil
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a whole number", column)
+		}
+		return number, nil
+	}
+
+	var rows []BookImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, BookImportRow{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := BookImportRow{
+			Line: line,
+			Book: BookRequest{
+				Title:           field(record, "title"),
+				Subtitle:        field(record, "subtitle"),
+				Author:          field(record, "author"),
+				Description:     field(record, "description"),
+				Publisher:       field(record, "publisher"),
+				ISBN:            field(record, "isbn"),
+				Edition:         field(record, "edition"),
+				Language:        field(record, "language"),
+				Format:          field(record, "format"),
+				Series:          field(record, "series"),
+				PublicationDate: field(record, "publication_date"),
+			},
+		}
+		if row.Book.SeriesPosition, err = intField(record, "series_position"); err != nil {
+			row.Error = err.Error()
+		} else if row.Book.PageCount, err = intField(record, "page_count"); err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseBookImportNDJSON(r io.Reader) ([]BookImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), constants.MaxBookImportSize)
+
+	var rows []BookImportRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := BookImportRow{Line: line}
+		if err := json.Unmarshal([]byte(text), &row.Book); err != nil {
+			row.Error = fmt.Sprintf("invalid json: %s", err.Error())
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func ToImportRows(rows []BookImportRow) []books.ImportRow {
+	result := make([]books.ImportRow, len(rows))
+
+	for i, row := range rows {
+		result[i] = books.ImportRow{
+			Line:  row.Line,
+			Book:  *row.Book.ToDomain(),
+			Error: row.Error,
+		}
+	}
+
+	return result
+}
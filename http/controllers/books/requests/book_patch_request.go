@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/helpers"
+)
+
+type BookPatchRequest struct {
+	Title           helpers.PatchString `json:"title"`
+	Subtitle        helpers.PatchString `json:"subtitle"`
+	Author          helpers.PatchString `json:"author"`
+	Description     helpers.PatchString `json:"description"`
+	Publisher       helpers.PatchString `json:"publisher"`
+	ISBN            helpers.PatchString `json:"isbn"`
+	Edition         helpers.PatchString `json:"edition"`
+	Language        helpers.PatchString `json:"language"`
+	Format          helpers.PatchString `json:"format"`
+	Series          helpers.PatchString `json:"series"`
+	SeriesPosition  helpers.PatchInt    `json:"series_position"`
+	PageCount       helpers.PatchInt    `json:"page_count"`
+	PublicationDate helpers.PatchString `json:"publication_date"`
+}
+
+func (b *BookPatchRequest) ToDomain() *books.Patch {
+	return &books.Patch{
+		Title:           b.Title.Ptr(),
+		Subtitle:        b.Subtitle.Ptr(),
+		Description:     b.Description.Ptr(),
+		Author:          b.Author.Ptr(),
+		Publisher:       b.Publisher.Ptr(),
+		ISBN:            b.ISBN.Ptr(),
+		Edition:         b.Edition.Ptr(),
+		Language:        b.Language.Ptr(),
+		Format:          b.Format.Ptr(),
+		Series:          b.Series.Ptr(),
+		SeriesPosition:  b.SeriesPosition.Ptr(),
+		PageCount:       b.PageCount.Ptr(),
+		PublicationDate: b.PublicationDate.Ptr(),
+	}
+}
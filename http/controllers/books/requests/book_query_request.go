@@ -0,0 +1,51 @@
+package requests
+
+import (
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type BookQueryRequest struct {
+	Page      int      `form:"page" binding:"omitempty,min=1"`
+	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort      string   `form:"sort" binding:"omitempty,oneof=title author rating created_at publication_date page_count"`
+	Order     string   `form:"order" binding:"omitempty,oneof=asc desc"`
+	Author    string   `form:"author"`
+	Publisher string   `form:"publisher"`
+	ISBN      string   `form:"isbn"`
+	MinRating *float64 `form:"min_rating" binding:"omitempty,min=0,max=10"`
+	MaxRating *float64 `form:"max_rating" binding:"omitempty,min=0,max=10"`
+	Category  int      `form:"category" binding:"omitempty,min=1"`
+	Tags      []string `form:"tag"`
+
+	Language      string `form:"language"`
+	Format        string `form:"format" binding:"omitempty,oneof=hardcover paperback ebook audio"`
+	Series        string `form:"series"`
+	MinPages      *int   `form:"min_pages" binding:"omitempty,min=0"`
+	MaxPages      *int   `form:"max_pages" binding:"omitempty,min=0"`
+	PublishedFrom string `form:"published_from" binding:"omitempty,datetime=2006-01-02"`
+	PublishedTo   string `form:"published_to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (q *BookQueryRequest) ToDomain() *books.Query {
+	return &books.Query{
+		Page:      q.Page,
+		Limit:     q.Limit,
+		Sort:      q.Sort,
+		Order:     q.Order,
+		Author:    q.Author,
+		Publisher: q.Publisher,
+		ISBN:      q.ISBN,
+		MinRating: q.MinRating,
+		MaxRating: q.MaxRating,
+		Category:  q.Category,
+		Tags:      append([]string(nil), q.Tags...),
+
+		Language:      q.Language,
+		Format:        q.Format,
+		Series:        q.Series,
+		MinPages:      q.MinPages,
+		MaxPages:      q.MaxPages,
+		PublishedFrom: parsePublicationDate(q.PublishedFrom),
+		PublishedTo:   parsePublicationDate(q.PublishedTo),
+	}
+}
@@ -1,23 +1,52 @@
 package requests
 
 import (
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
 	"github.com/snykk/golib_backend/domains/books"
 )
 
 type BookRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Author      string `json:"author" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	Publisher   string `json:"publisher" binding:"required"`
-	ISBN        string `json:"isbn" binding:"required"`
+	Title           string `json:"title" binding:"required"`
+	Subtitle        string `json:"subtitle"`
+	Author          string `json:"author" binding:"required"`
+	Description     string `json:"description" binding:"required"`
+	Publisher       string `json:"publisher" binding:"required"`
+	ISBN            string `json:"isbn" binding:"required"`
+	Edition         string `json:"edition"`
+	Language        string `json:"language"`
+	Format          string `json:"format"`
+	Series          string `json:"series"`
+	SeriesPosition  int    `json:"series_position" binding:"omitempty,min=0"`
+	PageCount       int    `json:"page_count" binding:"omitempty,min=0"`
+	PublicationDate string `json:"publication_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
 func (bookRequest *BookRequest) ToDomain() *books.Domain {
 	return &books.Domain{
-		Title:       bookRequest.Title,
-		Description: bookRequest.Description,
-		Author:      bookRequest.Author,
-		Publisher:   bookRequest.Publisher,
-		ISBN:        bookRequest.ISBN,
+		Title:           bookRequest.Title,
+		Subtitle:        bookRequest.Subtitle,
+		Description:     bookRequest.Description,
+		Author:          bookRequest.Author,
+		Publisher:       bookRequest.Publisher,
+		ISBN:            bookRequest.ISBN,
+		Edition:         bookRequest.Edition,
+		Language:        bookRequest.Language,
+		Format:          bookRequest.Format,
+		Series:          bookRequest.Series,
+		SeriesPosition:  bookRequest.SeriesPosition,
+		PageCount:       bookRequest.PageCount,
+		PublicationDate: parsePublicationDate(bookRequest.PublicationDate),
 	}
 }
+
+// parsePublicationDate relies on the binding having validated the layout already, anything else is left empty
+func parsePublicationDate(value string) *time.Time {
+	date, err := time.Parse(constants.PublicationDateLayout, value)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
@@ -0,0 +1,17 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/books"
+
+type BookSearchRequest struct {
+	Q     string `form:"q" binding:"required"`
+	Page  int    `form:"page" binding:"omitempty,min=1"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (r *BookSearchRequest) ToDomain() *books.SearchQuery {
+	return &books.SearchQuery{
+		Keyword: r.Q,
+		Page:    r.Page,
+		Limit:   r.Limit,
+	}
+}
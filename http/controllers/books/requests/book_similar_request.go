@@ -0,0 +1,5 @@
+package requests
+
+type BookSimilarRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=20"`
+}
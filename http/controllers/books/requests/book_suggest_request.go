@@ -0,0 +1,16 @@
+package requests
+
+import (
+	"fmt"
+	"strings"
+)
+
+type BookSuggestRequest struct {
+	Prefix string `form:"prefix" binding:"required"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+// CacheKey normalizes the prefix so "Ato" and "ato " share the same cached suggestions
+func (r *BookSuggestRequest) CacheKey() string {
+	return fmt.Sprintf("books/suggest/%s/%d", strings.ToLower(strings.TrimSpace(r.Prefix)), r.Limit)
+}
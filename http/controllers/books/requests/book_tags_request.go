@@ -0,0 +1,5 @@
+package requests
+
+type BookTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1,dive,required"`
+}
@@ -5,19 +5,35 @@ import (
 )
 
 type BookUpdateRequests struct {
-	Title       string `json:"title"`
-	Author      string `json:"author"`
-	Description string `json:"description"`
-	Publisher   string `json:"publisher"`
-	ISBN        string `json:"isbn"`
+	Title           string `json:"title"`
+	Subtitle        string `json:"subtitle"`
+	Author          string `json:"author"`
+	Description     string `json:"description"`
+	Publisher       string `json:"publisher"`
+	ISBN            string `json:"isbn"`
+	Edition         string `json:"edition"`
+	Language        string `json:"language"`
+	Format          string `json:"format"`
+	Series          string `json:"series"`
+	SeriesPosition  int    `json:"series_position" binding:"omitempty,min=0"`
+	PageCount       int    `json:"page_count" binding:"omitempty,min=0"`
+	PublicationDate string `json:"publication_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
 func (b *BookUpdateRequests) ToDomain() *books.Domain {
 	return &books.Domain{
-		Title:       b.Title,
-		Description: b.Description,
-		Author:      b.Author,
-		Publisher:   b.Publisher,
-		ISBN:        b.ISBN,
+		Title:           b.Title,
+		Subtitle:        b.Subtitle,
+		Description:     b.Description,
+		Author:          b.Author,
+		Publisher:       b.Publisher,
+		ISBN:            b.ISBN,
+		Edition:         b.Edition,
+		Language:        b.Language,
+		Format:          b.Format,
+		Series:          b.Series,
+		SeriesPosition:  b.SeriesPosition,
+		PageCount:       b.PageCount,
+		PublicationDate: parsePublicationDate(b.PublicationDate),
 	}
 }
@@ -0,0 +1,39 @@
+package responses
+
+import (
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type BookImportResponse struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	BookID int    `json:"book_id,omitempty"`
+	ISBN   string `json:"isbn,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BookImportSummary struct {
+	Total   int `json:"total"`
+	Created int `json:"created"`
+	Failed  int `json:"failed"`
+}
+
+func FromImportResult(result books.ImportResult) BookImportResponse {
+	return BookImportResponse{
+		Line:   result.Line,
+		Status: result.Status,
+		BookID: result.BookID,
+		ISBN:   result.ISBN,
+		Error:  result.Error,
+	}
+}
+
+func ToImportResponseList(results []books.ImportResult) []BookImportResponse {
+	var result []BookImportResponse
+
+	for _, val := range results {
+		result = append(result, FromImportResult(val))
+	}
+
+	return result
+}
@@ -0,0 +1,62 @@
+package responses
+
+import (
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type MetadataImportResponse struct {
+	Line   int      `json:"line"`
+	Status string   `json:"status"`
+	BookID int      `json:"book_id,omitempty"`
+	ISBN   string   `json:"isbn,omitempty"`
+	Fields []string `json:"fields,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+type MetadataImportSummary struct {
+	DryRun    bool `json:"dry_run"`
+	Total     int  `json:"total"`
+	Created   int  `json:"created"`
+	Enriched  int  `json:"enriched"`
+	Unchanged int  `json:"unchanged"`
+	Failed    int  `json:"failed"`
+}
+
+func FromMetadataResult(result books.MetadataResult) MetadataImportResponse {
+	return MetadataImportResponse{
+		Line:   result.Line,
+		Status: result.Status,
+		BookID: result.BookID,
+		ISBN:   result.ISBN,
+		Fields: result.Fields,
+		Error:  result.Error,
+	}
+}
+
+func ToMetadataImportResponseList(results []books.MetadataResult) []MetadataImportResponse {
+	var result []MetadataImportResponse
+
+	for _, val := range results {
+		result = append(result, FromMetadataResult(val))
+	}
+
+	return result
+}
+
+// Add counts the results of one more batch, the import command sends a large dump in several of them
+func (s *MetadataImportSummary) Add(results []books.MetadataResult) {
+	for _, result := range results {
+		s.Total++
+		switch result.Status {
+		case constants.BookImportCreated:
+			s.Created++
+		case constants.BookImportEnriched:
+			s.Enriched++
+		case constants.BookImportUnchanged:
+			s.Unchanged++
+		default:
+			s.Failed++
+		}
+	}
+}
@@ -3,33 +3,82 @@ package responses
 import (
 	"time"
 
+	"github.com/snykk/golib_backend/constants"
 	"github.com/snykk/golib_backend/domains/books"
 )
 
+type CategoryResponse struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentId *int   `json:"parent_id"`
+}
+
 type BookResponse struct {
-	Id          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Author      string    `json:"author"`
-	Publisher   string    `json:"publisher"`
-	ISBN        string    `json:"isbn"`
-	Rating      *float64  `json:"rating"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	Id              int                `json:"id"`
+	Title           string             `json:"title"`
+	Subtitle        string             `json:"subtitle,omitempty"`
+	Description     string             `json:"description"`
+	Author          string             `json:"author"`
+	Publisher       string             `json:"publisher"`
+	PublisherId     *int               `json:"publisher_id"`
+	ISBN            string             `json:"isbn"`
+	Edition         string             `json:"edition,omitempty"`
+	Language        string             `json:"language,omitempty"`
+	Format          string             `json:"format,omitempty"`
+	Series          string             `json:"series,omitempty"`
+	SeriesPosition  int                `json:"series_position,omitempty"`
+	PageCount       int                `json:"page_count,omitempty"`
+	PublicationDate string             `json:"publication_date,omitempty"`
+	Rating          *float64           `json:"rating"`
+	CoverURL        string             `json:"cover_url,omitempty"`
+	Thumbnails      map[string]string  `json:"thumbnails,omitempty"`
+	Categories      []CategoryResponse `json:"categories"`
+	Tags            []string           `json:"tags"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
 }
 
 func FromDomain(bookDomain books.Domain) BookResponse {
-	return BookResponse{
-		Id:          bookDomain.ID,
-		Title:       bookDomain.Title,
-		Description: bookDomain.Description,
-		Author:      bookDomain.Author,
-		Publisher:   bookDomain.Publisher,
-		ISBN:        bookDomain.ISBN,
-		Rating:      bookDomain.Rating,
-		CreatedAt:   bookDomain.CreatedAt,
-		UpdatedAt:   bookDomain.UpdatedAt,
+	response := BookResponse{
+		Id:             bookDomain.ID,
+		Title:          bookDomain.Title,
+		Subtitle:       bookDomain.Subtitle,
+		Description:    bookDomain.Description,
+		Author:         bookDomain.Author,
+		Publisher:      bookDomain.Publisher,
+		PublisherId:    bookDomain.PublisherId,
+		ISBN:           bookDomain.ISBN,
+		Edition:        bookDomain.Edition,
+		Language:       bookDomain.Language,
+		Format:         bookDomain.Format,
+		Series:         bookDomain.Series,
+		SeriesPosition: bookDomain.SeriesPosition,
+		PageCount:      bookDomain.PageCount,
+		Rating:         bookDomain.Rating,
+		Categories:     make([]CategoryResponse, 0, len(bookDomain.Categories)),
+		Tags:           make([]string, 0, len(bookDomain.Tags)),
+		CreatedAt:      bookDomain.CreatedAt,
+		UpdatedAt:      bookDomain.UpdatedAt,
 	}
+
+	for _, category := range bookDomain.Categories {
+		response.Categories = append(response.Categories, CategoryResponse{Id: category.ID, Name: category.Name, ParentId: category.ParentId})
+	}
+	response.Tags = append(response.Tags, bookDomain.Tags...)
+
+	if bookDomain.PublicationDate != nil {
+		response.PublicationDate = bookDomain.PublicationDate.Format(constants.PublicationDateLayout)
+	}
+
+	if bookDomain.Cover != "" {
+		response.CoverURL = constants.CoverURLPrefix + bookDomain.Cover
+		response.Thumbnails = make(map[string]string, len(constants.ListCoverThumbnailSize))
+		for _, size := range constants.ListCoverThumbnailSize {
+			response.Thumbnails[size] = constants.CoverURLPrefix + books.ThumbnailKey(bookDomain.Cover, size)
+		}
+	}
+
+	return response
 }
 
 func ToResponseList(domains []books.Domain) []BookResponse {
@@ -0,0 +1,31 @@
+package responses
+
+import (
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type BookSearchResponse struct {
+	Book           BookResponse `json:"book"`
+	Score          float64      `json:"score"`
+	TitleHighlight string       `json:"title_highlight"`
+	Snippet        string       `json:"snippet"`
+}
+
+func FromSearchResult(result books.SearchResult) BookSearchResponse {
+	return BookSearchResponse{
+		Book:           FromDomain(result.Book),
+		Score:          result.Rank,
+		TitleHighlight: result.TitleHighlight,
+		Snippet:        result.Snippet,
+	}
+}
+
+func ToSearchResponseList(results []books.SearchResult) []BookSearchResponse {
+	var result []BookSearchResponse
+
+	for _, val := range results {
+		result = append(result, FromSearchResult(val))
+	}
+
+	return result
+}
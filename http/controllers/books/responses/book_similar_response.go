@@ -0,0 +1,25 @@
+package responses
+
+import (
+	"math"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type BookSimilarResponse struct {
+	Book  BookResponse `json:"book"`
+	Score float64      `json:"score"`
+}
+
+func ToSimilarResponseList(domains []books.Similar) []BookSimilarResponse {
+	var result []BookSimilarResponse
+
+	for _, val := range domains {
+		result = append(result, BookSimilarResponse{
+			Book:  FromDomain(val.Book),
+			Score: math.Round(val.Score*1000) / 1000,
+		})
+	}
+
+	return result
+}
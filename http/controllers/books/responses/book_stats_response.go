@@ -0,0 +1,61 @@
+package responses
+
+import (
+	"math"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type RatingCountResponse struct {
+	Rating  int `json:"rating"`
+	Reviews int `json:"reviews"`
+}
+
+type RatingMonthResponse struct {
+	Month   string  `json:"month"`
+	Reviews int     `json:"reviews"`
+	Average float64 `json:"average"`
+}
+
+type BookStatsResponse struct {
+	BookId    int                   `json:"book_id"`
+	Reviews   int                   `json:"reviews"`
+	Average   float64               `json:"average"`
+	Median    float64               `json:"median"`
+	StdDev    float64               `json:"std_dev"`
+	Histogram []RatingCountResponse `json:"histogram"`
+	Months    []RatingMonthResponse `json:"months"`
+}
+
+func FromStatsDomain(domain books.Stats) BookStatsResponse {
+	response := BookStatsResponse{
+		BookId:    domain.BookId,
+		Reviews:   domain.Reviews,
+		Average:   roundRating(domain.Average),
+		Median:    domain.Median,
+		StdDev:    roundRating(domain.StdDev),
+		Histogram: []RatingCountResponse{},
+		Months:    []RatingMonthResponse{},
+	}
+
+	for _, count := range domain.Histogram {
+		response.Histogram = append(response.Histogram, RatingCountResponse{Rating: count.Rating, Reviews: count.Reviews})
+	}
+	for _, month := range domain.Months {
+		var average float64
+		if month.Reviews > 0 {
+			average = float64(month.RatingSum) / float64(month.Reviews)
+		}
+		response.Months = append(response.Months, RatingMonthResponse{
+			Month:   month.Month.Format("2006-01"),
+			Reviews: month.Reviews,
+			Average: roundRating(average),
+		})
+	}
+
+	return response
+}
+
+func roundRating(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,27 @@
+package responses
+
+import (
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type BookSuggestResponse struct {
+	Value string `json:"value"`
+	Field string `json:"field"`
+}
+
+func FromSuggestion(suggestion books.Suggestion) BookSuggestResponse {
+	return BookSuggestResponse{
+		Value: suggestion.Value,
+		Field: suggestion.Field,
+	}
+}
+
+func ToSuggestResponseList(suggestions []books.Suggestion) []BookSuggestResponse {
+	var result []BookSuggestResponse
+
+	for _, val := range suggestions {
+		result = append(result, FromSuggestion(val))
+	}
+
+	return result
+}
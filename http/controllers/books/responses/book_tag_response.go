@@ -0,0 +1,21 @@
+package responses
+
+import "github.com/snykk/golib_backend/domains/books"
+
+type TagResponse struct {
+	Name  string `json:"name"`
+	Books int    `json:"books"`
+}
+
+func ToTagResponseList(domains []books.Tag) []TagResponse {
+	var result []TagResponse
+
+	for _, val := range domains {
+		result = append(result, TagResponse{
+			Name:  val.Name,
+			Books: val.Books,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/books"
+)
+
+type FieldChangeResponse struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type BookSnapshotResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+	Publisher   string `json:"publisher"`
+	PublisherId *int   `json:"publisher_id"`
+	ISBN        string `json:"isbn"`
+	Cover       string `json:"cover"`
+}
+
+type BookVersionResponse struct {
+	Version    int                   `json:"version"`
+	Action     string                `json:"action"`
+	UserId     *int                  `json:"user_id"`
+	Changes    []FieldChangeResponse `json:"changes"`
+	Snapshot   BookSnapshotResponse  `json:"snapshot"`
+	RevertedTo *int                  `json:"reverted_to,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+func FromVersionDomain(domain books.Version) BookVersionResponse {
+	response := BookVersionResponse{
+		Version: domain.Version,
+		Action:  domain.Action,
+		UserId:  domain.UserId,
+		Changes: make([]FieldChangeResponse, 0, len(domain.Changes)),
+		Snapshot: BookSnapshotResponse{
+			Title:       domain.Snapshot.Title,
+			Description: domain.Snapshot.Description,
+			Author:      domain.Snapshot.Author,
+			Publisher:   domain.Snapshot.Publisher,
+			PublisherId: domain.Snapshot.PublisherId,
+			ISBN:        domain.Snapshot.ISBN,
+			Cover:       domain.Snapshot.Cover,
+		},
+		RevertedTo: domain.RevertedTo,
+		CreatedAt:  domain.CreatedAt,
+	}
+
+	for _, change := range domain.Changes {
+		response.Changes = append(response.Changes, FieldChangeResponse{Field: change.Field, From: change.From, To: change.To})
+	}
+
+	return response
+}
+
+func ToVersionResponseList(domains []books.Version) []BookVersionResponse {
+	var result []BookVersionResponse
+
+	for _, val := range domains {
+		result = append(result, FromVersionDomain(val))
+	}
+
+	return result
+}
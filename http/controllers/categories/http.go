@@ -0,0 +1,186 @@
+package categories
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/categories"
+	"github.com/snykk/golib_backend/http/controllers"
+	bookReq "github.com/snykk/golib_backend/http/controllers/books/requests"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+	"github.com/snykk/golib_backend/http/controllers/categories/requests"
+	"github.com/snykk/golib_backend/http/controllers/categories/responses"
+)
+
+type CategoryController struct {
+	categoryUsecase categories.Usecase
+	ristrettoCache  cache.RistrettoCache
+}
+
+func NewCategoryController(categoryUsecase categories.Usecase, ristrettoCache cache.RistrettoCache) CategoryController {
+	return CategoryController{
+		categoryUsecase: categoryUsecase,
+		ristrettoCache:  ristrettoCache,
+	}
+}
+
+func (c *CategoryController) Store(ctx *gin.Context) {
+	var categoryRequest requests.CategoryRequest
+	if err := ctx.ShouldBindJSON(&categoryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	category, statusCode, err := c.categoryUsecase.Store(ctxx, categoryRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("categories")
+
+	controllers.NewSuccessResponse(ctx, statusCode, "category inserted successfully", gin.H{
+		"category": responses.FromDomain(category),
+	})
+}
+
+func (c *CategoryController) GetTree(ctx *gin.Context) {
+	if val := c.ristrettoCache.Get("categories"); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, "category data fetched successfully", gin.H{
+			"categories": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	tree, statusCode, err := c.categoryUsecase.GetTree(ctxx)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	categoryResponses := responses.ToResponseList(tree)
+
+	if categoryResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "category data is empty", []int{})
+		return
+	}
+
+	go c.ristrettoCache.Set("categories", categoryResponses)
+
+	controllers.NewSuccessResponse(ctx, statusCode, "category data fetched successfully", gin.H{
+		"categories": categoryResponses,
+	})
+}
+
+func (c *CategoryController) GetById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	category, statusCode, err := c.categoryUsecase.GetById(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("category data with id %d fetched successfully", id), gin.H{
+		"category": responses.FromDomain(category),
+	})
+}
+
+func (c *CategoryController) Update(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var categoryRequest requests.CategoryRequest
+	if err := ctx.ShouldBindJSON(&categoryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	category, bookIds, statusCode, err := c.categoryUsecase.Update(ctxx, categoryRequest.ToDomain(), id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// books carry the category name in their response
+	keys := []string{"categories", "books"}
+	for _, bookId := range bookIds {
+		keys = append(keys, fmt.Sprintf("book/%d", bookId))
+	}
+	go c.ristrettoCache.Del(keys...)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("category data with id %d updated successfully", id), gin.H{
+		"category": responses.FromDomain(category),
+	})
+}
+
+func (c *CategoryController) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.categoryUsecase.Delete(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("categories")
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("category data with id %d deleted successfully", id), nil)
+}
+
+func (c *CategoryController) GetBooks(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var bookQueryRequest bookReq.BookQueryRequest
+	if err := ctx.ShouldBindQuery(&bookQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	query := bookQueryRequest.ToDomain()
+	listOfBooks, total, statusCode, err := c.categoryUsecase.GetBooks(ctxx, id, query)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	bookResponses := bookRes.ToResponseList(listOfBooks)
+	meta := controllers.NewPaginationMeta(query.Page, query.Limit, total)
+
+	if bookResponses == nil {
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("book data with category id %d is empty", id), []int{}, meta)
+		return
+	}
+
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("book data with category id %d fetched successfully", id), gin.H{
+		"books": bookResponses,
+	}, meta)
+}
+
+func (c *CategoryController) SetBookCategories(ctx *gin.Context) {
+	bookId, _ := strconv.Atoi(ctx.Param("id"))
+	var bookCategoriesRequest requests.BookCategoriesRequest
+	if err := ctx.ShouldBindJSON(&bookCategoriesRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	book, statusCode, err := c.categoryUsecase.SetBookCategories(ctxx, bookId, bookCategoriesRequest.CategoryIds)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", bookId))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("categories of book with id %d updated successfully", bookId), gin.H{
+		"book": bookRes.FromDomain(book),
+	})
+}
@@ -0,0 +1,222 @@
+package categories_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	categoryMocks "github.com/snykk/golib_backend/datasources/databases/categories/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/categories"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/categories"
+	"github.com/snykk/golib_backend/http/controllers/categories/requests"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	categoryRepository *categoryMocks.Repository
+	bookRepository     *bookMocks.Repository
+	ristrettoMock      *cacheMocks.RistrettoCache
+	categoryUsecase    categories.Usecase
+	categoryController controllers.CategoryController
+	s                  *gin.Engine
+	categoriesFromDB   []categories.Domain
+	bookFromDB         books.Domain
+)
+
+func setup(t *testing.T) {
+	categoryRepository = categoryMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	categoryUsecase = categories.NewCategoryUsecase(categoryRepository, bookRepository)
+	categoryController = controllers.NewCategoryController(categoryUsecase, ristrettoMock)
+
+	root := 1
+	categoriesFromDB = []categories.Domain{
+		{ID: 1, Name: "Fiction", CreatedAt: time.Now()},
+		{ID: 2, Name: "Fantasy", ParentId: &root, CreatedAt: time.Now()},
+	}
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		Categories:  []books.Category{{ID: 2, Name: "Fantasy", ParentId: &root}},
+		CreatedAt:   time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  true,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/categories", categoryController.Store)
+	t.Run("When Success Store Category", func(t *testing.T) {
+		req := requests.CategoryRequest{Name: "Fiction"}
+		reqBody, _ := json.Marshal(req)
+
+		categoryRepository.Mock.On("GetByName", mock.Anything, "Fiction", (*int)(nil)).Return(categories.Domain{}, errors.New("record not found")).Once()
+		categoryRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*categories.Domain")).Return(categoriesFromDB[0], nil).Once()
+		ristrettoMock.Mock.On("Del", "categories").Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/categories", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "category inserted successfully")
+	})
+}
+
+func TestGetTree(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/categories", categoryController.GetTree)
+	t.Run("When Success Get Category Tree", func(t *testing.T) {
+		categoryRepository.Mock.On("GetAll", mock.Anything).Return(categoriesFromDB, nil).Once()
+		ristrettoMock.Mock.On("Get", "categories").Return(nil).Once()
+		ristrettoMock.Mock.On("Set", "categories", mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/categories", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"children":[{"id":2,"name":"Fantasy","parent_id":1,"children":[]`)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/categories/:id/books", categoryController.GetBooks)
+	t.Run("When Success Get Category Books", func(t *testing.T) {
+		categoryRepository.Mock.On("GetById", mock.Anything, 1).Return(categoriesFromDB[0], nil).Once()
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.MatchedBy(func(query *books.Query) bool {
+			return query.Category == 1 && query.Page == 1 && query.Limit == 5
+		})).Return([]books.Domain{bookFromDB}, 1, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/categories/1/books?limit=5", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, bookFromDB.Title)
+		assert.Contains(t, body, `"total_items":1`)
+	})
+	t.Run("When Failure Category Not Found", func(t *testing.T) {
+		categoryRepository.Mock.On("GetById", mock.Anything, 9).Return(categories.Domain{}, errors.New("record not found")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/categories/9/books", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestSetBookCategories(t *testing.T) {
+	setup(t)
+	// Define route
+	s.PUT("/books/:id/categories", categoryController.SetBookCategories)
+	t.Run("When Success Set Book Categories", func(t *testing.T) {
+		req := requests.BookCategoriesRequest{CategoryIds: []int{2}}
+		reqBody, _ := json.Marshal(req)
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Twice()
+		categoryRepository.Mock.On("GetByIds", mock.Anything, []int{2}).Return(categoriesFromDB[1:], nil).Once()
+		categoryRepository.Mock.On("ReplaceBookCategories", mock.Anything, bookFromDB.ID, []int{2}).Return(nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/books/%d/categories", bookFromDB.ID), bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"categories":[{"id":2,"name":"Fantasy","parent_id":1}]`)
+	})
+	t.Run("When Failure Missing Category Ids", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/books/%d/categories", bookFromDB.ID), bytes.NewReader([]byte(`{}`)))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
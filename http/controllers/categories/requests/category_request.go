@@ -0,0 +1,20 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/categories"
+
+type CategoryRequest struct {
+	Name     string `json:"name" binding:"required,max=50"`
+	ParentId *int   `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+func (r *CategoryRequest) ToDomain() *categories.Domain {
+	return &categories.Domain{
+		Name:     r.Name,
+		ParentId: r.ParentId,
+	}
+}
+
+// BookCategoriesRequest replaces every category of a book, an empty list removes them all
+type BookCategoriesRequest struct {
+	CategoryIds []int `json:"category_ids" binding:"required,dive,min=1"`
+}
@@ -0,0 +1,37 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/categories"
+)
+
+type CategoryResponse struct {
+	Id        int                `json:"id"`
+	Name      string             `json:"name"`
+	ParentId  *int               `json:"parent_id"`
+	Children  []CategoryResponse `json:"children"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+func FromDomain(domain categories.Domain) CategoryResponse {
+	return CategoryResponse{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		ParentId:  domain.ParentId,
+		Children:  append([]CategoryResponse{}, ToResponseList(domain.Children)...),
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToResponseList(domains []categories.Domain) []CategoryResponse {
+	var result []CategoryResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,301 @@
+package circulations
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"github.com/snykk/golib_backend/helpers"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/circulations/requests"
+	"github.com/snykk/golib_backend/http/controllers/circulations/responses"
+	"github.com/snykk/golib_backend/http/token"
+)
+
+type CirculationController struct {
+	circulationUsecase circulations.Usecase
+}
+
+func NewCirculationController(circulationUsecase circulations.Usecase) CirculationController {
+	return CirculationController{
+		circulationUsecase: circulationUsecase,
+	}
+}
+
+func (c *CirculationController) StoreCopy(ctx *gin.Context) {
+	var copyRequest requests.CopyRequest
+	if err := ctx.ShouldBindJSON(&copyRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := helpers.IsCopyConditionValid(copyRequest.Condition); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	bookCopy, statusCode, err := c.circulationUsecase.StoreCopy(ctxx, copyRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "copy inserted successfully", map[string]interface{}{
+		"copy": responses.FromCopyDomain(bookCopy),
+	})
+}
+
+func (c *CirculationController) GetCopiesByBookId(ctx *gin.Context) {
+	bookId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	copies, statusCode, err := c.circulationUsecase.GetCopiesByBookId(ctxx, bookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	copyResponses := responses.ToCopyResponseList(copies)
+
+	if copyResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("copy data with book id %d is empty", bookId), []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("copy data with book id %d fetched successfully", bookId), map[string]interface{}{
+		"copies": copyResponses,
+	})
+}
+
+func (c *CirculationController) UpdateCopy(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var copyRequest requests.CopyUpdateRequest
+	if err := ctx.ShouldBindJSON(&copyRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := helpers.IsCopyConditionValid(copyRequest.Condition); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	bookCopy, statusCode, err := c.circulationUsecase.UpdateCopy(ctxx, copyRequest.ToDomain(), id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("copy data with id %d updated successfully", id), map[string]interface{}{
+		"copy": responses.FromCopyDomain(bookCopy),
+	})
+}
+
+func (c *CirculationController) DeleteCopy(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.circulationUsecase.DeleteCopy(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("copy data with id %d deleted successfully", id), nil)
+}
+
+func (c *CirculationController) Checkout(ctx *gin.Context) {
+	var checkoutRequest requests.CheckoutRequest
+	if err := ctx.ShouldBindJSON(&checkoutRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	loan, statusCode, err := c.circulationUsecase.Checkout(ctxx, checkoutRequest.Barcode, checkoutRequest.UserId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("copy %s checked out successfully", checkoutRequest.Barcode), map[string]interface{}{
+		"loan": responses.FromLoanDomain(loan),
+	})
+}
+
+func (c *CirculationController) Return(ctx *gin.Context) {
+	var returnRequest requests.ReturnRequest
+	if err := ctx.ShouldBindJSON(&returnRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	loan, statusCode, err := c.circulationUsecase.Return(ctxx, returnRequest.Barcode)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("copy %s returned successfully", returnRequest.Barcode), map[string]interface{}{
+		"loan": responses.FromLoanDomain(loan),
+	})
+}
+
+func (c *CirculationController) GetActiveLoans(ctx *gin.Context) {
+	overdueOnly, _ := strconv.ParseBool(ctx.Query("overdue"))
+
+	ctxx := ctx.Request.Context()
+	loans, statusCode, err := c.circulationUsecase.GetActiveLoans(ctxx, overdueOnly)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	loanResponses := responses.ToLoanResponseList(loans)
+
+	if loanResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "loan data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "loan data fetched successfully", map[string]interface{}{
+		"loans": loanResponses,
+	})
+}
+
+func (c *CirculationController) GetUserLoans(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	loans, statusCode, err := c.circulationUsecase.GetLoansByUserId(ctxx, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	loanResponses := responses.ToLoanResponseList(loans)
+
+	if loanResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "loan data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "loan data fetched successfully", map[string]interface{}{
+		"loans": loanResponses,
+	})
+}
+
+func (c *CirculationController) Renew(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	loanId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	loan, statusCode, err := c.circulationUsecase.Renew(ctxx, loanId, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("loan with id %d renewed successfully", loanId), map[string]interface{}{
+		"loan": responses.FromLoanDomain(loan),
+	})
+}
+
+func (c *CirculationController) PayFine(ctx *gin.Context) {
+	loanId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	loan, statusCode, err := c.circulationUsecase.PayFine(ctxx, loanId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("fine of loan with id %d paid successfully", loanId), map[string]interface{}{
+		"loan": responses.FromLoanDomain(loan),
+	})
+}
+
+func (c *CirculationController) PlaceHold(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	var holdRequest requests.HoldRequest
+	if err := ctx.ShouldBindJSON(&holdRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	hold, statusCode, err := c.circulationUsecase.PlaceHold(ctxx, holdRequest.BookId, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "hold placed successfully", map[string]interface{}{
+		"hold": responses.FromHoldDomain(hold),
+	})
+}
+
+func (c *CirculationController) CancelHold(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	holdId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.circulationUsecase.CancelHold(ctxx, holdId, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("hold with id %d cancelled successfully", holdId), nil)
+}
+
+func (c *CirculationController) GetHoldsByBookId(ctx *gin.Context) {
+	bookId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	holds, statusCode, err := c.circulationUsecase.GetHoldsByBookId(ctxx, bookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	holdResponses := responses.ToHoldResponseList(holds)
+
+	if holdResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("hold data with book id %d is empty", bookId), []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("hold data with book id %d fetched successfully", bookId), map[string]interface{}{
+		"holds": holdResponses,
+	})
+}
+
+func (c *CirculationController) GetUserHolds(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	holds, statusCode, err := c.circulationUsecase.GetHoldsByUserId(ctxx, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	holdResponses := responses.ToHoldResponseList(holds)
+
+	if holdResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "hold data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "hold data fetched successfully", map[string]interface{}{
+		"holds": holdResponses,
+	})
+}
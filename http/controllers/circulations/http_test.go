@@ -0,0 +1,291 @@
+package circulations_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	circulationMocks "github.com/snykk/golib_backend/datasources/databases/circulations/mocks"
+	userMocks "github.com/snykk/golib_backend/datasources/databases/users/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/circulations"
+	"github.com/snykk/golib_backend/domains/users"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/circulations"
+	"github.com/snykk/golib_backend/http/controllers/circulations/requests"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	circulationRepository *circulationMocks.Repository
+	bookRepository        *bookMocks.Repository
+	userRepository        *userMocks.Repository
+	circulationUsecase    circulations.Usecase
+	circulationController controllers.CirculationController
+	s                     *gin.Engine
+	bookFromDB            books.Domain
+	userFromDB            users.Domain
+	copyFromDB            circulations.CopyDomain
+	loanFromDB            circulations.LoanDomain
+)
+
+func setup(t *testing.T) {
+	circulationRepository = circulationMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	userRepository = userMocks.NewRepository(t)
+	circulationUsecase = circulations.NewCirculationUsecase(circulationRepository, bookRepository, userRepository)
+	circulationController = controllers.NewCirculationController(circulationUsecase)
+
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "1111111111111",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+	userFromDB = users.Domain{
+		ID:          1,
+		FullName:    "patrick star",
+		Username:    "itsmepatrick",
+		Email:       "najibfikri13@gmail.com",
+		Password:    "11111",
+		Role:        "user",
+		Gender:      "male",
+		IsActivated: true,
+	}
+	copyFromDB = circulations.CopyDomain{
+		ID:        1,
+		BookId:    bookFromDB.ID,
+		Book:      bookFromDB,
+		Barcode:   "GLB-000001",
+		Condition: "good",
+		Location:  "Shelf A1",
+		Status:    constants.CopyAvailable,
+		CreatedAt: time.Now(),
+	}
+	loanFromDB = circulations.LoanDomain{
+		ID:           1,
+		CopyId:       copyFromDB.ID,
+		Copy:         copyFromDB,
+		UserId:       userFromDB.ID,
+		User:         userFromDB,
+		CheckedOutAt: time.Now(),
+		DueAt:        time.Now().Add(constants.LoanPeriod),
+		CreatedAt:    time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash(userFromDB.Password)
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   userFromDB.ID,
+		IsAdmin:  true,
+		Email:    userFromDB.Email,
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    userFromDB.Username,
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStoreCopy(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/circulations/copies", circulationController.StoreCopy)
+	t.Run("When Success Store Copy", func(t *testing.T) {
+		req := requests.CopyRequest{
+			BookId:    bookFromDB.ID,
+			Barcode:   copyFromDB.Barcode,
+			Condition: "good",
+			Location:  "Shelf A1",
+		}
+		reqBody, _ := json.Marshal(req)
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Once()
+		circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(circulations.CopyDomain{}, errors.New("record not found")).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+		circulationRepository.Mock.On("StoreCopy", mock.Anything, mock.AnythingOfType("*circulations.CopyDomain"), (*circulations.HoldDomain)(nil)).Return(copyFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/circulations/copies", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "copy inserted successfully")
+	})
+	t.Run("When Failure Invalid Condition", func(t *testing.T) {
+		req := requests.CopyRequest{
+			BookId:    bookFromDB.ID,
+			Barcode:   copyFromDB.Barcode,
+			Condition: "broken",
+			Location:  "Shelf A1",
+		}
+		reqBody, _ := json.Marshal(req)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/circulations/copies", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "condition must be one of")
+	})
+}
+
+func TestCheckout(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/circulations/checkout", circulationController.Checkout)
+	t.Run("When Success Checkout", func(t *testing.T) {
+		req := requests.CheckoutRequest{
+			Barcode: copyFromDB.Barcode,
+			UserId:  userFromDB.ID,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, copyFromDB.Barcode).Return(copyFromDB, nil).Once()
+		userRepository.Mock.On("GetById", mock.Anything, userFromDB.ID).Return(userFromDB, nil).Once()
+		circulationRepository.Mock.On("CountActiveLoansByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+		circulationRepository.Mock.On("GetUnpaidFineByUserId", mock.Anything, userFromDB.ID).Return(0, nil).Once()
+		circulationRepository.Mock.On("GetActiveHoldsByBookId", mock.Anything, bookFromDB.ID).Return([]circulations.HoldDomain{}, nil).Once()
+		circulationRepository.Mock.On("Checkout", mock.Anything, mock.AnythingOfType("*circulations.LoanDomain"), (*circulations.HoldDomain)(nil), (*circulations.HoldDomain)(nil)).Return(loanFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/circulations/checkout", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, fmt.Sprintf("copy %s checked out successfully", copyFromDB.Barcode))
+	})
+	t.Run("When Failure Request is Empty", func(t *testing.T) {
+		reqBody, _ := json.Marshal(requests.CheckoutRequest{})
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/circulations/checkout", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, body, "failed on the 'required' tag")
+	})
+}
+
+func TestGetUserLoans(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/circulations/loans/me", circulationController.GetUserLoans)
+	t.Run("When Success Fetched Loans", func(t *testing.T) {
+		circulationRepository.Mock.On("GetLoansByUserId", mock.Anything, userFromDB.ID).Return([]circulations.LoanDomain{loanFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/circulations/loans/me", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "loan data fetched successfully")
+	})
+	t.Run("When Success Empty Data", func(t *testing.T) {
+		circulationRepository.Mock.On("GetLoansByUserId", mock.Anything, userFromDB.ID).Return([]circulations.LoanDomain{}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/circulations/loans/me", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "loan data is empty")
+	})
+}
+
+func TestReturn(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/circulations/return", circulationController.Return)
+	t.Run("When Failure Copy Not Found", func(t *testing.T) {
+		reqBody, _ := json.Marshal(requests.ReturnRequest{Barcode: "unknown"})
+
+		circulationRepository.Mock.On("GetCopyByBarcode", mock.Anything, "unknown").Return(circulations.CopyDomain{}, errors.New("record not found")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/circulations/return", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, body, "copy not found")
+	})
+}
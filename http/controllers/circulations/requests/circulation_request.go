@@ -0,0 +1,14 @@
+package requests
+
+type CheckoutRequest struct {
+	Barcode string `json:"barcode" binding:"required"`
+	UserId  int    `json:"user_id" binding:"required"`
+}
+
+type ReturnRequest struct {
+	Barcode string `json:"barcode" binding:"required"`
+}
+
+type HoldRequest struct {
+	BookId int `json:"book_id" binding:"required"`
+}
@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/circulations"
+
+type CopyRequest struct {
+	BookId    int    `json:"book_id" binding:"required"`
+	Barcode   string `json:"barcode" binding:"required"`
+	Condition string `json:"condition" binding:"required"`
+	Location  string `json:"location" binding:"required"`
+}
+
+func (r *CopyRequest) ToDomain() *circulations.CopyDomain {
+	return &circulations.CopyDomain{
+		BookId:    r.BookId,
+		Barcode:   r.Barcode,
+		Condition: r.Condition,
+		Location:  r.Location,
+	}
+}
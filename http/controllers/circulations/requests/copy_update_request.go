@@ -0,0 +1,17 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/circulations"
+
+type CopyUpdateRequest struct {
+	Barcode   string `json:"barcode" binding:"required"`
+	Condition string `json:"condition" binding:"required"`
+	Location  string `json:"location" binding:"required"`
+}
+
+func (r *CopyUpdateRequest) ToDomain() *circulations.CopyDomain {
+	return &circulations.CopyDomain{
+		Barcode:   r.Barcode,
+		Condition: r.Condition,
+		Location:  r.Location,
+	}
+}
@@ -0,0 +1,44 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/circulations"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+type CopyResponse struct {
+	Id        int                  `json:"id"`
+	BookId    int                  `json:"book_id"`
+	Book      bookRes.BookResponse `json:"book"`
+	Barcode   string               `json:"barcode"`
+	Condition string               `json:"condition"`
+	Location  string               `json:"location"`
+	Status    string               `json:"status"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+func FromCopyDomain(domain circulations.CopyDomain) CopyResponse {
+	return CopyResponse{
+		Id:        domain.ID,
+		BookId:    domain.BookId,
+		Book:      bookRes.FromDomain(domain.Book),
+		Barcode:   domain.Barcode,
+		Condition: domain.Condition,
+		Location:  domain.Location,
+		Status:    domain.Status,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToCopyResponseList(domains []circulations.CopyDomain) []CopyResponse {
+	var result []CopyResponse
+
+	for _, val := range domains {
+		result = append(result, FromCopyDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/circulations"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+	userRes "github.com/snykk/golib_backend/http/controllers/users/responses"
+)
+
+type HoldResponse struct {
+	Id        int                      `json:"id"`
+	BookId    int                      `json:"book_id"`
+	Book      bookRes.BookResponse     `json:"book"`
+	UserId    int                      `json:"user_id"`
+	User      userRes.UserInfoResponse `json:"user"`
+	CopyId    int                      `json:"copy_id,omitempty"`
+	Status    string                   `json:"status"`
+	Position  int                      `json:"position,omitempty"`
+	ExpiresAt *time.Time               `json:"expires_at,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+func FromHoldDomain(domain circulations.HoldDomain) HoldResponse {
+	return HoldResponse{
+		Id:        domain.ID,
+		BookId:    domain.BookId,
+		Book:      bookRes.FromDomain(domain.Book),
+		UserId:    domain.UserId,
+		User:      userRes.FromDomainToUserInfo(domain.User),
+		CopyId:    domain.CopyId,
+		Status:    domain.Status,
+		Position:  domain.Position,
+		ExpiresAt: domain.ExpiresAt,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToHoldResponseList(domains []circulations.HoldDomain) []HoldResponse {
+	var result []HoldResponse
+
+	for _, val := range domains {
+		result = append(result, FromHoldDomain(val))
+	}
+
+	return result
+}
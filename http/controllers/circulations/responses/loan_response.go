@@ -0,0 +1,52 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/circulations"
+	userRes "github.com/snykk/golib_backend/http/controllers/users/responses"
+)
+
+type LoanResponse struct {
+	Id           int                      `json:"id"`
+	CopyId       int                      `json:"copy_id"`
+	Copy         CopyResponse             `json:"copy"`
+	UserId       int                      `json:"user_id"`
+	User         userRes.UserInfoResponse `json:"user"`
+	CheckedOutAt time.Time                `json:"checked_out_at"`
+	DueAt        time.Time                `json:"due_at"`
+	ReturnedAt   *time.Time               `json:"returned_at"`
+	Renewals     int                      `json:"renewals"`
+	Fine         int                      `json:"fine"`
+	FinePaid     bool                     `json:"fine_paid"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
+
+func FromLoanDomain(domain circulations.LoanDomain) LoanResponse {
+	return LoanResponse{
+		Id:           domain.ID,
+		CopyId:       domain.CopyId,
+		Copy:         FromCopyDomain(domain.Copy),
+		UserId:       domain.UserId,
+		User:         userRes.FromDomainToUserInfo(domain.User),
+		CheckedOutAt: domain.CheckedOutAt,
+		DueAt:        domain.DueAt,
+		ReturnedAt:   domain.ReturnedAt,
+		Renewals:     domain.Renewals,
+		Fine:         domain.Fine,
+		FinePaid:     domain.FinePaid,
+		CreatedAt:    domain.CreatedAt,
+		UpdatedAt:    domain.UpdatedAt,
+	}
+}
+
+func ToLoanResponseList(domains []circulations.LoanDomain) []LoanResponse {
+	var result []LoanResponse
+
+	for _, val := range domains {
+		result = append(result, FromLoanDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,143 @@
+package collections
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/collections"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/collections/requests"
+	"github.com/snykk/golib_backend/http/controllers/collections/responses"
+)
+
+type CollectionController struct {
+	collectionUsecase collections.Usecase
+	ristrettoCache    cache.RistrettoCache
+}
+
+func NewCollectionController(collectionUsecase collections.Usecase, ristrettoCache cache.RistrettoCache) CollectionController {
+	return CollectionController{
+		collectionUsecase: collectionUsecase,
+		ristrettoCache:    ristrettoCache,
+	}
+}
+
+func (c *CollectionController) GetHome(ctx *gin.Context) {
+	if val := c.ristrettoCache.Get("home"); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, "home data fetched successfully", val)
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	home, statusCode, err := c.collectionUsecase.GetHome(ctxx)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	homeResponse := responses.FromHomeDomain(home, time.Now())
+
+	// the ttl also bounds how late a collection appears or disappears after its window opens or closes
+	go c.ristrettoCache.SetWithTTL("home", homeResponse, constants.HomeCacheTTL)
+
+	controllers.NewSuccessResponse(ctx, statusCode, "home data fetched successfully", homeResponse)
+}
+
+func (c *CollectionController) GetAll(ctx *gin.Context) {
+	ctxx := ctx.Request.Context()
+	listOfCollections, statusCode, err := c.collectionUsecase.GetAll(ctxx)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	collectionResponses := responses.ToResponseList(listOfCollections, time.Now())
+
+	if collectionResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "collection data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "collection data fetched successfully", gin.H{
+		"collections": collectionResponses,
+	})
+}
+
+func (c *CollectionController) GetById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	collection, statusCode, err := c.collectionUsecase.GetById(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("collection data with id %d fetched successfully", id), gin.H{
+		"collection": responses.FromDomain(collection, time.Now()),
+	})
+}
+
+func (c *CollectionController) Store(ctx *gin.Context) {
+	var collectionRequest requests.CollectionRequest
+	if err := ctx.ShouldBindJSON(&collectionRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	collection, statusCode, err := c.collectionUsecase.Store(ctxx, collectionRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("home")
+
+	controllers.NewSuccessResponse(ctx, statusCode, "collection inserted successfully", gin.H{
+		"collection": responses.FromDomain(collection, time.Now()),
+	})
+}
+
+func (c *CollectionController) Update(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var collectionRequest requests.CollectionRequest
+	if err := ctx.ShouldBindJSON(&collectionRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	collection, statusCode, err := c.collectionUsecase.Update(ctxx, id, collectionRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("home")
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("collection data with id %d updated successfully", id), gin.H{
+		"collection": responses.FromDomain(collection, time.Now()),
+	})
+}
+
+func (c *CollectionController) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.collectionUsecase.Delete(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("home")
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("collection data with id %d deleted successfully", id), nil)
+}
@@ -0,0 +1,149 @@
+package collections_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	collectionMocks "github.com/snykk/golib_backend/datasources/databases/collections/mocks"
+	rankingMocks "github.com/snykk/golib_backend/datasources/databases/rankings/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/collections"
+	"github.com/snykk/golib_backend/domains/rankings"
+	controllers "github.com/snykk/golib_backend/http/controllers/collections"
+	"github.com/snykk/golib_backend/http/controllers/collections/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	collectionRepository *collectionMocks.Repository
+	bookRepository       *bookMocks.Repository
+	rankingRepository    *rankingMocks.Repository
+	ristrettoMock        *cacheMocks.RistrettoCache
+	collectionUsecase    collections.Usecase
+	collectionController controllers.CollectionController
+	s                    *gin.Engine
+	collectionFromDB     collections.Domain
+	bookFromDB           books.Domain
+)
+
+func setup(t *testing.T) {
+	collectionRepository = collectionMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	rankingRepository = rankingMocks.NewRepository(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	collectionUsecase = collections.NewCollectionUsecase(collectionRepository, bookRepository, rankingRepository)
+	collectionController = controllers.NewCollectionController(collectionUsecase, ristrettoMock)
+
+	bookFromDB = books.Domain{
+		ID:        2,
+		Title:     "Atomic Habits",
+		Author:    "James Clear",
+		Publisher: "Gramedia",
+		ISBN:      "9780735211292",
+		CreatedAt: time.Now(),
+	}
+
+	collectionFromDB = collections.Domain{
+		ID:        1,
+		Title:     "Staff picks",
+		BookIds:   []int{2},
+		Books:     []books.Domain{bookFromDB},
+		CreatedAt: time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+}
+
+func TestGetHome(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/home", collectionController.GetHome)
+	t.Run("When Success Get Home", func(t *testing.T) {
+		ristrettoMock.Mock.On("Get", "home").Return(nil).Once()
+		collectionRepository.Mock.On("GetActive", mock.Anything, mock.AnythingOfType("time.Time")).Return([]collections.Domain{collectionFromDB}, nil).Once()
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return([]books.Domain{bookFromDB}, 1, nil).Once()
+		rankingRepository.Mock.On("GetByKind", mock.Anything, constants.RankingTop, constants.HomeTopRatedLimit).Return([]rankings.Ranking{}, nil).Once()
+		ristrettoMock.Mock.On("SetWithTTL", "home", mock.Anything, constants.HomeCacheTTL).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/home", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "home data fetched successfully")
+		assert.Contains(t, body, `"title":"Staff picks"`)
+		assert.Contains(t, body, `"top_rated":[]`)
+	})
+	t.Run("When Success Get Home From Cache", func(t *testing.T) {
+		ristrettoMock.Mock.On("Get", "home").Return(map[string]interface{}{"collections": []int{}}).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/home", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), `"collections":[]`)
+	})
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/admin/collections", collectionController.Store)
+	t.Run("When Success Store Collection", func(t *testing.T) {
+		bookRepository.Mock.On("GetByIds", mock.Anything, []int{2}).Return([]books.Domain{bookFromDB}, nil).Once()
+		collectionRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*collections.Domain")).Return(collectionFromDB, nil).Once()
+		ristrettoMock.Mock.On("Del", "home").Maybe()
+
+		reqBody, _ := json.Marshal(requests.CollectionRequest{Title: "Staff picks", BookIds: []int{2}})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/admin/collections", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, body, "collection inserted successfully")
+		assert.Contains(t, body, `"active":true`)
+	})
+	t.Run("When Failure Without Books", func(t *testing.T) {
+		reqBody, _ := json.Marshal(requests.CollectionRequest{Title: "Staff picks"})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/admin/collections", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/collections"
+)
+
+type CollectionRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	Position    int        `json:"position" binding:"omitempty,min=0"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	BookIds     []int      `json:"book_ids" binding:"required,min=1,max=50,dive,min=1"`
+}
+
+func (r *CollectionRequest) ToDomain() *collections.Domain {
+	return &collections.Domain{
+		Title:       r.Title,
+		Description: r.Description,
+		Position:    r.Position,
+		StartsAt:    r.StartsAt,
+		EndsAt:      r.EndsAt,
+		BookIds:     append([]int(nil), r.BookIds...),
+	}
+}
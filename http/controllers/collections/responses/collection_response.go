@@ -0,0 +1,78 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/collections"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+	rankingRes "github.com/snykk/golib_backend/http/controllers/rankings/responses"
+)
+
+type CollectionResponse struct {
+	Id          int                    `json:"id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Position    int                    `json:"position"`
+	StartsAt    *time.Time             `json:"starts_at"`
+	EndsAt      *time.Time             `json:"ends_at"`
+	Active      bool                   `json:"active"`
+	Books       []bookRes.BookResponse `json:"books"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type HomeResponse struct {
+	Collections []CollectionResponse         `json:"collections"`
+	Newest      []bookRes.BookResponse       `json:"newest"`
+	TopRated    []rankingRes.RankingResponse `json:"top_rated"`
+}
+
+func FromDomain(domain collections.Domain, now time.Time) CollectionResponse {
+	response := CollectionResponse{
+		Id:          domain.ID,
+		Title:       domain.Title,
+		Description: domain.Description,
+		Position:    domain.Position,
+		StartsAt:    domain.StartsAt,
+		EndsAt:      domain.EndsAt,
+		Active:      domain.IsActive(now),
+		Books:       bookRes.ToResponseList(domain.Books),
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+	}
+	if response.Books == nil {
+		response.Books = []bookRes.BookResponse{}
+	}
+
+	return response
+}
+
+func ToResponseList(domains []collections.Domain, now time.Time) []CollectionResponse {
+	var result []CollectionResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val, now))
+	}
+
+	return result
+}
+
+// FromHomeDomain keeps every section an array, an empty section reads as [] rather than null
+func FromHomeDomain(home collections.Home, now time.Time) HomeResponse {
+	response := HomeResponse{
+		Collections: ToResponseList(home.Collections, now),
+		Newest:      bookRes.ToResponseList(home.Newest),
+		TopRated:    rankingRes.ToResponseList(home.TopRated),
+	}
+	if response.Collections == nil {
+		response.Collections = []CollectionResponse{}
+	}
+	if response.Newest == nil {
+		response.Newest = []bookRes.BookResponse{}
+	}
+	if response.TopRated == nil {
+		response.TopRated = []rankingRes.RankingResponse{}
+	}
+
+	return response
+}
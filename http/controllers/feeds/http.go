@@ -0,0 +1,145 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/feeds"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/feeds/requests"
+	"github.com/snykk/golib_backend/http/controllers/feeds/responses"
+)
+
+type FeedController struct {
+	feedUsecase feeds.Usecase
+}
+
+func NewFeedController(feedUsecase feeds.Usecase) FeedController {
+	return FeedController{
+		feedUsecase: feedUsecase,
+	}
+}
+
+func (c *FeedController) GetOPDSRoot(ctx *gin.Context) {
+	renderXML(ctx, constants.OPDSNavigationType, responses.OPDSRoot(baseURL(ctx), time.Now()))
+}
+
+func (c *FeedController) GetOPDSBooks(ctx *gin.Context) {
+	var feedQueryRequest requests.FeedQueryRequest
+	if err := ctx.ShouldBindQuery(&feedQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	feed, statusCode, err := c.feedUsecase.GetBooks(ctxx, feedQueryRequest.ToSort(), feedQueryRequest.ToPage())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	url := responses.FeedURL{Base: baseURL(ctx), Path: "/opds/books", Query: "sort=" + feed.Sort}
+	renderXML(ctx, constants.OPDSAcquisitionType, responses.FromOPDSBookFeed(url, feed))
+}
+
+func (c *FeedController) GetBooksAtom(ctx *gin.Context) {
+	feed, ok := c.getNewBooks(ctx)
+	if !ok {
+		return
+	}
+
+	url := responses.FeedURL{Base: baseURL(ctx), Path: "/feeds/books.atom"}
+	renderXML(ctx, constants.AtomContentType, responses.FromBookFeed(url, feed))
+}
+
+func (c *FeedController) GetBooksRSS(ctx *gin.Context) {
+	feed, ok := c.getNewBooks(ctx)
+	if !ok {
+		return
+	}
+
+	url := responses.FeedURL{Base: baseURL(ctx), Path: "/feeds/books.rss"}
+	renderXML(ctx, constants.RSSContentType, responses.FromBookFeedRSS(url, feed))
+}
+
+func (c *FeedController) GetReviewsAtom(ctx *gin.Context) {
+	feed, ok := c.getBookReviews(ctx)
+	if !ok {
+		return
+	}
+
+	url := responses.FeedURL{Base: baseURL(ctx), Path: fmt.Sprintf("/feeds/books/%d/reviews.atom", feed.Book.ID)}
+	renderXML(ctx, constants.AtomContentType, responses.FromReviewFeed(url, feed))
+}
+
+func (c *FeedController) GetReviewsRSS(ctx *gin.Context) {
+	feed, ok := c.getBookReviews(ctx)
+	if !ok {
+		return
+	}
+
+	url := responses.FeedURL{Base: baseURL(ctx), Path: fmt.Sprintf("/feeds/books/%d/reviews.rss", feed.Book.ID)}
+	renderXML(ctx, constants.RSSContentType, responses.FromReviewFeedRSS(url, feed))
+}
+
+// getNewBooks fetches the page of the new books feed asked for, the error response is already sent when it fails
+func (c *FeedController) getNewBooks(ctx *gin.Context) (feeds.BookFeed, bool) {
+	var feedQueryRequest requests.FeedQueryRequest
+	if err := ctx.ShouldBindQuery(&feedQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return feeds.BookFeed{}, false
+	}
+
+	ctxx := ctx.Request.Context()
+	feed, statusCode, err := c.feedUsecase.GetBooks(ctxx, constants.FeedSortNewest, feedQueryRequest.ToPage())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return feeds.BookFeed{}, false
+	}
+
+	return feed, true
+}
+
+func (c *FeedController) getBookReviews(ctx *gin.Context) (feeds.ReviewFeed, bool) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var feedQueryRequest requests.FeedQueryRequest
+	if err := ctx.ShouldBindQuery(&feedQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return feeds.ReviewFeed{}, false
+	}
+
+	ctxx := ctx.Request.Context()
+	feed, statusCode, err := c.feedUsecase.GetBookReviews(ctxx, id, feedQueryRequest.ToPage())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return feeds.ReviewFeed{}, false
+	}
+
+	return feed, true
+}
+
+func renderXML(ctx *gin.Context, contentType string, document interface{}) {
+	body, err := xml.Marshal(document)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
+
+// baseURL is the scheme and host the client reached us on, feeds need absolute links since readers fetch them out of context
+func baseURL(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	return scheme + "://" + ctx.Request.Host
+}
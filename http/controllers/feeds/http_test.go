@@ -0,0 +1,237 @@
+package feeds_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	reviewMocks "github.com/snykk/golib_backend/datasources/databases/reviews/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/feeds"
+	"github.com/snykk/golib_backend/domains/reviews"
+	"github.com/snykk/golib_backend/domains/users"
+	controllers "github.com/snykk/golib_backend/http/controllers/feeds"
+	"github.com/snykk/golib_backend/http/controllers/feeds/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	bookRepository   *bookMocks.Repository
+	reviewRepository *reviewMocks.Repository
+	feedUsecase      feeds.Usecase
+	feedController   controllers.FeedController
+	s                *gin.Engine
+	booksFromDB      []books.Domain
+	reviewsFromDB    []reviews.Domain
+)
+
+func setup(t *testing.T) {
+	bookRepository = bookMocks.NewRepository(t)
+	reviewRepository = reviewMocks.NewRepository(t)
+	feedUsecase = feeds.NewFeedUsecase(bookRepository, reviewRepository)
+	feedController = controllers.NewFeedController(feedUsecase)
+
+	booksFromDB = []books.Domain{
+		{
+			ID:          1,
+			Title:       "Laskar Pelangi",
+			Description: "Ten children & their teacher on Belitung",
+			Author:      "Andrea Hirata",
+			Publisher:   "Bentang Pustaka",
+			ISBN:        "9789793062792",
+			Cover:       "1/cover.png",
+			Tags:        []string{"novel"},
+			CreatedAt:   time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:   time.Date(2022, 11, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	reviewsFromDB = []reviews.Domain{
+		{
+			ID:        3,
+			Text:      "A warm story about friendship",
+			Rating:    9,
+			BookId:    1,
+			Book:      booksFromDB[0],
+			UserId:    1,
+			User:      users.Domain{ID: 1, Username: "snykk"},
+			CreatedAt: time.Date(2022, 11, 5, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2022, 11, 5, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.GET("/opds", feedController.GetOPDSRoot)
+	s.GET("/opds/books", feedController.GetOPDSBooks)
+	s.GET("/feeds/books.atom", feedController.GetBooksAtom)
+	s.GET("/feeds/books.rss", feedController.GetBooksRSS)
+	s.GET("/feeds/books/:id/reviews.atom", feedController.GetReviewsAtom)
+	s.GET("/feeds/books/:id/reviews.rss", feedController.GetReviewsRSS)
+}
+
+func linkHref(links []responses.AtomLink, rel string) string {
+	for _, link := range links {
+		if link.Rel == rel {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+func TestGetOPDSRoot(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get OPDS Root", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/opds", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		var feed responses.AtomFeed
+		err := xml.Unmarshal(w.Body.Bytes(), &feed)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Nil(t, err)
+		assert.Equal(t, constants.OPDSNavigationType, w.Header().Get("Content-Type"))
+		assert.Len(t, feed.Entries, 2)
+		assert.Equal(t, "http://example.com/opds/books?sort=newest", linkHref(feed.Entries[0].Links, "http://opds-spec.org/sort/new"))
+	})
+}
+
+func TestGetOPDSBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get OPDS Books", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, &books.Query{Page: 2, Limit: constants.FeedPageSize, Sort: "title", Order: "asc"}).Return(booksFromDB, 45, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/opds/books?sort=title&page=2", nil)
+		r.Header.Set("X-Forwarded-Proto", "https")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `<dc:identifier>urn:isbn:9789793062792</dc:identifier>`)
+		assert.Contains(t, body, `<link rel="http://opds-spec.org/acquisition/borrow" href="https://example.com/circulations/copies/book/1" type="application/json"></link>`)
+		assert.Contains(t, body, `<link rel="http://opds-spec.org/image" href="https://example.com/covers/1/cover.png" type="image/png"></link>`)
+		assert.Contains(t, body, `<link rel="previous" href="https://example.com/opds/books?sort=title&amp;page=1"`)
+		assert.Contains(t, body, `<link rel="next" href="https://example.com/opds/books?sort=title&amp;page=3"`)
+		assert.Contains(t, body, `<link rel="last" href="https://example.com/opds/books?sort=title&amp;page=3"`)
+	})
+	t.Run("When Failure Unknown Sort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/opds/books?sort=rating", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetBooksAtom(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get New Books Atom", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return(booksFromDB, 1, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/feeds/books.atom", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		var feed responses.AtomFeed
+		err := xml.Unmarshal(w.Body.Bytes(), &feed)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Nil(t, err)
+		assert.Equal(t, "2022-11-02T00:00:00Z", feed.Updated)
+		assert.Equal(t, "http://example.com/feeds/books.atom?page=1", linkHref(feed.Links, "self"))
+		assert.Equal(t, "", linkHref(feed.Links, "next"))
+		assert.Equal(t, "http://example.com/books/1", feed.Entries[0].ID)
+	})
+}
+
+func TestGetBooksRSS(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get New Books RSS", func(t *testing.T) {
+		bookRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*books.Query")).Return(booksFromDB, 1, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/feeds/books.rss", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		var feed responses.RSSFeed
+		err := xml.Unmarshal(w.Body.Bytes(), &feed)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Nil(t, err)
+		assert.Equal(t, constants.RSSContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "Tue, 01 Nov 2022 00:00:00 +0000", feed.Channel.Items[0].PubDate)
+		assert.Equal(t, "Ten children & their teacher on Belitung", feed.Channel.Items[0].Description)
+	})
+}
+
+func TestGetReviewsAtom(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Reviews Atom", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 1).Return(booksFromDB[0], nil).Once()
+		reviewRepository.Mock.On("GetRecentByBookId", mock.Anything, 1, 1, constants.FeedPageSize).Return(reviewsFromDB, 1, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/feeds/books/1/reviews.atom", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		var feed responses.AtomFeed
+		err := xml.Unmarshal(w.Body.Bytes(), &feed)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Nil(t, err)
+		assert.Equal(t, "Reviews of Laskar Pelangi", feed.Title)
+		assert.Equal(t, "snykk rated Laskar Pelangi 9/10", feed.Entries[0].Title)
+		assert.Equal(t, "A warm story about friendship", feed.Entries[0].Content.Value)
+	})
+}
+
+func TestGetReviewsRSS(t *testing.T) {
+	setup(t)
+	t.Run("When Failure Book Doesn't Exist", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 9).Return(books.Domain{}, assert.AnError).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/feeds/books/9/reviews.rss", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "book not found")
+	})
+}
@@ -0,0 +1,22 @@
+package requests
+
+import "github.com/snykk/golib_backend/constants"
+
+type FeedQueryRequest struct {
+	Page int    `form:"page" binding:"omitempty,min=1"`
+	Sort string `form:"sort" binding:"omitempty,oneof=newest title"`
+}
+
+func (q *FeedQueryRequest) ToPage() int {
+	if q.Page < 1 {
+		return 1
+	}
+	return q.Page
+}
+
+func (q *FeedQueryRequest) ToSort() string {
+	if q.Sort == "" {
+		return constants.FeedSortNewest
+	}
+	return q.Sort
+}
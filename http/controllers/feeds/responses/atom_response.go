@@ -0,0 +1,251 @@
+package responses
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/feeds"
+	"github.com/snykk/golib_backend/domains/reviews"
+)
+
+const (
+	AtomNamespace = "http://www.w3.org/2005/Atom"
+	DCNamespace   = "http://purl.org/dc/terms/"
+	OPDSNamespace = "http://opds-spec.org/2010/catalog"
+
+	opdsImageRel     = "http://opds-spec.org/image"
+	opdsThumbnailRel = "http://opds-spec.org/image/thumbnail"
+	opdsBorrowRel    = "http://opds-spec.org/acquisition/borrow"
+	opdsSortNewRel   = "http://opds-spec.org/sort/new"
+)
+
+type AtomFeed struct {
+	XMLName   xml.Name    `xml:"feed"`
+	Xmlns     string      `xml:"xmlns,attr"`
+	XmlnsDC   string      `xml:"xmlns:dc,attr,omitempty"`
+	XmlnsOPDS string      `xml:"xmlns:opds,attr,omitempty"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Author    AtomPerson  `xml:"author"`
+	Links     []AtomLink  `xml:"link"`
+	Entries   []AtomEntry `xml:"entry"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Authors    []AtomPerson   `xml:"author"`
+	Identifier string         `xml:"dc:identifier,omitempty"`
+	Publisher  string         `xml:"dc:publisher,omitempty"`
+	Language   string         `xml:"dc:language,omitempty"`
+	Issued     string         `xml:"dc:issued,omitempty"`
+	Categories []AtomCategory `xml:"category"`
+	Summary    *AtomText      `xml:"summary,omitempty"`
+	Content    *AtomText      `xml:"content,omitempty"`
+	Links      []AtomLink     `xml:"link"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type AtomLink struct {
+	Rel   string `xml:"rel,attr"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type AtomCategory struct {
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+	Label  string `xml:"label,attr,omitempty"`
+}
+
+type AtomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// FeedURL gives the absolute address of every page of a feed, Base is the scheme and host the request came in on
+type FeedURL struct {
+	Base  string
+	Path  string
+	Query string
+}
+
+func (u FeedURL) Page(page int) string {
+	if u.Query != "" {
+		return fmt.Sprintf("%s%s?%s&page=%d", u.Base, u.Path, u.Query, page)
+	}
+	return fmt.Sprintf("%s%s?page=%d", u.Base, u.Path, page)
+}
+
+// PagingLinks are the self link plus the RFC 5005 links a reader follows to walk through the whole feed
+func PagingLinks(page feeds.Page, url FeedURL, linkType string) []AtomLink {
+	links := []AtomLink{
+		{Rel: "self", Href: url.Page(page.Page), Type: linkType},
+		{Rel: "first", Href: url.Page(1), Type: linkType},
+	}
+	if page.HasPrevious() {
+		links = append(links, AtomLink{Rel: "previous", Href: url.Page(page.Page - 1), Type: linkType})
+	}
+	if page.HasNext() {
+		links = append(links, AtomLink{Rel: "next", Href: url.Page(page.Page + 1), Type: linkType})
+	}
+	links = append(links, AtomLink{Rel: "last", Href: url.Page(page.LastPage()), Type: linkType})
+
+	return links
+}
+
+func formatAtomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// feedUpdated is the most recent change among the entries, an empty feed has nothing to date it by but now
+func feedUpdated(times []time.Time) string {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	if latest.IsZero() {
+		latest = time.Now()
+	}
+
+	return formatAtomTime(latest)
+}
+
+func BookEntry(base string, book books.Domain, opds bool) AtomEntry {
+	bookURL := fmt.Sprintf("%s/books/%d", base, book.ID)
+	entry := AtomEntry{
+		ID:         bookURL,
+		Title:      book.Title,
+		Updated:    formatAtomTime(book.UpdatedAt),
+		Published:  formatAtomTime(book.CreatedAt),
+		Authors:    []AtomPerson{{Name: book.Author}},
+		Publisher:  book.Publisher,
+		Language:   book.Language,
+		Categories: make([]AtomCategory, 0, len(book.Categories)+len(book.Tags)),
+		Links:      []AtomLink{{Rel: "alternate", Href: bookURL, Type: "application/json"}},
+	}
+	if book.ISBN != "" {
+		entry.Identifier = "urn:isbn:" + book.ISBN
+	}
+	if book.PublicationDate != nil {
+		entry.Issued = book.PublicationDate.Format(constants.PublicationDateLayout)
+	}
+	if book.Description != "" {
+		entry.Summary = &AtomText{Type: "text", Value: book.Description}
+	}
+
+	for _, category := range book.Categories {
+		entry.Categories = append(entry.Categories, AtomCategory{Term: category.Name, Scheme: base + "/categories", Label: category.Name})
+	}
+	for _, tag := range book.Tags {
+		entry.Categories = append(entry.Categories, AtomCategory{Term: tag, Scheme: base + "/tags", Label: tag})
+	}
+
+	if book.Cover != "" {
+		entry.Links = append(entry.Links,
+			AtomLink{Rel: opdsImageRel, Href: base + constants.CoverURLPrefix + book.Cover, Type: coverType(book.Cover)},
+			AtomLink{Rel: opdsThumbnailRel, Href: base + constants.CoverURLPrefix + books.ThumbnailKey(book.Cover, constants.CoverSmall), Type: "image/jpeg"},
+		)
+	}
+
+	// the copies are on the shelves rather than in a file, so the only way to acquire one is borrowing it
+	if opds {
+		entry.Links = append(entry.Links, AtomLink{Rel: opdsBorrowRel, Href: fmt.Sprintf("%s/circulations/copies/book/%d", base, book.ID), Type: "application/json"})
+	}
+
+	return entry
+}
+
+func ReviewEntry(base string, review reviews.Domain) AtomEntry {
+	reviewURL := fmt.Sprintf("%s/reviews/%d", base, review.ID)
+
+	return AtomEntry{
+		ID:        reviewURL,
+		Title:     reviewTitle(review),
+		Updated:   formatAtomTime(review.UpdatedAt),
+		Published: formatAtomTime(review.CreatedAt),
+		Authors:   []AtomPerson{{Name: review.User.Username}},
+		Content:   &AtomText{Type: "text", Value: review.Text},
+		Links:     []AtomLink{{Rel: "alternate", Href: reviewURL, Type: "application/json"}},
+	}
+}
+
+func reviewTitle(review reviews.Domain) string {
+	return fmt.Sprintf("%s rated %s %d/%d", review.User.Username, review.Book.Title, review.Rating, constants.MaxRating)
+}
+
+func FromBookFeed(url FeedURL, feed feeds.BookFeed) AtomFeed {
+	atom := AtomFeed{
+		Xmlns:   AtomNamespace,
+		XmlnsDC: DCNamespace,
+		ID:      url.Base + url.Path,
+		Title:   "New books",
+		Updated: bookFeedUpdated(feed.Books),
+		Author:  AtomPerson{Name: constants.FeedAuthor, URI: url.Base},
+		Links:   append(PagingLinks(feed.Page, url, constants.AtomContentType), AtomLink{Rel: "alternate", Href: url.Base + "/books", Type: "application/json"}),
+		Entries: make([]AtomEntry, 0, len(feed.Books)),
+	}
+	for _, book := range feed.Books {
+		atom.Entries = append(atom.Entries, BookEntry(url.Base, book, false))
+	}
+
+	return atom
+}
+
+func FromReviewFeed(url FeedURL, feed feeds.ReviewFeed) AtomFeed {
+	updated := make([]time.Time, 0, len(feed.Reviews))
+	for _, review := range feed.Reviews {
+		updated = append(updated, review.UpdatedAt)
+	}
+
+	atom := AtomFeed{
+		Xmlns:   AtomNamespace,
+		ID:      url.Base + url.Path,
+		Title:   "Reviews of " + feed.Book.Title,
+		Updated: feedUpdated(updated),
+		Author:  AtomPerson{Name: constants.FeedAuthor, URI: url.Base},
+		Links:   append(PagingLinks(feed.Page, url, constants.AtomContentType), AtomLink{Rel: "related", Href: fmt.Sprintf("%s/books/%d", url.Base, feed.Book.ID), Type: "application/json"}),
+		Entries: make([]AtomEntry, 0, len(feed.Reviews)),
+	}
+	for _, review := range feed.Reviews {
+		atom.Entries = append(atom.Entries, ReviewEntry(url.Base, review))
+	}
+
+	return atom
+}
+
+func bookFeedUpdated(domains []books.Domain) string {
+	updated := make([]time.Time, 0, len(domains))
+	for _, book := range domains {
+		updated = append(updated, book.UpdatedAt)
+	}
+
+	return feedUpdated(updated)
+}
+
+// coverType maps the extension the cover was stored with back to the content type it was uploaded as
+func coverType(key string) string {
+	for contentType, extension := range constants.MapperCoverContentTypeToExtension {
+		if strings.TrimPrefix(path.Ext(key), ".") == extension {
+			return contentType
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,73 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/feeds"
+)
+
+// OPDSRoot is the navigation feed an e-reader starts from, each entry leads to an acquisition feed of the catalog
+func OPDSRoot(base string, now time.Time) AtomFeed {
+	start := base + "/opds"
+	updated := formatAtomTime(now)
+
+	return AtomFeed{
+		Xmlns:     AtomNamespace,
+		XmlnsOPDS: OPDSNamespace,
+		ID:        start,
+		Title:     "Golib catalog",
+		Updated:   updated,
+		Author:    AtomPerson{Name: constants.FeedAuthor, URI: base},
+		Links: []AtomLink{
+			{Rel: "self", Href: start, Type: constants.OPDSNavigationType},
+			{Rel: "start", Href: start, Type: constants.OPDSNavigationType},
+		},
+		Entries: []AtomEntry{
+			{
+				ID:      start + "/books?sort=" + constants.FeedSortNewest,
+				Title:   "New books",
+				Updated: updated,
+				Content: &AtomText{Type: "text", Value: "The most recently added books"},
+				Links:   []AtomLink{{Rel: opdsSortNewRel, Href: start + "/books?sort=" + constants.FeedSortNewest, Type: constants.OPDSAcquisitionType}},
+			},
+			{
+				ID:      start + "/books?sort=" + constants.FeedSortTitle,
+				Title:   "All books",
+				Updated: updated,
+				Content: &AtomText{Type: "text", Value: "Every book in the catalog by title"},
+				Links:   []AtomLink{{Rel: "subsection", Href: start + "/books?sort=" + constants.FeedSortTitle, Type: constants.OPDSAcquisitionType}},
+			},
+		},
+	}
+}
+
+func FromOPDSBookFeed(url FeedURL, feed feeds.BookFeed) AtomFeed {
+	title := "New books"
+	if feed.Sort == constants.FeedSortTitle {
+		title = "All books"
+	}
+
+	start := url.Base + "/opds"
+	links := append(PagingLinks(feed.Page, url, constants.OPDSAcquisitionType),
+		AtomLink{Rel: "start", Href: start, Type: constants.OPDSNavigationType},
+		AtomLink{Rel: "up", Href: start, Type: constants.OPDSNavigationType},
+	)
+
+	opds := AtomFeed{
+		Xmlns:     AtomNamespace,
+		XmlnsDC:   DCNamespace,
+		XmlnsOPDS: OPDSNamespace,
+		ID:        url.Base + url.Path + "?" + url.Query,
+		Title:     title,
+		Updated:   bookFeedUpdated(feed.Books),
+		Author:    AtomPerson{Name: constants.FeedAuthor, URI: url.Base},
+		Links:     links,
+		Entries:   make([]AtomEntry, 0, len(feed.Books)),
+	}
+	for _, book := range feed.Books {
+		opds.Entries = append(opds.Entries, BookEntry(url.Base, book, true))
+	}
+
+	return opds
+}
@@ -0,0 +1,132 @@
+package responses
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/feeds"
+)
+
+type RSSFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XmlnsAtom string     `xml:"xmlns:atom,attr"`
+	XmlnsDC   string     `xml:"xmlns:dc,attr"`
+	Channel   RSSChannel `xml:"channel"`
+}
+
+// RSSChannel borrows the atom:link element, RSS 2.0 has no way of its own to point at the next page
+type RSSChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	AtomLinks     []AtomLink `xml:"atom:link"`
+	Items         []RSSItem  `xml:"item"`
+}
+
+type RSSItem struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	GUID        RSSGUID   `xml:"guid"`
+	Description string    `xml:"description,omitempty"`
+	Creator     string    `xml:"dc:creator,omitempty"`
+	Categories  []string  `xml:"category"`
+	PubDate     string    `xml:"pubDate"`
+	Enclosure   *RSSImage `xml:"enclosure,omitempty"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type RSSImage struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+func formatRSSTime(t time.Time) string {
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+func newRSSFeed(channel RSSChannel) RSSFeed {
+	return RSSFeed{Version: "2.0", XmlnsAtom: AtomNamespace, XmlnsDC: "http://purl.org/dc/elements/1.1/", Channel: channel}
+}
+
+func FromBookFeedRSS(url FeedURL, feed feeds.BookFeed) RSSFeed {
+	channel := RSSChannel{
+		Title:       "New books",
+		Link:        url.Base + "/books",
+		Description: "Books recently added to the Golib catalog",
+		AtomLinks:   PagingLinks(feed.Page, url, constants.RSSContentType),
+		Items:       make([]RSSItem, 0, len(feed.Books)),
+	}
+	if len(feed.Books) > 0 {
+		channel.LastBuildDate = formatRSSTime(latestBook(feed.Books).UpdatedAt)
+	}
+
+	for _, book := range feed.Books {
+		link := fmt.Sprintf("%s/books/%d", url.Base, book.ID)
+		item := RSSItem{
+			Title:       book.Title,
+			Link:        link,
+			GUID:        RSSGUID{Value: link},
+			Description: book.Description,
+			Creator:     book.Author,
+			Categories:  book.Tags,
+			PubDate:     formatRSSTime(book.CreatedAt),
+		}
+		// the length of the cover is unknown without reading it, 0 is what the spec suggests then
+		if book.Cover != "" {
+			item.Enclosure = &RSSImage{URL: url.Base + constants.CoverURLPrefix + book.Cover, Type: coverType(book.Cover)}
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	return newRSSFeed(channel)
+}
+
+func FromReviewFeedRSS(url FeedURL, feed feeds.ReviewFeed) RSSFeed {
+	bookURL := fmt.Sprintf("%s/books/%d", url.Base, feed.Book.ID)
+	channel := RSSChannel{
+		Title:       "Reviews of " + feed.Book.Title,
+		Link:        bookURL,
+		Description: fmt.Sprintf("Recent reviews of %s by %s", feed.Book.Title, feed.Book.Author),
+		AtomLinks:   PagingLinks(feed.Page, url, constants.RSSContentType),
+		Items:       make([]RSSItem, 0, len(feed.Reviews)),
+	}
+	if len(feed.Reviews) > 0 {
+		// reviews come newest first
+		channel.LastBuildDate = formatRSSTime(feed.Reviews[0].CreatedAt)
+	}
+
+	for _, review := range feed.Reviews {
+		link := fmt.Sprintf("%s/reviews/%d", url.Base, review.ID)
+		channel.Items = append(channel.Items, RSSItem{
+			Title:       reviewTitle(review),
+			Link:        link,
+			GUID:        RSSGUID{Value: link},
+			Description: review.Text,
+			Creator:     review.User.Username,
+			PubDate:     formatRSSTime(review.CreatedAt),
+		})
+	}
+
+	return newRSSFeed(channel)
+}
+
+func latestBook(domains []books.Domain) books.Domain {
+	latest := domains[0]
+	for _, book := range domains[1:] {
+		if book.UpdatedAt.After(latest.UpdatedAt) {
+			latest = book
+		}
+	}
+
+	return latest
+}
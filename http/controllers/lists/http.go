@@ -0,0 +1,276 @@
+package lists
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/lists"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/lists/requests"
+	"github.com/snykk/golib_backend/http/controllers/lists/responses"
+	"github.com/snykk/golib_backend/http/token"
+)
+
+type ListController struct {
+	listUsecase lists.Usecase
+}
+
+func NewListController(listUsecase lists.Usecase) ListController {
+	return ListController{
+		listUsecase: listUsecase,
+	}
+}
+
+func (c *ListController) GetAll(ctx *gin.Context) {
+	var listQueryRequest requests.ListQueryRequest
+	if err := ctx.ShouldBindQuery(&listQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	query := listQueryRequest.ToDomain()
+	readingLists, total, statusCode, err := c.listUsecase.GetAll(ctxx, query)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	listResponses := responses.ToResponseList(readingLists)
+	meta := controllers.NewPaginationMeta(query.Page, query.Limit, total)
+
+	if listResponses == nil {
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, "reading list data is empty", []int{}, meta)
+		return
+	}
+
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, "reading list data fetched successfully", gin.H{
+		"lists": listResponses,
+	}, meta)
+}
+
+func (c *ListController) GetUserLists(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	readingLists, statusCode, err := c.listUsecase.GetByUserId(ctxx, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	listResponses := responses.ToResponseList(readingLists)
+
+	if listResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "reading list data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "reading list data fetched successfully", gin.H{
+		"lists": listResponses,
+	})
+}
+
+func (c *ListController) GetById(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	list, statusCode, err := c.listUsecase.GetById(ctxx, userClaims.UserID, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("reading list data with id %d fetched successfully", id), gin.H{
+		"list":    responses.FromDomain(list),
+		"entries": responses.ToEntryResponseList(list.Entries),
+	})
+}
+
+func (c *ListController) Store(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	var listRequest requests.ListRequest
+	if err := ctx.ShouldBindJSON(&listRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	list, statusCode, err := c.listUsecase.Store(ctxx, userClaims.UserID, listRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "reading list inserted successfully", gin.H{
+		"list": responses.FromDomain(list),
+	})
+}
+
+func (c *ListController) Update(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var listRequest requests.ListRequest
+	if err := ctx.ShouldBindJSON(&listRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	list, statusCode, err := c.listUsecase.Update(ctxx, userClaims.UserID, id, listRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("reading list data with id %d updated successfully", id), gin.H{
+		"list": responses.FromDomain(list),
+	})
+}
+
+func (c *ListController) Delete(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.listUsecase.Delete(ctxx, userClaims.UserID, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("reading list data with id %d deleted successfully", id), nil)
+}
+
+func (c *ListController) AddBook(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var listEntryRequest requests.ListEntryRequest
+	if err := ctx.ShouldBindJSON(&listEntryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	entry, statusCode, err := c.listUsecase.AddBook(ctxx, userClaims.UserID, id, listEntryRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d added to reading list with id %d", entry.BookId, id), gin.H{
+		"entry": responses.FromEntryDomain(entry),
+	})
+}
+
+func (c *ListController) UpdateBook(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	bookId, _ := strconv.Atoi(ctx.Param("book_id"))
+
+	var listEntryUpdateRequest requests.ListEntryUpdateRequest
+	if err := ctx.ShouldBindJSON(&listEntryUpdateRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	entry, statusCode, err := c.listUsecase.UpdateBook(ctxx, userClaims.UserID, id, bookId, listEntryUpdateRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d on reading list with id %d updated successfully", bookId, id), gin.H{
+		"entry": responses.FromEntryDomain(entry),
+	})
+}
+
+func (c *ListController) RemoveBook(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	bookId, _ := strconv.Atoi(ctx.Param("book_id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.listUsecase.RemoveBook(ctxx, userClaims.UserID, id, bookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d removed from reading list with id %d", bookId, id), nil)
+}
+
+func (c *ListController) AddEditor(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	var listEditorRequest requests.ListEditorRequest
+	if err := ctx.ShouldBindJSON(&listEditorRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.listUsecase.AddEditor(ctxx, userClaims.UserID, id, listEditorRequest.UserId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("user with id %d now edits reading list with id %d", listEditorRequest.UserId, id), nil)
+}
+
+func (c *ListController) RemoveEditor(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	editorId, _ := strconv.Atoi(ctx.Param("user_id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.listUsecase.RemoveEditor(ctxx, userClaims.UserID, id, editorId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("user with id %d no longer edits reading list with id %d", editorId, id), nil)
+}
+
+func (c *ListController) Vote(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	votes, statusCode, err := c.listUsecase.Vote(ctxx, userClaims.UserID, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("reading list with id %d upvoted", id), gin.H{
+		"votes": votes,
+	})
+}
+
+func (c *ListController) Unvote(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	votes, statusCode, err := c.listUsecase.Unvote(ctxx, userClaims.UserID, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("upvote on reading list with id %d withdrawn", id), gin.H{
+		"votes": votes,
+	})
+}
@@ -0,0 +1,247 @@
+package lists_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	listMocks "github.com/snykk/golib_backend/datasources/databases/lists/mocks"
+	userMocks "github.com/snykk/golib_backend/datasources/databases/users/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/lists"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/lists"
+	"github.com/snykk/golib_backend/http/controllers/lists/requests"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	listRepository *listMocks.Repository
+	bookRepository *bookMocks.Repository
+	userRepository *userMocks.Repository
+	listUsecase    lists.Usecase
+	listController controllers.ListController
+	s              *gin.Engine
+	listFromDB     lists.Domain
+	bookFromDB     books.Domain
+)
+
+func setup(t *testing.T) {
+	listRepository = listMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	userRepository = userMocks.NewRepository(t)
+	listUsecase = lists.NewListUsecase(listRepository, bookRepository, userRepository)
+	listController = controllers.NewListController(listUsecase)
+
+	listFromDB = lists.Domain{
+		ID:          1,
+		OwnerId:     1,
+		Owner:       "itsmepatrick",
+		Title:       "Best Indonesian novels",
+		Description: "lorem ipsum doler sit amet",
+		Visibility:  constants.ReadingListPublic,
+		Books:       1,
+		Votes:       3,
+		Editors:     []lists.Editor{},
+		CreatedAt:   time.Now(),
+	}
+
+	bookFromDB = books.Domain{
+		ID:        5,
+		Title:     "Laskar Pelangi",
+		Author:    "Andrea Hirata",
+		Publisher: "Bentang Pustaka",
+		ISBN:      "9789793062792",
+		CreatedAt: time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  false,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetAll(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/lists", listController.GetAll)
+	t.Run("When Success Get Public Lists", func(t *testing.T) {
+		listRepository.Mock.On("GetAll", mock.Anything, &lists.Query{Page: 1, Limit: 5, Sort: constants.ReadingListSortRecent}).Return([]lists.Domain{listFromDB}, 6, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/lists?limit=5&sort=recent", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "reading list data fetched successfully")
+		assert.Contains(t, body, `"total_pages":2`)
+		assert.Contains(t, body, `"votes":3`)
+	})
+	t.Run("When Failure Unknown Sort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/lists?sort=title", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/lists/:id", listController.GetById)
+	t.Run("When Success Get List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		listRepository.Mock.On("GetEntries", mock.Anything, 1).Return([]lists.Entry{{ListId: 1, BookId: 5, Book: bookFromDB, Position: 1, Note: "a classic"}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/lists/1", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "reading list data with id 1 fetched successfully")
+		assert.Contains(t, body, `"note":"a classic"`)
+	})
+	t.Run("When Failure List Doesn't Exist", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 9).Return(lists.Domain{}, errors.New("record not found")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/lists/9", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/lists", listController.Store)
+	t.Run("When Success Store List", func(t *testing.T) {
+		listRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*lists.Domain")).Return(listFromDB, nil).Once()
+
+		reqBody, _ := json.Marshal(requests.ListRequest{Title: "Best Indonesian novels"})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/lists", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "reading list inserted successfully")
+	})
+	t.Run("When Failure Unknown Visibility", func(t *testing.T) {
+		reqBody, _ := json.Marshal(requests.ListRequest{Title: "Best Indonesian novels", Visibility: "friends"})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/lists", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestAddBook(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/lists/:id/books", listController.AddBook)
+	t.Run("When Success Add Book", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 5).Return(bookFromDB, nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{}, errors.New("record not found")).Once()
+		listRepository.Mock.On("CountEntries", mock.Anything, 1).Return(0, nil).Once()
+		listRepository.Mock.On("InsertEntry", mock.Anything, mock.AnythingOfType("*lists.Entry")).Return(nil).Once()
+		listRepository.Mock.On("GetEntry", mock.Anything, 1, 5).Return(lists.Entry{ListId: 1, BookId: 5, Book: bookFromDB, Position: 1}, nil).Once()
+
+		reqBody, _ := json.Marshal(requests.ListEntryRequest{BookId: 5})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/lists/1/books", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "book with id 5 added to reading list with id 1")
+	})
+}
+
+func TestVote(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/lists/:id/votes", listController.Vote)
+	t.Run("When Failure Voting Own List", func(t *testing.T) {
+		listRepository.Mock.On("GetById", mock.Anything, 1).Return(listFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/lists/1/votes", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "you can't upvote your own reading list")
+	})
+}
@@ -0,0 +1,5 @@
+package requests
+
+type ListEditorRequest struct {
+	UserId int `json:"user_id" binding:"required,min=1"`
+}
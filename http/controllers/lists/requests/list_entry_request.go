@@ -0,0 +1,29 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/lists"
+
+type ListEntryRequest struct {
+	BookId   int    `json:"book_id" binding:"required,min=1"`
+	Note     string `json:"note"`
+	Position int    `json:"position" binding:"omitempty,min=1"`
+}
+
+func (r *ListEntryRequest) ToDomain() *lists.Entry {
+	return &lists.Entry{
+		BookId:   r.BookId,
+		Note:     r.Note,
+		Position: r.Position,
+	}
+}
+
+type ListEntryUpdateRequest struct {
+	Note     *string `json:"note"`
+	Position *int    `json:"position" binding:"omitempty,min=1"`
+}
+
+func (r *ListEntryUpdateRequest) ToDomain() *lists.EntryPatch {
+	return &lists.EntryPatch{
+		Note:     r.Note,
+		Position: r.Position,
+	}
+}
@@ -0,0 +1,17 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/lists"
+
+type ListQueryRequest struct {
+	Page  int    `form:"page" binding:"omitempty,min=1"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort  string `form:"sort" binding:"omitempty,oneof=votes recent"`
+}
+
+func (q *ListQueryRequest) ToDomain() *lists.Query {
+	return &lists.Query{
+		Page:  q.Page,
+		Limit: q.Limit,
+		Sort:  q.Sort,
+	}
+}
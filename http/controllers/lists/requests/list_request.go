@@ -0,0 +1,17 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/lists"
+
+type ListRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=public unlisted private"`
+}
+
+func (r *ListRequest) ToDomain() *lists.Domain {
+	return &lists.Domain{
+		Title:       r.Title,
+		Description: r.Description,
+		Visibility:  r.Visibility,
+	}
+}
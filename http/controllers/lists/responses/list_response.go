@@ -0,0 +1,91 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/lists"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+type EditorResponse struct {
+	UserId   int       `json:"user_id"`
+	Username string    `json:"username"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+type EntryResponse struct {
+	Position int                  `json:"position"`
+	Book     bookRes.BookResponse `json:"book"`
+	Note     string               `json:"note"`
+	AddedBy  *int                 `json:"added_by"`
+	AddedAt  time.Time            `json:"added_at"`
+}
+
+type ListResponse struct {
+	Id          int              `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Visibility  string           `json:"visibility"`
+	OwnerId     int              `json:"owner_id"`
+	Owner       string           `json:"owner"`
+	Books       int              `json:"books"`
+	Votes       int              `json:"votes"`
+	Editors     []EditorResponse `json:"editors,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+func FromEntryDomain(domain lists.Entry) EntryResponse {
+	return EntryResponse{
+		Position: domain.Position,
+		Book:     bookRes.FromDomain(domain.Book),
+		Note:     domain.Note,
+		AddedBy:  domain.AddedBy,
+		AddedAt:  domain.CreatedAt,
+	}
+}
+
+func FromDomain(domain lists.Domain) ListResponse {
+	response := ListResponse{
+		Id:          domain.ID,
+		Title:       domain.Title,
+		Description: domain.Description,
+		Visibility:  domain.Visibility,
+		OwnerId:     domain.OwnerId,
+		Owner:       domain.Owner,
+		Books:       domain.Books,
+		Votes:       domain.Votes,
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+	}
+
+	for _, editor := range domain.Editors {
+		response.Editors = append(response.Editors, EditorResponse{
+			UserId:   editor.UserId,
+			Username: editor.Username,
+			AddedAt:  editor.CreatedAt,
+		})
+	}
+
+	return response
+}
+
+func ToEntryResponseList(domains []lists.Entry) []EntryResponse {
+	result := make([]EntryResponse, 0, len(domains))
+
+	for _, val := range domains {
+		result = append(result, FromEntryDomain(val))
+	}
+
+	return result
+}
+
+func ToResponseList(domains []lists.Domain) []ListResponse {
+	var result []ListResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
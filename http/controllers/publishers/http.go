@@ -0,0 +1,188 @@
+package publishers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/publishers"
+	"github.com/snykk/golib_backend/http/controllers"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+	"github.com/snykk/golib_backend/http/controllers/publishers/requests"
+	"github.com/snykk/golib_backend/http/controllers/publishers/responses"
+)
+
+type PublisherController struct {
+	publisherUsecase publishers.Usecase
+	ristrettoCache   cache.RistrettoCache
+}
+
+func NewPublisherController(publisherUsecase publishers.Usecase, ristrettoCache cache.RistrettoCache) PublisherController {
+	return PublisherController{
+		publisherUsecase: publisherUsecase,
+		ristrettoCache:   ristrettoCache,
+	}
+}
+
+func (c *PublisherController) Store(ctx *gin.Context) {
+	var publisherRequest requests.PublisherRequest
+	if err := ctx.ShouldBindJSON(&publisherRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	publisher, statusCode, err := c.publisherUsecase.Store(ctxx, publisherRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("publishers")
+
+	controllers.NewSuccessResponse(ctx, statusCode, "publisher inserted successfully", gin.H{
+		"publisher": responses.FromDomain(publisher),
+	})
+}
+
+func (c *PublisherController) GetAll(ctx *gin.Context) {
+	if val := c.ristrettoCache.Get("publishers"); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, "publisher data fetched successfully", gin.H{
+			"publishers": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	listOfPublishers, statusCode, err := c.publisherUsecase.GetAll(ctxx)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	publisherResponses := responses.ToResponseList(listOfPublishers)
+
+	if publisherResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "publisher data is empty", []int{})
+		return
+	}
+
+	go c.ristrettoCache.Set("publishers", publisherResponses)
+
+	controllers.NewSuccessResponse(ctx, statusCode, "publisher data fetched successfully", gin.H{
+		"publishers": publisherResponses,
+	})
+}
+
+func (c *PublisherController) GetById(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if val := c.ristrettoCache.Get(fmt.Sprintf("publisher/%d", id)); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, fmt.Sprintf("publisher data with id %d fetched successfully", id), gin.H{
+			"publisher": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	publisher, statusCode, err := c.publisherUsecase.GetById(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	publisherResponse := responses.FromDomain(publisher)
+
+	go c.ristrettoCache.Set(fmt.Sprintf("publisher/%d", id), publisherResponse)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("publisher data with id %d fetched successfully", id), gin.H{
+		"publisher": publisherResponse,
+	})
+}
+
+func (c *PublisherController) Update(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var publisherRequest requests.PublisherRequest
+	if err := ctx.ShouldBindJSON(&publisherRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	publisher, bookIds, statusCode, err := c.publisherUsecase.Update(ctxx, publisherRequest.ToDomain(), id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	// the publisher text of every book it published changed along with the name
+	keys := []string{"publishers", fmt.Sprintf("publisher/%d", id), "books"}
+	for _, bookId := range bookIds {
+		keys = append(keys, fmt.Sprintf("book/%d", bookId))
+	}
+	go c.ristrettoCache.Del(keys...)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("publisher data with id %d updated successfully", id), gin.H{
+		"publisher": responses.FromDomain(publisher),
+	})
+}
+
+func (c *PublisherController) Delete(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.publisherUsecase.Delete(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("publishers", fmt.Sprintf("publisher/%d", id))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("publisher data with id %d deleted successfully", id), nil)
+}
+
+func (c *PublisherController) GetBooks(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	listOfBooks, statusCode, err := c.publisherUsecase.GetBooks(ctxx, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	bookResponses := bookRes.ToResponseList(listOfBooks)
+
+	if bookResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with publisher id %d is empty", id), []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book data with publisher id %d fetched successfully", id), gin.H{
+		"books": bookResponses,
+	})
+}
+
+func (c *PublisherController) AssignBook(ctx *gin.Context) {
+	bookId, _ := strconv.Atoi(ctx.Param("id"))
+	var bookPublisherRequest requests.BookPublisherRequest
+	if err := ctx.ShouldBindJSON(&bookPublisherRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	book, statusCode, err := c.publisherUsecase.AssignBook(ctxx, bookId, bookPublisherRequest.PublisherId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del("books", fmt.Sprintf("book/%d", bookId))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("publisher of book with id %d updated successfully", bookId), gin.H{
+		"book": bookRes.FromDomain(book),
+	})
+}
@@ -0,0 +1,232 @@
+package publishers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	publisherMocks "github.com/snykk/golib_backend/datasources/databases/publishers/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/publishers"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/publishers"
+	"github.com/snykk/golib_backend/http/controllers/publishers/requests"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	publisherRepository *publisherMocks.Repository
+	bookRepository      *bookMocks.Repository
+	ristrettoMock       *cacheMocks.RistrettoCache
+	publisherUsecase    publishers.Usecase
+	publisherController controllers.PublisherController
+	s                   *gin.Engine
+	publisherFromDB     publishers.Domain
+	bookFromDB          books.Domain
+)
+
+func setup(t *testing.T) {
+	publisherRepository = publisherMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	publisherUsecase = publishers.NewPublisherUsecase(publisherRepository, bookRepository)
+	publisherController = controllers.NewPublisherController(publisherUsecase, ristrettoMock)
+
+	publisherFromDB = publishers.Domain{
+		ID:        1,
+		Name:      "Gramedia",
+		CreatedAt: time.Now(),
+	}
+	bookFromDB = books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		PublisherId: &publisherFromDB.ID,
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  true,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestStore(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/publishers", publisherController.Store)
+	t.Run("When Success Store Publisher", func(t *testing.T) {
+		req := requests.PublisherRequest{
+			Name: publisherFromDB.Name,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		publisherRepository.Mock.On("GetByName", mock.Anything, publisherFromDB.Name).Return(publishers.Domain{}, errors.New("record not found")).Once()
+		publisherRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*publishers.Domain")).Return(publisherFromDB, nil).Once()
+		ristrettoMock.Mock.On("Del", "publishers").Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/publishers", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
+		assert.Contains(t, body, "publisher inserted successfully")
+	})
+	t.Run("When Failure Missing Name", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/publishers", bytes.NewReader([]byte(`{}`)))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestGetById(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/publishers/:id", publisherController.GetById)
+	t.Run("When Success Get Publisher By Id", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		ristrettoMock.Mock.On("Get", fmt.Sprintf("publisher/%d", publisherFromDB.ID)).Return(nil).Once()
+		ristrettoMock.Mock.On("Set", fmt.Sprintf("publisher/%d", publisherFromDB.ID), mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/publishers/%d", publisherFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, publisherFromDB.Name)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/publishers/:id/books", publisherController.GetBooks)
+	t.Run("When Success Get Publisher Books", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("GetBooks", mock.Anything, publisherFromDB.ID).Return([]books.Domain{bookFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/publishers/%d/books", publisherFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, bookFromDB.Title)
+		assert.Contains(t, body, `"publisher_id":1`)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	setup(t)
+	// Define route
+	s.DELETE("/publishers/:id", publisherController.Delete)
+	t.Run("When Failure Publisher Still Has Books", func(t *testing.T) {
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("GetBooks", mock.Anything, publisherFromDB.ID).Return([]books.Domain{bookFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/publishers/%d", publisherFromDB.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "publisher still has 1 books")
+	})
+}
+
+func TestAssignBook(t *testing.T) {
+	setup(t)
+	// Define route
+	s.PUT("/books/:id/publisher", publisherController.AssignBook)
+	t.Run("When Success Assign Book", func(t *testing.T) {
+		req := requests.BookPublisherRequest{
+			PublisherId: publisherFromDB.ID,
+		}
+		reqBody, _ := json.Marshal(req)
+
+		bookRepository.Mock.On("GetById", mock.Anything, bookFromDB.ID).Return(bookFromDB, nil).Twice()
+		publisherRepository.Mock.On("GetById", mock.Anything, publisherFromDB.ID).Return(publisherFromDB, nil).Once()
+		publisherRepository.Mock.On("AssignBook", mock.Anything, bookFromDB.ID, publisherFromDB).Return(nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/books/%d/publisher", bookFromDB.ID), bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "publisher of book with id 1 updated successfully")
+	})
+}
@@ -0,0 +1,17 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/publishers"
+
+type PublisherRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+func (r *PublisherRequest) ToDomain() *publishers.Domain {
+	return &publishers.Domain{
+		Name: r.Name,
+	}
+}
+
+type BookPublisherRequest struct {
+	PublisherId int `json:"publisher_id" binding:"required"`
+}
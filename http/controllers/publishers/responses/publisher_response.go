@@ -0,0 +1,33 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/publishers"
+)
+
+type PublisherResponse struct {
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromDomain(domain publishers.Domain) PublisherResponse {
+	return PublisherResponse{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToResponseList(domains []publishers.Domain) []PublisherResponse {
+	var result []PublisherResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+package rankings
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/rankings"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/rankings/requests"
+	"github.com/snykk/golib_backend/http/controllers/rankings/responses"
+)
+
+type RankingController struct {
+	rankingUsecase rankings.Usecase
+	ristrettoCache cache.RistrettoCache
+}
+
+func NewRankingController(rankingUsecase rankings.Usecase, ristrettoCache cache.RistrettoCache) RankingController {
+	return RankingController{
+		rankingUsecase: rankingUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+func (c *RankingController) GetTop(ctx *gin.Context) {
+	c.getByKind(ctx, constants.RankingTop)
+}
+
+func (c *RankingController) GetTrending(ctx *gin.Context) {
+	c.getByKind(ctx, constants.RankingTrending)
+}
+
+func (c *RankingController) getByKind(ctx *gin.Context, kind string) {
+	var rankingQueryRequest requests.RankingQueryRequest
+	if err := ctx.ShouldBindQuery(&rankingQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cacheKey := rankingQueryRequest.CacheKey(kind)
+	if val := c.ristrettoCache.Get(cacheKey); val != nil {
+		controllers.NewSuccessResponse(ctx, http.StatusOK, fmt.Sprintf("%s books fetched successfully", kind), map[string]interface{}{
+			"books": val,
+		})
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	rankings, statusCode, err := c.rankingUsecase.GetByKind(ctxx, kind, rankingQueryRequest.Limit)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	rankingResponses := responses.ToResponseList(rankings)
+
+	if rankingResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("%s books are empty", kind), []int{})
+		return
+	}
+
+	// rankings only change when the job refreshes them, so a short expiry is enough
+	go c.ristrettoCache.SetWithTTL(cacheKey, rankingResponses, constants.RankingCacheTTL)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("%s books fetched successfully", kind), map[string]interface{}{
+		"books": rankingResponses,
+	})
+}
@@ -0,0 +1,147 @@
+package rankings_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	rankingMocks "github.com/snykk/golib_backend/datasources/databases/rankings/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/rankings"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/rankings"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	rankingRepository *rankingMocks.Repository
+	ristrettoMock     *cacheMocks.RistrettoCache
+	rankingUsecase    rankings.Usecase
+	rankingController controllers.RankingController
+	s                 *gin.Engine
+	rankingsFromDB    []rankings.Ranking
+)
+
+func setup(t *testing.T) {
+	rankingRepository = rankingMocks.NewRepository(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	rankingUsecase = rankings.NewRankingUsecase(rankingRepository, rankings.Options{PriorWeight: 10, TrendingWindow: 7 * 24 * time.Hour})
+	rankingController = controllers.NewRankingController(rankingUsecase, ristrettoMock)
+
+	book := books.Domain{
+		ID:          1,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      new(float64),
+		CreatedAt:   time.Now(),
+	}
+	rankingsFromDB = []rankings.Ranking{
+		{Kind: constants.RankingTop, BookId: 1, Book: book, Score: 8.4666, Reviews: 50, Rank: 1},
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  false,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetTop(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/top", rankingController.GetTop)
+	t.Run("When Success Get Top Books", func(t *testing.T) {
+		ristrettoMock.Mock.On("Get", "books/top/5").Return(nil).Once()
+		rankingRepository.Mock.On("GetByKind", mock.Anything, constants.RankingTop, 5).Return(rankingsFromDB, nil).Once()
+		ristrettoMock.Mock.On("SetWithTTL", "books/top/5", mock.Anything, constants.RankingCacheTTL).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/top?limit=5", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "top books fetched successfully")
+		assert.Contains(t, body, `"score":8.47`)
+		assert.Contains(t, body, `"reviews":50`)
+	})
+	t.Run("When Success From Cache", func(t *testing.T) {
+		ristrettoMock.Mock.On("Get", "books/top/0").Return([]int{1}).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/top", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+}
+
+func TestGetTrending(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/books/trending", rankingController.GetTrending)
+	t.Run("When Success Trending Is Empty", func(t *testing.T) {
+		ristrettoMock.Mock.On("Get", "books/trending/0").Return(nil).Once()
+		rankingRepository.Mock.On("GetByKind", mock.Anything, constants.RankingTrending, constants.DefaultRankingLimit).Return([]rankings.Ranking{}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/trending", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "trending books are empty")
+	})
+	t.Run("When Failure Invalid Limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/books/trending?limit=500", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,11 @@
+package requests
+
+import "fmt"
+
+type RankingQueryRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (r *RankingQueryRequest) CacheKey(kind string) string {
+	return fmt.Sprintf("books/%s/%d", kind, r.Limit)
+}
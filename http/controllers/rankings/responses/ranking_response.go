@@ -0,0 +1,34 @@
+package responses
+
+import (
+	"math"
+
+	"github.com/snykk/golib_backend/domains/rankings"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+type RankingResponse struct {
+	Rank    int                  `json:"rank"`
+	Book    bookRes.BookResponse `json:"book"`
+	Score   float64              `json:"score"`
+	Reviews int                  `json:"reviews"`
+}
+
+func FromDomain(domain rankings.Ranking) RankingResponse {
+	return RankingResponse{
+		Rank:    domain.Rank,
+		Book:    bookRes.FromDomain(domain.Book),
+		Score:   math.Round(domain.Score*100) / 100,
+		Reviews: domain.Reviews,
+	}
+}
+
+func ToResponseList(domains []rankings.Ranking) []RankingResponse {
+	var result []RankingResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package recommendations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/domains/recommendations"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/recommendations/requests"
+	"github.com/snykk/golib_backend/http/controllers/recommendations/responses"
+	"github.com/snykk/golib_backend/http/token"
+)
+
+type RecommendationController struct {
+	recommendationUsecase recommendations.Usecase
+}
+
+func NewRecommendationController(recommendationUsecase recommendations.Usecase) RecommendationController {
+	return RecommendationController{
+		recommendationUsecase: recommendationUsecase,
+	}
+}
+
+func (c *RecommendationController) GetUserRecommendations(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	var recommendationQueryRequest requests.RecommendationQueryRequest
+	if err := ctx.ShouldBindQuery(&recommendationQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	recommendations, source, statusCode, err := c.recommendationUsecase.GetByUserId(ctxx, userClaims.UserID, recommendationQueryRequest.Limit)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	recommendationResponses := responses.ToResponseList(recommendations)
+
+	if recommendationResponses == nil {
+		controllers.NewSuccessResponse(ctx, statusCode, "recommendation data is empty", []int{})
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "recommendation data fetched successfully", map[string]interface{}{
+		"source":          source,
+		"recommendations": recommendationResponses,
+	})
+}
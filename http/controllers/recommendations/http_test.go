@@ -0,0 +1,136 @@
+package recommendations_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	recommendationMocks "github.com/snykk/golib_backend/datasources/databases/recommendations/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/recommendations"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/recommendations"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	recommendationRepository *recommendationMocks.Repository
+	recommendationUsecase    recommendations.Usecase
+	recommendationController controllers.RecommendationController
+	s                        *gin.Engine
+	bookFromDB               books.Domain
+)
+
+func setup(t *testing.T) {
+	recommendationRepository = recommendationMocks.NewRepository(t)
+	recommendationUsecase = recommendations.NewRecommendationUsecase(recommendationRepository)
+	recommendationController = controllers.NewRecommendationController(recommendationUsecase)
+
+	rating := 8.5
+	bookFromDB = books.Domain{
+		ID:          2,
+		Title:       "Atomic Habits",
+		Description: "lorem ipsum doler sit amet",
+		Author:      "James Clear",
+		Publisher:   "Gramedia",
+		ISBN:        "9780735211292",
+		Rating:      &rating,
+		CreatedAt:   time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  false,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetUserRecommendations(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/users/me/recommendations", recommendationController.GetUserRecommendations)
+	t.Run("When Success Get Personalized Recommendations", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetByUserId", mock.Anything, 1, 5).Return([]recommendations.Recommendation{{UserId: 1, BookId: 2, Book: bookFromDB, Score: 9.123, Rank: 1}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/recommendations?limit=5", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"source":"personalized"`)
+		assert.Contains(t, body, `"score":9.12`)
+		assert.Contains(t, body, bookFromDB.Title)
+	})
+	t.Run("When Success Cold Start Falls Back To Top Rated", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetByUserId", mock.Anything, 1, constants.DefaultRecommendationLimit).Return([]recommendations.Recommendation{}, nil).Once()
+		recommendationRepository.Mock.On("GetTopRated", mock.Anything, 1, constants.DefaultRecommendationLimit).Return([]books.Domain{bookFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/recommendations", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"source":"top_rated"`)
+		assert.Contains(t, body, `"score":8.5`)
+	})
+	t.Run("When Failure Invalid Limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/recommendations?limit=500", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+	t.Run("When Failure Database Error", func(t *testing.T) {
+		recommendationRepository.Mock.On("GetByUserId", mock.Anything, 1, 3).Return(nil, errors.New("connection refused")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/recommendations?limit=3", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	})
+}
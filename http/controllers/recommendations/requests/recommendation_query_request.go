@@ -0,0 +1,5 @@
+package requests
+
+type RecommendationQueryRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
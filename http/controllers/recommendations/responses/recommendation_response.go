@@ -0,0 +1,32 @@
+package responses
+
+import (
+	"math"
+
+	"github.com/snykk/golib_backend/domains/recommendations"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+type RecommendationResponse struct {
+	Book  bookRes.BookResponse `json:"book"`
+	Score float64              `json:"score"`
+	Rank  int                  `json:"rank"`
+}
+
+func FromDomain(domain recommendations.Recommendation) RecommendationResponse {
+	return RecommendationResponse{
+		Book:  bookRes.FromDomain(domain.Book),
+		Score: math.Round(domain.Score*100) / 100,
+		Rank:  domain.Rank,
+	}
+}
+
+func ToResponseList(domains []recommendations.Recommendation) []RecommendationResponse {
+	var result []RecommendationResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/snykk/golib_backend/constants"
@@ -16,6 +17,11 @@ import (
 	"github.com/snykk/golib_backend/http/token"
 )
 
+type reviewPage struct {
+	Reviews []responses.ReviewResponse
+	Meta    controllers.CursorMeta
+}
+
 type ReviewController struct {
 	reviewUsecase  reviews.Usecase
 	ristrettoCache cache.RistrettoCache
@@ -55,7 +61,8 @@ func (c *ReviewController) Store(ctx *gin.Context) {
 		return
 	}
 
-	go c.ristrettoCache.Del("reviews", "users", fmt.Sprintf("user/%d", userClaims.UserID), "books", fmt.Sprintf("book/%d", reviewRequest.BookId))
+	c.bumpListGeneration()
+	go c.ristrettoCache.Del("users", fmt.Sprintf("user/%d", userClaims.UserID), "books", fmt.Sprintf("book/%d", reviewRequest.BookId))
 
 	controllers.NewSuccessResponse(ctx, statusCode, "review created successfully", gin.H{
 		"reviews": responses.FromDomain(review),
@@ -63,33 +70,59 @@ func (c *ReviewController) Store(ctx *gin.Context) {
 }
 
 func (c *ReviewController) GetAll(ctx *gin.Context) {
-	if val := c.ristrettoCache.Get("reviews"); val != nil {
-		controllers.NewSuccessResponse(ctx, http.StatusOK, "review data fetched successfully", map[string]interface{}{
-			"reviews": val,
-		})
+	var reviewQueryRequest requests.ReviewQueryRequest
+	if err := ctx.ShouldBindQuery(&reviewQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	ctxx := ctx.Request.Context()
+	query := reviewQueryRequest.ToDomain()
+	query.ApplyDefaults()
+	cacheKey := fmt.Sprintf("reviews?generation=%d&%s", c.listGeneration(), query.CacheKey())
+	if page, ok := c.ristrettoCache.Get(cacheKey).(reviewPage); ok {
+		controllers.NewSuccessResponseWithMeta(ctx, http.StatusOK, "review data fetched successfully", map[string]interface{}{
+			"reviews": page.Reviews,
+		}, page.Meta)
+		return
+	}
 
-	listOfReviews, statusCode, err := c.reviewUsecase.GetAll(ctxx)
+	ctxx := ctx.Request.Context()
+	listOfReviews, nextCursor, statusCode, err := c.reviewUsecase.GetAll(ctxx, query)
 	if err != nil {
 		controllers.NewErrorResponse(ctx, statusCode, err.Error())
 		return
 	}
 
 	reviews := responses.ToResponseList(listOfReviews)
+	meta := controllers.NewCursorMeta(query.Limit, nextCursor)
 
 	if reviews == nil {
-		controllers.NewSuccessResponse(ctx, statusCode, "review data is empty", []int{})
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, "review data is empty", []int{}, meta)
 		return
 	}
 
-	go c.ristrettoCache.Set("reviews", reviews)
+	go c.ristrettoCache.SetWithTTL(cacheKey, reviewPage{Reviews: reviews, Meta: meta}, constants.ReviewListCacheTTL)
 
-	controllers.NewSuccessResponse(ctx, statusCode, "review data fetched successfully", map[string]interface{}{
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, "review data fetched successfully", map[string]interface{}{
 		"reviews": reviews,
-	})
+	}, meta)
+}
+
+// listGeneration reads the generation of the cached listing pages, starting a new one when none was cached yet
+func (c *ReviewController) listGeneration() int64 {
+	if generation, ok := c.ristrettoCache.Get("reviews").(int64); ok {
+		return generation
+	}
+
+	return c.bumpListGeneration()
+}
+
+// bumpListGeneration moves the listing to a new generation after a write, the pages cached under the old one are
+// never read again and expire on their own
+func (c *ReviewController) bumpListGeneration() int64 {
+	generation := time.Now().UnixNano()
+	c.ristrettoCache.Set("reviews", generation)
+	return generation
 }
 
 func (c *ReviewController) GetById(ctx *gin.Context) {
@@ -120,46 +153,60 @@ func (c *ReviewController) GetById(ctx *gin.Context) {
 
 func (c *ReviewController) GetByBookId(ctx *gin.Context) {
 	bookId, _ := strconv.Atoi(ctx.Param("id"))
-	ctxx := ctx.Request.Context()
+	var reviewQueryRequest requests.ReviewQueryRequest
+	if err := ctx.ShouldBindQuery(&reviewQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	reviewsDomain, statusCode, err := c.reviewUsecase.GetByBookId(ctxx, bookId)
+	ctxx := ctx.Request.Context()
+	query := reviewQueryRequest.ToDomain()
+	reviewsDomain, nextCursor, statusCode, err := c.reviewUsecase.GetByBookId(ctxx, bookId, query)
 	if err != nil {
 		controllers.NewErrorResponse(ctx, statusCode, err.Error())
 		return
 	}
 
 	reviews := responses.ToResponseList(reviewsDomain)
+	meta := controllers.NewCursorMeta(query.Limit, nextCursor)
 
 	if reviews == nil {
-		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("review data with book id %d is empty", bookId), []int{})
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("review data with book id %d is empty", bookId), []int{}, meta)
 		return
 	}
 
-	controllers.NewSuccessResponse(ctx, http.StatusOK, fmt.Sprintf("review data with book id %d fetched successfully", bookId), map[string]interface{}{
+	controllers.NewSuccessResponseWithMeta(ctx, http.StatusOK, fmt.Sprintf("review data with book id %d fetched successfully", bookId), map[string]interface{}{
 		"review": reviews,
-	})
+	}, meta)
 }
 
 func (c *ReviewController) GetByUserid(ctx *gin.Context) {
 	userId, _ := strconv.Atoi(ctx.Param("id"))
-	ctxx := ctx.Request.Context()
+	var reviewQueryRequest requests.ReviewQueryRequest
+	if err := ctx.ShouldBindQuery(&reviewQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	reviewsDomain, statusCode, err := c.reviewUsecase.GetByUserId(ctxx, userId)
+	ctxx := ctx.Request.Context()
+	query := reviewQueryRequest.ToDomain()
+	reviewsDomain, nextCursor, statusCode, err := c.reviewUsecase.GetByUserId(ctxx, userId, query)
 	if err != nil {
 		controllers.NewErrorResponse(ctx, statusCode, err.Error())
 		return
 	}
 
 	reviews := responses.ToResponseList(reviewsDomain)
+	meta := controllers.NewCursorMeta(query.Limit, nextCursor)
 
 	if reviews == nil {
-		controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("review data with user id %d is empty", userId), []int{})
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("review data with user id %d is empty", userId), []int{}, meta)
 		return
 	}
 
-	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("review data with user id %d fetched successfully", userId), map[string]interface{}{
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("review data with user id %d fetched successfully", userId), map[string]interface{}{
 		"review": reviews,
-	})
+	}, meta)
 }
 
 func (c *ReviewController) Update(ctx *gin.Context) {
@@ -184,7 +231,8 @@ func (c *ReviewController) Update(ctx *gin.Context) {
 		return
 	}
 
-	go c.ristrettoCache.Del("reviews", fmt.Sprintf("review/%d", review.ID), "users", fmt.Sprintf("user/%d", userClaims.UserID), "books", fmt.Sprintf("book/%d", reviewRequest.BookId))
+	c.bumpListGeneration()
+	go c.ristrettoCache.Del(fmt.Sprintf("review/%d", review.ID), "users", fmt.Sprintf("user/%d", userClaims.UserID), "books", fmt.Sprintf("book/%d", reviewRequest.BookId))
 
 	controllers.NewSuccessResponse(ctx, statusCode, "review updated successfully", gin.H{
 		"reviews": responses.FromDomain(review),
@@ -202,7 +250,51 @@ func (c *ReviewController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	go c.ristrettoCache.Del("reviews", fmt.Sprintf("review/%d", reviewid), "books", fmt.Sprintf("book/%d", userClaims.UserID), "books", fmt.Sprintf("book/%d", bookId))
+	c.bumpListGeneration()
+	go c.ristrettoCache.Del(fmt.Sprintf("review/%d", reviewid), "books", fmt.Sprintf("book/%d", userClaims.UserID), "books", fmt.Sprintf("book/%d", bookId))
 
 	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("review data with id %d deleted successfully", reviewid), nil)
 }
+
+func (c *ReviewController) Vote(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	reviewId, _ := strconv.Atoi(ctx.Param("id"))
+	var reviewVoteRequest requests.ReviewVoteRequest
+	if err := ctx.ShouldBindJSON(&reviewVoteRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	helpfulCount, statusCode, err := c.reviewUsecase.Vote(ctxx, userClaims.UserID, reviewId, reviewVoteRequest.Vote)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.bumpListGeneration()
+	go c.ristrettoCache.Del(fmt.Sprintf("review/%d", reviewId))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("review with id %d voted %s", reviewId, reviewVoteRequest.Vote), gin.H{
+		"helpful_count": helpfulCount,
+	})
+}
+
+func (c *ReviewController) Unvote(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	reviewId, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	helpfulCount, statusCode, err := c.reviewUsecase.Unvote(ctxx, userClaims.UserID, reviewId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.bumpListGeneration()
+	go c.ristrettoCache.Del(fmt.Sprintf("review/%d", reviewId))
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("vote on review with id %d withdrawn", reviewId), gin.H{
+		"helpful_count": helpfulCount,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -119,7 +120,8 @@ func TestStore(t *testing.T) {
 
 		reviewRepository.Mock.On("GetUserReview", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(reviews.Domain{}, errors.New("reviews not found")).Once() // when user does'nt have review yet
 		reviewRepository.Mock.On("Store", mock.Anything, mock.AnythingOfType("*reviews.Domain")).Return(reviewDataFromDB, nil).Once()
-		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		ristrettoMock.Mock.On("Set", "reviews", mock.AnythingOfType("int64")).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodPost, "/reviews", bytes.NewReader(reqBody))
@@ -215,9 +217,11 @@ func TestGetAll(t *testing.T) {
 	s.GET("/reviews", reviewController.GetAll)
 	t.Run("When Success", func(t *testing.T) {
 		t.Run("Fetched review Data", func(t *testing.T) {
-			reviewRepository.Mock.On("GetAll", mock.Anything).Return(reviewsDataFromDB, nil).Once()
+			reviewRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*reviews.Query")).Return(reviewsDataFromDB, nil).Once()
 			ristrettoMock.Mock.On("Get", "reviews").Return(nil).Once()
-			ristrettoMock.Mock.On("Set", "reviews", mock.Anything).Once()
+			ristrettoMock.Mock.On("Set", "reviews", mock.AnythingOfType("int64")).Once()
+			ristrettoMock.Mock.On("Get", mock.MatchedBy(isListingPage)).Return(nil).Once()
+			ristrettoMock.Mock.On("SetWithTTL", mock.MatchedBy(isListingPage), mock.Anything, constants.ReviewListCacheTTL).Once()
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodGet, "/reviews", nil)
@@ -237,8 +241,8 @@ func TestGetAll(t *testing.T) {
 			assert.Contains(t, body, "review data fetched successfully")
 		})
 		t.Run("When Empty Data", func(t *testing.T) {
-			reviewRepository.Mock.On("GetAll", mock.Anything).Return([]reviews.Domain{}, nil).Once()
-			ristrettoMock.Mock.On("Get", "reviews").Return(nil).Once()
+			reviewRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{}, nil).Once()
+			expectListingMiss()
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodGet, "/reviews", nil)
@@ -256,10 +260,78 @@ func TestGetAll(t *testing.T) {
 			assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 			assert.Contains(t, body, "review data is empty")
 		})
+		t.Run("When Next Page Follows", func(t *testing.T) {
+			secondReview := reviewDataFromDB
+			secondReview.ID = 2
+			reviewRepository.Mock.On("GetAll", mock.Anything, mock.MatchedBy(func(query *reviews.Query) bool {
+				return query.Limit == 1 && query.Sort == constants.ReviewSortLowestRating
+			})).Return([]reviews.Domain{reviewDataFromDB, secondReview}, nil).Once()
+			expectListingMiss()
+			ristrettoMock.Mock.On("SetWithTTL", mock.MatchedBy(isListingPage), mock.Anything, constants.ReviewListCacheTTL).Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/reviews?limit=1&sort=lowest_rating", nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			body := w.Body.String()
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+			assert.Contains(t, body, `"has_more":true`)
+			assert.Contains(t, body, `"next_cursor":"`+reviews.CursorOf(constants.ReviewSortLowestRating, reviewDataFromDB).Encode()+`"`)
+		})
+	})
+	t.Run("When Explicit Defaults Share The Omitted Page", func(t *testing.T) {
+		query := reviews.Query{}
+		query.ApplyDefaults()
+		ristrettoMock.Mock.On("Get", "reviews").Return(int64(1)).Once()
+		ristrettoMock.Mock.On("Get", "reviews?generation=1&"+query.CacheKey()).Return(nil).Once()
+		reviewRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*reviews.Query")).Return(reviewsDataFromDB, nil).Once()
+		ristrettoMock.Mock.On("SetWithTTL", "reviews?generation=1&"+query.CacheKey(), mock.Anything, constants.ReviewListCacheTTL).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/reviews?limit=%d&sort=%s", constants.DefaultReviewLimit, constants.DefaultReviewSort), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+	t.Run("When Invalid Query", func(t *testing.T) {
+		for _, query := range []string{"sort=random", "limit=500"} {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/reviews?"+query, nil)
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+		}
+	})
+	t.Run("When Invalid Cursor", func(t *testing.T) {
+		expectListingMiss()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/reviews?cursor=broken", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "cursor is invalid")
 	})
 	t.Run("When Failure", func(t *testing.T) {
-		reviewRepository.Mock.On("GetAll", mock.Anything).Return([]reviews.Domain{}, constants.ErrUnexpected).Once()
-		ristrettoMock.Mock.On("Get", "reviews").Return(nil).Once()
+		reviewRepository.Mock.On("GetAll", mock.Anything, mock.AnythingOfType("*reviews.Query")).Return([]reviews.Domain{}, constants.ErrUnexpected).Once()
+		expectListingMiss()
 
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/reviews", nil)
@@ -277,6 +349,56 @@ func TestGetAll(t *testing.T) {
 
 }
 
+// isListingPage matches the cache key of a listing page
+func isListingPage(key string) bool {
+	return strings.HasPrefix(key, "reviews?generation=")
+}
+
+// expectListingMiss finds the cached listing in generation 1 without the requested page in it
+func expectListingMiss() {
+	ristrettoMock.Mock.On("Get", "reviews").Return(int64(1)).Once()
+	ristrettoMock.Mock.On("Get", mock.MatchedBy(isListingPage)).Return(nil).Once()
+}
+
+func TestGetByBookId(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/reviews/book/:id", reviewController.GetByBookId)
+	t.Run("When Success Fetched Book Reviews", func(t *testing.T) {
+		reviewRepository.Mock.On("GetByBookId", mock.Anything, reviewDataFromDB.BookId, mock.MatchedBy(func(query *reviews.Query) bool {
+			return query.Sort == constants.ReviewSortHighestRating && query.Limit == 5
+		})).Return(reviewsDataFromDB, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/reviews/book/%d?sort=highest_rating&limit=5", reviewDataFromDB.BookId), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"has_more":false`)
+		assert.NotContains(t, body, "next_cursor")
+	})
+	t.Run("When Failure Cursor Of Another Sort", func(t *testing.T) {
+		cursor := reviews.CursorOf(constants.ReviewSortNewest, reviewDataFromDB).Encode()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/reviews/book/%d?sort=oldest&cursor=%s", reviewDataFromDB.BookId, cursor), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "cursor was issued for sort newest")
+	})
+}
+
 func TestGetById(t *testing.T) {
 	setup(t)
 	// Define route
@@ -344,7 +466,8 @@ func TestUpdate(t *testing.T) {
 		reviewRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(reviewDataFromDB, nil).Once() // when user does'nt have review yet
 		reviewRepository.Mock.On("Update", mock.Anything, mock.AnythingOfType("*reviews.Domain")).Return(nil).Once()
 		reviewRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(updatedReview, nil).Once() // when user does'nt have review yet
-		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		ristrettoMock.Mock.On("Set", "reviews", mock.AnythingOfType("int64")).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/reviews/%d", reviewDataFromDB.ID), bytes.NewReader(reqBody))
@@ -441,7 +564,8 @@ func TestDelete(t *testing.T) {
 	t.Run("When Success Delete review Data", func(t *testing.T) {
 		reviewRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(reviewDataFromDB, nil).Once()
 		reviewRepository.Mock.On("Delete", mock.Anything, mock.AnythingOfType("*reviews.Domain")).Return(reviewDataFromDB.BookId, nil).Once()
-		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		ristrettoMock.Mock.On("Set", "reviews", mock.AnythingOfType("int64")).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/reviews/%d", reviewDataFromDB.ID), nil)
@@ -476,3 +600,82 @@ func TestDelete(t *testing.T) {
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 	})
 }
+
+func TestVote(t *testing.T) {
+	setup(t)
+	// Define route
+	s.PUT("/reviews/:id/vote", reviewController.Vote)
+	s.DELETE("/reviews/:id/vote", reviewController.Unvote)
+	otherReview := reviewDataFromDB
+	otherReview.ID = 2
+	otherReview.UserId = userFromDB.ID + 1
+	t.Run("When Success Vote On Review", func(t *testing.T) {
+		reviewRepository.Mock.On("GetById", mock.Anything, otherReview.ID).Return(otherReview, nil).Once()
+		reviewRepository.Mock.On("Vote", mock.Anything, otherReview.ID, userFromDB.ID, 1).Return(4, nil).Once()
+		ristrettoMock.Mock.On("Set", "reviews", mock.AnythingOfType("int64")).Once()
+		ristrettoMock.Mock.On("Del", fmt.Sprintf("review/%d", otherReview.ID))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/reviews/%d/vote", otherReview.ID), bytes.NewBufferString(`{"vote":"up"}`))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"helpful_count":4`)
+	})
+	t.Run("When Success Withdraw Vote", func(t *testing.T) {
+		reviewRepository.Mock.On("GetById", mock.Anything, otherReview.ID).Return(otherReview, nil).Once()
+		reviewRepository.Mock.On("Unvote", mock.Anything, otherReview.ID, userFromDB.ID).Return(3, true, nil).Once()
+		ristrettoMock.Mock.On("Set", "reviews", mock.AnythingOfType("int64")).Once()
+		ristrettoMock.Mock.On("Del", fmt.Sprintf("review/%d", otherReview.ID))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/reviews/%d/vote", otherReview.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), `"helpful_count":3`)
+	})
+	t.Run("When Failure", func(t *testing.T) {
+		t.Run("When Vote Is Invalid", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/reviews/%d/vote", otherReview.ID), bytes.NewBufferString(`{"vote":"meh"}`))
+
+			r.Header.Set("Content-Type", "application/json")
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+		t.Run("When Voting On Own Review", func(t *testing.T) {
+			reviewRepository.Mock.On("GetById", mock.Anything, reviewDataFromDB.ID).Return(reviewDataFromDB, nil).Once()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/reviews/%d/vote", reviewDataFromDB.ID), bytes.NewBufferString(`{"vote":"up"}`))
+
+			r.Header.Set("Content-Type", "application/json")
+
+			// Perform requests
+			s.ServeHTTP(w, r)
+
+			// Assertions
+			// Assert status code
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+			assert.Contains(t, w.Body.String(), "you can't vote on your own review")
+		})
+	})
+}
@@ -0,0 +1,19 @@
+package requests
+
+import (
+	"github.com/snykk/golib_backend/domains/reviews"
+)
+
+type ReviewQueryRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=newest oldest highest_rating lowest_rating most_helpful"`
+	Cursor string `form:"cursor"`
+}
+
+func (q *ReviewQueryRequest) ToDomain() *reviews.Query {
+	return &reviews.Query{
+		Limit:  q.Limit,
+		Sort:   q.Sort,
+		Cursor: q.Cursor,
+	}
+}
@@ -0,0 +1,5 @@
+package requests
+
+type ReviewVoteRequest struct {
+	Vote string `json:"vote" binding:"required,oneof=up down"`
+}
@@ -9,28 +9,30 @@ import (
 )
 
 type ReviewResponse struct {
-	Id        int    `json:"id"`
-	Text      string `json:"text"`
-	Rating    int    `json:"rating"`
-	BookId    int    `json:"book_id"`
-	Book      bookRes.BookResponse
-	UserId    int `json:"user_id"`
-	User      userRes.UserInfoResponse
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id           int    `json:"id"`
+	Text         string `json:"text"`
+	Rating       int    `json:"rating"`
+	HelpfulCount int    `json:"helpful_count"`
+	BookId       int    `json:"book_id"`
+	Book         bookRes.BookResponse
+	UserId       int `json:"user_id"`
+	User         userRes.UserInfoResponse
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 func FromDomain(domain reviews.Domain) ReviewResponse {
 	return ReviewResponse{
-		Id:        domain.ID,
-		Text:      domain.Text,
-		Rating:    domain.Rating,
-		BookId:    domain.BookId,
-		Book:      bookRes.FromDomain(domain.Book),
-		UserId:    domain.UserId,
-		User:      userRes.FromDomainToUserInfo(domain.User),
-		CreatedAt: domain.CreatedAt,
-		UpdatedAt: domain.UpdatedAt,
+		Id:           domain.ID,
+		Text:         domain.Text,
+		Rating:       domain.Rating,
+		HelpfulCount: domain.HelpfulCount,
+		BookId:       domain.BookId,
+		Book:         bookRes.FromDomain(domain.Book),
+		UserId:       domain.UserId,
+		User:         userRes.FromDomainToUserInfo(domain.User),
+		CreatedAt:    domain.CreatedAt,
+		UpdatedAt:    domain.UpdatedAt,
 	}
 }
 
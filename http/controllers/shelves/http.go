@@ -0,0 +1,212 @@
+package shelves
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/shelves"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/shelves/requests"
+	"github.com/snykk/golib_backend/http/controllers/shelves/responses"
+	"github.com/snykk/golib_backend/http/token"
+)
+
+type ShelfController struct {
+	shelfUsecase   shelves.Usecase
+	ristrettoCache cache.RistrettoCache
+}
+
+func NewShelfController(shelfUsecase shelves.Usecase, ristrettoCache cache.RistrettoCache) ShelfController {
+	return ShelfController{
+		shelfUsecase:   shelfUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+// dropProfile forgets the cached profile of the user, it carries their shelf counts
+func (c *ShelfController) dropProfile(userClaims token.JwtCustomClaim) {
+	go c.ristrettoCache.Del(fmt.Sprintf("user/%d", userClaims.UserID), fmt.Sprintf("user/%s", userClaims.Email))
+}
+
+func (c *ShelfController) GetShelves(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	ctxx := ctx.Request.Context()
+	statuses, shelves, statusCode, err := c.shelfUsecase.GetShelves(ctxx, userClaims.UserID)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "shelf data fetched successfully", gin.H{
+		"statuses": statuses,
+		"shelves":  responses.ToResponseList(shelves),
+	})
+}
+
+func (c *ShelfController) GetBooks(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	shelf := ctx.Param("shelf")
+
+	var shelfQueryRequest requests.ShelfQueryRequest
+	if err := ctx.ShouldBindQuery(&shelfQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	query := shelfQueryRequest.ToDomain()
+	entries, total, statusCode, err := c.shelfUsecase.GetBooks(ctxx, userClaims.UserID, shelf, query)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	entryResponses := responses.ToEntryResponseList(entries)
+	meta := controllers.NewPaginationMeta(query.Page, query.Limit, total)
+
+	if entryResponses == nil {
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("shelf %s is empty", shelf), []int{}, meta)
+		return
+	}
+
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, fmt.Sprintf("book data on shelf %s fetched successfully", shelf), gin.H{
+		"books": entryResponses,
+	}, meta)
+}
+
+func (c *ShelfController) SetStatus(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	bookId, _ := strconv.Atoi(ctx.Param("book_id"))
+
+	var shelfEntryRequest requests.ShelfEntryRequest
+	if err := ctx.ShouldBindJSON(&shelfEntryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	entry, statusCode, err := c.shelfUsecase.SetStatus(ctxx, userClaims.UserID, bookId, shelfEntryRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.dropProfile(userClaims)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d moved to %s", bookId, entry.Status), gin.H{
+		"book": responses.FromEntryDomain(entry),
+	})
+}
+
+func (c *ShelfController) RemoveBook(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	bookId, _ := strconv.Atoi(ctx.Param("book_id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.shelfUsecase.RemoveBook(ctxx, userClaims.UserID, bookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	c.dropProfile(userClaims)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d removed from your shelves", bookId), nil)
+}
+
+func (c *ShelfController) CreateShelf(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+
+	var shelfRequest requests.ShelfRequest
+	if err := ctx.ShouldBindJSON(&shelfRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	shelf, statusCode, err := c.shelfUsecase.CreateShelf(ctxx, userClaims.UserID, shelfRequest.Name)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, "shelf created successfully", gin.H{
+		"shelf": responses.FromDomain(shelf),
+	})
+}
+
+func (c *ShelfController) RenameShelf(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("shelf"))
+
+	var shelfRequest requests.ShelfRequest
+	if err := ctx.ShouldBindJSON(&shelfRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	shelf, statusCode, err := c.shelfUsecase.RenameShelf(ctxx, userClaims.UserID, id, shelfRequest.Name)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("shelf with id %d renamed successfully", id), gin.H{
+		"shelf": responses.FromDomain(shelf),
+	})
+}
+
+func (c *ShelfController) DeleteShelf(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("shelf"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.shelfUsecase.DeleteShelf(ctxx, userClaims.UserID, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("shelf with id %d deleted successfully", id), nil)
+}
+
+func (c *ShelfController) AddToShelf(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("shelf"))
+
+	var shelfBookRequest requests.ShelfBookRequest
+	if err := ctx.ShouldBindJSON(&shelfBookRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.shelfUsecase.AddToShelf(ctxx, userClaims.UserID, id, shelfBookRequest.BookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d added to shelf with id %d", shelfBookRequest.BookId, id), nil)
+}
+
+func (c *ShelfController) RemoveFromShelf(ctx *gin.Context) {
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	id, _ := strconv.Atoi(ctx.Param("shelf"))
+	bookId, _ := strconv.Atoi(ctx.Param("book_id"))
+
+	ctxx := ctx.Request.Context()
+	statusCode, err := c.shelfUsecase.RemoveFromShelf(ctxx, userClaims.UserID, id, bookId)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("book with id %d removed from shelf with id %d", bookId, id), nil)
+}
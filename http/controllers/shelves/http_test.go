@@ -0,0 +1,288 @@
+package shelves_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	shelfMocks "github.com/snykk/golib_backend/datasources/databases/shelves/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/shelves"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/shelves"
+	"github.com/snykk/golib_backend/http/controllers/shelves/requests"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	shelfRepository *shelfMocks.Repository
+	bookRepository  *bookMocks.Repository
+	ristrettoMock   *cacheMocks.RistrettoCache
+	shelfUsecase    shelves.Usecase
+	shelfController controllers.ShelfController
+	s               *gin.Engine
+	bookFromDB      books.Domain
+	shelfFromDB     shelves.Shelf
+)
+
+func setup(t *testing.T) {
+	shelfRepository = shelfMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	shelfUsecase = shelves.NewShelfUsecase(shelfRepository, bookRepository)
+	shelfController = controllers.NewShelfController(shelfUsecase, ristrettoMock)
+
+	bookFromDB = books.Domain{
+		ID:        2,
+		Title:     "Atomic Habits",
+		Author:    "James Clear",
+		Publisher: "Gramedia",
+		ISBN:      "9780735211292",
+		CreatedAt: time.Now(),
+	}
+
+	shelfFromDB = shelves.Shelf{
+		ID:        3,
+		UserId:    1,
+		Name:      "Favorites",
+		Books:     2,
+		CreatedAt: time.Now(),
+	}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+
+	// Define routes the way they are registered
+	shelfRoute := s.Group("/users/me/shelves")
+	{
+		shelfRoute.GET("", shelfController.GetShelves)
+		shelfRoute.POST("", shelfController.CreateShelf)
+		shelfRoute.PUT("/books/:book_id", shelfController.SetStatus)
+		shelfRoute.DELETE("/books/:book_id", shelfController.RemoveBook)
+		shelfRoute.GET("/:shelf", shelfController.GetBooks)
+		shelfRoute.PUT("/:shelf", shelfController.RenameShelf)
+		shelfRoute.DELETE("/:shelf", shelfController.DeleteShelf)
+		shelfRoute.POST("/:shelf/books", shelfController.AddToShelf)
+		shelfRoute.DELETE("/:shelf/books/:book_id", shelfController.RemoveFromShelf)
+	}
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  false,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetShelves(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Shelves", func(t *testing.T) {
+		shelfRepository.Mock.On("CountStatuses", mock.Anything, 1).Return(map[string]int{constants.ShelfWantToRead: 2, constants.ShelfReading: 1, constants.ShelfRead: 0}, nil).Once()
+		shelfRepository.Mock.On("GetShelves", mock.Anything, 1).Return([]shelves.Shelf{shelfFromDB}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/shelves", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "shelf data fetched successfully")
+		assert.Contains(t, body, `"want_to_read":2`)
+		assert.Contains(t, body, `"name":"Favorites"`)
+	})
+}
+
+func TestGetBooks(t *testing.T) {
+	setup(t)
+	t.Run("When Success Get Books On A Status Shelf", func(t *testing.T) {
+		total := 200
+		page := 50
+		entries := []shelves.Entry{{UserId: 1, BookId: 2, Book: bookFromDB, Status: constants.ShelfReading, CurrentPage: &page, TotalPages: &total}}
+		shelfRepository.Mock.On("GetEntries", mock.Anything, 1, constants.ShelfReading, &shelves.Query{Page: 1, Limit: 5}).Return(entries, 6, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/shelves/reading?limit=5", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "book data on shelf reading fetched successfully")
+		assert.Contains(t, body, `"progress":25`)
+		assert.Contains(t, body, `"total_pages":200`)
+	})
+	t.Run("When Success Shelf Is Empty", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		shelfRepository.Mock.On("GetShelfBooks", mock.Anything, 3, &shelves.Query{Page: 1, Limit: constants.DefaultShelfLimit}).Return([]shelves.Entry{}, 0, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/shelves/3", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "shelf 3 is empty")
+	})
+	t.Run("When Failure Unknown Shelf", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/users/me/shelves/finished", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestSetStatus(t *testing.T) {
+	setup(t)
+	t.Run("When Success Set Status", func(t *testing.T) {
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("GetEntry", mock.Anything, 1, 2).Return(shelves.Entry{}, errors.New("record not found")).Once()
+		shelfRepository.Mock.On("SaveEntry", mock.Anything, mock.AnythingOfType("*shelves.Entry")).Return(shelves.Entry{UserId: 1, BookId: 2, Book: bookFromDB, Status: constants.ShelfWantToRead}, nil).Once()
+		ristrettoMock.Mock.On("Del", "user/1", "user/najibfikri13@gmail.com").Maybe()
+
+		reqBody, _ := json.Marshal(requests.ShelfEntryRequest{Status: constants.ShelfWantToRead})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/users/me/shelves/books/2", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "book with id 2 moved to want_to_read")
+	})
+	t.Run("When Failure Invalid Status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/users/me/shelves/books/2", bytes.NewReader([]byte(`{"status":"abandoned"}`)))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestCreateShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Create Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelfByName", mock.Anything, 1, "Classics").Return(shelves.Shelf{}, errors.New("record not found")).Once()
+		shelfRepository.Mock.On("StoreShelf", mock.Anything, &shelves.Shelf{UserId: 1, Name: "Classics"}).Return(shelves.Shelf{ID: 4, UserId: 1, Name: "Classics"}, nil).Once()
+
+		reqBody, _ := json.Marshal(requests.ShelfRequest{Name: "Classics"})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users/me/shelves", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "shelf created successfully")
+	})
+	t.Run("When Failure Name Is Taken", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelfByName", mock.Anything, 1, "Favorites").Return(shelfFromDB, nil).Once()
+
+		reqBody, _ := json.Marshal(requests.ShelfRequest{Name: "Favorites"})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users/me/shelves", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+}
+
+func TestAddToShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Success Add Book To Shelf", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 3).Return(shelfFromDB, nil).Once()
+		bookRepository.Mock.On("GetById", mock.Anything, 2).Return(bookFromDB, nil).Once()
+		shelfRepository.Mock.On("AddShelfBook", mock.Anything, 3, 2).Return(nil).Once()
+
+		reqBody, _ := json.Marshal(requests.ShelfBookRequest{BookId: 2})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/users/me/shelves/3/books", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "book with id 2 added to shelf with id 3")
+	})
+}
+
+func TestRemoveFromShelf(t *testing.T) {
+	setup(t)
+	t.Run("When Failure Shelf Doesn't Exist", func(t *testing.T) {
+		shelfRepository.Mock.On("GetShelf", mock.Anything, 1, 7).Return(shelves.Shelf{}, errors.New("record not found")).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, "/users/me/shelves/7/books/2", nil)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,5 @@
+package requests
+
+type ShelfBookRequest struct {
+	BookId int `json:"book_id" binding:"required,min=1"`
+}
@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/shelves"
+)
+
+type ShelfEntryRequest struct {
+	Status      string     `json:"status" binding:"required,oneof=want_to_read reading read"`
+	StartedAt   *time.Time `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	CurrentPage *int       `json:"current_page" binding:"omitempty,min=0"`
+	TotalPages  *int       `json:"total_pages" binding:"omitempty,min=1"`
+}
+
+func (r *ShelfEntryRequest) ToDomain() *shelves.Entry {
+	return &shelves.Entry{
+		Status:      r.Status,
+		StartedAt:   r.StartedAt,
+		FinishedAt:  r.FinishedAt,
+		CurrentPage: r.CurrentPage,
+		TotalPages:  r.TotalPages,
+	}
+}
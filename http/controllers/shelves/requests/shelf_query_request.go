@@ -0,0 +1,15 @@
+package requests
+
+import "github.com/snykk/golib_backend/domains/shelves"
+
+type ShelfQueryRequest struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (q *ShelfQueryRequest) ToDomain() *shelves.Query {
+	return &shelves.Query{
+		Page:  q.Page,
+		Limit: q.Limit,
+	}
+}
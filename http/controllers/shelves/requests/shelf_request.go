@@ -0,0 +1,5 @@
+package requests
+
+type ShelfRequest struct {
+	Name string `json:"name" binding:"required"`
+}
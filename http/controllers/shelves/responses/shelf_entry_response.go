@@ -0,0 +1,55 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/shelves"
+	bookRes "github.com/snykk/golib_backend/http/controllers/books/responses"
+)
+
+type ShelfEntryResponse struct {
+	Book        bookRes.BookResponse `json:"book"`
+	Status      string               `json:"status,omitempty"`
+	StartedAt   *time.Time           `json:"started_at"`
+	FinishedAt  *time.Time           `json:"finished_at"`
+	CurrentPage int                  `json:"current_page"`
+	TotalPages  *int                 `json:"total_pages"`
+	Progress    *float64             `json:"progress"`
+	AddedAt     time.Time            `json:"added_at"`
+	UpdatedAt   *time.Time           `json:"updated_at,omitempty"`
+}
+
+func FromEntryDomain(domain shelves.Entry) ShelfEntryResponse {
+	response := ShelfEntryResponse{
+		Book:       bookRes.FromDomain(domain.Book),
+		Status:     domain.Status,
+		StartedAt:  domain.StartedAt,
+		FinishedAt: domain.FinishedAt,
+		TotalPages: domain.TotalPages,
+		AddedAt:    domain.CreatedAt,
+	}
+	if domain.CurrentPage != nil {
+		response.CurrentPage = *domain.CurrentPage
+	}
+	if !domain.UpdatedAt.IsZero() {
+		response.UpdatedAt = &domain.UpdatedAt
+	}
+
+	// progress is the percentage of pages read, left null while total_pages is unknown
+	if domain.TotalPages != nil && *domain.TotalPages > 0 {
+		progress := float64(response.CurrentPage*10000/(*domain.TotalPages)) / 100
+		response.Progress = &progress
+	}
+
+	return response
+}
+
+func ToEntryResponseList(domains []shelves.Entry) []ShelfEntryResponse {
+	var result []ShelfEntryResponse
+
+	for _, val := range domains {
+		result = append(result, FromEntryDomain(val))
+	}
+
+	return result
+}
@@ -0,0 +1,35 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/shelves"
+)
+
+type ShelfResponse struct {
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	Books     int       `json:"books"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromDomain(domain shelves.Shelf) ShelfResponse {
+	return ShelfResponse{
+		Id:        domain.ID,
+		Name:      domain.Name,
+		Books:     domain.Books,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func ToResponseList(domains []shelves.Shelf) []ShelfResponse {
+	result := make([]ShelfResponse, 0, len(domains))
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
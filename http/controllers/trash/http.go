@@ -0,0 +1,105 @@
+package trash
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/datasources/cache"
+	"github.com/snykk/golib_backend/domains/trash"
+	"github.com/snykk/golib_backend/http/controllers"
+	"github.com/snykk/golib_backend/http/controllers/trash/requests"
+	"github.com/snykk/golib_backend/http/controllers/trash/responses"
+)
+
+type TrashController struct {
+	trashUsecase   trash.Usecase
+	ristrettoCache cache.RistrettoCache
+}
+
+func NewTrashController(trashUsecase trash.Usecase, ristrettoCache cache.RistrettoCache) TrashController {
+	return TrashController{
+		trashUsecase:   trashUsecase,
+		ristrettoCache: ristrettoCache,
+	}
+}
+
+func (c *TrashController) GetAll(ctx *gin.Context) {
+	var trashQueryRequest requests.TrashQueryRequest
+	if err := ctx.ShouldBindQuery(&trashQueryRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	query := trashQueryRequest.ToDomain()
+	items, total, statusCode, err := c.trashUsecase.GetAll(ctxx, query)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	trashResponses := responses.ToResponseList(items)
+	meta := controllers.NewPaginationMeta(query.Page, query.Limit, total)
+
+	if trashResponses == nil {
+		controllers.NewSuccessResponseWithMeta(ctx, statusCode, "trash is empty", []int{}, meta)
+		return
+	}
+
+	controllers.NewSuccessResponseWithMeta(ctx, statusCode, "trash data fetched successfully", map[string]interface{}{
+		"items": trashResponses,
+	}, meta)
+}
+
+func (c *TrashController) Restore(ctx *gin.Context) {
+	itemType := ctx.Param("type")
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	item, statusCode, err := c.trashUsecase.Restore(ctxx, itemType, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del(cacheKeys(item)...)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("%s with id %d restored successfully", strings.TrimSuffix(item.Type, "s"), id), map[string]interface{}{
+		"item": responses.FromDomain(item),
+	})
+}
+
+func (c *TrashController) Purge(ctx *gin.Context) {
+	itemType := ctx.Param("type")
+	id, _ := strconv.Atoi(ctx.Param("id"))
+
+	ctxx := ctx.Request.Context()
+	item, statusCode, err := c.trashUsecase.Purge(ctxx, itemType, id)
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	go c.ristrettoCache.Del(cacheKeys(item)...)
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("%s with id %d purged successfully", strings.TrimSuffix(item.Type, "s"), id), nil)
+}
+
+// cacheKeys lists what a restored or purged item shows up in, ratings and review counters included
+func cacheKeys(item trash.Item) []string {
+	keys := []string{"books", "users", "reviews"}
+	switch item.Type {
+	case constants.TrashBooks:
+		keys = append(keys, fmt.Sprintf("book/%d", item.ID))
+	case constants.TrashUsers:
+		keys = append(keys, fmt.Sprintf("user/%d", item.ID))
+	case constants.TrashReviews:
+		keys = append(keys, fmt.Sprintf("review/%d", item.ID), fmt.Sprintf("book/%d", item.BookId), fmt.Sprintf("user/%d", item.UserId))
+	}
+
+	return keys
+}
@@ -0,0 +1,183 @@
+package trash_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/snykk/golib_backend/config"
+	"github.com/snykk/golib_backend/constants"
+	cacheMocks "github.com/snykk/golib_backend/datasources/cache/mocks"
+	bookMocks "github.com/snykk/golib_backend/datasources/databases/books/mocks"
+	trashMocks "github.com/snykk/golib_backend/datasources/databases/trash/mocks"
+	storageMocks "github.com/snykk/golib_backend/datasources/storage/mocks"
+	"github.com/snykk/golib_backend/domains/books"
+	"github.com/snykk/golib_backend/domains/trash"
+	"github.com/snykk/golib_backend/helpers"
+	controllers "github.com/snykk/golib_backend/http/controllers/trash"
+	"github.com/snykk/golib_backend/http/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	trashRepository *trashMocks.Repository
+	bookRepository  *bookMocks.Repository
+	blobStorage     *storageMocks.BlobStorage
+	ristrettoMock   *cacheMocks.RistrettoCache
+	trashUsecase    trash.Usecase
+	trashController controllers.TrashController
+	s               *gin.Engine
+	bookItem        trash.Item
+	reviewItem      trash.Item
+)
+
+func setup(t *testing.T) {
+	trashRepository = trashMocks.NewRepository(t)
+	bookRepository = bookMocks.NewRepository(t)
+	blobStorage = storageMocks.NewBlobStorage(t)
+	ristrettoMock = cacheMocks.NewRistrettoCache(t)
+	trashUsecase = trash.NewTrashUsecase(trashRepository, bookRepository, blobStorage)
+	trashController = controllers.NewTrashController(trashUsecase, ristrettoMock)
+
+	deletedAt := time.Now().Add(-time.Hour)
+	bookItem = trash.Item{Type: constants.TrashBooks, ID: 1, Label: "Atomic Habits", ISBN: "9780735211292", DeletedAt: deletedAt}
+	reviewItem = trash.Item{Type: constants.TrashReviews, ID: 3, Label: "great book", BookId: 1, UserId: 2, DeletedAt: deletedAt}
+
+	// Create gin engine
+	s = gin.Default()
+	s.Use(lazyAuth)
+}
+
+func lazyAuth(ctx *gin.Context) {
+	// hash
+	pass, _ := helpers.GenerateHash("11111")
+	// prepare claims
+	jwtClaims := token.JwtCustomClaim{
+		UserID:   1,
+		IsAdmin:  true,
+		Email:    "najibfikri13@gmail.com",
+		Password: pass,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpired)).Unix(),
+			Issuer:    "itsmepatrick",
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	ctx.Set(constants.CtxAuthenticatedUserKey, jwtClaims)
+}
+
+func TestGetAll(t *testing.T) {
+	setup(t)
+	// Define route
+	s.GET("/admin/trash", trashController.GetAll)
+	t.Run("When Success Get Trash", func(t *testing.T) {
+		trashRepository.Mock.On("GetAll", mock.Anything, &trash.Query{Page: 1, Limit: constants.DefaultTrashLimit}).Return([]trash.Item{reviewItem, bookItem}, 2, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/admin/trash", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, `"type":"reviews"`)
+		assert.Contains(t, body, `"isbn":"9780735211292"`)
+		assert.Contains(t, body, `"total_items":2`)
+	})
+	t.Run("When Failure Unknown Type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/admin/trash?type=copies", nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	setup(t)
+	// Define route
+	s.POST("/admin/trash/:type/:id/restore", trashController.Restore)
+	t.Run("When Success Restore Review", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashReviews, reviewItem.ID).Return(reviewItem, nil).Once()
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, reviewItem.BookId).Return(trash.Item{}, errors.New("record not found")).Once()
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashUsers, reviewItem.UserId).Return(trash.Item{}, errors.New("record not found")).Once()
+		trashRepository.Mock.On("Restore", mock.Anything, &reviewItem).Return(nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/trash/reviews/%d/restore", reviewItem.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "review with id 3 restored successfully")
+	})
+	t.Run("When Failure ISBN Taken", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, bookItem.ID).Return(bookItem, nil).Once()
+		bookRepository.Mock.On("GetByISBN", mock.Anything, bookItem.ISBN).Return(books.Domain{ID: 5}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/trash/books/%d/restore", bookItem.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+}
+
+func TestPurge(t *testing.T) {
+	setup(t)
+	// Define route
+	s.DELETE("/admin/trash/:type/:id", trashController.Purge)
+	t.Run("When Success Purge Book", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, bookItem.ID).Return(bookItem, nil).Once()
+		trashRepository.Mock.On("CountCirculations", mock.Anything, &bookItem).Return(0, nil).Once()
+		trashRepository.Mock.On("Purge", mock.Anything, &bookItem).Return(nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/trash/books/%d", bookItem.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "purged successfully")
+	})
+	t.Run("When Failure Book Has Copies", func(t *testing.T) {
+		trashRepository.Mock.On("GetById", mock.Anything, constants.TrashBooks, bookItem.ID).Return(bookItem, nil).Once()
+		trashRepository.Mock.On("CountCirculations", mock.Anything, &bookItem).Return(3, nil).Once()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/trash/books/%d", bookItem.ID), nil)
+
+		// Perform requests
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusConflict, w.Result().StatusCode)
+	})
+}
@@ -0,0 +1,19 @@
+package requests
+
+import (
+	"github.com/snykk/golib_backend/domains/trash"
+)
+
+type TrashQueryRequest struct {
+	Type  string `form:"type" binding:"omitempty,oneof=books users reviews"`
+	Page  int    `form:"page" binding:"omitempty,min=1"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (q *TrashQueryRequest) ToDomain() *trash.Query {
+	return &trash.Query{
+		Type:  q.Type,
+		Page:  q.Page,
+		Limit: q.Limit,
+	}
+}
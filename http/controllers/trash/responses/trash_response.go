@@ -0,0 +1,39 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/snykk/golib_backend/domains/trash"
+)
+
+type TrashResponse struct {
+	Type      string    `json:"type"`
+	Id        int       `json:"id"`
+	Label     string    `json:"label"`
+	ISBN      string    `json:"isbn,omitempty"`
+	BookId    int       `json:"book_id,omitempty"`
+	UserId    int       `json:"user_id,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func FromDomain(domain trash.Item) TrashResponse {
+	return TrashResponse{
+		Type:      domain.Type,
+		Id:        domain.ID,
+		Label:     domain.Label,
+		ISBN:      domain.ISBN,
+		BookId:    domain.BookId,
+		UserId:    domain.UserId,
+		DeletedAt: domain.DeletedAt,
+	}
+}
+
+func ToResponseList(domains []trash.Item) []TrashResponse {
+	var result []TrashResponse
+
+	for _, val := range domains {
+		result = append(result, FromDomain(val))
+	}
+
+	return result
+}
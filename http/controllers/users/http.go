@@ -185,6 +185,38 @@ func (c *UserController) Update(ctx *gin.Context) {
 	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("user data with id %d updated successfully", userClaims.UserID), responses.FromDomain(userDomainn))
 }
 
+func (c *UserController) Patch(ctx *gin.Context) {
+	if ctx.ContentType() != constants.MergePatchContentType {
+		ctx.Header("Accept-Patch", constants.MergePatchContentType)
+		controllers.NewErrorResponse(ctx, http.StatusUnsupportedMediaType, fmt.Sprintf("content type must be %s", constants.MergePatchContentType))
+		return
+	}
+
+	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
+	var userPatchRequest request.UserPatchRequest
+
+	if err := helpers.DecodeMergePatch(ctx.Request.Body, &userPatchRequest); err != nil {
+		controllers.NewErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctxx := ctx.Request.Context()
+	userDomain, changed, statusCode, err := c.usecase.Patch(ctxx, userClaims.UserID, userPatchRequest.ToDomain())
+	if err != nil {
+		controllers.NewErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+
+	if len(changed) > 0 {
+		go c.ristrettoCache.Del("users", fmt.Sprintf("user/%d", userClaims.UserID))
+	}
+
+	controllers.NewSuccessResponse(ctx, statusCode, fmt.Sprintf("user data with id %d patched successfully", userClaims.UserID), map[string]interface{}{
+		"user":    responses.FromDomain(userDomain),
+		"changed": changed,
+	})
+}
+
 func (c *UserController) Delete(ctx *gin.Context) {
 	userClaims := ctx.MustGet(constants.CtxAuthenticatedUserKey).(token.JwtCustomClaim)
 	ctxx := ctx.Request.Context()
@@ -310,6 +310,7 @@ func TestGetById(t *testing.T) {
 	id := 1
 	t.Run("When Success Fetched User Data By Id", func(t *testing.T) {
 		userRepository.Mock.On("GetById", mock.Anything, mock.AnythingOfType("int")).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("CountShelves", mock.Anything, userDataFromDB.ID).Return(map[string]int{constants.ShelfWantToRead: 2, constants.ShelfReading: 1, constants.ShelfRead: 0}, nil).Once()
 		ristrettoMock.Mock.On("Get", fmt.Sprintf("user/%d", id)).Return(nil).Once()
 		ristrettoMock.Mock.On("Set", fmt.Sprintf("user/%d", id), mock.Anything).Once()
 
@@ -329,6 +330,7 @@ func TestGetById(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 		assert.Contains(t, body, fmt.Sprintf("user data with id %d fetched successfully", id))
+		assert.Contains(t, body, `"want_to_read":2`)
 	})
 	t.Run("When Failure Fetched Users Data", func(t *testing.T) {
 		ristrettoMock.Mock.On("Get", fmt.Sprintf("user/%d", id)).Return(nil).Once()
@@ -357,6 +359,7 @@ func TestGetUserData(t *testing.T) {
 	emailUserAuthenticated := userDataFromDB.Email
 	t.Run("When Success Fetched User Data", func(t *testing.T) {
 		userRepository.Mock.On("GetByEmail", mock.Anything, mock.AnythingOfType("*users.Domain")).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("CountShelves", mock.Anything, userDataFromDB.ID).Return(map[string]int{constants.ShelfWantToRead: 0, constants.ShelfReading: 1, constants.ShelfRead: 3}, nil).Once()
 		ristrettoMock.Mock.On("Get", fmt.Sprintf("user/%s", emailUserAuthenticated)).Return(nil).Once()
 		ristrettoMock.Mock.On("Set", fmt.Sprintf("user/%s", emailUserAuthenticated), mock.Anything).Once()
 
@@ -376,6 +379,7 @@ func TestGetUserData(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 		assert.Contains(t, w.Result().Header.Get("Content-Type"), "application/json")
 		assert.Contains(t, body, "user data fetched successfully")
+		assert.Contains(t, body, `"read":3`)
 	})
 
 	t.Run("When Failure Fetched User Data", func(t *testing.T) {
@@ -458,6 +462,67 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestPatch(t *testing.T) {
+	setup(t)
+	// Define route
+	s.PATCH("/users", userController.Patch)
+	t.Run("When Success Patch User Data", func(t *testing.T) {
+		reqBody := []byte(`{"fullname":"patrick star edited"}`)
+
+		patched := userDataFromDB
+		patched.FullName = "patrick star edited"
+
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("GetByUsername", mock.Anything, userDataFromDB.Username).Return(userDataFromDB, nil).Once()
+		userRepository.Mock.On("Patch", mock.Anything, mock.AnythingOfType("*users.Domain"), []string{"fullname"}).Return(nil).Once()
+		userRepository.Mock.On("GetById", mock.Anything, userDataFromDB.ID).Return(patched, nil).Once()
+		ristrettoMock.Mock.On("Del", mock.Anything, mock.Anything).Maybe()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, "/users", bytes.NewReader(reqBody))
+
+		r.Header.Set("Content-Type", constants.MergePatchContentType)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		body := w.Body.String()
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, body, "patched successfully")
+		assert.Contains(t, body, `"changed":["fullname"]`)
+	})
+	t.Run("When Failure Wrong Content Type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, "/users", bytes.NewReader([]byte(`{"fullname":"patrick"}`)))
+
+		r.Header.Set("Content-Type", "application/json")
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Result().StatusCode)
+		assert.Equal(t, constants.MergePatchContentType, w.Result().Header.Get("Accept-Patch"))
+	})
+	t.Run("When Failure Unknown Field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPatch, "/users", bytes.NewReader([]byte(`{"email":"patrick@gmail.com"}`)))
+
+		r.Header.Set("Content-Type", constants.MergePatchContentType)
+
+		// Perform request
+		s.ServeHTTP(w, r)
+
+		// Assertions
+		// Assert status code
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	setup(t)
 	// Define route
@@ -0,0 +1,20 @@
+package request
+
+import (
+	users "github.com/snykk/golib_backend/domains/users"
+	"github.com/snykk/golib_backend/helpers"
+)
+
+type UserPatchRequest struct {
+	FullName helpers.PatchString `json:"fullname"`
+	Username helpers.PatchString `json:"username"`
+	Gender   helpers.PatchString `json:"gender"`
+}
+
+func (user UserPatchRequest) ToDomain() *users.Patch {
+	return &users.Patch{
+		FullName: user.FullName.Ptr(),
+		Username: user.Username.Ptr(),
+		Gender:   user.Gender.Ptr(),
+	}
+}
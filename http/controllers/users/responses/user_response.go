@@ -7,17 +7,18 @@ import (
 )
 
 type UserResponse struct {
-	Id        int       `json:"id"`
-	FullName  string    `json:"fullname"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	Gender    string    `json:"gender"`
-	Password  string    `json:"password,omitempty"`
-	Reviews   int       `json:"reviews"`
-	Token     string    `json:"token,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id        int            `json:"id"`
+	FullName  string         `json:"fullname"`
+	Username  string         `json:"username"`
+	Email     string         `json:"email"`
+	Role      string         `json:"role"`
+	Gender    string         `json:"gender"`
+	Password  string         `json:"password,omitempty"`
+	Reviews   int            `json:"reviews"`
+	Shelves   map[string]int `json:"shelves,omitempty"`
+	Token     string         `json:"token,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
 }
 
 func (u *UserResponse) ToDomain() users.Domain {
@@ -29,6 +30,7 @@ func (u *UserResponse) ToDomain() users.Domain {
 		Role:      u.Role,
 		Gender:    u.Gender,
 		Reviews:   u.Reviews,
+		Shelves:   u.Shelves,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -44,6 +46,7 @@ func FromDomain(u users.Domain) UserResponse {
 		Gender:    u.Gender,
 		Password:  u.Password,
 		Reviews:   u.Reviews,
+		Shelves:   u.Shelves,
 		Token:     u.Token,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/snykk/golib_backend/constants"
+	"github.com/snykk/golib_backend/helpers"
 	"github.com/snykk/golib_backend/http/controllers"
 	"github.com/snykk/golib_backend/http/token"
 )
@@ -51,5 +52,6 @@ func (m *AuthMiddleware) Handle(ctx *gin.Context) {
 	}
 
 	ctx.Set(constants.CtxAuthenticatedUserKey, user)
+	ctx.Request = ctx.Request.WithContext(helpers.WithActor(ctx.Request.Context(), user.UserID))
 	ctx.Next()
 }
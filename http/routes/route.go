@@ -14,15 +14,28 @@ type Base struct {
 }
 
 type Routes struct {
-	Auth    map[string]string `json:"auth"`
-	Users   map[string]string `json:"users"`
-	Books   map[string]string `json:"books"`
-	Reviews map[string]string `json:"reviews"`
+	Home         map[string]string `json:"home"`
+	Auth         map[string]string `json:"auth"`
+	Users        map[string]string `json:"users"`
+	Books        map[string]string `json:"books"`
+	Reviews      map[string]string `json:"reviews"`
+	Lists        map[string]string `json:"lists"`
+	Circulations map[string]string `json:"circulations"`
+	Authors      map[string]string `json:"authors"`
+	Publishers   map[string]string `json:"publishers"`
+	Categories   map[string]string `json:"categories"`
+	Tags         map[string]string `json:"tags"`
+	Trash        map[string]string `json:"trash"`
+	Collections  map[string]string `json:"collections"`
+	Feeds        map[string]string `json:"feeds"`
 }
 
 func RootHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, Base{
 		Routes: Routes{
+			Home: map[string]string{
+				"home screen [GET]": "/home (featured collections, newest and top rated books)",
+			},
 			Auth: map[string]string{
 				"login [POST]":     "/auth/login",
 				"regis [POST]":     "/auth/regis",
@@ -30,29 +43,141 @@ func RootHandler(ctx *gin.Context) {
 				"verif OTP [POST]": "/auth/verif-otp",
 			},
 			Users: map[string]string{
-				"get all users [GET] <CommonTokenJWT>":    "/users",
-				"get user by id [GET] <CommonTokenJWT>":   "/users/:id",
-				"get user data [GET] <CommonTokenJWT>":    "/users/me",
-				"update user data [PUT] <CommonTokenJWT>": "/users",
-				"delete user [DELETE] <CommonTokenJWT>":   "/users",
-				"change email [POST] <CommonTokenJWT>":    "/users/change-email",
-				"change password [POST] <CommonTokenJWT>": "/users/change-password",
+				"get all users [GET] <CommonTokenJWT>":             "/users",
+				"get user by id [GET] <CommonTokenJWT>":            "/users/:id",
+				"get user data [GET] <CommonTokenJWT>":             "/users/me",
+				"update user data [PUT] <CommonTokenJWT>":          "/users",
+				"patch user data [PATCH] <CommonTokenJWT>":         "/users (application/merge-patch+json)",
+				"delete user [DELETE] <CommonTokenJWT>":            "/users",
+				"change email [POST] <CommonTokenJWT>":             "/users/change-email",
+				"change password [POST] <CommonTokenJWT>":          "/users/change-password",
+				"get recommendations [GET] <CommonTokenJWT>":       "/users/me/recommendations?limit= (personalized, or top rated books for new readers)",
+				"get shelves [GET] <CommonTokenJWT>":               "/users/me/shelves (status counts and custom shelves)",
+				"get shelf books [GET] <CommonTokenJWT>":           "/users/me/shelves/:shelf?page=&limit= (want_to_read, reading, read or a custom shelf id)",
+				"set reading status [PUT] <CommonTokenJWT>":        "/users/me/shelves/books/:book_id (status, started_at, finished_at, current_page, total_pages)",
+				"remove reading status [DELETE] <CommonTokenJWT>":  "/users/me/shelves/books/:book_id",
+				"create shelf [POST] <CommonTokenJWT>":             "/users/me/shelves",
+				"rename shelf [PUT] <CommonTokenJWT>":              "/users/me/shelves/:shelf",
+				"delete shelf [DELETE] <CommonTokenJWT>":           "/users/me/shelves/:shelf",
+				"add book to shelf [POST] <CommonTokenJWT>":        "/users/me/shelves/:shelf/books",
+				"remove book from shelf [DELETE] <CommonTokenJWT>": "/users/me/shelves/:shelf/books/:book_id",
 			},
 			Books: map[string]string{
-				"get all books [GET] <CommonTokenJWT>":  "/books",
-				"get book by id [GET] <CommonTokenJWT>": "/books/:id",
-				"create book [POST] <AdminTokenJWT>":    "/books",
-				"update book [PUT] <AdminTokenJWT>":     "/books/:id",
-				"delete book [DELETE] <AdminTokenJWT>":  "/books/:id",
+				"get all books [GET] <CommonTokenJWT>":         "/books?page=&limit=&sort=&order=&author=&publisher=&isbn=&min_rating=&max_rating=&category=&tag=&language=&format=&series=&min_pages=&max_pages=&published_from=&published_to=",
+				"search books [GET] <CommonTokenJWT>":          "/books/search?q=&page=&limit=",
+				"suggest books [GET] <CommonTokenJWT>":         "/books/suggest?prefix=&limit=",
+				"top rated books [GET] <CommonTokenJWT>":       "/books/top?limit= (bayesian average of review ratings)",
+				"trending books [GET] <CommonTokenJWT>":        "/books/trending?limit= (most reviewed lately)",
+				"get book by id [GET] <CommonTokenJWT>":        "/books/:id",
+				"get book by isbn [GET] <CommonTokenJWT>":      "/books/isbn/:isbn",
+				"create book [POST] <AdminTokenJWT>":           "/books",
+				"import books [POST] <AdminTokenJWT>":          "/books/import (multipart \"file\", csv or ndjson)",
+				"import book metadata [POST] <AdminTokenJWT>":  "/books/import/metadata (multipart \"file\", openlibrary dump or onix 3.0, dry_run=true to only report)",
+				"export books [GET] <AdminTokenJWT>":           "/books/export?format=csv|ndjson|marc21|marcxml (or Accept header)",
+				"update book [PUT] <AdminTokenJWT>":            "/books/:id",
+				"patch book [PATCH] <AdminTokenJWT>":           "/books/:id (application/merge-patch+json)",
+				"delete book [DELETE] <AdminTokenJWT>":         "/books/:id",
+				"upload book cover [POST] <AdminTokenJWT>":     "/books/:id/cover (multipart \"cover\", jpeg, png or webp)",
+				"get book authors [GET] <CommonTokenJWT>":      "/books/:id/authors",
+				"set book authors [PUT] <AdminTokenJWT>":       "/books/:id/authors (roles: author, editor, translator)",
+				"set book publisher [PUT] <AdminTokenJWT>":     "/books/:id/publisher",
+				"set book categories [PUT] <AdminTokenJWT>":    "/books/:id/categories",
+				"add book tags [POST] <CommonTokenJWT>":        "/books/:id/tags",
+				"remove book tag [DELETE] <AdminTokenJWT>":     "/books/:id/tags/:tag",
+				"get book history [GET] <CommonTokenJWT>":      "/books/:id/history",
+				"get similar books [GET] <CommonTokenJWT>":     "/books/:id/similar?limit=",
+				"get book rating stats [GET] <CommonTokenJWT>": "/books/:id/stats (histogram, median, std dev and monthly reviews)",
+				"revert book [POST] <AdminTokenJWT>":           "/books/:id/history/:version/revert",
+				"get book cover [GET]":                         "/covers/*key (use cover_url or thumbnails of a book)",
 			},
 			Reviews: map[string]string{
-				"get all reviews [GET] <CommonTokenJWT>":       "/reviews",
-				"get review by id [GET] <CommonTokenJWT>":      "/reviews/:id",
-				"get review by book id [GET] <CommonTokenJWT>": "/reviews/book/:id",
-				"get review by user id [GET] <CommonTokenJWT>": "/reviews/user/:id",
-				"create review [POST] <CommonTokenJWT>":        "/reviews",
-				"update review [PUT] <CommonTokenJWT>":         "/reviews/:id",
-				"delete review [DELETE] <CommonTokenJWT>":      "/reviews/:id",
+				"get all reviews [GET] <CommonTokenJWT>":         "/reviews?limit=&sort=newest|oldest|highest_rating|lowest_rating|most_helpful&cursor=",
+				"get review by id [GET] <CommonTokenJWT>":        "/reviews/:id",
+				"get review by book id [GET] <CommonTokenJWT>":   "/reviews/book/:id?limit=&sort=&cursor=",
+				"get review by user id [GET] <CommonTokenJWT>":   "/reviews/user/:id?limit=&sort=&cursor=",
+				"create review [POST] <CommonTokenJWT>":          "/reviews",
+				"update review [PUT] <CommonTokenJWT>":           "/reviews/:id",
+				"delete review [DELETE] <CommonTokenJWT>":        "/reviews/:id",
+				"vote on review [PUT] <CommonTokenJWT>":          "/reviews/:id/vote (vote=up|down, not on your own review)",
+				"withdraw review vote [DELETE] <CommonTokenJWT>": "/reviews/:id/vote",
+			},
+			Lists: map[string]string{
+				"get public lists [GET] <CommonTokenJWT>":         "/lists?page=&limit=&sort=votes|recent",
+				"get my lists [GET] <CommonTokenJWT>":             "/users/me/lists (owned or co-edited)",
+				"get list by id [GET] <CommonTokenJWT>":           "/lists/:id",
+				"create list [POST] <CommonTokenJWT>":             "/lists (title, description, visibility public|unlisted|private)",
+				"update list [PUT] <CommonTokenJWT>":              "/lists/:id",
+				"delete list [DELETE] <CommonTokenJWT>":           "/lists/:id (owner only)",
+				"add book to list [POST] <CommonTokenJWT>":        "/lists/:id/books (book_id, note, position)",
+				"update book on list [PUT] <CommonTokenJWT>":      "/lists/:id/books/:book_id (note, position)",
+				"remove book from list [DELETE] <CommonTokenJWT>": "/lists/:id/books/:book_id",
+				"add co-editor [POST] <CommonTokenJWT>":           "/lists/:id/editors (user_id, owner only)",
+				"remove co-editor [DELETE] <CommonTokenJWT>":      "/lists/:id/editors/:user_id",
+				"upvote list [POST] <CommonTokenJWT>":             "/lists/:id/votes",
+				"withdraw upvote [DELETE] <CommonTokenJWT>":       "/lists/:id/votes",
+			},
+			Circulations: map[string]string{
+				"get copies by book id [GET] <CommonTokenJWT>": "/circulations/copies/book/:id",
+				"get user loans [GET] <CommonTokenJWT>":        "/circulations/loans/me",
+				"renew loan [POST] <CommonTokenJWT>":           "/circulations/loans/:id/renew",
+				"get user holds [GET] <CommonTokenJWT>":        "/circulations/holds/me",
+				"place hold [POST] <CommonTokenJWT>":           "/circulations/holds",
+				"cancel hold [DELETE] <CommonTokenJWT>":        "/circulations/holds/:id",
+				"create copy [POST] <AdminTokenJWT>":           "/circulations/copies",
+				"update copy [PUT] <AdminTokenJWT>":            "/circulations/copies/:id",
+				"delete copy [DELETE] <AdminTokenJWT>":         "/circulations/copies/:id",
+				"check out copy [POST] <AdminTokenJWT>":        "/circulations/checkout",
+				"return copy [POST] <AdminTokenJWT>":           "/circulations/return",
+				"get active loans [GET] <AdminTokenJWT>":       "/circulations/loans?overdue=",
+				"pay loan fine [POST] <AdminTokenJWT>":         "/circulations/loans/:id/pay-fine",
+				"get holds by book id [GET] <AdminTokenJWT>":   "/circulations/holds/book/:id",
+			},
+			Authors: map[string]string{
+				"get all authors [GET] <CommonTokenJWT>":  "/authors",
+				"get author by id [GET] <CommonTokenJWT>": "/authors/:id",
+				"get author books [GET] <CommonTokenJWT>": "/authors/:id/books",
+				"create author [POST] <AdminTokenJWT>":    "/authors",
+				"update author [PUT] <AdminTokenJWT>":     "/authors/:id",
+				"delete author [DELETE] <AdminTokenJWT>":  "/authors/:id",
+			},
+			Publishers: map[string]string{
+				"get all publishers [GET] <CommonTokenJWT>":  "/publishers",
+				"get publisher by id [GET] <CommonTokenJWT>": "/publishers/:id",
+				"get publisher books [GET] <CommonTokenJWT>": "/publishers/:id/books",
+				"create publisher [POST] <AdminTokenJWT>":    "/publishers",
+				"update publisher [PUT] <AdminTokenJWT>":     "/publishers/:id",
+				"delete publisher [DELETE] <AdminTokenJWT>":  "/publishers/:id",
+			},
+			Categories: map[string]string{
+				"get category tree [GET] <CommonTokenJWT>":  "/categories",
+				"get category by id [GET] <CommonTokenJWT>": "/categories/:id",
+				"get category books [GET] <CommonTokenJWT>": "/categories/:id/books?page=&limit=&sort=&order=&tag=",
+				"create category [POST] <AdminTokenJWT>":    "/categories",
+				"update category [PUT] <AdminTokenJWT>":     "/categories/:id",
+				"delete category [DELETE] <AdminTokenJWT>":  "/categories/:id",
+			},
+			Tags: map[string]string{
+				"get all tags [GET] <CommonTokenJWT>": "/tags",
+			},
+			Trash: map[string]string{
+				"get trash [GET] <AdminTokenJWT>":           "/admin/trash?type=books|users|reviews&page=&limit=",
+				"restore from trash [POST] <AdminTokenJWT>": "/admin/trash/:type/:id/restore",
+				"purge from trash [DELETE] <AdminTokenJWT>": "/admin/trash/:type/:id",
+			},
+			Collections: map[string]string{
+				"get all collections [GET] <AdminTokenJWT>":  "/admin/collections (scheduled and expired ones too)",
+				"get collection by id [GET] <AdminTokenJWT>": "/admin/collections/:id",
+				"create collection [POST] <AdminTokenJWT>":   "/admin/collections (title, description, position, starts_at, ends_at, book_ids)",
+				"update collection [PUT] <AdminTokenJWT>":    "/admin/collections/:id",
+				"delete collection [DELETE] <AdminTokenJWT>": "/admin/collections/:id",
+			},
+			Feeds: map[string]string{
+				"opds catalog [GET]":      "/opds",
+				"opds books [GET]":        "/opds/books?sort=newest|title&page=",
+				"new books atom [GET]":    "/feeds/books.atom?page=",
+				"new books rss [GET]":     "/feeds/books.rss?page=",
+				"book reviews atom [GET]": "/feeds/books/:id/reviews.atom?page=",
+				"book reviews rss [GET]":  "/feeds/books/:id/reviews.rss?page=",
 			},
 		},
 		Middleware: map[string]string{
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	authorRepository "github.com/snykk/golib_backend/datasources/databases/authors"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	authorUsecase "github.com/snykk/golib_backend/domains/authors"
+	authorController "github.com/snykk/golib_backend/http/controllers/authors"
+)
+
+type authorsRoutes struct {
+	controller          authorController.AuthorController
+	router              *gin.Engine
+	db                  *gorm.DB
+	authMiddleware      gin.HandlerFunc
+	authAdminMiddleware gin.HandlerFunc
+}
+
+func NewAuthorsRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, router *gin.Engine, authMiddleware gin.HandlerFunc, authAdminMiddleware gin.HandlerFunc) *authorsRoutes {
+	authorRepository := authorRepository.NewPostgreAuthorRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	authorUsecase := authorUsecase.NewAuthorUsecase(authorRepository, bookRepository)
+	authorController := authorController.NewAuthorController(authorUsecase, ristrettoCache)
+
+	return &authorsRoutes{controller: authorController, router: router, db: db, authMiddleware: authMiddleware, authAdminMiddleware: authAdminMiddleware}
+}
+
+func (r *authorsRoutes) AuthorsRoute() {
+	// Author
+	authorRoute := r.router.Group("authors")
+	// all users
+	authorRoute.GET("", r.authMiddleware, r.controller.GetAll)
+	authorRoute.GET("/:id", r.authMiddleware, r.controller.GetById)
+	authorRoute.GET("/:id/books", r.authMiddleware, r.controller.GetBooks)
+	// admin only
+	authorRoute.POST("", r.authAdminMiddleware, r.controller.Store)
+	authorRoute.PUT("/:id", r.authAdminMiddleware, r.controller.Update)
+	authorRoute.DELETE("/:id", r.authAdminMiddleware, r.controller.Delete)
+
+	// Book credits
+	bookRoute := r.router.Group("books")
+	// all users
+	bookRoute.GET("/:id/authors", r.authMiddleware, r.controller.GetBookAuthors)
+	// admin only
+	bookRoute.PUT("/:id/authors", r.authAdminMiddleware, r.controller.SetBookAuthors)
+}
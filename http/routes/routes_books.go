@@ -6,7 +6,6 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/snykk/golib_backend/datasources/cache"
-	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
 	bookUseCase "github.com/snykk/golib_backend/domains/books"
 	bookController "github.com/snykk/golib_backend/http/controllers/books"
 )
@@ -19,9 +18,7 @@ type booksRoutes struct {
 	authAdminMiddleware gin.HandlerFunc
 }
 
-func NewBooksRoute(db *gorm.DB, jwtService token.JWTService, ristrettoCache cache.RistrettoCache, router *gin.Engine, authMiddleware gin.HandlerFunc, authAdminMiddleware gin.HandlerFunc) *booksRoutes {
-	bookRepository := bookRepository.NewPostgreBookRepository(db)
-	bookUseCase := bookUseCase.NewBookUsecase(bookRepository)
+func NewBooksRoute(db *gorm.DB, jwtService token.JWTService, ristrettoCache cache.RistrettoCache, bookUseCase bookUseCase.Usecase, router *gin.Engine, authMiddleware gin.HandlerFunc, authAdminMiddleware gin.HandlerFunc) *booksRoutes {
 	bookController := bookController.NewBookController(bookUseCase, ristrettoCache)
 
 	return &booksRoutes{controller: bookController, router: router, db: db, authMiddleware: authMiddleware, authAdminMiddleware: authAdminMiddleware}
@@ -32,9 +29,30 @@ func (r *booksRoutes) BooksRoute() {
 	bookRoute := r.router.Group("books")
 	// all users
 	bookRoute.GET("", r.authMiddleware, r.controller.GetAll)
+	bookRoute.GET("/search", r.authMiddleware, r.controller.Search)
+	bookRoute.GET("/suggest", r.authMiddleware, r.controller.Suggest)
+	bookRoute.GET("/isbn/:isbn", r.authMiddleware, r.controller.GetByISBN)
 	bookRoute.GET("/:id", r.authMiddleware, r.controller.GetById)
+	bookRoute.GET("/:id/history", r.authMiddleware, r.controller.GetHistory)
+	bookRoute.GET("/:id/similar", r.authMiddleware, r.controller.GetSimilar)
+	bookRoute.GET("/:id/stats", r.authMiddleware, r.controller.GetStats)
+	bookRoute.POST("/:id/tags", r.authMiddleware, r.controller.AddTags)
 	// admin only
 	bookRoute.POST("", r.authAdminMiddleware, r.controller.Store)
+	bookRoute.POST("/import", r.authAdminMiddleware, r.controller.Import)
+	bookRoute.POST("/import/metadata", r.authAdminMiddleware, r.controller.ImportMetadata)
+	bookRoute.GET("/export", r.authAdminMiddleware, r.controller.Export)
 	bookRoute.PUT("/:id", r.authAdminMiddleware, r.controller.Update)
+	bookRoute.PATCH("/:id", r.authAdminMiddleware, r.controller.Patch)
 	bookRoute.DELETE("/:id", r.authAdminMiddleware, r.controller.Delete)
+	bookRoute.POST("/:id/cover", r.authAdminMiddleware, r.controller.UploadCover)
+	bookRoute.DELETE("/:id/tags/:tag", r.authAdminMiddleware, r.controller.RemoveTag)
+	bookRoute.POST("/:id/history/:version/revert", r.authAdminMiddleware, r.controller.Revert)
+
+	// Tag
+	r.router.GET("/tags", r.authMiddleware, r.controller.GetTags)
+
+	// Cover
+	// public, so covers can be used directly in <img> tags
+	r.router.GET("/covers/*key", r.controller.GetCover)
 }
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	categoryRepository "github.com/snykk/golib_backend/datasources/databases/categories"
+	categoryUsecase "github.com/snykk/golib_backend/domains/categories"
+	categoryController "github.com/snykk/golib_backend/http/controllers/categories"
+)
+
+type categoriesRoutes struct {
+	controller          categoryController.CategoryController
+	router              *gin.Engine
+	db                  *gorm.DB
+	authMiddleware      gin.HandlerFunc
+	authAdminMiddleware gin.HandlerFunc
+}
+
+func NewCategoriesRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, router *gin.Engine, authMiddleware gin.HandlerFunc, authAdminMiddleware gin.HandlerFunc) *categoriesRoutes {
+	categoryRepository := categoryRepository.NewPostgreCategoryRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	categoryUsecase := categoryUsecase.NewCategoryUsecase(categoryRepository, bookRepository)
+	categoryController := categoryController.NewCategoryController(categoryUsecase, ristrettoCache)
+
+	return &categoriesRoutes{controller: categoryController, router: router, db: db, authMiddleware: authMiddleware, authAdminMiddleware: authAdminMiddleware}
+}
+
+func (r *categoriesRoutes) CategoriesRoute() {
+	// Category
+	categoryRoute := r.router.Group("categories")
+	// all users
+	categoryRoute.GET("", r.authMiddleware, r.controller.GetTree)
+	categoryRoute.GET("/:id", r.authMiddleware, r.controller.GetById)
+	categoryRoute.GET("/:id/books", r.authMiddleware, r.controller.GetBooks)
+	// admin only
+	categoryRoute.POST("", r.authAdminMiddleware, r.controller.Store)
+	categoryRoute.PUT("/:id", r.authAdminMiddleware, r.controller.Update)
+	categoryRoute.DELETE("/:id", r.authAdminMiddleware, r.controller.Delete)
+
+	// Book categories
+	bookRoute := r.router.Group("books")
+	// admin only
+	bookRoute.PUT("/:id/categories", r.authAdminMiddleware, r.controller.SetBookCategories)
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	circulationRepository "github.com/snykk/golib_backend/datasources/databases/circulations"
+	userRepository "github.com/snykk/golib_backend/datasources/databases/users"
+	circulationUsecase "github.com/snykk/golib_backend/domains/circulations"
+	circulationController "github.com/snykk/golib_backend/http/controllers/circulations"
+)
+
+type circulationsRoutes struct {
+	controller          circulationController.CirculationController
+	router              *gin.Engine
+	db                  *gorm.DB
+	authMiddleware      gin.HandlerFunc
+	authAdminMiddleware gin.HandlerFunc
+}
+
+func NewCirculationsRoute(db *gorm.DB, router *gin.Engine, authMiddleware gin.HandlerFunc, authAdminMiddleware gin.HandlerFunc) *circulationsRoutes {
+	circulationRepository := circulationRepository.NewPostgreCirculationRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	userRepository := userRepository.NewPostgreUserRepository(db)
+	circulationUsecase := circulationUsecase.NewCirculationUsecase(circulationRepository, bookRepository, userRepository)
+	circulationController := circulationController.NewCirculationController(circulationUsecase)
+
+	return &circulationsRoutes{controller: circulationController, router: router, db: db, authMiddleware: authMiddleware, authAdminMiddleware: authAdminMiddleware}
+}
+
+func (r *circulationsRoutes) CirculationsRoute() {
+	// => Circulation
+	circulationRoute := r.router.Group("circulations")
+	// all users
+	circulationRoute.GET("/copies/book/:id", r.authMiddleware, r.controller.GetCopiesByBookId)
+	circulationRoute.GET("/loans/me", r.authMiddleware, r.controller.GetUserLoans)
+	circulationRoute.POST("/loans/:id/renew", r.authMiddleware, r.controller.Renew)
+	circulationRoute.GET("/holds/me", r.authMiddleware, r.controller.GetUserHolds)
+	circulationRoute.POST("/holds", r.authMiddleware, r.controller.PlaceHold)
+	circulationRoute.DELETE("/holds/:id", r.authMiddleware, r.controller.CancelHold)
+	// admin only
+	circulationRoute.POST("/copies", r.authAdminMiddleware, r.controller.StoreCopy)
+	circulationRoute.PUT("/copies/:id", r.authAdminMiddleware, r.controller.UpdateCopy)
+	circulationRoute.DELETE("/copies/:id", r.authAdminMiddleware, r.controller.DeleteCopy)
+	circulationRoute.POST("/checkout", r.authAdminMiddleware, r.controller.Checkout)
+	circulationRoute.POST("/return", r.authAdminMiddleware, r.controller.Return)
+	circulationRoute.GET("/loans", r.authAdminMiddleware, r.controller.GetActiveLoans)
+	circulationRoute.POST("/loans/:id/pay-fine", r.authAdminMiddleware, r.controller.PayFine)
+	circulationRoute.GET("/holds/book/:id", r.authAdminMiddleware, r.controller.GetHoldsByBookId)
+}
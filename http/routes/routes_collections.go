@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	collectionRepository "github.com/snykk/golib_backend/datasources/databases/collections"
+	rankingRepository "github.com/snykk/golib_backend/datasources/databases/rankings"
+	collectionUsecase "github.com/snykk/golib_backend/domains/collections"
+	collectionController "github.com/snykk/golib_backend/http/controllers/collections"
+)
+
+type collectionsRoutes struct {
+	controller          collectionController.CollectionController
+	router              *gin.Engine
+	db                  *gorm.DB
+	authAdminMiddleware gin.HandlerFunc
+}
+
+func NewCollectionsRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, router *gin.Engine, authAdminMiddleware gin.HandlerFunc) *collectionsRoutes {
+	collectionRepository := collectionRepository.NewPostgreCollectionRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	rankingRepository := rankingRepository.NewPostgreRankingRepository(db)
+	collectionUsecase := collectionUsecase.NewCollectionUsecase(collectionRepository, bookRepository, rankingRepository)
+	collectionController := collectionController.NewCollectionController(collectionUsecase, ristrettoCache)
+
+	return &collectionsRoutes{controller: collectionController, router: router, db: db, authAdminMiddleware: authAdminMiddleware}
+}
+
+func (r *collectionsRoutes) CollectionsRoute() {
+	// Home, public like the root route
+	r.router.GET("/home", r.controller.GetHome)
+
+	// Featured collections
+	collectionRoute := r.router.Group("admin/collections")
+	// admin only
+	collectionRoute.Use(r.authAdminMiddleware)
+	{
+		collectionRoute.GET("", r.controller.GetAll)
+		collectionRoute.GET("/:id", r.controller.GetById)
+		collectionRoute.POST("", r.controller.Store)
+		collectionRoute.PUT("/:id", r.controller.Update)
+		collectionRoute.DELETE("/:id", r.controller.Delete)
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	reviewRepository "github.com/snykk/golib_backend/datasources/databases/reviews"
+	feedUsecase "github.com/snykk/golib_backend/domains/feeds"
+	feedController "github.com/snykk/golib_backend/http/controllers/feeds"
+)
+
+type feedsRoutes struct {
+	controller feedController.FeedController
+	router     *gin.Engine
+	db         *gorm.DB
+}
+
+func NewFeedsRoute(db *gorm.DB, router *gin.Engine) *feedsRoutes {
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	reviewRepository := reviewRepository.NewPostgreReviewRepository(db)
+	feedUsecase := feedUsecase.NewFeedUsecase(bookRepository, reviewRepository)
+	feedController := feedController.NewFeedController(feedUsecase)
+
+	return &feedsRoutes{controller: feedController, router: router, db: db}
+}
+
+func (r *feedsRoutes) FeedsRoute() {
+	// public, e-readers and feed readers have no way to send a token
+
+	// OPDS catalog
+	opdsRoute := r.router.Group("opds")
+	opdsRoute.GET("", r.controller.GetOPDSRoot)
+	opdsRoute.GET("/books", r.controller.GetOPDSBooks)
+
+	// Atom and RSS
+	feedRoute := r.router.Group("feeds")
+	feedRoute.GET("/books.atom", r.controller.GetBooksAtom)
+	feedRoute.GET("/books.rss", r.controller.GetBooksRSS)
+	feedRoute.GET("/books/:id/reviews.atom", r.controller.GetReviewsAtom)
+	feedRoute.GET("/books/:id/reviews.rss", r.controller.GetReviewsRSS)
+}
@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	listRepository "github.com/snykk/golib_backend/datasources/databases/lists"
+	userRepository "github.com/snykk/golib_backend/datasources/databases/users"
+	listUsecase "github.com/snykk/golib_backend/domains/lists"
+	listController "github.com/snykk/golib_backend/http/controllers/lists"
+)
+
+type listsRoutes struct {
+	controller     listController.ListController
+	router         *gin.Engine
+	db             *gorm.DB
+	authMiddleware gin.HandlerFunc
+}
+
+func NewListsRoute(db *gorm.DB, router *gin.Engine, authMiddleware gin.HandlerFunc) *listsRoutes {
+	listRepository := listRepository.NewPostgreListRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	userRepository := userRepository.NewPostgreUserRepository(db)
+	listUsecase := listUsecase.NewListUsecase(listRepository, bookRepository, userRepository)
+	listController := listController.NewListController(listUsecase)
+
+	return &listsRoutes{controller: listController, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *listsRoutes) ListsRoute() {
+	// => Reading lists
+	listRoute := r.router.Group("lists")
+	listRoute.Use(r.authMiddleware)
+	{
+		listRoute.GET("", r.controller.GetAll)
+		listRoute.POST("", r.controller.Store)
+		listRoute.GET("/:id", r.controller.GetById)
+		listRoute.PUT("/:id", r.controller.Update)
+		listRoute.DELETE("/:id", r.controller.Delete)
+		listRoute.POST("/:id/books", r.controller.AddBook)
+		listRoute.PUT("/:id/books/:book_id", r.controller.UpdateBook)
+		listRoute.DELETE("/:id/books/:book_id", r.controller.RemoveBook)
+		listRoute.POST("/:id/editors", r.controller.AddEditor)
+		listRoute.DELETE("/:id/editors/:user_id", r.controller.RemoveEditor)
+		listRoute.POST("/:id/votes", r.controller.Vote)
+		listRoute.DELETE("/:id/votes", r.controller.Unvote)
+	}
+
+	userListRoute := r.router.Group("users/me/lists")
+	userListRoute.Use(r.authMiddleware)
+	{
+		userListRoute.GET("", r.controller.GetUserLists)
+	}
+}
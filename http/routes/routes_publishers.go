@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	publisherRepository "github.com/snykk/golib_backend/datasources/databases/publishers"
+	publisherUsecase "github.com/snykk/golib_backend/domains/publishers"
+	publisherController "github.com/snykk/golib_backend/http/controllers/publishers"
+)
+
+type publishersRoutes struct {
+	controller          publisherController.PublisherController
+	router              *gin.Engine
+	db                  *gorm.DB
+	authMiddleware      gin.HandlerFunc
+	authAdminMiddleware gin.HandlerFunc
+}
+
+func NewPublishersRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, router *gin.Engine, authMiddleware gin.HandlerFunc, authAdminMiddleware gin.HandlerFunc) *publishersRoutes {
+	publisherRepository := publisherRepository.NewPostgrePublisherRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	publisherUsecase := publisherUsecase.NewPublisherUsecase(publisherRepository, bookRepository)
+	publisherController := publisherController.NewPublisherController(publisherUsecase, ristrettoCache)
+
+	return &publishersRoutes{controller: publisherController, router: router, db: db, authMiddleware: authMiddleware, authAdminMiddleware: authAdminMiddleware}
+}
+
+func (r *publishersRoutes) PublishersRoute() {
+	// Publisher
+	publisherRoute := r.router.Group("publishers")
+	// all users
+	publisherRoute.GET("", r.authMiddleware, r.controller.GetAll)
+	publisherRoute.GET("/:id", r.authMiddleware, r.controller.GetById)
+	publisherRoute.GET("/:id/books", r.authMiddleware, r.controller.GetBooks)
+	// admin only
+	publisherRoute.POST("", r.authAdminMiddleware, r.controller.Store)
+	publisherRoute.PUT("/:id", r.authAdminMiddleware, r.controller.Update)
+	publisherRoute.DELETE("/:id", r.authAdminMiddleware, r.controller.Delete)
+
+	// Book publisher
+	bookRoute := r.router.Group("books")
+	// admin only
+	bookRoute.PUT("/:id/publisher", r.authAdminMiddleware, r.controller.AssignBook)
+}
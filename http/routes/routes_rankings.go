@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	rankingRepository "github.com/snykk/golib_backend/datasources/databases/rankings"
+	rankingUsecase "github.com/snykk/golib_backend/domains/rankings"
+	rankingController "github.com/snykk/golib_backend/http/controllers/rankings"
+)
+
+type rankingsRoutes struct {
+	controller     rankingController.RankingController
+	router         *gin.Engine
+	db             *gorm.DB
+	authMiddleware gin.HandlerFunc
+}
+
+func NewRankingsRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, options rankingUsecase.Options, router *gin.Engine, authMiddleware gin.HandlerFunc) *rankingsRoutes {
+	rankingRepository := rankingRepository.NewPostgreRankingRepository(db)
+	rankingUsecase := rankingUsecase.NewRankingUsecase(rankingRepository, options)
+	rankingController := rankingController.NewRankingController(rankingUsecase, ristrettoCache)
+
+	return &rankingsRoutes{controller: rankingController, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *rankingsRoutes) RankingsRoute() {
+	// Rankings
+	rankingRoute := r.router.Group("books")
+	rankingRoute.Use(r.authMiddleware)
+	{
+		rankingRoute.GET("/top", r.controller.GetTop)
+		rankingRoute.GET("/trending", r.controller.GetTrending)
+	}
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	recommendationRepository "github.com/snykk/golib_backend/datasources/databases/recommendations"
+	recommendationUsecase "github.com/snykk/golib_backend/domains/recommendations"
+	recommendationController "github.com/snykk/golib_backend/http/controllers/recommendations"
+)
+
+type recommendationsRoutes struct {
+	controller     recommendationController.RecommendationController
+	router         *gin.Engine
+	db             *gorm.DB
+	authMiddleware gin.HandlerFunc
+}
+
+func NewRecommendationsRoute(db *gorm.DB, router *gin.Engine, authMiddleware gin.HandlerFunc) *recommendationsRoutes {
+	recommendationRepository := recommendationRepository.NewPostgreRecommendationRepository(db)
+	recommendationUsecase := recommendationUsecase.NewRecommendationUsecase(recommendationRepository)
+	recommendationController := recommendationController.NewRecommendationController(recommendationUsecase)
+
+	return &recommendationsRoutes{controller: recommendationController, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *recommendationsRoutes) RecommendationsRoute() {
+	// Recommendations
+	recommendationRoute := r.router.Group("users/me/recommendations")
+	recommendationRoute.Use(r.authMiddleware)
+	{
+		recommendationRoute.GET("", r.controller.GetUserRecommendations)
+	}
+}
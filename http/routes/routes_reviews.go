@@ -38,6 +38,8 @@ func (r *reviewsRoutes) ReviewsRoute() {
 		reviewRoute.GET("/user/:id", r.controller.GetByUserid)
 		reviewRoute.PUT("/:id", r.controller.Update)
 		reviewRoute.DELETE("/:id", r.controller.Delete)
+		reviewRoute.PUT("/:id/vote", r.controller.Vote)
+		reviewRoute.DELETE("/:id/vote", r.controller.Unvote)
 	}
 
 }
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	shelfRepository "github.com/snykk/golib_backend/datasources/databases/shelves"
+	shelfUsecase "github.com/snykk/golib_backend/domains/shelves"
+	shelfController "github.com/snykk/golib_backend/http/controllers/shelves"
+)
+
+type shelvesRoutes struct {
+	controller     shelfController.ShelfController
+	router         *gin.Engine
+	db             *gorm.DB
+	authMiddleware gin.HandlerFunc
+}
+
+func NewShelvesRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, router *gin.Engine, authMiddleware gin.HandlerFunc) *shelvesRoutes {
+	shelfRepository := shelfRepository.NewPostgreShelfRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	shelfUsecase := shelfUsecase.NewShelfUsecase(shelfRepository, bookRepository)
+	shelfController := shelfController.NewShelfController(shelfUsecase, ristrettoCache)
+
+	return &shelvesRoutes{controller: shelfController, router: router, db: db, authMiddleware: authMiddleware}
+}
+
+func (r *shelvesRoutes) ShelvesRoute() {
+	// Shelves
+	shelfRoute := r.router.Group("users/me/shelves")
+	shelfRoute.Use(r.authMiddleware)
+	{
+		shelfRoute.GET("", r.controller.GetShelves)
+		shelfRoute.POST("", r.controller.CreateShelf)
+		shelfRoute.PUT("/books/:book_id", r.controller.SetStatus)
+		shelfRoute.DELETE("/books/:book_id", r.controller.RemoveBook)
+		shelfRoute.GET("/:shelf", r.controller.GetBooks)
+		shelfRoute.PUT("/:shelf", r.controller.RenameShelf)
+		shelfRoute.DELETE("/:shelf", r.controller.DeleteShelf)
+		shelfRoute.POST("/:shelf/books", r.controller.AddToShelf)
+		shelfRoute.DELETE("/:shelf/books/:book_id", r.controller.RemoveFromShelf)
+	}
+}
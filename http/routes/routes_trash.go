@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/snykk/golib_backend/datasources/cache"
+	bookRepository "github.com/snykk/golib_backend/datasources/databases/books"
+	trashRepository "github.com/snykk/golib_backend/datasources/databases/trash"
+	bookUseCase "github.com/snykk/golib_backend/domains/books"
+	trashUsecase "github.com/snykk/golib_backend/domains/trash"
+	trashController "github.com/snykk/golib_backend/http/controllers/trash"
+)
+
+type trashRoutes struct {
+	controller          trashController.TrashController
+	router              *gin.Engine
+	db                  *gorm.DB
+	authAdminMiddleware gin.HandlerFunc
+}
+
+func NewTrashRoute(db *gorm.DB, ristrettoCache cache.RistrettoCache, blobStorage bookUseCase.BlobStorage, router *gin.Engine, authAdminMiddleware gin.HandlerFunc) *trashRoutes {
+	trashRepository := trashRepository.NewPostgreTrashRepository(db)
+	bookRepository := bookRepository.NewPostgreBookRepository(db)
+	trashUsecase := trashUsecase.NewTrashUsecase(trashRepository, bookRepository, blobStorage)
+	trashController := trashController.NewTrashController(trashUsecase, ristrettoCache)
+
+	return &trashRoutes{controller: trashController, router: router, db: db, authAdminMiddleware: authAdminMiddleware}
+}
+
+func (r *trashRoutes) TrashRoute() {
+	// Trash
+	trashRoute := r.router.Group("admin/trash")
+	// admin only
+	trashRoute.Use(r.authAdminMiddleware)
+	{
+		trashRoute.GET("", r.controller.GetAll)
+		trashRoute.POST("/:type/:id/restore", r.controller.Restore)
+		trashRoute.DELETE("/:type/:id", r.controller.Purge)
+	}
+}
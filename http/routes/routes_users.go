@@ -42,6 +42,7 @@ func (r *usersRoutes) UsersRoute() {
 		userRoute.GET("/:id", r.controller.GetById)
 		userRoute.GET("/me", r.controller.GetUserData)
 		userRoute.PUT("", r.controller.Update)
+		userRoute.PATCH("", r.controller.Patch)
 		userRoute.DELETE("", r.controller.Delete)
 		userRoute.POST("/change-password", r.controller.ChangePassword)
 		userRoute.POST("/change-email", r.controller.ChangeEmail)